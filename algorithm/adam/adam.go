@@ -72,8 +72,8 @@ func adam(f func(ConstVector) (MagicScalar, error), x0 ConstVector, step_size, b
 
   n := x0.Dim()
   // copy variables
-  x1 := AsDenseReal64Vector(x0)
-  x2 := AsDenseReal64Vector(x0)
+  x1 := AsDenseMagicVector(GradientType(x0), x0)
+  x2 := AsDenseMagicVector(GradientType(x0), x0)
   // beta1/2_t variables
   beta1_t := beta1
   beta2_t := beta2
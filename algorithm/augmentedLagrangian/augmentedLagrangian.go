@@ -0,0 +1,314 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Nocedal, Jorge, and Stephen Wright. Numerical optimization.
+// Springer Science & Business Media, 2006.
+//
+// Birgin, Ernesto G., and José Mario Martínez. Practical augmented
+// Lagrangian methods for constrained optimization. Society for Industrial
+// and Applied Mathematics, 2014.
+
+/* -------------------------------------------------------------------------- */
+
+package augmentedLagrangian
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/bfgs"
+import   "github.com/pbenner/autodiff/algorithm/newton"
+
+/* -------------------------------------------------------------------------- */
+
+type Objective func(ConstVector) (MagicScalar, error)
+
+// Equality constraints h(x) = 0
+type EqualityConstraints struct {
+  Value func(ConstVector) (MagicVector, error)
+}
+
+// Inequality constraints g(x) <= 0
+type InequalityConstraints struct {
+  Value func(ConstVector) (MagicVector, error)
+}
+
+// Tolerance for the violation of the constraints and the gradient norm
+// of the inner problems
+type Epsilon struct {
+  Value float64
+}
+
+// Maximal number of outer iterations, i.e. updates of the multipliers
+// (default: 100)
+type MaxIterations struct {
+  Value int
+}
+
+// The hook receives the current multipliers of the equality (lambda) and
+// inequality (mu) constraints
+type Hook struct {
+  Value func(x, lambda, mu ConstVector, y ConstScalar) bool
+}
+
+// Initial and maximal value of the penalty parameter. The method fails if
+// the penalty reached its maximum and the violation of the constraints
+// does not decrease sufficiently.
+type Penalty struct {
+  Initial float64
+  Max     float64
+}
+
+// Method used for the unconstrained subproblems, either "Bfgs" (default)
+// or "Newton"
+type InnerSolver struct {
+  Value string
+}
+
+/* -------------------------------------------------------------------------- */
+
+type augmentedLagrangian struct {
+  f      Objective
+  h      EqualityConstraints
+  g      InequalityConstraints
+  lambda DenseFloat64Vector
+  mu     DenseFloat64Vector
+  rho    float64
+}
+
+func (obj *augmentedLagrangian) evalConstraints(x ConstVector) (MagicVector, MagicVector, error) {
+  var h, g MagicVector
+  var err error
+  if obj.h.Value != nil {
+    if h, err = obj.h.Value(x); err != nil {
+      return nil, nil, err
+    }
+  }
+  if obj.g.Value != nil {
+    if g, err = obj.g.Value(x); err != nil {
+      return nil, nil, err
+    }
+  }
+  return h, g, nil
+}
+
+// Evaluate the augmented Lagrangian
+//   f(x) + sum_i [lambda_i h_i(x) + rho/2 h_i(x)^2]
+//        + 1/(2 rho) sum_j [max(0, mu_j + rho g_j(x))^2 - mu_j^2]
+func (obj *augmentedLagrangian) eval(x ConstVector) (MagicScalar, error) {
+  y, err := obj.f(x)
+  if err != nil {
+    return nil, err
+  }
+  h, g, err := obj.evalConstraints(x)
+  if err != nil {
+    return nil, err
+  }
+  rho := obj.rho
+  r := y.CloneMagicScalar()
+  t := y.CloneMagicScalar()
+  if h != nil {
+    if h.Dim() != obj.lambda.Dim() {
+      return nil, fmt.Errorf("equality constraints have invalid dimension")
+    }
+    for i := 0; i < h.Dim(); i++ {
+      t.Mul(ConstFloat64(rho/2.0), h.ConstAt(i))
+      t.Add(t, ConstFloat64(obj.lambda[i]))
+      t.Mul(t, h.ConstAt(i))
+      r.Add(r, t)
+    }
+  }
+  if g != nil {
+    if g.Dim() != obj.mu.Dim() {
+      return nil, fmt.Errorf("inequality constraints have invalid dimension")
+    }
+    for j := 0; j < g.Dim(); j++ {
+      mu := obj.mu[j]
+      if mu + rho*g.Float64At(j) > 0.0 {
+        t.Mul(ConstFloat64(rho), g.ConstAt(j))
+        t.Add(t, ConstFloat64(mu))
+        t.Mul(t, t)
+        t.Div(t, ConstFloat64(2.0*rho))
+        r.Add(r, t)
+      }
+      r.Sub(r, ConstFloat64(mu*mu/(2.0*rho)))
+    }
+  }
+  return r, nil
+}
+
+// Update multipliers and return the violation of the constraints and the
+// complementarity condition.
+func (obj *augmentedLagrangian) update(x ConstVector) (float64, error) {
+  h, g, err := obj.evalConstraints(x)
+  if err != nil {
+    return 0.0, err
+  }
+  r := 0.0
+  if h != nil {
+    for i := 0; i < h.Dim(); i++ {
+      r = math.Max(r, math.Abs(h.Float64At(i)))
+      obj.lambda[i] += obj.rho*h.Float64At(i)
+    }
+  }
+  if g != nil {
+    for j := 0; j < g.Dim(); j++ {
+      r = math.Max(r, math.Abs(math.Max(g.Float64At(j), -obj.mu[j]/obj.rho)))
+      obj.mu[j] = math.Max(0.0, obj.mu[j] + obj.rho*g.Float64At(j))
+    }
+  }
+  return r, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func equals(x1, x2 ConstVector) bool {
+  for i := 0; i < x1.Dim(); i++ {
+    if x1.Float64At(i) != x2.Float64At(i) {
+      return false
+    }
+  }
+  return true
+}
+
+func run(f Objective, x0 ConstVector,
+  equalityConstraints EqualityConstraints,
+  inequalityConstraints InequalityConstraints,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook,
+  penalty Penalty,
+  innerSolver InnerSolver) (Vector, error) {
+
+  if penalty.Initial <= 0.0 || penalty.Max < penalty.Initial {
+    return nil, fmt.Errorf("invalid penalty parameter")
+  }
+  x := AsDenseFloat64Vector(x0)
+
+  obj := augmentedLagrangian{f: f, h: equalityConstraints, g: inequalityConstraints, rho: penalty.Initial}
+  // get number of constraints
+  if h, g, err := obj.evalConstraints(x); err != nil {
+    return nil, fmt.Errorf("invalid initial value: %s", err)
+  } else {
+    if h != nil {
+      obj.lambda = NullDenseFloat64Vector(h.Dim())
+    }
+    if g != nil {
+      obj.mu = NullDenseFloat64Vector(g.Dim())
+    }
+  }
+  // inner solver
+  var solve func(x Vector) (Vector, error)
+  switch innerSolver.Value {
+  case "Bfgs":
+    solve = func(x Vector) (Vector, error) {
+      return bfgs.Run(obj.eval, x, bfgs.Epsilon{epsilon.Value})
+    }
+  case "Newton":
+    solve = func(x Vector) (Vector, error) {
+      return newton.RunMin(obj.eval, x, newton.Epsilon{epsilon.Value}, newton.HessianModification{"LDL"})
+    }
+  default:
+    panic(fmt.Sprintf("invalid inner solver: %s", innerSolver.Value))
+  }
+  // constraint violation of the last iteration
+  v1 := math.Inf(1)
+
+  for i := 0; i < maxIterations.Value; i++ {
+    // minimize augmented Lagrangian
+    if r, err := solve(x); err != nil {
+      // the inner solver may fail to reach the tolerance because of limited
+      // numerical precision, continue if some progress was made
+      if r == nil || equals(r, x) {
+        return x, err
+      }
+      x = AsDenseFloat64Vector(r)
+    } else {
+      x = AsDenseFloat64Vector(r)
+    }
+    v2, err := obj.update(x)
+    if err != nil {
+      return x, err
+    }
+    if math.IsNaN(v2) {
+      return x, fmt.Errorf("NaN value detected")
+    }
+    // execute hook if available
+    if hook.Value != nil {
+      y, err := f(x)
+      if err != nil {
+        return x, err
+      }
+      if hook.Value(x, obj.lambda, obj.mu, y) {
+        break
+      }
+    }
+    // evaluate stop criterion
+    if v2 < epsilon.Value {
+      break
+    }
+    // increase penalty if the violation did not decrease sufficiently
+    if v2 > 0.25*v1 {
+      if obj.rho == penalty.Max {
+        return x, fmt.Errorf("constraint violation does not decrease at maximal penalty (violation: %e)", v2)
+      }
+      obj.rho = math.Min(10.0*obj.rho, penalty.Max)
+    }
+    v1 = v2
+  }
+  return x, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Minimize f subject to equality constraints h(x) = 0 and inequality
+// constraints g(x) <= 0 with the augmented Lagrangian method. Derivatives
+// of f and the constraints are computed with automatic differentiation.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  equalityConstraints   := EqualityConstraints  {nil}
+  inequalityConstraints := InequalityConstraints{nil}
+  epsilon               := Epsilon              {1e-8}
+  maxIterations         := MaxIterations        {100}
+  hook                  := Hook                 {nil}
+  penalty               := Penalty              {10.0, 1e10}
+  innerSolver           := InnerSolver          {"Bfgs"}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case EqualityConstraints:
+      equalityConstraints = a
+    case InequalityConstraints:
+      inequalityConstraints = a
+    case Epsilon:
+      epsilon = a
+    case MaxIterations:
+      maxIterations = a
+    case Hook:
+      hook = a
+    case Penalty:
+      penalty = a
+    case InnerSolver:
+      innerSolver = a
+    default:
+      panic("AugmentedLagrangian(): Invalid optional argument!")
+    }
+  }
+  return run(f, x0, equalityConstraints, inequalityConstraints, epsilon, maxIterations, hook, penalty, innerSolver)
+}
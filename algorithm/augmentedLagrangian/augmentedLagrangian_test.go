@@ -0,0 +1,124 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package augmentedLagrangian
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestAugmentedLagrangian1(test *testing.T) {
+  // minimize x1 + x2 subject to x1^2 + x2^2 = 2
+  f := func(x ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    r.Add(x.ConstAt(0), x.ConstAt(1))
+    return r, nil
+  }
+  h := func(x ConstVector) (MagicVector, error) {
+    r := NullDenseReal64Vector(1)
+    t := NullReal64()
+    r.At(0).Mul(x.ConstAt(0), x.ConstAt(0))
+    t.Mul(x.ConstAt(1), x.ConstAt(1))
+    r.At(0).Add(r.At(0), t)
+    r.At(0).Sub(r.At(0), ConstFloat64(2.0))
+    return r, nil
+  }
+  for _, solver := range []string{"Bfgs", "Newton"} {
+    var lambda float64
+    hook := func(x, l, m ConstVector, y ConstScalar) bool {
+      lambda = l.Float64At(0)
+      return false
+    }
+    x, err := Run(f, NewDenseFloat64Vector([]float64{-0.5, 0.1}), EqualityConstraints{h}, InnerSolver{solver}, Hook{hook})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(x.Float64At(0) + 1.0) > 1e-6 || math.Abs(x.Float64At(1) + 1.0) > 1e-6 {
+      test.Errorf("test failed for inner solver %s", solver)
+    }
+    // Lagrange multiplier at the solution is 1/2
+    if math.Abs(lambda - 0.5) > 1e-6 {
+      test.Errorf("test failed for inner solver %s", solver)
+    }
+  }
+}
+
+func TestAugmentedLagrangian2(test *testing.T) {
+  // minimize (x1 - 2)^2 + (x2 - 1)^2 subject to x1^2 - x2 <= 0 and
+  // x1 + x2 <= 2
+  f := func(x ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    t := NullReal64()
+    r.Sub(x.ConstAt(0), ConstFloat64(2.0))
+    r.Mul(r, r)
+    t.Sub(x.ConstAt(1), ConstFloat64(1.0))
+    t.Mul(t, t)
+    r.Add(r, t)
+    return r, nil
+  }
+  g := func(x ConstVector) (MagicVector, error) {
+    r := NullDenseReal64Vector(2)
+    r.At(0).Mul(x.ConstAt(0), x.ConstAt(0))
+    r.At(0).Sub(r.At(0), x.ConstAt(1))
+    r.At(1).Add(x.ConstAt(0), x.ConstAt(1))
+    r.At(1).Sub(r.At(1), ConstFloat64(2.0))
+    return r, nil
+  }
+  for _, solver := range []string{"Bfgs", "Newton"} {
+    x, err := Run(f, NewDenseFloat64Vector([]float64{0, 0}), InequalityConstraints{g}, InnerSolver{solver})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(x.Float64At(0) - 1.0) > 1e-6 || math.Abs(x.Float64At(1) - 1.0) > 1e-6 {
+      test.Errorf("test failed for inner solver %s", solver)
+    }
+  }
+}
+
+func TestAugmentedLagrangian3(test *testing.T) {
+  // minimize (x - 1)^2 subject to x <= -1 and x >= 1, which is infeasible
+  f := func(x ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    r.Sub(x.ConstAt(0), ConstFloat64(1.0))
+    r.Mul(r, r)
+    return r, nil
+  }
+  g := func(x ConstVector) (MagicVector, error) {
+    r := NullDenseReal64Vector(2)
+    r.At(0).Add(x.ConstAt(0), ConstFloat64(1.0))
+    r.At(1).Sub(ConstFloat64(1.0), x.ConstAt(0))
+    return r, nil
+  }
+  for _, penalty := range []Penalty{{10.0, 1e4}, {10.0, 1e10}} {
+    n := 0
+    hook := func(x, l, m ConstVector, y ConstScalar) bool {
+      n++
+      return false
+    }
+    if _, err := Run(f, NewDenseFloat64Vector([]float64{0.0}), InequalityConstraints{g}, penalty, Hook{hook}); err == nil {
+      test.Error("test failed")
+    }
+    if n >= 100 {
+      test.Error("test failed")
+    }
+  }
+}
@@ -28,6 +28,7 @@ import   "fmt"
 import   "math"
 
 import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
 import   "github.com/pbenner/autodiff/algorithm/lineSearch"
 import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
 
@@ -81,7 +82,7 @@ func newObjectiveInSitu(f Objective) ObjectiveInSitu {
 
 func (f ObjectiveInSitu) Differentiate(x, g Vector, y Scalar) error {
   if f.X == nil {
-    f.X = NullDenseReal64Vector(x.Dim())
+    f.X = NullDenseMagicVector(GradientType(x), x.Dim())
   }
   f.X.Set(x)
   f.X.Variables(1)
@@ -179,7 +180,7 @@ func bfgs_updateH(g1, g2, p2 Vector, H1, H2, I Matrix, t1, t2 Scalar, t3, t4 Vec
   return true
 }
 
-func bfgs(f_ Objective, f ObjectiveInSitu, x0 Vector, H0 Matrix, epsilon Epsilon, maxIterations MaxIterations, hook Hook, constraints Constraints) (Vector, error) {
+func bfgs(f_ Objective, f ObjectiveInSitu, x0 Vector, H0 Matrix, epsilon Epsilon, maxIterations MaxIterations, hook Hook, constraints Constraints, lineSearchArgs []interface{}) (Vector, error) {
 
   // nomenclature:
   // B: Hessian
@@ -207,9 +208,23 @@ func bfgs(f_ Objective, f ObjectiveInSitu, x0 Vector, H0 Matrix, epsilon Epsilon
   I  := NullDenseMatrix(t, n, n)
   I.SetIdentity()
 
-  // here comes the magic!
-  P2 := NullDenseReal64Vector(n)
-  X2 := AsDenseReal64Vector(x1)
+  // objective function for the line search, which keeps a copy of the
+  // last evaluated point so that its value and gradient can be reused
+  // at the new position
+  xl := NullDenseFloat64Vector(n)
+  gl := NullDenseFloat64Vector(n)
+  yl := 0.0
+  ok := false
+  gf := AutoGradient(f_, GradientType(x1), n)
+  phi := func(x, gradient DenseFloat64Vector) (float64, error) {
+    y, err := gf(x, gradient)
+    if ok = err == nil; ok {
+      xl.Set(x)
+      gl.Set(gradient)
+      yl = y
+    }
+    return y, err
+  }
 
   equals := func(x1, x2 Vector) bool {
     for i := 0; i < x1.Dim(); i++ {
@@ -240,16 +255,10 @@ func bfgs(f_ Objective, f ObjectiveInSitu, x0 Vector, H0 Matrix, epsilon Epsilon
   first_update := true
   for i := 0; i < maxIterations.Value; i++ {
     bgfs_computeDirection(x1, y1, g1, H1, p1)
-    // line search objective
-    phi := func(alpha ConstScalar) (MagicScalar, error) {
-      P2.VmulS(p1, alpha)
-      X2.VaddV(x1, P2)
-      return f_(X2)
-    }
     // perform line search to find a new point x2
-    alpha, err := lineSearch.Run(phi, Float64Type, lineSearch.Parameters{1, 100})
+    alpha, err := lineSearch.RunAlongDirection(phi, x1, y1.GetFloat64(), g1, p1, append([]interface{}{lineSearch.Parameters{1, 100}}, lineSearchArgs...)...)
     // compute new position
-    p2.VmulS(p1, alpha)
+    p2.VmulS(p1, ConstFloat64(alpha))
     x2.VaddV(x1, p2)
 
     if err != nil || equals(x1, x2) {
@@ -262,8 +271,12 @@ func bfgs(f_ Objective, f ObjectiveInSitu, x0 Vector, H0 Matrix, epsilon Epsilon
         H2.Set(H0)
       }
     } else {
-      // evaluate objective at new position
-      if err := f.Differentiate(x2, g2, y2); err != nil {
+      // evaluate objective at new position unless the line search
+      // already did
+      if ok && equals(x2, xl) {
+        g2.Set(gl)
+        y2.SetFloat64(yl)
+      } else if err := f.Differentiate(x2, g2, y2); err != nil {
         return x1, fmt.Errorf("invalid value: %s", err)
       }
       // execute hook if available
@@ -314,6 +327,8 @@ func Run(f Objective, x0 Vector, args ...interface{}) (Vector, error) {
   epsilon       := Epsilon      {1e-8}
   maxIterations := MaxIterations{int(^uint(0) >> 1)}
   constraints   := Constraints  { nil}
+  // options passed to the line search
+  lineSearchArgs := []interface{}{}
 
   n := x0.Dim()
 
@@ -329,6 +344,10 @@ func Run(f Objective, x0 Vector, args ...interface{}) (Vector, error) {
       maxIterations = a
     case Constraints:
       constraints = a
+    case lineSearch.Method:
+      lineSearchArgs = append(lineSearchArgs, a)
+    case lineSearch.Wolfe:
+      lineSearchArgs = append(lineSearchArgs, a)
     default:
       panic("Bfgs(): Invalid optional argument!")
     }
@@ -346,5 +365,5 @@ func Run(f Objective, x0 Vector, args ...interface{}) (Vector, error) {
   if err != nil {
     return nil, err
   }
-  return bfgs(f, newObjectiveInSitu(f), x0, H, epsilon, maxIterations, hook, constraints)
+  return bfgs(f, newObjectiveInSitu(f), x0, H, epsilon, maxIterations, hook, constraints, lineSearchArgs)
 }
@@ -23,6 +23,7 @@ import   "os"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
 
 /* -------------------------------------------------------------------------- */
 
@@ -117,3 +118,88 @@ func TestBfgsRosenbrock(test *testing.T) {
   }
   os.Remove("bfgs_test2.table")
 }
+
+func TestBfgsRosenbrockReverse(test *testing.T) {
+
+  f := func(x_ ConstVector) (MagicScalar, error) {
+    // x_ is a reverse-mode vector, since x0 has reverse-mode type
+    x := x_.(DenseReverseReal64Vector)
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReverseReal64()
+    t2 := NullReverseReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+
+  t  := NewFloat64(0.0)
+  x0 := NewDenseReverseReal64Vector([]float64{-0.5, 2})
+  xr := NewDenseFloat64Vector([]float64{   1, 1})
+  xn, err := Run(f, x0,
+    Epsilon{1e-10})
+  if err != nil {
+    test.Error(err)
+  }
+  if t.Vnorm(xn.VsubV(xn, xr)).GetFloat64() > 1e-8 {
+    test.Error("BFGS Rosenbrock test failed!")
+  }
+}
+
+func TestBfgsLineSearch(test *testing.T) {
+
+  f := func(x ConstVector) (MagicScalar, error) {
+    // Rosenbrock function
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  t  := NewFloat64(0.0)
+  xr := NewDenseFloat64Vector([]float64{1, 1})
+  for _, method := range []string{"Armijo", "MoreThuente", "HagerZhang"} {
+    x0 := NewDenseFloat64Vector([]float64{-0.5, 2})
+    xn, err := Run(f, x0, Epsilon{1e-10}, lineSearch.Method{method})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if t.Vnorm(xn.VsubV(xn, xr)).GetFloat64() > 1e-8 {
+      test.Errorf("BFGS Rosenbrock test failed for line search method %s", method)
+    }
+  }
+}
+
+func TestBfgsEvaluations(test *testing.T) {
+  n := 0
+  // f(x) = 1/2 (x1^2 + x2^2) is minimized by a single unit step along
+  // the initial direction
+  f := func(x ConstVector) (MagicScalar, error) {
+    n++
+    r := NullReal64()
+    t := NullReal64()
+    r.Mul(x.ConstAt(0), x.ConstAt(0))
+    t.Mul(x.ConstAt(1), x.ConstAt(1))
+    r.Add(r, t)
+    r.Mul(r, ConstFloat64(0.5))
+    return r, nil
+  }
+  x, err := Run(f, NewDenseFloat64Vector([]float64{1, -2}))
+  if err != nil {
+    test.Error(err); return
+  }
+  if x.Float64At(0) != 0.0 || x.Float64At(1) != 0.0 {
+    test.Error("test failed")
+  }
+  // one evaluation at x0 and one by the line search, which is reused
+  // at the new position
+  if n != 2 {
+    test.Error("test failed")
+  }
+}
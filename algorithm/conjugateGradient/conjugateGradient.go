@@ -0,0 +1,295 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Nocedal, Jorge, and Stephen Wright. Numerical optimization.
+// Springer Science & Business Media, 2006.
+//
+// Hager, William W., and Hongchao Zhang. "A survey of nonlinear conjugate
+// gradient methods." Pacific journal of Optimization 2.1 (2006): 35-58.
+
+/* -------------------------------------------------------------------------- */
+
+package conjugateGradient
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
+
+/* -------------------------------------------------------------------------- */
+
+type Objective func(ConstVector) (MagicScalar, error)
+
+// Objective function that stores the gradient at x in the second argument
+// and returns the function value
+type DenseGradientF func(x, gradient DenseFloat64Vector) (float64, error)
+
+// Formula for updating the search direction, which is either "FR"
+// (Fletcher-Reeves), "PR+" (Polak-Ribiere+, default), "HS" (Hestenes-Stiefel)
+// or "DY" (Dai-Yuan)
+type Method struct {
+  Value string
+}
+
+// Restart with the steepest descent direction every n iterations. The
+// default is the number of variables.
+type Restart struct {
+  Value int
+}
+
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+type Hook struct {
+  Value func(x, gradient ConstVector, y ConstScalar) bool
+}
+
+type Constraints struct {
+  Value func(x Vector) bool
+}
+
+type ConstConstraints struct {
+  Value func(x ConstVector) bool
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Compute the parameter beta for the new search direction p = -g2 + beta p,
+// where g1 and g2 are the previous and current gradients.
+func getBeta(method Method, g1, g2, p DenseFloat64Vector) float64 {
+  switch method.Value {
+  case "FR":
+    return Ddot(g2, g2)/Ddot(g1, g1)
+  case "PR+":
+    return math.Max(0.0, (Ddot(g2, g2) - Ddot(g2, g1))/Ddot(g1, g1))
+  case "HS":
+    return (Ddot(g2, g2) - Ddot(g2, g1))/(Ddot(p, g2) - Ddot(p, g1))
+  case "DY":
+    return Ddot(g2, g2)/(Ddot(p, g2) - Ddot(p, g1))
+  default:
+    panic(fmt.Sprintf("invalid method: %s", method.Value))
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func conjugateGradient(f DenseGradientF, x0 DenseFloat64Vector,
+  method Method,
+  restart Restart,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook,
+  constraints ConstConstraints,
+  lineSearchArgs []interface{}) (DenseFloat64Vector, error) {
+
+  n := x0.Dim()
+  x1 := x0.Clone()
+  x2 := x0.Clone()
+  g1 := NullDenseFloat64Vector(n)
+  g2 := NullDenseFloat64Vector(n)
+  p  := NullDenseFloat64Vector(n)
+
+  // check initial value
+  if constraints.Value != nil && !constraints.Value(x1) {
+    return x1, fmt.Errorf("invalid initial value: %v", x1)
+  }
+  // evaluate objective function
+  y1, err := f(x1, g1)
+  if err != nil {
+    return x1, fmt.Errorf("invalid initial value: %s", err)
+  }
+  // line search objective and constraints
+  line := lineSearch.DenseLine{F: f, X0: x1, P: p, X: x2, G: g2, Alpha: math.NaN()}
+  lineConstraints := line.Constraints(constraints.Value)
+
+  // the strong Wolfe conditions with a small curvature constant guarantee
+  // descent directions for most updates
+  lineSearchArgs = append([]interface{}{
+    lineSearch.Method{"MoreThuente"},
+    lineSearch.Wolfe {1e-4, 0.1}}, lineSearchArgs...)
+
+  // steepest descent direction
+  for i := range p {
+    p[i] = -g1[i]
+  }
+  // initial step length
+  alpha := 1.0/math.Max(1.0, Dnorm(g1))
+  // number of iterations since the last restart
+  k := 0
+  for i := 0; i < maxIterations.Value; i++ {
+    // execute hook if available
+    if hook.Value != nil && hook.Value(x1, g1, ConstFloat64(y1)) {
+      break
+    }
+    // evaluate stop criterion
+    if gnorm := Dnorm(g1); gnorm < epsilon.Value {
+      break
+    } else
+    if math.IsNaN(gnorm) {
+      return x1, fmt.Errorf("NaN value detected")
+    }
+    alpha, err = lineSearch.RunFloat64(line.Eval, append(lineSearchArgs,
+      lineConstraints,
+      lineSearch.Parameters{alpha, 20})...)
+    if err == nil {
+      err = line.Step(alpha)
+    }
+    if err != nil || alpha == 0.0 {
+      if k == 0 {
+        return x1, fmt.Errorf("line search failed")
+      }
+      // restart with steepest descent
+      for i := range p {
+        p[i] = -g1[i]
+      }
+      alpha = 1.0/math.Max(1.0, Dnorm(g1))
+      k     = 0
+      continue
+    }
+    // previous directional derivative
+    d1 := Ddot(g1, p)
+    k++
+    // restart if the maximum number of iterations is reached or if
+    // consecutive gradients are far from orthogonal [Nocedal and Wright
+    // (2006), Eq. 5.52]
+    if k >= restart.Value || math.Abs(Ddot(g2, g1)) >= 0.1*Ddot(g2, g2) {
+      for i := range p {
+        p[i] = -g2[i]
+      }
+      k = 0
+    } else {
+      beta := getBeta(method, g1, g2, p)
+      for i := range p {
+        p[i] = -g2[i] + beta*p[i]
+      }
+    }
+    if d2 := Ddot(g2, p); d2 >= 0.0 || math.IsNaN(d2) {
+      // not a descent direction
+      for i := range p {
+        p[i] = -g2[i]
+      }
+      k = 0
+    }
+    // initial step length for the next line search [Nocedal and Wright
+    // (2006), Eq. 3.60]
+    alpha = math.Min(1.0, alpha*d1/Ddot(g2, p))
+
+    x1, x2 = x2, x1
+    g1, g2 = g2, g1
+    y1     = line.Y
+    line.X0, line.X, line.G = x1, x2, g2
+  }
+  return x1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  method           Method
+  restart          Restart
+  epsilon          Epsilon
+  maxIterations    MaxIterations
+  hook             Hook
+  constraints      Constraints
+  constConstraints ConstConstraints
+  lineSearchArgs   []interface{}
+}
+
+func getOptions(n int, args []interface{}) options {
+  opt := options{
+    method       : Method       {"PR+"},
+    restart      : Restart      {    n},
+    epsilon      : Epsilon      { 1e-8},
+    maxIterations: MaxIterations{int(^uint(0) >> 1)}}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Method:
+      opt.method = a
+    case Restart:
+      opt.restart = a
+    case Epsilon:
+      opt.epsilon = a
+    case MaxIterations:
+      opt.maxIterations = a
+    case Hook:
+      opt.hook = a
+    case Constraints:
+      opt.constraints = a
+    case ConstConstraints:
+      opt.constConstraints = a
+    case lineSearch.Method:
+      opt.lineSearchArgs = append(opt.lineSearchArgs, a)
+    case lineSearch.Wolfe:
+      opt.lineSearchArgs = append(opt.lineSearchArgs, a)
+    default:
+      panic("ConjugateGradient(): Invalid optional argument!")
+    }
+  }
+  switch opt.method.Value {
+  case "FR", "PR+", "HS", "DY":
+  default:
+    panic(fmt.Sprintf("ConjugateGradient(): Invalid method `%s'!", opt.method.Value))
+  }
+  if opt.restart.Value < 1 {
+    opt.restart.Value = 1
+  }
+  return opt
+}
+
+// Minimize f with the nonlinear conjugate gradient method starting at x0.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  n := x0.Dim()
+
+  opt := getOptions(n, args)
+
+  // objective function
+  g := AutoGradient(f, GradientType(x0), n)
+  // constraints
+  c := ConstConstraints{JoinConstraints(opt.constraints.Value, opt.constConstraints.Value)}
+
+  return conjugateGradient(g, AsDenseFloat64Vector(x0), opt.method, opt.restart, opt.epsilon, opt.maxIterations, opt.hook, c, opt.lineSearchArgs)
+}
+
+// Minimize an objective function f that computes its own gradient. This
+// avoids automatic differentiation and the memory required by the
+// algorithm is linear in the number of variables.
+func RunGradient(f interface{}, x0 ConstVector, args ...interface{}) (ConstVector, error) {
+
+  opt := getOptions(x0.Dim(), args)
+  // constraints
+  c := ConstConstraints{JoinConstraints(opt.constraints.Value, opt.constConstraints.Value)}
+
+  switch a := f.(type) {
+  case DenseGradientF:
+    return conjugateGradient(a, AsDenseFloat64Vector(x0), opt.method, opt.restart, opt.epsilon, opt.maxIterations, opt.hook, c, opt.lineSearchArgs)
+  case func(x, gradient DenseFloat64Vector) (float64, error):
+    return conjugateGradient(a, AsDenseFloat64Vector(x0), opt.method, opt.restart, opt.epsilon, opt.maxIterations, opt.hook, c, opt.lineSearchArgs)
+  default:
+    panic("invalid objective function")
+  }
+}
@@ -0,0 +1,534 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Friedman, Jerome, Trevor Hastie, and Rob Tibshirani. "Regularization
+// paths for generalized linear models via coordinate descent." Journal of
+// statistical software 33.1 (2010): 1-22.
+//
+// Tibshirani, Robert, et al. "Strong rules for discarding predictors in
+// lasso-type problems." Journal of the Royal Statistical Society: Series B
+// (Statistical Methodology) 74.2 (2012): 245-266.
+
+/* -------------------------------------------------------------------------- */
+
+package coordinateDescent
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Elastic-net mixing parameter, i.e. the penalty is given by
+//   lambda sum_j p_j (alpha |x_j| + (1-alpha)/2 x_j^2)
+// where p_j is the penalty factor of variable j (default: 1, lasso)
+type Alpha struct {
+  Value float64
+}
+
+// Decreasing sequence of regularization strengths. If not given, a path of
+// PathLength values is computed on a log scale, starting at the smallest
+// lambda for which all penalized variables are zero.
+type Lambda struct {
+  Value []float64
+}
+
+// Number of lambda values on the path (default: 100)
+type PathLength struct {
+  Value int
+}
+
+// Smallest lambda on the path relative to the largest (default: 1e-4)
+type LambdaMinRatio struct {
+  Value float64
+}
+
+// Smallest lambda on the path, overrides LambdaMinRatio if positive
+type LambdaMin struct {
+  Value float64
+}
+
+// Observation weights
+type Weights struct {
+  Value []float64
+}
+
+// Penalty factors of the variables, a penalty factor of zero excludes a
+// variable (e.g. the intercept) from regularization
+type PenaltyFactors struct {
+  Value []float64
+}
+
+// Discard variables with the sequential strong rule and iterate on the
+// active set only (default: true). Discarded variables are checked against
+// the optimality conditions once the active set converged.
+type Screening struct {
+  Value bool
+}
+
+// Convergence is reached if the relative change of the parameters is
+// smaller than epsilon
+type Epsilon struct {
+  Value float64
+}
+
+// Maximum number of Newton iterations for each lambda
+type MaxIterations struct {
+  Value int
+}
+
+// The hook receives the parameters, the relative change of the parameters,
+// the current lambda and the total number of Newton iterations
+type Hook struct {
+  Value func(x ConstVector, delta, lambda ConstScalar, i int) bool
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  alpha          Alpha
+  lambda         Lambda
+  pathLength     PathLength
+  lambdaMinRatio LambdaMinRatio
+  lambdaMin      LambdaMin
+  weights        Weights
+  penaltyFactors PenaltyFactors
+  screening      Screening
+  epsilon        Epsilon
+  maxIterations  MaxIterations
+  hook           Hook
+}
+
+func getOptions(args []interface{}) options {
+  opt := options{
+    alpha         : Alpha         { 1.0},
+    pathLength    : PathLength    {  100},
+    lambdaMinRatio: LambdaMinRatio{ 1e-4},
+    screening     : Screening     { true},
+    epsilon       : Epsilon       { 1e-7},
+    maxIterations : MaxIterations {int(^uint(0) >> 1)}}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Alpha:
+      opt.alpha = a
+    case Lambda:
+      opt.lambda = a
+    case PathLength:
+      opt.pathLength = a
+    case LambdaMinRatio:
+      opt.lambdaMinRatio = a
+    case LambdaMin:
+      opt.lambdaMin = a
+    case Weights:
+      opt.weights = a
+    case PenaltyFactors:
+      opt.penaltyFactors = a
+    case Screening:
+      opt.screening = a
+    case Epsilon:
+      opt.epsilon = a
+    case MaxIterations:
+      opt.maxIterations = a
+    case Hook:
+      opt.hook = a
+    default:
+      panic("CoordinateDescent(): Invalid optional argument!")
+    }
+  }
+  return opt
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Sparse column of the design matrix
+type column struct {
+  index []int
+  value []float64
+}
+
+type problem struct {
+  family Family
+  alpha  float64
+  y      []float64
+  w      []float64
+  pf     []float64
+  cols   []column
+  x      DenseFloat64Vector
+  // linear predictor
+  eta    []float64
+  // derivatives of the loss at the expansion point
+  d1     []float64
+  d2     []float64
+  // gradient of the quadratic model with respect to eta
+  q      []float64
+  // curvature of the quadratic model along each variable
+  a      []float64
+}
+
+func newProblem(family Family, x []ConstVector, y []float64, opt options) (*problem, error) {
+  n := len(x)
+  if n == 0 {
+    return nil, fmt.Errorf("no data given")
+  }
+  if len(y) != n {
+    return nil, fmt.Errorf("number of observations and responses do not match")
+  }
+  m := x[0].Dim()
+  if m == 0 {
+    return nil, fmt.Errorf("data has zero dimension")
+  }
+  if opt.alpha.Value < 0.0 || opt.alpha.Value > 1.0 {
+    return nil, fmt.Errorf("invalid elastic-net parameter alpha")
+  }
+  r := problem{}
+  r.family = family
+  r.alpha  = opt.alpha.Value
+  r.y      = y
+  r.w      = opt.weights.Value
+  r.pf     = opt.penaltyFactors.Value
+  if r.w == nil {
+    r.w = make([]float64, n)
+    for i := range r.w {
+      r.w[i] = 1.0
+    }
+  } else
+  if len(r.w) != n {
+    return nil, fmt.Errorf("weights have invalid dimension")
+  }
+  if r.pf == nil {
+    r.pf = make([]float64, m)
+    for j := range r.pf {
+      r.pf[j] = 1.0
+    }
+  } else
+  if len(r.pf) != m {
+    return nil, fmt.Errorf("penalty factors have invalid dimension")
+  }
+  // convert data to column format
+  r.cols = make([]column, m)
+  for i := 0; i < n; i++ {
+    if x[i].Dim() != m {
+      return nil, fmt.Errorf("data has inconsistent dimensions")
+    }
+    for it := x[i].ConstIterator(); it.Ok(); it.Next() {
+      if v := it.GetConst().GetFloat64(); v != 0.0 {
+        j := it.Index()
+        r.cols[j].index = append(r.cols[j].index, i)
+        r.cols[j].value = append(r.cols[j].value, v)
+      }
+    }
+  }
+  r.x   = NullDenseFloat64Vector(m)
+  r.eta = make([]float64, n)
+  r.d1  = make([]float64, n)
+  r.d2  = make([]float64, n)
+  r.q   = make([]float64, n)
+  r.a   = make([]float64, m)
+  return &r, nil
+}
+
+// Value of the penalized objective function
+func (obj *problem) objective(lambda float64) float64 {
+  r := 0.0
+  for i := range obj.eta {
+    l, _, _ := obj.family.Eval(obj.eta[i], obj.y[i])
+    r += obj.w[i]*l
+  }
+  for j, xj := range obj.x {
+    if xj != 0.0 && obj.pf[j] != 0.0 {
+      r += lambda*obj.pf[j]*(obj.alpha*math.Abs(xj) + 0.5*(1.0 - obj.alpha)*xj*xj)
+    }
+  }
+  return r
+}
+
+// Gradient of the unpenalized objective function with respect to all
+// variables
+func (obj *problem) gradient(g []float64) {
+  for i := range obj.eta {
+    _, obj.d1[i], _ = obj.family.Eval(obj.eta[i], obj.y[i])
+  }
+  for j, col := range obj.cols {
+    g[j] = 0.0
+    for k, i := range col.index {
+      g[j] += obj.w[i]*obj.d1[i]*col.value[k]
+    }
+  }
+}
+
+// Compute a quadratic approximation of the loss at the current linear
+// predictor
+func (obj *problem) expand(active []int) {
+  // lower bound on the curvature, which prevents numerical problems if
+  // fitted probabilities are close to zero or one
+  const minCurvature = 1e-5
+  for i := range obj.eta {
+    _, obj.d1[i], obj.d2[i] = obj.family.Eval(obj.eta[i], obj.y[i])
+    obj.d2[i] = math.Max(obj.d2[i], minCurvature)
+    obj.q [i] = obj.d1[i]
+  }
+  for _, j := range active {
+    col := obj.cols[j]
+    obj.a[j] = 0.0
+    for k, i := range col.index {
+      obj.a[j] += obj.w[i]*obj.d2[i]*col.value[k]*col.value[k]
+    }
+  }
+}
+
+// Minimize the quadratic approximation along each active variable once.
+// Returns the relative change of the parameters.
+func (obj *problem) sweep(active []int, lambda float64) float64 {
+  max_x     := 0.0
+  max_delta := 0.0
+  for _, j := range active {
+    col := obj.cols[j]
+    if obj.a[j] == 0.0 {
+      continue
+    }
+    b := 0.0
+    for k, i := range col.index {
+      b += obj.w[i]*obj.q[i]*col.value[k]
+    }
+    z := obj.a[j]*obj.x[j] - b
+    t := 0.0
+    // soft-thresholding
+    if l := lambda*obj.alpha*obj.pf[j]; z > l {
+      t = (z - l)/(obj.a[j] + lambda*(1.0 - obj.alpha)*obj.pf[j])
+    } else
+    if z < -l {
+      t = (z + l)/(obj.a[j] + lambda*(1.0 - obj.alpha)*obj.pf[j])
+    }
+    if d := t - obj.x[j]; d != 0.0 {
+      for k, i := range col.index {
+        obj.q  [i] += obj.d2[i]*d*col.value[k]
+        obj.eta[i] += d*col.value[k]
+      }
+      obj.x[j]  = t
+      max_delta = math.Max(max_delta, math.Abs(d))
+    }
+    max_x = math.Max(max_x, math.Abs(t))
+  }
+  if max_x != 0.0 {
+    return max_delta/max_x
+  }
+  return max_delta
+}
+
+// Backtracking line search from (x0, eta0) towards the current full step,
+// which is stored in (x1, eta1). The step length t relative to the full
+// step is halved until the objective drops below f1. Returns the objective
+// at the accepted point, or restores x0 if t drops below 1e-10.
+func (obj *problem) backtrack(active []int, lambda, f1 float64, x0, x1 DenseFloat64Vector, eta0, eta1 []float64) float64 {
+  x1.Set(obj.x)
+  copy(eta1, obj.eta)
+  for t := 0.5; t >= 1e-10; t *= 0.5 {
+    for _, j := range active {
+      obj.x[j] = (1.0 - t)*x0[j] + t*x1[j]
+    }
+    for i := range obj.eta {
+      obj.eta[i] = (1.0 - t)*eta0[i] + t*eta1[i]
+    }
+    if f2 := obj.objective(lambda); f2 <= f1 {
+      return f2
+    }
+  }
+  obj.x.Set(x0)
+  copy(obj.eta, eta0)
+  return math.Inf(1)
+}
+
+// Minimize the penalized objective function with respect to the active
+// variables using a proximal Newton method, where the subproblems are
+// solved with cyclic coordinate descent.
+func (obj *problem) solve(active []int, lambda float64, opt options, iteration *int) (bool, error) {
+  // maximum number of coordinate descent sweeps for each subproblem
+  const maxSweeps = 100000
+  x0   := obj.x.Clone()
+  eta0 := make([]float64, len(obj.eta))
+  // full proximal Newton step
+  x1   := obj.x.Clone()
+  eta1 := make([]float64, len(obj.eta))
+  f1   := obj.objective(lambda)
+  for k := 0; k < opt.maxIterations.Value; k++ {
+    x0.Set(obj.x)
+    copy(eta0, obj.eta)
+    obj.expand(active)
+    for s := 0; s < maxSweeps; s++ {
+      if obj.sweep(active, lambda) <= opt.epsilon.Value {
+        break
+      }
+    }
+    // backtracking line search on the penalized objective
+    f2 := obj.objective(lambda)
+    if math.IsNaN(f2) {
+      return false, fmt.Errorf("NaN value detected")
+    }
+    if f2 > f1 {
+      if f2 = obj.backtrack(active, lambda, f1, x0, x1, eta0, eta1); f2 > f1 {
+        // no further progress possible
+        return false, nil
+      }
+    }
+    f1 = f2
+    // relative change of the parameters
+    max_x     := 0.0
+    max_delta := 0.0
+    for _, j := range active {
+      max_x     = math.Max(max_x    , math.Abs(obj.x[j]))
+      max_delta = math.Max(max_delta, math.Abs(obj.x[j] - x0[j]))
+    }
+    delta := max_delta
+    if max_x != 0.0 {
+      delta = max_delta/max_x
+    }
+    *iteration++
+    // execute hook if available
+    if opt.hook.Value != nil && opt.hook.Value(obj.x, ConstFloat64(delta), ConstFloat64(lambda), *iteration) {
+      return true, nil
+    }
+    // evaluate stop criterion
+    if delta <= opt.epsilon.Value {
+      break
+    }
+  }
+  return false, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *problem) getLambda(g []float64, opt options) []float64 {
+  if opt.lambda.Value != nil {
+    return opt.lambda.Value
+  }
+  // largest lambda on the path, for which all penalized variables are zero
+  lambdaMax := 0.0
+  for j := range obj.x {
+    if obj.pf[j] != 0.0 {
+      lambdaMax = math.Max(lambdaMax, math.Abs(g[j])/(math.Max(obj.alpha, 1e-3)*obj.pf[j]))
+    }
+  }
+  lambdaMin := lambdaMax*opt.lambdaMinRatio.Value
+  if opt.lambdaMin.Value > 0.0 {
+    lambdaMin = opt.lambdaMin.Value
+  }
+  if lambdaMin >= lambdaMax || opt.pathLength.Value <= 1 {
+    return []float64{lambdaMin}
+  }
+  lambda := make([]float64, opt.pathLength.Value)
+  for k := range lambda {
+    t := float64(k)/float64(len(lambda)-1)
+    lambda[k] = math.Exp((1.0 - t)*math.Log(lambdaMax) + t*math.Log(lambdaMin))
+  }
+  lambda[len(lambda)-1] = lambdaMin
+  return lambda
+}
+
+func run(family Family, x []ConstVector, y []float64, opt options) ([]float64, []Vector, error) {
+  obj, err := newProblem(family, x, y, opt)
+  if err != nil {
+    return nil, nil, err
+  }
+  m := len(obj.cols)
+  g := make([]float64, m)
+  // total number of Newton iterations
+  iteration := 0
+  // fit unpenalized variables
+  active := []int{}
+  for j := 0; j < m; j++ {
+    if obj.pf[j] == 0.0 {
+      active = append(active, j)
+    }
+  }
+  if len(active) > 0 {
+    if stop, err := obj.solve(active, 0.0, opt, &iteration); err != nil {
+      return nil, nil, err
+    } else
+    if stop {
+      return []float64{math.Inf(1)}, []Vector{obj.x.Clone()}, nil
+    }
+  }
+  obj.gradient(g)
+
+  lambda     := obj.getLambda(g, opt)
+  lambdaPrev := math.Inf(1)
+  if len(lambda) > 0 {
+    lambdaPrev = lambda[0]
+  }
+  path := make([]Vector, 0, len(lambda))
+  for k, l := range lambda {
+    if l < 0.0 || l > lambdaPrev {
+      return nil, nil, fmt.Errorf("lambda values must be positive and decreasing")
+    }
+    // select active variables with the sequential strong rule
+    isActive := make([]bool, m)
+    active    = active[:0]
+    for j := 0; j < m; j++ {
+      if !opt.screening.Value || obj.pf[j] == 0.0 || obj.x[j] != 0.0 ||
+        math.Abs(g[j]) >= obj.alpha*obj.pf[j]*(2.0*l - lambdaPrev) {
+        isActive[j] = true
+        active      = append(active, j)
+      }
+    }
+    for {
+      stop, err := obj.solve(active, l, opt, &iteration)
+      if err != nil {
+        return nil, nil, err
+      }
+      obj.gradient(g)
+      if stop {
+        return lambda[:k+1], append(path, obj.x.Clone()), nil
+      }
+      // check optimality conditions of discarded variables
+      violations := 0
+      for j := 0; j < m; j++ {
+        if !isActive[j] && math.Abs(g[j]) > obj.alpha*obj.pf[j]*l {
+          isActive[j] = true
+          active      = append(active, j)
+          violations++
+        }
+      }
+      if violations == 0 {
+        break
+      }
+    }
+    path = append(path, obj.x.Clone())
+    lambdaPrev = l
+  }
+  return lambda, path, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Fit a generalized linear model with elastic-net regularization along a
+// decreasing sequence of lambda values using cyclic coordinate descent
+// with warm starts. The objective function is given by
+//   sum_i w_i l(eta_i, y_i) + lambda sum_j p_j (alpha |x_j| + (1-alpha)/2 x_j^2)
+// where l is the loss of the given family, eta = D x is the linear predictor
+// and the rows of the design matrix D are given by data. An intercept must
+// be included as a constant column of D with a penalty factor of zero.
+// Returns the lambda values and the corresponding parameters.
+func Run(family Family, data []ConstVector, y []float64, args ...interface{}) ([]float64, []Vector, error) {
+
+  opt := getOptions(args)
+
+  return run(family, data, y, opt)
+}
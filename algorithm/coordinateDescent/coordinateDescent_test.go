@@ -0,0 +1,187 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+package coordinateDescent
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Generate a design matrix with an intercept column and a sparse
+// parameter vector
+func newData(family Family, n, m int, seed int64) ([]ConstVector, []float64) {
+  r := rand.New(rand.NewSource(seed))
+  x := make([]ConstVector, n)
+  y := make([]float64, n)
+  for i := 0; i < n; i++ {
+    v := NullDenseFloat64Vector(m)
+    v[0] = 1.0
+    for j := 1; j < m; j++ {
+      v[j] = r.NormFloat64()
+    }
+    eta := 0.5 + v[1] - 0.5*v[2] + 0.25*v[3]
+    switch family.(type) {
+    case Gaussian:
+      y[i] = eta + r.NormFloat64()
+    case Binomial:
+      if r.Float64() < 1.0/(1.0 + math.Exp(-eta)) {
+        y[i] = 1.0
+      }
+    case Poisson:
+      y[i] = math.Floor(math.Exp(0.5*eta) + 2.0*r.Float64())
+    }
+    x[i] = v
+  }
+  return x, y
+}
+
+// Check optimality conditions of the penalized objective function
+func checkKKT(family Family, x []ConstVector, y []float64, theta ConstVector, lambda, alpha float64, pf []float64, eps float64) bool {
+  m := theta.Dim()
+  g := make([]float64, m)
+  for i := range x {
+    eta := 0.0
+    for j := 0; j < m; j++ {
+      eta += x[i].Float64At(j)*theta.Float64At(j)
+    }
+    _, d1, _ := family.Eval(eta, y[i])
+    for j := 0; j < m; j++ {
+      g[j] += d1*x[i].Float64At(j)
+    }
+  }
+  for j := 0; j < m; j++ {
+    t := theta.Float64At(j)
+    l := lambda*pf[j]
+    if t == 0.0 {
+      if math.Abs(g[j]) > alpha*l + eps {
+        return false
+      }
+    } else {
+      if math.Abs(g[j] + alpha*l*math.Copysign(1.0, t) + (1.0 - alpha)*l*t) > eps {
+        return false
+      }
+    }
+  }
+  return true
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestCoordinateDescentGaussian(test *testing.T) {
+  x, y := newData(Gaussian{}, 100, 20, 1)
+  pf   := make([]float64, 20)
+  for j := 1; j < 20; j++ {
+    pf[j] = 1.0
+  }
+  lambda, path, err := Run(Gaussian{}, x, y, PenaltyFactors{pf}, PathLength{20}, LambdaMinRatio{1e-2}, Epsilon{1e-12})
+  if err != nil {
+    test.Fatal(err)
+  }
+  if len(lambda) != 20 || len(path) != 20 {
+    test.Fatal("test failed")
+  }
+  // all penalized variables must be zero at the beginning of the path
+  for j := 1; j < 20; j++ {
+    if path[0].Float64At(j) != 0.0 {
+      test.Error("test failed")
+    }
+  }
+  for k := range lambda {
+    if !checkKKT(Gaussian{}, x, y, path[k], lambda[k], 1.0, pf, 1e-6) {
+      test.Errorf("test failed for lambda = %f", lambda[k])
+    }
+  }
+  // without screening
+  _, path2, err := Run(Gaussian{}, x, y, PenaltyFactors{pf}, PathLength{20}, LambdaMinRatio{1e-2}, Epsilon{1e-12}, Screening{false})
+  if err != nil {
+    test.Fatal(err)
+  }
+  for k := range path {
+    if !path[k].Equals(path2[k], 1e-8) {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestCoordinateDescentBinomial(test *testing.T) {
+  x, y := newData(Binomial{}, 200, 50, 2)
+  pf   := make([]float64, 50)
+  for j := 1; j < 50; j++ {
+    pf[j] = 1.0
+  }
+  for _, alpha := range []float64{1.0, 0.5, 0.0} {
+    lambda, path, err := Run(Binomial{}, x, y, PenaltyFactors{pf}, Alpha{alpha}, PathLength{10}, LambdaMinRatio{1e-2}, Epsilon{1e-12})
+    if err != nil {
+      test.Fatal(err)
+    }
+    for k := range lambda {
+      if !checkKKT(Binomial{}, x, y, path[k], lambda[k], alpha, pf, 1e-6) {
+        test.Errorf("test failed for alpha = %f and lambda = %f", alpha, lambda[k])
+      }
+    }
+  }
+}
+
+func TestCoordinateDescentPoisson(test *testing.T) {
+  x, y := newData(Poisson{}, 200, 10, 3)
+  pf   := make([]float64, 10)
+  for j := 1; j < 10; j++ {
+    pf[j] = 1.0
+  }
+  lambda, path, err := Run(Poisson{}, x, y, PenaltyFactors{pf}, Lambda{[]float64{20.0, 10.0, 5.0}}, Epsilon{1e-12})
+  if err != nil {
+    test.Fatal(err)
+  }
+  if len(lambda) != 3 || len(path) != 3 {
+    test.Fatal("test failed")
+  }
+  for k := range lambda {
+    if !checkKKT(Poisson{}, x, y, path[k], lambda[k], 1.0, pf, 1e-6) {
+      test.Errorf("test failed for lambda = %f", lambda[k])
+    }
+  }
+}
+
+func TestCoordinateDescentBacktracking(test *testing.T) {
+  // single intercept with f(x) = 1/2 (x - 1.5)^2, the full step from
+  // x0 = 0 to x1 = 10 is too long and the first step length with
+  // f(t x1) <= f(x0) is t = 1/4
+  obj, err := newProblem(Gaussian{}, []ConstVector{NewDenseFloat64Vector([]float64{1.0})}, []float64{1.5}, getOptions(nil))
+  if err != nil {
+    test.Fatal(err)
+  }
+  x0   := NullDenseFloat64Vector(1)
+  x1   := NullDenseFloat64Vector(1)
+  eta0 := []float64{0.0}
+  eta1 := []float64{0.0}
+  f1   := obj.objective(0.0)
+  obj.x  [0] = 10.0
+  obj.eta[0] = 10.0
+  if f2 := obj.backtrack([]int{0}, 0.0, f1, x0, x1, eta0, eta1); f2 != 0.5 {
+    test.Error("test failed")
+  }
+  if obj.x[0] != 2.5 || obj.eta[0] != 2.5 {
+    test.Error("test failed")
+  }
+}
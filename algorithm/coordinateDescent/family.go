@@ -0,0 +1,72 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+package coordinateDescent
+
+/* -------------------------------------------------------------------------- */
+
+import   "math"
+
+/* -------------------------------------------------------------------------- */
+
+// A family defines the loss of a generalized linear model as a function of
+// the linear predictor eta and the response y. Eval returns the loss and
+// its first and second derivative with respect to eta.
+type Family interface {
+  Eval(eta, y float64) (float64, float64, float64)
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Linear regression with squared error loss (eta - y)^2/2
+type Gaussian struct {
+}
+
+func (Gaussian) Eval(eta, y float64) (float64, float64, float64) {
+  r := eta - y
+  return 0.5*r*r, r, 1.0
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Logistic regression with responses y in {0, 1} and negative
+// log-likelihood log(1 + exp(eta)) - y eta
+type Binomial struct {
+}
+
+func (Binomial) Eval(eta, y float64) (float64, float64, float64) {
+  var l float64
+  if eta > 0.0 {
+    l = eta + math.Log1p(math.Exp(-eta))
+  } else {
+    l = math.Log1p(math.Exp(eta))
+  }
+  p := 1.0/(1.0 + math.Exp(-eta))
+  return l - y*eta, p - y, p*(1.0 - p)
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Poisson regression with log link and negative log-likelihood
+// exp(eta) - y eta (up to a constant)
+type Poisson struct {
+}
+
+func (Poisson) Eval(eta, y float64) (float64, float64, float64) {
+  mu := math.Exp(eta)
+  return mu - y*eta, mu - y, mu
+}
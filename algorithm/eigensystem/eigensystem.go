@@ -20,6 +20,7 @@ package eigensystem
 
 //import   "fmt"
 import   "math"
+import   "math/cmplx"
 import   "sort"
 
 import . "github.com/pbenner/autodiff"
@@ -36,13 +37,20 @@ type ComputeEigenvectors struct {
   Value bool
 }
 
+// Return complex eigenvalues and eigenvectors as DenseComplex128Vector
+// and DenseComplex128Matrix. Eigenvalues of real matrices that form
+// complex conjugate pairs are otherwise reduced to their real parts.
+type Complex struct {
+  Value bool
+}
+
 type InSitu struct {
   QrAlgorithm  qrAlgorithm.InSitu
   Eigenvalues  Vector
   Eigenvectors Matrix
 }
 
-/* sort eigenvalues by absolute value
+/* sort eigenvalues by modulus
  * -------------------------------------------------------------------------- */
 
 type sortEigenvaluesType struct {
@@ -55,7 +63,7 @@ func (obj sortEigenvaluesType) Len() int {
 }
 
 func (obj sortEigenvaluesType) Less(i, j int) bool {
-  return math.Abs(obj.v.ConstAt(i).GetFloat64()) < math.Abs(obj.v.ConstAt(j).GetFloat64())
+  return cmplx.Abs(obj.v.ConstAt(i).GetComplex128()) < cmplx.Abs(obj.v.ConstAt(j).GetComplex128())
 }
 
 func (obj sortEigenvaluesType) Swap(i, j int) {
@@ -146,6 +154,135 @@ func sortEigensystem(eigenvectors Matrix, eigenvalues Vector) {
   }
 }
 
+/* complex eigensystem
+ * -------------------------------------------------------------------------- */
+
+// Transform the real Schur form h = u^T a u into the complex Schur form
+// by triangularizing each 2x2 block on the diagonal of h with a unitary
+// transformation. The transformation is also applied to the columns of
+// u, if u is not nil.
+func getComplexSchur(h, u Matrix) {
+  n, _ := h.Dims()
+  for i := 0; i < n-1; i++ {
+    c := h.ConstAt(i+1,i).GetComplex128()
+    if c == 0.0 {
+      continue
+    }
+    a := h.ConstAt(i+0,i+0).GetComplex128()
+    b := h.ConstAt(i+0,i+1).GetComplex128()
+    d := h.ConstAt(i+1,i+1).GetComplex128()
+    // eigenvalue and eigenvector of the block
+    l  := (a+d)/2.0 + cmplx.Sqrt((a-d)*(a-d)/4.0 + b*c)
+    x1 := b
+    x2 := l-a
+    if cmplx.Abs(x1) + cmplx.Abs(x2) < cmplx.Abs(l-d) + cmplx.Abs(c) {
+      x1 = l-d
+      x2 = c
+    }
+    z := complex(math.Sqrt(real(x1*cmplx.Conj(x1) + x2*cmplx.Conj(x2))), 0.0)
+    x1 /= z
+    x2 /= z
+    // apply q = [x, y] with y = (-conj(x2), conj(x1)) to h and u
+    for j := 0; j < n; j++ {
+      t1 := h.ConstAt(i+0,j).GetComplex128()
+      t2 := h.ConstAt(i+1,j).GetComplex128()
+      h.At(i+0,j).SetComplex128(cmplx.Conj(x1)*t1 + cmplx.Conj(x2)*t2)
+      h.At(i+1,j).SetComplex128(-x2*t1 + x1*t2)
+    }
+    for _, m := range []Matrix{h, u} {
+      if m == nil {
+        continue
+      }
+      for j := 0; j < n; j++ {
+        t1 := m.ConstAt(j,i+0).GetComplex128()
+        t2 := m.ConstAt(j,i+1).GetComplex128()
+        m.At(j,i+0).SetComplex128(t1*x1 + t2*x2)
+        m.At(j,i+1).SetComplex128(t2*cmplx.Conj(x1) - t1*cmplx.Conj(x2))
+      }
+    }
+    h.At(i+1,i).SetComplex128(0.0)
+    i++
+  }
+}
+
+// Compute eigenvectors of the upper triangular matrix h by back
+// substitution and transform them with u. Pivots that vanish because of
+// repeated eigenvalues are perturbed.
+func getComplexEigenvectors(eigenvectors Matrix, h, u Matrix) {
+  if eigenvectors == nil {
+    return
+  }
+  n, _ := h.Dims()
+  // threshold for small pivots
+  eps := 0.0
+  for it := h.ConstIterator(); it.Ok(); it.Next() {
+    eps = math.Max(eps, cmplx.Abs(it.GetConst().GetComplex128()))
+  }
+  eps = math.Max(eps*1e-14, math.SmallestNonzeroFloat64)
+
+  y := make([]complex128, n)
+  for k := 0; k < n; k++ {
+    l := h.ConstAt(k,k).GetComplex128()
+    for i := k+1; i < n; i++ {
+      y[i] = 0.0
+    }
+    y[k] = 1.0
+    for i := k-1; i >= 0; i-- {
+      s := complex128(0.0)
+      for j := i+1; j <= k; j++ {
+        s += h.ConstAt(i,j).GetComplex128()*y[j]
+      }
+      p := h.ConstAt(i,i).GetComplex128() - l
+      if cmplx.Abs(p) < eps {
+        p = complex(eps, 0.0)
+      }
+      y[i] = -s/p
+    }
+    // v = u y
+    z := 0.0
+    for i := 0; i < n; i++ {
+      s := complex128(0.0)
+      for j := 0; j <= k; j++ {
+        s += u.ConstAt(i,j).GetComplex128()*y[j]
+      }
+      eigenvectors.At(i,k).SetComplex128(s)
+      z += real(s)*real(s) + imag(s)*imag(s)
+    }
+    z = math.Sqrt(z)
+    for i := 0; i < n; i++ {
+      eigenvectors.At(i,k).SetComplex128(eigenvectors.ConstAt(i,k).GetComplex128()/complex(z, 0.0))
+    }
+  }
+}
+
+func eigensystemComplex(a Matrix, inSitu *InSitu, computeEigenvectors bool, args ...interface{}) (Vector, Matrix, error) {
+  eigenvalues  := inSitu.Eigenvalues
+  eigenvectors := inSitu.Eigenvectors
+
+  n, _ := a.Dims()
+
+  args = append(args, qrAlgorithm.ComputeU{computeEigenvectors})
+  args = append(args, &inSitu.QrAlgorithm)
+  h_, u_, err := qrAlgorithm.Run(a, args...)
+  if err != nil {
+    return nil, nil, err
+  }
+  h := AsDenseComplex128Matrix(h_)
+  if computeEigenvectors {
+    u := AsDenseComplex128Matrix(u_)
+    getComplexSchur(h, u)
+    getComplexEigenvectors(eigenvectors, h, u)
+  } else {
+    getComplexSchur(h, nil)
+  }
+  for i := 0; i < n; i++ {
+    eigenvalues.At(i).Set(h.ConstAt(i,i))
+  }
+  sortEigensystem(eigenvectors, eigenvalues)
+
+  return eigenvalues, eigenvectors, nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func eigensystem(a Matrix, inSitu *InSitu, computeEigenvectors, symmetric bool, args ...interface{}) (Vector, Matrix, error) {
@@ -185,6 +322,7 @@ func Run(a Matrix, args_ ...interface{}) (Vector, Matrix, error) {
   // default values for optional arguments
   computeEigenvectors := true
   symmetric           := false
+  complexResult       := false
   inSitu              := &InSitu{}
   // arguments passed on to the qrAlgorithm
   var args []interface{}
@@ -195,6 +333,8 @@ func Run(a Matrix, args_ ...interface{}) (Vector, Matrix, error) {
       computeEigenvectors = tmp.Value
     case Symmetric:
       symmetric = tmp.Value
+    case Complex:
+      complexResult = tmp.Value
     case qrAlgorithm.ComputeU:
       // drop this option
     case *InSitu:
@@ -203,6 +343,15 @@ func Run(a Matrix, args_ ...interface{}) (Vector, Matrix, error) {
       args = append(args, arg)
     }
   }
+  if complexResult {
+    if inSitu.Eigenvalues == nil {
+      inSitu.Eigenvalues = NullDenseComplex128Vector(n)
+    }
+    if inSitu.Eigenvectors == nil && computeEigenvectors {
+      inSitu.Eigenvectors = NullDenseComplex128Matrix(n, n)
+    }
+    return eigensystemComplex(a, inSitu, computeEigenvectors, args...)
+  }
   if inSitu.Eigenvalues == nil {
     inSitu.Eigenvalues = NullDenseVector(t, n)
   }
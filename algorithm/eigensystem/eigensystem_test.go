@@ -78,3 +78,52 @@ func Test2(test *testing.T) {
     }
   }
 }
+
+func Test3(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    1, 2,  3, 4,
+    4, 4,  4, 4,
+    0, 1, -1, 1,
+    0, 0,  2, 3 }, 4, 4)
+  b := NewDenseFloat64Matrix([]float64{
+     0, -2, 0, 1,
+     2,  0, 1, 0,
+     0,  0, 3, 1,
+    -1,  0, 0, 1 }, 4, 4)
+  for _, a := range []Matrix{a, b} {
+    e, v, err := Run(a, Complex{true})
+    if err != nil {
+      test.Error(err); continue
+    }
+    // check that a v = lambda v
+    c := AsDenseComplex128Matrix(a)
+    r := NullDenseComplex128Vector(4)
+    t := NullComplex128()
+    for j := 0; j < 4; j++ {
+      r.MdotV(c, v.Col(j))
+      r.VsubV(r, v.Col(j).VmulS(v.Col(j), e.ConstAt(j)))
+      if t.Vnorm(r).GetFloat64() > 1e-8 {
+        test.Errorf("test failed for eigenvector `%d'", j)
+      }
+    }
+  }
+  // rotation with eigenvalues 2, i, -i
+  a = NewDenseFloat64Matrix([]float64{
+    0, -1, 0,
+    1,  0, 0,
+    0,  0, 2 }, 3, 3)
+  if e, _, err := Run(a, Complex{true}, ComputeEigenvectors{false}); err != nil {
+    test.Error(err)
+  } else {
+    if math.Abs(e.ConstAt(0).GetFloat64() - 2.0) > 1e-8 {
+      test.Error("test failed")
+    }
+    if !e.ConstAt(1).Equals(ConstComplex128(1i), 1e-8) && !e.ConstAt(1).Equals(ConstComplex128(-1i), 1e-8) {
+      test.Error("test failed")
+    }
+    t := NullComplex128()
+    if t.Add(e.ConstAt(1), e.ConstAt(2)); !t.Equals(ConstFloat64(0.0), 1e-8) {
+      test.Error("test failed")
+    }
+  }
+}
@@ -0,0 +1,277 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Beck, Amir, and Marc Teboulle. "A fast iterative shrinkage-thresholding
+// algorithm for linear inverse problems." SIAM journal on imaging sciences
+// 2.1 (2009): 183-202.
+//
+// O'Donoghue, Brendan, and Emmanuel Candes. "Adaptive restart for
+// accelerated gradient schemes." Foundations of computational mathematics
+// 15.3 (2015): 715-732.
+
+/* -------------------------------------------------------------------------- */
+
+package fista
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+import   "github.com/pbenner/autodiff/algorithm/saga"
+
+/* -------------------------------------------------------------------------- */
+
+// Smooth part of the objective function
+type Objective func(ConstVector) (MagicScalar, error)
+
+// Smooth part of the objective function, the gradient is stored in the
+// second argument
+type DenseGradientF func(x, gradient DenseFloat64Vector) (float64, error)
+
+// Proximal operator of the non-smooth part of the objective function. The
+// operator receives lambda multiplied by the current step size. If no
+// operator is given, FISTA reduces to an accelerated gradient method.
+type ProximalOperator struct {
+  Value saga.ProximalOperatorType
+}
+
+// Initial step size, which is decreased by backtracking whenever the
+// quadratic upper bound of the smooth part is violated
+type StepSize struct {
+  Value float64
+}
+
+// Reset momentum whenever the update direction and the gradient mapping
+// form an acute angle (default: true)
+type Restart struct {
+  Value bool
+}
+
+// Convergence is reached if the norm of the gradient mapping is smaller
+// than epsilon
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+// The hook receives the current iterate and the value of the smooth part
+// of the objective function
+type Hook struct {
+  Value func(x ConstVector, y ConstScalar) bool
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  proxop        ProximalOperator
+  stepSize      StepSize
+  restart       Restart
+  epsilon       Epsilon
+  maxIterations MaxIterations
+  hook          Hook
+}
+
+func getOptions(args []interface{}) options {
+  opt := options{
+    stepSize     : StepSize     { 1.0},
+    restart      : Restart      {true},
+    epsilon      : Epsilon      {1e-8},
+    maxIterations: MaxIterations{int(^uint(0) >> 1)}}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case ProximalOperator:
+      opt.proxop = a
+    case StepSize:
+      opt.stepSize = a
+    case Restart:
+      opt.restart = a
+    case Epsilon:
+      opt.epsilon = a
+    case MaxIterations:
+      opt.maxIterations = a
+    case Hook:
+      opt.hook = a
+    default:
+      panic("Fista(): Invalid optional argument!")
+    }
+  }
+  return opt
+}
+
+/* -------------------------------------------------------------------------- */
+
+func fista(f DenseGradientF, x0 DenseFloat64Vector, opt options) (ConstVector, error) {
+  if opt.stepSize.Value <= 0.0 {
+    return nil, fmt.Errorf("invalid step size")
+  }
+  n := x0.Dim()
+  // x1: current iterate, x2: new iterate, y: extrapolated point
+  x1 := x0.Clone()
+  x2 := NullDenseFloat64Vector(n)
+  y  := x0.Clone()
+  w  := NullDenseFloat64Vector(n)
+  g  := NullDenseFloat64Vector(n)
+  gt := NullDenseFloat64Vector(n)
+
+  proxop := opt.proxop.Value
+  lambda := 0.0
+  if proxop != nil {
+    lambda = proxop.GetLambda()
+    // restore lambda when done
+    defer proxop.SetLambda(lambda)
+  }
+  // proximal gradient step x2 = prox_{t lambda}(y - t g)
+  step := func(t float64) {
+    for i := 0; i < n; i++ {
+      w[i] = y[i] - t*g[i]
+    }
+    if proxop != nil {
+      proxop.SetLambda(t*lambda)
+      proxop.Eval(x2, w)
+    } else {
+      x2.Set(w)
+    }
+  }
+  // apply proximal operator to initial value
+  if proxop != nil {
+    proxop.SetLambda(0.0)
+    proxop.Eval(x1, x0)
+    y.Set(x1)
+  }
+  f1, err := f(x1, gt)
+  if err != nil {
+    return nil, fmt.Errorf("invalid initial value: %s", err)
+  }
+  t     := opt.stepSize.Value
+  theta := 1.0
+
+  for k := 0; k < opt.maxIterations.Value; k++ {
+    // execute hook if available
+    if opt.hook.Value != nil && opt.hook.Value(x1, ConstFloat64(f1)) {
+      break
+    }
+    fy, err := f(y, g)
+    if err != nil {
+      return x1, err
+    }
+    // backtracking
+    var f2 float64
+    for {
+      step(t)
+      if f2, err = f(x2, gt); err != nil {
+        return x1, err
+      }
+      // quadratic upper bound of f at y
+      q := fy
+      r := 0.0
+      s := 0.0
+      for i := 0; i < n; i++ {
+        d := x2[i] - y[i]
+        q += g[i]*d
+        r += d*d
+        s += (gt[i] - g[i])*(gt[i] - g[i])
+      }
+      if f2 <= q + r/(2.0*t) {
+        break
+      }
+      // close to the minimum the difference of function values is dominated
+      // by rounding errors, check the Lipschitz condition on the gradient
+      // instead
+      if f2 - q <= 1e-10*math.Abs(fy) && t*t*s <= r {
+        break
+      }
+      if t *= 0.5; t < 1e-20 {
+        return x1, fmt.Errorf("step size too small")
+      }
+    }
+    if math.IsNaN(f2) {
+      return x1, fmt.Errorf("NaN value detected")
+    }
+    // norm of the gradient mapping (y - x2)/t and inner product for the
+    // restart condition
+    gnorm := 0.0
+    inner := 0.0
+    for i := 0; i < n; i++ {
+      gnorm += (y[i] - x2[i])*(y[i] - x2[i])
+      inner += (y[i] - x2[i])*(x2[i] - x1[i])
+    }
+    gnorm = math.Sqrt(gnorm)/t
+    // update extrapolated point
+    if opt.restart.Value && inner > 0.0 {
+      theta = 1.0
+      y.Set(x2)
+    } else {
+      theta1 := theta
+      theta   = (1.0 + math.Sqrt(1.0 + 4.0*theta*theta))/2.0
+      for i := 0; i < n; i++ {
+        y[i] = x2[i] + (theta1 - 1.0)/theta*(x2[i] - x1[i])
+      }
+    }
+    x1, x2 = x2, x1
+    f1     = f2
+    // evaluate stop criterion
+    if gnorm < opt.epsilon.Value {
+      break
+    }
+  }
+  return x1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Minimize f(x) + r(x) with the fast iterative shrinkage-thresholding
+// algorithm (FISTA) starting at x0, where f is smooth and r is given by
+// its proximal operator. The gradient of f is computed with automatic
+// differentiation.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  n := x0.Dim()
+
+  opt := getOptions(args)
+
+  // objective function
+  g := AutoGradient(f, GradientType(x0), n)
+
+  if r, err := fista(g, AsDenseFloat64Vector(x0), opt); r != nil {
+    return AsDenseFloat64Vector(r), err
+  } else {
+    return nil, err
+  }
+}
+
+// Minimize f(x) + r(x), where f computes its own gradient. This avoids
+// automatic differentiation.
+func RunGradient(f interface{}, x0 ConstVector, args ...interface{}) (ConstVector, error) {
+
+  opt := getOptions(args)
+
+  switch a := f.(type) {
+  case DenseGradientF:
+    return fista(a, AsDenseFloat64Vector(x0), opt)
+  case func(x, gradient DenseFloat64Vector) (float64, error):
+    return fista(a, AsDenseFloat64Vector(x0), opt)
+  default:
+    panic("invalid objective function")
+  }
+}
@@ -0,0 +1,256 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+package fista
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/saga"
+
+/* -------------------------------------------------------------------------- */
+
+// Least-squares objective 1/2 ||A x - b||^2
+type leastSquares struct {
+  A [][]float64
+  b []float64
+}
+
+func newLeastSquares(m, n int, seed int64) leastSquares {
+  r := rand.New(rand.NewSource(seed))
+  A := make([][]float64, m)
+  b := make([]float64, m)
+  for i := 0; i < m; i++ {
+    A[i] = make([]float64, n)
+    for j := 0; j < n; j++ {
+      A[i][j] = r.NormFloat64()
+    }
+    b[i] = 2.0*r.NormFloat64()
+  }
+  return leastSquares{A, b}
+}
+
+func (obj leastSquares) eval(x, gradient DenseFloat64Vector) (float64, error) {
+  y := 0.0
+  for j := range gradient {
+    gradient[j] = 0.0
+  }
+  for i := range obj.A {
+    r := -obj.b[i]
+    for j := range x {
+      r += obj.A[i][j]*x[j]
+    }
+    y += 0.5*r*r
+    for j := range x {
+      gradient[j] += r*obj.A[i][j]
+    }
+  }
+  return y, nil
+}
+
+func (obj leastSquares) gradient(x ConstVector) DenseFloat64Vector {
+  g := NullDenseFloat64Vector(x.Dim())
+  obj.eval(AsDenseFloat64Vector(x), g)
+  return g
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestFistaLasso(test *testing.T) {
+  const lambda = 10.0
+  f  := newLeastSquares(50, 20, 1)
+  x0 := NullDenseFloat64Vector(20)
+
+  for _, restart := range []bool{false, true} {
+    proxop := &saga.ProximalOperatorL1{lambda}
+    xn, err := RunGradient(DenseGradientF(f.eval), x0,
+      ProximalOperator{proxop},
+      Restart{restart},
+      Epsilon{1e-10})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if proxop.Lambda != lambda {
+      test.Error("test failed")
+    }
+    // check optimality conditions
+    g := f.gradient(xn)
+    k := 0
+    for i := 0; i < xn.Dim(); i++ {
+      if x := xn.Float64At(i); x == 0.0 {
+        k++
+        if math.Abs(g[i]) > lambda + 1e-6 {
+          test.Errorf("test failed for variable %d", i)
+        }
+      } else {
+        if math.Abs(g[i] + lambda*math.Copysign(1.0, x)) > 1e-6 {
+          test.Errorf("test failed for variable %d", i)
+        }
+      }
+    }
+    if k == 0 {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestFistaGroupLasso(test *testing.T) {
+  const lambda = 20.0
+  f      := newLeastSquares(50, 12, 2)
+  groups := [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9, 10, 11}}
+  x0     := NullDenseFloat64Vector(12)
+
+  xn, err := RunGradient(DenseGradientF(f.eval), x0,
+    ProximalOperator{&saga.ProximalOperatorGroupL1{lambda, groups}},
+    Epsilon{1e-10})
+  if err != nil {
+    test.Fatal(err)
+  }
+  g := f.gradient(xn)
+  for _, group := range groups {
+    r1, r2 := 0.0, 0.0
+    for _, i := range group {
+      r1 += xn.Float64At(i)*xn.Float64At(i)
+      r2 += g[i]*g[i]
+    }
+    r1 = math.Sqrt(r1)
+    r2 = math.Sqrt(r2)
+    if r1 == 0.0 {
+      if r2 > lambda + 1e-6 {
+        test.Error("test failed")
+      }
+    } else {
+      for _, i := range group {
+        if math.Abs(g[i] + lambda*xn.Float64At(i)/r1) > 1e-6 {
+          test.Error("test failed")
+        }
+      }
+    }
+  }
+}
+
+func TestFistaElasticNet(test *testing.T) {
+  const lambda = 10.0
+  const alpha  = 0.5
+  f  := newLeastSquares(50, 20, 3)
+  x0 := NullDenseFloat64Vector(20)
+
+  xn, err := RunGradient(DenseGradientF(f.eval), x0,
+    ProximalOperator{&saga.ProximalOperatorElasticNet{lambda, alpha}},
+    Epsilon{1e-10})
+  if err != nil {
+    test.Fatal(err)
+  }
+  g := f.gradient(xn)
+  for i := 0; i < xn.Dim(); i++ {
+    if x := xn.Float64At(i); x == 0.0 {
+      if math.Abs(g[i]) > lambda*alpha + 1e-6 {
+        test.Errorf("test failed for variable %d", i)
+      }
+    } else {
+      if math.Abs(g[i] + lambda*alpha*math.Copysign(1.0, x) + lambda*(1.0-alpha)*x) > 1e-6 {
+        test.Errorf("test failed for variable %d", i)
+      }
+    }
+  }
+}
+
+func TestFistaBox(test *testing.T) {
+  // minimize (x1 - 2)^2 + (x2 + 2)^2 + (x3 - 0.5)^2 subject to 0 <= x <= 1
+  f := func(x ConstVector) (MagicScalar, error) {
+    c := []float64{2.0, -2.0, 0.5}
+    r := NullReal64()
+    t := NullReal64()
+    for i := 0; i < x.Dim(); i++ {
+      t.Sub(x.ConstAt(i), ConstFloat64(c[i]))
+      t.Mul(t, t)
+      r.Add(r, t)
+    }
+    return r, nil
+  }
+  proxop := &saga.ProximalOperatorBox{
+    Lower: DenseFloat64Vector{0, 0, 0},
+    Upper: DenseFloat64Vector{1, 1, 1}}
+  x0 := NewDenseFloat64Vector([]float64{5, 5, 5})
+  xr := NewDenseFloat64Vector([]float64{1, 0, 0.5})
+
+  xn, err := Run(f, x0, ProximalOperator{proxop}, Epsilon{1e-10})
+  if err != nil {
+    test.Fatal(err)
+  }
+  if math.Abs(xn.Float64At(0) - xr[0]) > 1e-8 ||
+     math.Abs(xn.Float64At(1) - xr[1]) > 1e-8 ||
+     math.Abs(xn.Float64At(2) - xr[2]) > 1e-8 {
+    test.Error("test failed")
+  }
+}
+
+func TestFistaRosenbrock(test *testing.T) {
+  // without proximal operator
+  f := func(x ConstVector) (MagicScalar, error) {
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  x0 := NewDenseFloat64Vector([]float64{-0.5, 2})
+
+  xn, err := Run(f, x0, Epsilon{1e-8})
+  if err != nil {
+    test.Fatal(err)
+  }
+  if math.Abs(xn.Float64At(0) - 1.0) > 1e-6 || math.Abs(xn.Float64At(1) - 1.0) > 1e-6 {
+    test.Error("test failed")
+  }
+}
+
+func TestFistaRosenbrockReverse(test *testing.T) {
+  // the gradient is computed with the scalar type of x0
+  f := func(x ConstVector) (MagicScalar, error) {
+    if x.ElementType() != ReverseReal64Type {
+      return nil, fmt.Errorf("invalid scalar type")
+    }
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReverseReal64()
+    t2 := NullReverseReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  x0 := NewDenseReverseReal64Vector([]float64{-0.5, 2})
+
+  xn, err := Run(f, x0, Epsilon{1e-8})
+  if err != nil {
+    test.Fatal(err)
+  }
+  if math.Abs(xn.Float64At(0) - 1.0) > 1e-6 || math.Abs(xn.Float64At(1) - 1.0) > 1e-6 {
+    test.Error("test failed")
+  }
+}
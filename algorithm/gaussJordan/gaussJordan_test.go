@@ -70,3 +70,28 @@ func TestGaussJordan2(test *testing.T) {
     }
   }
 }
+
+func TestGaussJordan3(test *testing.T) {
+  n := 3
+  // pivoting results in a row permutation that is a cycle of length 3
+  a := NewDenseFloat64Matrix([]float64{1, 4, 0, 1, 0, 1, 3, 1, 0}, n, n)
+  b := NewDenseFloat64Vector([]float64{1, 1, 1})
+  r := NewDenseFloat64Matrix([]float64{-1, 0, 4, 3, 0, -1, 1, 11, -4}, n, n)
+  s := NewDenseFloat64Vector([]float64{3, 2, 8})
+  r.MdivS(r, ConstFloat64(11.0))
+  s.VdivS(s, ConstFloat64(11.0))
+  t := NewFloat64(0.0)
+  x := NullDenseFloat64Matrix(n, n)
+  x.SetIdentity()
+
+  if err := Run(a, x, b); err != nil {
+    test.Error(err)
+  } else {
+    if t.Mnorm(r.MsubM(x, r)).GetFloat64() > 1e-8 {
+      test.Error("Gauss-Jordan method failed!")
+    }
+    if t.Vnorm(s.VsubV(b, s)).GetFloat64() > 1e-8 {
+      test.Error("Gauss-Jordan method failed!")
+    }
+  }
+}
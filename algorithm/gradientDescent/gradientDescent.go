@@ -22,6 +22,7 @@ import   "math"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/algorithm"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
 
 /* -------------------------------------------------------------------------- */
 
@@ -36,13 +37,17 @@ type Hook struct {
 /* -------------------------------------------------------------------------- */
 
 func gradientDescent(f func(ConstVector) (MagicScalar, error), x0 Vector, step, epsilon float64,
-  hook func([]float64, ConstVector, ConstScalar) bool) (Vector, error) {
+  hook func([]float64, ConstVector, ConstScalar) bool, lineSearchArgs []interface{}) (Vector, error) {
 
   // copy variables
   x := AsDenseReal64Vector(x0)
   x.Variables(1)
   // slice containing the gradient
   gradient := make([]float64, x.Dim())
+  // objective function for the line search and search direction
+  // p = -gradient
+  g := AutoGradient(f, Real64Type, x.Dim())
+  p := NullDenseFloat64Vector(x.Dim())
 
   for {
     // evaluate objective function
@@ -63,9 +68,19 @@ func gradientDescent(f func(ConstVector) (MagicScalar, error), x0 Vector, step,
     if Norm(gradient) < epsilon {
       break
     }
+    // select step size along the negative gradient
+    alpha := step
+    if len(lineSearchArgs) > 0 {
+      for i := 0; i < x.Dim(); i++ {
+        p[i] = -gradient[i]
+      }
+      if alpha, err = lineSearch.RunAlongDirection(g, x, s.GetFloat64(), DenseFloat64Vector(gradient), p, append([]interface{}{lineSearch.Parameters{step, 20}}, lineSearchArgs...)...); err != nil {
+        return x, err
+      }
+    }
     // update variables
     for i := 0; i < x.Dim(); i++ {
-      x.At(i).Sub(x.At(i), ConstFloat64(step*s.GetDerivative(i)))
+      x.At(i).Sub(x.At(i), ConstFloat64(alpha*s.GetDerivative(i)))
       if math.IsNaN(x.ConstAt(i).GetFloat64()) {
         panic("Gradient descent diverged!")
       }
@@ -76,10 +91,15 @@ func gradientDescent(f func(ConstVector) (MagicScalar, error), x0 Vector, step,
 
 /* -------------------------------------------------------------------------- */
 
+// Minimize f using gradient descent with a fixed step size. If a line search
+// method is given as optional argument, the step size is used as initial
+// trial step for the line search along the negative gradient.
 func Run(f func(ConstVector) (MagicScalar, error), x0 Vector, step float64, args ...interface{}) (Vector, error) {
 
   hook    := Hook   { nil}.Value
   epsilon := Epsilon{1e-8}.Value
+  // options passed to the line search
+  lineSearchArgs := []interface{}{}
 
   for _, arg := range args {
     switch a := arg.(type) {
@@ -87,9 +107,13 @@ func Run(f func(ConstVector) (MagicScalar, error), x0 Vector, step float64, args
       hook = a.Value
     case Epsilon:
       epsilon = a.Value
+    case lineSearch.Method:
+      lineSearchArgs = append(lineSearchArgs, a)
+    case lineSearch.Wolfe:
+      lineSearchArgs = append(lineSearchArgs, a)
     default:
       panic("GradientDescent(): Invalid optional argument!")
     }
   }
-  return gradientDescent(f, x0, step, epsilon, hook)
+  return gradientDescent(f, x0, step, epsilon, hook, lineSearchArgs)
 }
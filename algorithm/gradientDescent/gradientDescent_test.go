@@ -19,9 +19,11 @@ package gradientDescent
 /* -------------------------------------------------------------------------- */
 
 //import   "fmt"
+import   "math"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
 
 /* -------------------------------------------------------------------------- */
 
@@ -51,3 +53,27 @@ func TestRProp(test *testing.T) {
     test.Error("Inverting matrix failed!")
   }
 }
+
+func TestLineSearch(test *testing.T) {
+  // f(x) = (x1 - 1)^2 + 4 (x2 + 2)^2
+  f := func(x ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    t := NullReal64()
+    r.Sub(x.ConstAt(0), ConstFloat64(1.0))
+    r.Mul(r, r)
+    t.Add(x.ConstAt(1), ConstFloat64(2.0))
+    t.Mul(t, t)
+    t.Mul(t, ConstFloat64(4.0))
+    r.Add(r, t)
+    return r, nil
+  }
+  for _, method := range []string{"Wolfe", "Armijo", "MoreThuente", "HagerZhang"} {
+    x, err := Run(f, NewDenseFloat64Vector([]float64{0, 0}), 1.0, Epsilon{1e-8}, lineSearch.Method{method})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(x.ConstAt(0).GetFloat64() - 1.0) > 1e-6 || math.Abs(x.ConstAt(1).GetFloat64() + 2.0) > 1e-6 {
+      test.Errorf("gradient descent failed for line search method %s", method)
+    }
+  }
+}
@@ -0,0 +1,101 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Solve A x = b with the biconjugate gradient stabilized method with
+// right preconditioning [van der Vorst (1992)]. Each iteration requires
+// two products with A.
+func BiCGSTAB(a Operator, b ConstVector, args ...interface{}) (Vector, error) {
+  n   := b.Dim()
+  opt := getOptions(n, args)
+  x, err := opt.initialize(b)
+  if err != nil {
+    return nil, err
+  }
+  r  := NullDenseFloat64Vector(n)
+  r0 := NullDenseFloat64Vector(n)
+  p  := NullDenseFloat64Vector(n)
+  p_ := NullDenseFloat64Vector(n)
+  s  := NullDenseFloat64Vector(n)
+  s_ := NullDenseFloat64Vector(n)
+  t  := NullDenseFloat64Vector(n)
+  v  := NullDenseFloat64Vector(n)
+  if err := residual(r, a, x, b); err != nil {
+    return x, err
+  }
+  r0.Set(r)
+  bnorm := norm(b)
+  rho   := 1.0
+  alpha := 1.0
+  omega := 1.0
+  for k := 0; ; k++ {
+    rnorm := norm(r)
+    if opt.callHook(x, rnorm) || rnorm <= opt.epsilon.Value*bnorm {
+      break
+    }
+    if k >= opt.maxIterations.Value {
+      return x, fmt.Errorf("maximum number of iterations reached")
+    }
+    rho_new := dot(r0, r)
+    if rho_new == 0.0 {
+      return x, fmt.Errorf("method broke down")
+    }
+    if k == 0 {
+      p.Set(r)
+    } else {
+      // p = r + beta (p - omega v)
+      axpy(p, p, -omega, v)
+      axpy(p, r, (rho_new/rho)*(alpha/omega), p)
+    }
+    rho = rho_new
+    if err := opt.precondition(p_, p); err != nil {
+      return x, err
+    }
+    if err := a(v, p_); err != nil {
+      return x, err
+    }
+    alpha = rho/dot(r0, v)
+    axpy(s, r, -alpha, v)
+    if norm(s) <= opt.epsilon.Value*bnorm {
+      axpy(x, x, alpha, p_)
+      r.Set(s)
+      continue
+    }
+    if err := opt.precondition(s_, s); err != nil {
+      return x, err
+    }
+    if err := a(t, s_); err != nil {
+      return x, err
+    }
+    omega = dot(t, s)/dot(t, t)
+    if omega == 0.0 {
+      return x, fmt.Errorf("method broke down")
+    }
+    axpy(x, x, alpha, p_)
+    axpy(x, x, omega, s_)
+    axpy(r, s, -omega, t)
+  }
+  return x, nil
+}
@@ -0,0 +1,76 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Solve A x = b for a symmetric positive definite operator A with the
+// (preconditioned) conjugate gradient method [Saad (2003), Algorithm 9.1].
+// The preconditioner must also be symmetric positive definite.
+func CG(a Operator, b ConstVector, args ...interface{}) (Vector, error) {
+  n   := b.Dim()
+  opt := getOptions(n, args)
+  x, err := opt.initialize(b)
+  if err != nil {
+    return nil, err
+  }
+  r := NullDenseFloat64Vector(n)
+  z := NullDenseFloat64Vector(n)
+  p := NullDenseFloat64Vector(n)
+  q := NullDenseFloat64Vector(n)
+  if err := residual(r, a, x, b); err != nil {
+    return x, err
+  }
+  if err := opt.precondition(z, r); err != nil {
+    return x, err
+  }
+  p.Set(z)
+  bnorm := norm(b)
+  rz    := dot(r, z)
+  for k := 0; ; k++ {
+    rnorm := norm(r)
+    if opt.callHook(x, rnorm) || rnorm <= opt.epsilon.Value*bnorm {
+      break
+    }
+    if k >= opt.maxIterations.Value {
+      return x, fmt.Errorf("maximum number of iterations reached")
+    }
+    if err := a(q, p); err != nil {
+      return x, err
+    }
+    pq := dot(p, q)
+    if pq <= 0.0 {
+      return x, fmt.Errorf("operator is not positive definite")
+    }
+    alpha := rz/pq
+    axpy(x, x,  alpha, p)
+    axpy(r, r, -alpha, q)
+    if err := opt.precondition(z, r); err != nil {
+      return x, err
+    }
+    rz_new := dot(r, z)
+    axpy(p, z, rz_new/rz, p)
+    rz = rz_new
+  }
+  return x, nil
+}
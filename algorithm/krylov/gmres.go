@@ -0,0 +1,132 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Solve A x = b with the restarted generalized minimal residual method
+// GMRES(m) with right preconditioning [Saad (2003), Algorithm 9.5]. The
+// restart parameter m is set with the Restart option. Each iteration
+// requires one product with A, MaxIterations limits the total number of
+// iterations.
+func GMRES(a Operator, b ConstVector, args ...interface{}) (Vector, error) {
+  n   := b.Dim()
+  opt := getOptions(n, args)
+  m   := opt.restart.Value
+  if m < 1 {
+    return nil, fmt.Errorf("invalid restart parameter")
+  }
+  x, err := opt.initialize(b)
+  if err != nil {
+    return nil, err
+  }
+  // Krylov basis and preconditioned basis
+  v := make([]Vector, m+1)
+  z := make([]Vector, m)
+  for j := 0; j <= m; j++ {
+    v[j] = NullDenseFloat64Vector(n)
+  }
+  for j := 0; j < m; j++ {
+    z[j] = NullDenseFloat64Vector(n)
+  }
+  // Hessenberg matrix, Givens rotations and right-hand side of the
+  // least-squares problem
+  h  := make([][]float64, m+1)
+  for i := 0; i <= m; i++ {
+    h[i] = make([]float64, m)
+  }
+  cs := make([]float64, m)
+  sn := make([]float64, m)
+  g  := make([]float64, m+1)
+  y  := make([]float64, m)
+
+  r := v[0]
+  bnorm := norm(b)
+  for k := 0; ; {
+    if err := residual(r, a, x, b); err != nil {
+      return x, err
+    }
+    beta := norm(r)
+    if opt.callHook(x, beta) || beta <= opt.epsilon.Value*bnorm {
+      break
+    }
+    if k >= opt.maxIterations.Value {
+      return x, fmt.Errorf("maximum number of iterations reached")
+    }
+    scale(v[0], 1.0/beta)
+    for i := range g {
+      g[i] = 0.0
+    }
+    g[0] = beta
+    // Arnoldi process
+    j := 0
+    for j < m && k < opt.maxIterations.Value {
+      if err := opt.precondition(z[j], v[j]); err != nil {
+        return x, err
+      }
+      w := v[j+1]
+      if err := a(w, z[j]); err != nil {
+        return x, err
+      }
+      // modified Gram-Schmidt
+      for i := 0; i <= j; i++ {
+        h[i][j] = dot(w, v[i])
+        axpy(w, w, -h[i][j], v[i])
+      }
+      h[j+1][j] = norm(w)
+      if h[j+1][j] != 0.0 {
+        scale(w, 1.0/h[j+1][j])
+      }
+      // apply previous rotations to the new column
+      for i := 0; i < j; i++ {
+        h[i][j], h[i+1][j] = cs[i]*h[i][j] + sn[i]*h[i+1][j], -sn[i]*h[i][j] + cs[i]*h[i+1][j]
+      }
+      // compute new rotation to eliminate h[j+1][j]
+      d := math.Hypot(h[j][j], h[j+1][j])
+      if d == 0.0 {
+        return x, fmt.Errorf("operator is singular")
+      }
+      cs[j] = h[j][j]/d
+      sn[j] = h[j+1][j]/d
+      h[j][j], h[j+1][j] = d, 0.0
+      g[j], g[j+1] = cs[j]*g[j], -sn[j]*g[j]
+      j++; k++
+      if math.Abs(g[j]) <= opt.epsilon.Value*bnorm {
+        break
+      }
+    }
+    // solve the triangular system h y = g and update x
+    for i := j-1; i >= 0; i-- {
+      y[i] = g[i]
+      for l := i+1; l < j; l++ {
+        y[i] -= h[i][l]*y[l]
+      }
+      y[i] /= h[i][i]
+    }
+    for i := 0; i < j; i++ {
+      axpy(x, x, y[i], z[i])
+    }
+  }
+  return x, nil
+}
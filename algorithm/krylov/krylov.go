@@ -0,0 +1,166 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// A linear operator that computes r = A x. Operators allow to solve linear
+// systems without storing the matrix A.
+type Operator func(r Vector, x ConstVector) error
+
+// A preconditioner computes r = M^-1 x, where M is an approximation of A.
+type Preconditioner func(r Vector, x ConstVector) error
+
+// The iteration stops if the norm of the residual is smaller than Value
+// times the norm of the right-hand side
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+// Hooks receive the current approximation and the norm of its residual.
+// The iteration stops if the hook returns true.
+type Hook struct {
+  Value func(ConstVector, ConstScalar) bool
+}
+
+// Initial approximation of the solution, zero by default
+type InitialValue struct {
+  Value ConstVector
+}
+
+// Number of iterations after which GMRES is restarted
+type Restart struct {
+  Value int
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Returns an operator that computes matrix-vector products with a.
+func MatrixOperator(a ConstMatrix) Operator {
+  return func(r Vector, x ConstVector) error {
+    r.MdotV(a, x)
+    return nil
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  epsilon        Epsilon
+  maxIterations  MaxIterations
+  hook           Hook
+  initialValue   InitialValue
+  restart        Restart
+  preconditioner Preconditioner
+}
+
+func getOptions(n int, args []interface{}) options {
+  r := options{
+    epsilon       : Epsilon      {1e-10},
+    maxIterations : MaxIterations{10*n},
+    restart       : Restart      {30}}
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Epsilon:
+      r.epsilon = a
+    case MaxIterations:
+      r.maxIterations = a
+    case Hook:
+      r.hook = a
+    case InitialValue:
+      r.initialValue = a
+    case Restart:
+      r.restart = a
+    case Preconditioner:
+      r.preconditioner = a
+    default:
+      panic(fmt.Sprintf("invalid optional argument: %v", arg))
+    }
+  }
+  return r
+}
+
+func (obj options) initialize(b ConstVector) (Vector, error) {
+  x := NullDenseFloat64Vector(b.Dim())
+  if obj.initialValue.Value != nil {
+    if obj.initialValue.Value.Dim() != b.Dim() {
+      return nil, fmt.Errorf("initial value has invalid dimension")
+    }
+    x.Set(obj.initialValue.Value)
+  }
+  return x, nil
+}
+
+func (obj options) precondition(r Vector, x ConstVector) error {
+  if obj.preconditioner == nil {
+    r.Set(x)
+    return nil
+  }
+  return obj.preconditioner(r, x)
+}
+
+func (obj options) callHook(x ConstVector, residual float64) bool {
+  return obj.hook.Value != nil && obj.hook.Value(x, ConstFloat64(residual))
+}
+
+/* -------------------------------------------------------------------------- */
+
+func dot(a, b ConstVector) float64 {
+  r := 0.0
+  for i := 0; i < a.Dim(); i++ {
+    r += a.Float64At(i)*b.Float64At(i)
+  }
+  return r
+}
+
+func norm(a ConstVector) float64 {
+  return math.Sqrt(dot(a, a))
+}
+
+// r = a + s b
+func axpy(r Vector, a ConstVector, s float64, b ConstVector) {
+  for i := 0; i < r.Dim(); i++ {
+    r.At(i).SetFloat64(a.Float64At(i) + s*b.Float64At(i))
+  }
+}
+
+// r = s r
+func scale(r Vector, s float64) {
+  for i := 0; i < r.Dim(); i++ {
+    r.At(i).SetFloat64(s*r.Float64At(i))
+  }
+}
+
+// r = b - A x
+func residual(r Vector, a Operator, x, b ConstVector) error {
+  if err := a(r, x); err != nil {
+    return err
+  }
+  axpy(r, b, -1.0, r)
+  return nil
+}
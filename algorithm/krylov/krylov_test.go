@@ -0,0 +1,140 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Tridiagonal matrix with a on the diagonal, b on the lower and c on the
+// upper diagonal
+func tridiagonal(n int, a, b, c float64) Matrix {
+  r := NullDenseFloat64Matrix(n, n)
+  for i := 0; i < n; i++ {
+    r.At(i, i).SetFloat64(a)
+    if i > 0 {
+      r.At(i, i-1).SetFloat64(b)
+    }
+    if i < n-1 {
+      r.At(i, i+1).SetFloat64(c)
+    }
+  }
+  return AsCsrFloat64Matrix(r)
+}
+
+func checkSolution(test *testing.T, a ConstMatrix, x, b ConstVector, err error) {
+  if err != nil {
+    test.Error(err); return
+  }
+  r := NullDenseFloat64Vector(b.Dim())
+  r.MdotV(a, x)
+  if !r.Equals(b, 1e-8) {
+    test.Error("test failed")
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestCG(test *testing.T) {
+  n := 100
+  a := tridiagonal(n, 2.5, -1, -1)
+  b := NullDenseFloat64Vector(n)
+  for i := 0; i < n; i++ {
+    b.At(i).SetFloat64(float64(i % 7) - 3.0)
+  }
+  p1, err := Jacobi(a)
+  if err != nil {
+    test.Error(err); return
+  }
+  p2, err := IncompleteCholesky(a)
+  if err != nil {
+    test.Error(err); return
+  }
+  iterations := []int{}
+  for _, p := range []Preconditioner{nil, p1, p2} {
+    k := 0
+    x, err := CG(MatrixOperator(a), b, p, Hook{func(x ConstVector, r ConstScalar) bool {
+      k++; return false
+    }})
+    checkSolution(test, a, x, b, err)
+    iterations = append(iterations, k)
+  }
+  // incomplete Cholesky is exact for tridiagonal matrices
+  if iterations[2] != 2 || iterations[1] > iterations[0] {
+    test.Error("test failed")
+  }
+  // limit number of iterations
+  if _, err := CG(MatrixOperator(a), b, MaxIterations{3}); err == nil {
+    test.Error("test failed")
+  }
+  // matrix is not positive definite
+  if _, err := CG(MatrixOperator(tridiagonal(n, -2.5, 1, 1)), b); err == nil {
+    test.Error("test failed")
+  }
+}
+
+func TestNonSymmetric(test *testing.T) {
+  n := 100
+  a := tridiagonal(n, 3, -2, -0.5)
+  b := NullDenseFloat64Vector(n)
+  for i := 0; i < n; i++ {
+    b.At(i).SetFloat64(float64(i % 5) - 2.0)
+  }
+  p, _ := Jacobi(a)
+  for _, restart := range []int{5, 30} {
+    x, err := GMRES(MatrixOperator(a), b, Restart{restart})
+    checkSolution(test, a, x, b, err)
+    x, err  = GMRES(MatrixOperator(a), b, Restart{restart}, p)
+    checkSolution(test, a, x, b, err)
+  }
+  x, err := BiCGSTAB(MatrixOperator(a), b)
+  checkSolution(test, a, x, b, err)
+  x, err  = BiCGSTAB(MatrixOperator(a), b, p, InitialValue{b})
+  checkSolution(test, a, x, b, err)
+}
+
+func TestOperator(test *testing.T) {
+  n := 50
+  // matrix-free operator for the tridiagonal matrix (-1, 4, -2)
+  f := func(r Vector, x ConstVector) error {
+    for i := 0; i < n; i++ {
+      s := 4.0*x.Float64At(i)
+      if i > 0 {
+        s -= x.Float64At(i-1)
+      }
+      if i < n-1 {
+        s -= 2.0*x.Float64At(i+1)
+      }
+      r.At(i).SetFloat64(s)
+    }
+    return nil
+  }
+  a := tridiagonal(n, 4, -1, -2)
+  b := NullDenseFloat64Vector(n)
+  for i := 0; i < n; i++ {
+    b.At(i).SetFloat64(1.0)
+  }
+  x, err := GMRES(f, b)
+  checkSolution(test, a, x, b, err)
+  x, err  = BiCGSTAB(f, b)
+  checkSolution(test, a, x, b, err)
+}
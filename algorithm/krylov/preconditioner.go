@@ -0,0 +1,126 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package krylov
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "sort"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Jacobi preconditioner M = diag(A).
+func Jacobi(a ConstMatrix) (Preconditioner, error) {
+  n, m := a.Dims()
+  if n != m {
+    return nil, fmt.Errorf("not a square matrix")
+  }
+  d := make([]float64, n)
+  for i := 0; i < n; i++ {
+    if d[i] = a.Float64At(i, i); d[i] == 0.0 {
+      return nil, fmt.Errorf("matrix has zero diagonal element")
+    }
+  }
+  return func(r Vector, x ConstVector) error {
+    for i := 0; i < n; i++ {
+      r.At(i).SetFloat64(x.Float64At(i)/d[i])
+    }
+    return nil
+  }, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Lower triangular matrix in compressed row format, where the last
+// element of each row is the diagonal element
+type lowerTriangular struct {
+  index  [][]int
+  values [][]float64
+}
+
+// Incomplete Cholesky preconditioner IC(0), i.e. M = L L^T where L has the
+// same sparsity pattern as the lower triangle of A. Only non-zero elements
+// of A are visited, so that sparse matrices should be passed in a sparse
+// format.
+func IncompleteCholesky(a ConstMatrix) (Preconditioner, error) {
+  n, m := a.Dims()
+  if n != m {
+    return nil, fmt.Errorf("not a square matrix")
+  }
+  l := lowerTriangular{make([][]int, n), make([][]float64, n)}
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    if i, j := it.Index(); j < i && it.GetConst().GetFloat64() != 0.0 {
+      l.index[i] = append(l.index[i], j)
+    }
+  }
+  for i := 0; i < n; i++ {
+    sort.Ints(l.index[i])
+    l.index [i] = append(l.index[i], i)
+    l.values[i] = make([]float64, len(l.index[i]))
+    for k, j := range l.index[i] {
+      l.values[i][k] = a.Float64At(i, j)
+    }
+  }
+  for i := 0; i < n; i++ {
+    for k, j := range l.index[i] {
+      // compute sum of L_il L_jl for l < j
+      s := 0.0
+      for p, q := 0, 0; p < k && q < len(l.index[j])-1; {
+        switch {
+        case l.index[i][p] < l.index[j][q]: p++
+        case l.index[i][p] > l.index[j][q]: q++
+        default:
+          s += l.values[i][p]*l.values[j][q]; p++; q++
+        }
+      }
+      if j < i {
+        l.values[i][k] = (l.values[i][k] - s)/l.values[j][len(l.values[j])-1]
+      } else {
+        if v := l.values[i][k] - s; v <= 0.0 {
+          return nil, fmt.Errorf("incomplete Cholesky factorization failed")
+        } else {
+          l.values[i][k] = math.Sqrt(v)
+        }
+      }
+    }
+  }
+  return func(r Vector, x ConstVector) error {
+    // solve L y = x
+    for i := 0; i < n; i++ {
+      s := x.Float64At(i)
+      k := len(l.index[i])-1
+      for p := 0; p < k; p++ {
+        s -= l.values[i][p]*r.Float64At(l.index[i][p])
+      }
+      r.At(i).SetFloat64(s/l.values[i][k])
+    }
+    // solve L^T r = y
+    for i := n-1; i >= 0; i-- {
+      k := len(l.index[i])-1
+      s := r.Float64At(i)/l.values[i][k]
+      r.At(i).SetFloat64(s)
+      for p := 0; p < k; p++ {
+        j := l.index[i][p]
+        r.At(j).SetFloat64(r.Float64At(j) - l.values[i][p]*s)
+      }
+    }
+    return nil
+  }, nil
+}
@@ -0,0 +1,323 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Nocedal, Jorge, and Stephen Wright. Numerical optimization.
+// Springer Science & Business Media, 2006.
+
+/* -------------------------------------------------------------------------- */
+
+package lbfgs
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
+
+/* -------------------------------------------------------------------------- */
+
+type Objective func(ConstVector) (MagicScalar, error)
+
+// Objective function that stores the gradient at x in the second argument
+// and returns the function value
+type DenseGradientF func(x, gradient DenseFloat64Vector) (float64, error)
+
+// Number of correction pairs used to approximate the inverse Hessian
+type Memory struct {
+  Value int
+}
+
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+type Hook struct {
+  Value func(x, gradient ConstVector, y ConstScalar) bool
+}
+
+type Constraints struct {
+  Value func(x Vector) bool
+}
+
+type ConstConstraints struct {
+  Value func(x ConstVector) bool
+}
+
+// Lower bounds on the variables. Elements may be -Inf if a variable is
+// not bounded from below.
+type LowerBounds struct {
+  Value ConstVector
+}
+
+// Upper bounds on the variables. Elements may be +Inf if a variable is
+// not bounded from above.
+type UpperBounds struct {
+  Value ConstVector
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Correction pairs s = x_{k+1} - x_k and y = g_{k+1} - g_k stored in a ring
+// buffer
+type history struct {
+  s   []DenseFloat64Vector
+  y   []DenseFloat64Vector
+  rho []float64
+  // index of the next pair and number of stored pairs
+  k   int
+  n   int
+}
+
+func newHistory(m, n int) history {
+  h := history{s: make([]DenseFloat64Vector, m), y: make([]DenseFloat64Vector, m), rho: make([]float64, m)}
+  for i := 0; i < m; i++ {
+    h.s[i] = NullDenseFloat64Vector(n)
+    h.y[i] = NullDenseFloat64Vector(n)
+  }
+  return h
+}
+
+func (h *history) reset() {
+  h.k = 0
+  h.n = 0
+}
+
+// Store a new correction pair. Pairs that violate the curvature condition
+// are skipped.
+func (h *history) push(x1, x2, g1, g2 DenseFloat64Vector) bool {
+  s  := h.s[h.k]
+  y  := h.y[h.k]
+  sy := 0.0
+  for i := range s {
+    s[i] = x2[i] - x1[i]
+    y[i] = g2[i] - g1[i]
+    sy  += s[i]*y[i]
+  }
+  if sy <= 0.0 {
+    return false
+  }
+  h.rho[h.k] = 1.0/sy
+  h.k = (h.k+1) % len(h.s)
+  if h.n < len(h.s) {
+    h.n++
+  }
+  return true
+}
+
+// Compute the search direction p = -H g with the two-loop recursion
+// [Nocedal and Wright (2006), Algorithm 7.4].
+func (h *history) direction(p, g DenseFloat64Vector, alpha []float64) {
+  m := len(h.s)
+  for i := range p {
+    p[i] = -g[i]
+  }
+  if h.n == 0 {
+    // scale first step to unit length
+    if gnorm := Dnorm(g); gnorm > 0.0 {
+      for i := range p {
+        p[i] /= gnorm
+      }
+    }
+    return
+  }
+  for l := 1; l <= h.n; l++ {
+    j := (h.k - l + m) % m
+    alpha[j] = h.rho[j]*Ddot(h.s[j], p)
+    Daxpy(p, -alpha[j], h.y[j])
+  }
+  // initial Hessian approximation H0 = s^T y / y^T y I
+  j := (h.k - 1 + m) % m
+  gamma := 1.0/(h.rho[j]*Ddot(h.y[j], h.y[j]))
+  for i := range p {
+    p[i] *= gamma
+  }
+  for l := h.n; l >= 1; l-- {
+    j := (h.k - l + m) % m
+    beta := h.rho[j]*Ddot(h.y[j], p)
+    Daxpy(p, alpha[j] - beta, h.s[j])
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func lbfgs(f DenseGradientF, x0 DenseFloat64Vector,
+  memory Memory,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook,
+  constraints ConstConstraints) (DenseFloat64Vector, error) {
+
+  n := x0.Dim()
+  x1 := x0.Clone()
+  x2 := x0.Clone()
+  g1 := NullDenseFloat64Vector(n)
+  g2 := NullDenseFloat64Vector(n)
+  p  := NullDenseFloat64Vector(n)
+  h  := newHistory(memory.Value, n)
+  // temporary memory for the two-loop recursion
+  t  := make([]float64, memory.Value)
+
+  // check initial value
+  if constraints.Value != nil && !constraints.Value(x1) {
+    return x1, fmt.Errorf("invalid initial value: %v", x1)
+  }
+  // evaluate objective function
+  y1, err := f(x1, g1)
+  if err != nil {
+    return x1, fmt.Errorf("invalid initial value: %s", err)
+  }
+  // line search objective and constraints
+  line := lineSearch.DenseLine{F: f, X0: x1, P: p, X: x2, G: g2, Alpha: math.NaN()}
+  lineConstraints := line.Constraints(constraints.Value)
+
+  for i := 0; i < maxIterations.Value; i++ {
+    // execute hook if available
+    if hook.Value != nil && hook.Value(x1, g1, ConstFloat64(y1)) {
+      break
+    }
+    // evaluate stop criterion
+    if gnorm := Dnorm(g1); gnorm < epsilon.Value {
+      break
+    } else
+    if math.IsNaN(gnorm) {
+      return x1, fmt.Errorf("NaN value detected")
+    }
+    h.direction(p, g1, t)
+    if Ddot(p, g1) >= 0.0 {
+      // not a descent direction, reset history
+      h.reset()
+      h.direction(p, g1, t)
+    }
+    alpha, err := lineSearch.RunFloat64(line.Eval,
+      lineConstraints,
+      lineSearch.Parameters{1, 20})
+    if err == nil {
+      err = line.Step(alpha)
+    }
+    if err != nil || alpha == 0.0 {
+      if h.n == 0 {
+        return x1, fmt.Errorf("line search failed")
+      }
+      // restart with steepest descent
+      h.reset()
+      continue
+    }
+    h.push(x1, x2, g1, g2)
+
+    x1, x2 = x2, x1
+    g1, g2 = g2, g1
+    y1     = line.Y
+    line.X0, line.X, line.G = x1, x2, g2
+  }
+  return x1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  memory           Memory
+  epsilon          Epsilon
+  maxIterations    MaxIterations
+  hook             Hook
+  constraints      Constraints
+  constConstraints ConstConstraints
+  lowerBounds      LowerBounds
+  upperBounds      UpperBounds
+}
+
+func getOptions(args []interface{}) options {
+  opt := options{
+    memory       : Memory       {  10},
+    epsilon      : Epsilon      {1e-8},
+    maxIterations: MaxIterations{int(^uint(0) >> 1)}}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Memory:
+      opt.memory = a
+    case Epsilon:
+      opt.epsilon = a
+    case MaxIterations:
+      opt.maxIterations = a
+    case Hook:
+      opt.hook = a
+    case Constraints:
+      opt.constraints = a
+    case ConstConstraints:
+      opt.constConstraints = a
+    case LowerBounds:
+      opt.lowerBounds = a
+    case UpperBounds:
+      opt.upperBounds = a
+    default:
+      panic("Lbfgs(): Invalid optional argument!")
+    }
+  }
+  if opt.memory.Value < 1 {
+    panic("Lbfgs(): Memory must be positive!")
+  }
+  return opt
+}
+
+func run(f DenseGradientF, x0 ConstVector, opt options, constraints ConstConstraints) (DenseFloat64Vector, error) {
+  if opt.lowerBounds.Value != nil || opt.upperBounds.Value != nil {
+    l, u, err := BoxBounds(x0.Dim(), opt.lowerBounds.Value, opt.upperBounds.Value)
+    if err != nil {
+      return nil, err
+    }
+    return lbfgsb(f, AsDenseFloat64Vector(x0), l, u, opt.memory, opt.epsilon, opt.maxIterations, opt.hook, constraints)
+  }
+  return lbfgs(f, AsDenseFloat64Vector(x0), opt.memory, opt.epsilon, opt.maxIterations, opt.hook, constraints)
+}
+
+// Minimize f with the limited-memory BFGS algorithm starting at x0.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  opt := getOptions(args)
+
+  n := x0.Dim()
+  // objective function
+  g := AutoGradient(f, GradientType(x0), n)
+
+  return run(g, x0, opt, ConstConstraints{JoinConstraints(opt.constraints.Value, opt.constConstraints.Value)})
+}
+
+// Minimize an objective function f that computes its own gradient. This
+// avoids automatic differentiation and the memory required by the
+// algorithm is linear in the number of variables.
+func RunGradient(f interface{}, x0 ConstVector, args ...interface{}) (ConstVector, error) {
+
+  opt := getOptions(args)
+  // constraints
+  c := ConstConstraints{JoinConstraints(opt.constraints.Value, opt.constConstraints.Value)}
+
+  switch a := f.(type) {
+  case DenseGradientF:
+    return run(a, x0, opt, c)
+  case func(x, gradient DenseFloat64Vector) (float64, error):
+    return run(a, x0, opt, c)
+  default:
+    panic("invalid objective function")
+  }
+}
@@ -0,0 +1,158 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lbfgs
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+
+/* -------------------------------------------------------------------------- */
+
+// Project x onto the box [l, u].
+func project(x DenseFloat64Vector, l, u []float64) {
+  for i := range x {
+    x[i] = math.Min(math.Max(x[i], l[i]), u[i])
+  }
+}
+
+// Compute the set of active variables, i.e. variables at a bound where
+// the negative gradient points outside the box. Returns the norm of the
+// projected gradient P(x - g) - x.
+func activeSet(active []bool, x, g DenseFloat64Vector, l, u []float64) float64 {
+  r := 0.0
+  for i := range x {
+    active[i] = (x[i] <= l[i] && g[i] > 0.0) || (x[i] >= u[i] && g[i] < 0.0)
+    d := math.Min(math.Max(x[i] - g[i], l[i]), u[i]) - x[i]
+    r += d*d
+  }
+  return math.Sqrt(r)
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Projected L-BFGS for box constraints. At each iteration the active set
+// is identified and the quasi-Newton direction is computed in the subspace
+// of free variables. Steps are projected onto the box and the step length
+// is selected by backtracking along the projected path until the Armijo
+// condition is satisfied [Kelley (1999), Section 5.5].
+func lbfgsb(f DenseGradientF, x0 DenseFloat64Vector, l, u []float64,
+  memory Memory,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook,
+  constraints ConstConstraints) (DenseFloat64Vector, error) {
+
+  // constant for the Armijo condition
+  c1 := 1e-4
+
+  n := x0.Dim()
+  x1 := x0.Clone()
+  x2 := x0.Clone()
+  g1 := NullDenseFloat64Vector(n)
+  g2 := NullDenseFloat64Vector(n)
+  gf := NullDenseFloat64Vector(n)
+  p  := NullDenseFloat64Vector(n)
+  h  := newHistory(memory.Value, n)
+  // temporary memory for the two-loop recursion
+  t  := make([]float64, memory.Value)
+  active := make([]bool, n)
+
+  project(x1, l, u)
+  // check initial value
+  if constraints.Value != nil && !constraints.Value(x1) {
+    return x1, fmt.Errorf("invalid initial value: %v", x1)
+  }
+  // evaluate objective function
+  y1, err := f(x1, g1)
+  if err != nil {
+    return x1, fmt.Errorf("invalid initial value: %s", err)
+  }
+  // compute direction in the subspace of free variables
+  direction := func() {
+    for i := range gf {
+      if active[i] {
+        gf[i] = 0.0
+      } else {
+        gf[i] = g1[i]
+      }
+    }
+    h.direction(p, gf, t)
+    for i := range p {
+      if active[i] {
+        p[i] = 0.0
+      }
+    }
+  }
+  for i := 0; i < maxIterations.Value; i++ {
+    // execute hook if available
+    if hook.Value != nil && hook.Value(x1, g1, ConstFloat64(y1)) {
+      break
+    }
+    // evaluate stop criterion
+    if pgnorm := activeSet(active, x1, g1, l, u); pgnorm < epsilon.Value {
+      break
+    } else
+    if math.IsNaN(pgnorm) {
+      return x1, fmt.Errorf("NaN value detected")
+    }
+    direction()
+    if Ddot(p, g1) >= 0.0 {
+      // not a descent direction, reset history
+      h.reset()
+      direction()
+    }
+    // backtracking along the projected path
+    y2    := 0.0
+    ok    := false
+    alpha := 1.0
+    for k := 0; k < 50 && !ok; k++ {
+      for i := range x2 {
+        x2[i] = x1[i] + alpha*p[i]
+      }
+      project(x2, l, u)
+      if constraints.Value == nil || constraints.Value(x2) {
+        if y2, err = f(x2, g2); err == nil {
+          // directional derivative along the projected step
+          d := 0.0
+          for i := range x2 {
+            d += g1[i]*(x2[i] - x1[i])
+          }
+          ok = y2 <= y1 + c1*d
+        }
+      }
+      alpha *= 0.5
+    }
+    if !ok {
+      if h.n == 0 {
+        return x1, fmt.Errorf("line search failed")
+      }
+      // restart with steepest descent
+      h.reset()
+      continue
+    }
+    h.push(x1, x2, g1, g2)
+
+    x1, x2 = x2, x1
+    g1, g2 = g2, g1
+    y1     = y2
+  }
+  return x1, nil
+}
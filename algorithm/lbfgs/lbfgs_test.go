@@ -0,0 +1,202 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lbfgs
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestLbfgsRosenbrock(test *testing.T) {
+
+  f := func(x ConstVector) (MagicScalar, error) {
+    // f(x1, x2) = (a - x1)^2 + b(x2 - x1^2)^2
+    // a = 1
+    // b = 100
+    // minimum: (x1,x2) = (a, a^2)
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  t  := NewFloat64(0.0)
+  x0 := NewDenseFloat64Vector([]float64{-0.5, 2})
+  xr := NewDenseFloat64Vector([]float64{   1, 1})
+  for _, m := range []int{1, 5} {
+    xn, err := Run(f, x0, Memory{m}, Epsilon{1e-10})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if t.Vnorm(xn.VsubV(xn, xr)).GetFloat64() > 1e-8 {
+      test.Error("L-BFGS Rosenbrock test failed!")
+    }
+  }
+}
+
+func TestLbfgsConstraints(test *testing.T) {
+
+  f := func(x ConstVector) (MagicScalar, error) {
+    // f(x) = (x - 2)^2, subject to x <= 1.5
+    t := NullReal64()
+    t.Sub(x.ConstAt(0), ConstFloat64(2.0))
+    t.Mul(t, t)
+    return t, nil
+  }
+  n := 0
+  xn, err := Run(f, NewDenseFloat64Vector([]float64{0.0}),
+    MaxIterations{20},
+    Constraints{func(x Vector) bool { return x.Float64At(0) <= 1.5 }},
+    Hook{func(x, g ConstVector, y ConstScalar) bool { n++; return false }})
+  if err != nil {
+    test.Error(err)
+  }
+  if xn.Float64At(0) > 1.5 || xn.Float64At(0) < 1.0 || n == 0 {
+    test.Error("test failed")
+  }
+}
+
+func TestLbfgsConstraints2(test *testing.T) {
+  // f(x) = (x_1 - 2)^2 + (x_2 - 2)^2, subject to x_1 <= 1.5 and x_2 <= 1.5
+  f1 := func(x ConstVector) (MagicScalar, error) {
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Sub(x.ConstAt(0), ConstFloat64(2.0))
+    t1.Mul(t1, t1)
+    t2.Sub(x.ConstAt(1), ConstFloat64(2.0))
+    t2.Mul(t2, t2)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  f2 := func(x, gradient DenseFloat64Vector) (float64, error) {
+    gradient[0] = 2.0*(x[0] - 2.0)
+    gradient[1] = 2.0*(x[1] - 2.0)
+    return (x[0] - 2.0)*(x[0] - 2.0) + (x[1] - 2.0)*(x[1] - 2.0), nil
+  }
+  c1 := Constraints     {func(x      Vector) bool { return x.Float64At(0) <= 1.5 }}
+  c2 := ConstConstraints{func(x ConstVector) bool { return x.Float64At(1) <= 1.5 }}
+  x0 := NewDenseFloat64Vector([]float64{0.0, 0.0})
+
+  check := func(xn ConstVector, err error) {
+    if err != nil {
+      test.Error(err); return
+    }
+    if xn.Float64At(0) > 1.5 || xn.Float64At(1) > 1.5 {
+      test.Error("test failed")
+    }
+  }
+  check(Run        (f1, x0, MaxIterations{20}, c1, c2))
+  check(RunGradient(f2, x0, MaxIterations{20}, c1, c2))
+}
+
+func TestLbfgsGradient(test *testing.T) {
+  // large ill-conditioned quadratic f(x) = sum_i i (x_i - 1)^2
+  n := 10000
+  f := func(x, gradient DenseFloat64Vector) (float64, error) {
+    y := 0.0
+    for i := 0; i < n; i++ {
+      d := x[i] - 1.0
+      y += float64(i+1)*d*d
+      gradient[i] = 2.0*float64(i+1)*d
+    }
+    return y, nil
+  }
+  xn, err := RunGradient(DenseGradientF(f), NullDenseFloat64Vector(n), Epsilon{1e-6})
+  if err != nil {
+    test.Error(err); return
+  }
+  for i := 0; i < n; i++ {
+    if math.Abs(xn.Float64At(i) - 1.0) > 1e-6 {
+      test.Error("test failed"); break
+    }
+  }
+}
+
+func TestLbfgsBounds1(test *testing.T) {
+
+  f := func(x ConstVector) (MagicScalar, error) {
+    // f(x1, x2) = (a - x1)^2 + b(x2 - x1^2)^2
+    a  := ConstFloat64(  1.0)
+    b  := ConstFloat64(100.0)
+    c  := ConstFloat64(  2.0)
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Mul(b, t1.Pow(t1.Sub(x.ConstAt(1), t1.Mul(x.ConstAt(0), x.ConstAt(0))), c))
+    t2.Pow(t2.Sub(a, x.ConstAt(0)), c)
+    t1.Add(t1, t2)
+    return t1, nil
+  }
+  // minimum subject to x1 <= 0.5 is at the boundary
+  t  := NewFloat64(0.0)
+  x0 := NewDenseFloat64Vector([]float64{-1.5, 2})
+  xr := NewDenseFloat64Vector([]float64{ 0.5, 0.25})
+  xn, err := Run(f, x0, Epsilon{1e-10},
+    LowerBounds{NewDenseFloat64Vector([]float64{-2, math.Inf(-1)})},
+    UpperBounds{NewDenseFloat64Vector([]float64{0.5, math.Inf(1)})})
+  if err != nil {
+    test.Error(err); return
+  }
+  if t.Vnorm(xn.VsubV(xn, xr)).GetFloat64() > 1e-8 {
+    test.Error("test failed")
+  }
+}
+
+func TestLbfgsBounds2(test *testing.T) {
+  // f(x) = sum_i (x_i - c_i)^2 with c_i in [-2, 2] and bounds [-1, 1],
+  // starting outside of the box
+  n := 100
+  f := func(x, gradient DenseFloat64Vector) (float64, error) {
+    y := 0.0
+    for i := 0; i < n; i++ {
+      d := x[i] - (4.0*float64(i)/float64(n-1) - 2.0)
+      y += d*d
+      gradient[i] = 2.0*d
+    }
+    return y, nil
+  }
+  l := NullDenseFloat64Vector(n)
+  u := NullDenseFloat64Vector(n)
+  x := NullDenseFloat64Vector(n)
+  for i := 0; i < n; i++ {
+    l[i] = -1.0
+    u[i] =  1.0
+    x[i] =  3.0
+  }
+  xn, err := RunGradient(DenseGradientF(f), x, LowerBounds{l}, UpperBounds{u})
+  if err != nil {
+    test.Error(err); return
+  }
+  for i := 0; i < n; i++ {
+    c := math.Min(math.Max(4.0*float64(i)/float64(n-1) - 2.0, -1.0), 1.0)
+    if math.Abs(xn.Float64At(i) - c) > 1e-6 {
+      test.Error("test failed"); break
+    }
+  }
+  // invalid bounds
+  if _, err := RunGradient(DenseGradientF(f), x, LowerBounds{u}, UpperBounds{l}); err == nil {
+    test.Error("test failed")
+  }
+}
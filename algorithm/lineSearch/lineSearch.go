@@ -27,6 +27,7 @@ import   "fmt"
 import   "math"
 
 import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
 
 /* -------------------------------------------------------------------------- */
 
@@ -46,6 +47,26 @@ type Hook struct {
   Value func(ConstScalar, ConstScalar, ConstScalar) bool
 }
 
+// Line search method, which is either "Wolfe" (default), "Armijo",
+// "MoreThuente" or "HagerZhang"
+type Method struct {
+  Value string
+}
+
+// Constants of the sufficient decrease (C1) and curvature (C2) conditions
+type Wolfe struct {
+  C1 float64
+  C2 float64
+}
+
+type options struct {
+  parameters  Parameters
+  constraints constraints
+  hook        Hook
+  method      Method
+  wolfe       Wolfe
+}
+
 func newConstraints(constraints Constraints) constraints {
   r := func(alpha float64) bool {
     if constraints.Value != nil {
@@ -67,13 +88,13 @@ func quadraticMin(a, fa, fpa, b, fb float64) float64 {
   return a - C / (2.0 * B)
 }
 
-func zoom(f objective, alpha_lo, alpha_hi, y0, ylo, yhi, g0, glo float64, maxEval int, hook Hook) (float64, error) {
+func zoom(f objective, alpha_lo, alpha_hi, y0, ylo, yhi, g0, glo float64, maxEval int, hook Hook, wolfe Wolfe) (float64, error) {
 
   var alpha_j float64
 
   // constants for Wolfe conditions
-  c1 := 1e-4
-  c2 := 0.9
+  c1 := wolfe.C1
+  c2 := wolfe.C2
 
   if maxEval <= 0 {
     return quadraticMin(alpha_lo, ylo, glo, alpha_hi, yhi), nil
@@ -119,21 +140,18 @@ func zoom(f objective, alpha_lo, alpha_hi, y0, ylo, yhi, g0, glo float64, maxEva
   return alpha_j, nil
 }
 
-func lineSearch(f objective,
-  parameters Parameters,
-  constraints constraints,
-  hook Hook) (float64, error) {
+func lineSearch(f objective, y0, g0 float64, opt options) (float64, error) {
 
-  maxEval := parameters.MaxEval
+  parameters  := opt.parameters
+  constraints := opt.constraints
+  hook        := opt.hook
+  maxEval     := parameters.MaxEval
 
   // constants for Wolfe conditions
-  c1 := 1e-4
-  c2 := 0.9
+  c1 := opt.wolfe.C1
+  c2 := opt.wolfe.C2
 
-  y0, g0, err := f(0.0)
-  if err != nil {
-    return 0.0, err
-  }
+  var err error
 
   // variables at step i
   yi, gi, alpha_i := y0, g0, 0.0
@@ -158,13 +176,13 @@ func lineSearch(f objective,
     }
 
     if yj > y0 + c1*alpha_j*g0 || (yj >= yi && i > 0) {
-      return zoom(f, alpha_i, alpha_j, y0, yi, yj, g0, gi, maxEval-i, hook)
+      return zoom(f, alpha_i, alpha_j, y0, yi, yj, g0, gi, maxEval-i, hook, opt.wolfe)
     }
     if math.Abs(gj) <= -c2*g0 {
       return alpha_j, nil
     }
     if gj >= 0.0 {
-      return zoom(f, alpha_j, alpha_i, y0, yj, yi, g0, gj, maxEval-i, hook)
+      return zoom(f, alpha_j, alpha_i, y0, yj, yi, g0, gj, maxEval-i, hook, opt.wolfe)
     }
     // select new alpha
     alpha_i = 2.0*alpha_j
@@ -178,11 +196,13 @@ func lineSearch(f objective,
 
 /* -------------------------------------------------------------------------- */
 
-func run(f objective, args ...interface{}) (float64, error) {
+func getOptions(args []interface{}) options {
 
   parameters  := Parameters {1, 20}
   constraints := Constraints{  nil}
   hook        := Hook       {  nil}
+  method      := Method     {"Wolfe"}
+  wolfe       := Wolfe      {1e-4, 0.9}
 
   for _, arg := range args {
     switch a := arg.(type) {
@@ -192,9 +212,37 @@ func run(f objective, args ...interface{}) (float64, error) {
       constraints = a
     case Hook:
       hook = a
+    case Method:
+      method = a
+    case Wolfe:
+      wolfe = a
     }
   }
-  return lineSearch(f, parameters, newConstraints(constraints), hook)
+  return options{parameters, newConstraints(constraints), hook, method, wolfe}
+}
+
+func runWithInitialValue(f objective, y0, g0 float64, args ...interface{}) (float64, error) {
+  opt := getOptions(args)
+  switch opt.method.Value {
+  case "Wolfe":
+    return lineSearch(f, y0, g0, opt)
+  case "Armijo":
+    return armijo(f, y0, g0, opt)
+  case "MoreThuente":
+    return moreThuente(f, y0, g0, opt)
+  case "HagerZhang":
+    return hagerZhang(f, y0, g0, opt)
+  default:
+    panic(fmt.Sprintf("invalid line search method: %s", opt.method.Value))
+  }
+}
+
+func run(f objective, args ...interface{}) (float64, error) {
+  y0, g0, err := f(0.0)
+  if err != nil {
+    return 0.0, err
+  }
+  return runWithInitialValue(f, y0, g0, args...)
 }
 
 /* -------------------------------------------------------------------------- */
@@ -223,3 +271,83 @@ func Run(f_ func(ConstScalar) (MagicScalar, error), t ScalarType, args ...interf
     return NewScalar(t, alpha), nil
   }
 }
+
+// Line search for an objective function that returns the function value
+// and the derivative with respect to the step length alpha. This avoids
+// automatic differentiation if the gradient of the full objective function
+// is available, since the derivative is simply the inner product of the
+// gradient with the search direction.
+func RunFloat64(f func(alpha float64) (float64, float64, error), args ...interface{}) (float64, error) {
+  return run(f, args...)
+}
+
+// Line search objective phi(alpha) = f(x0 + alpha p) for objective
+// functions that compute their own gradient. Each evaluation stores the
+// point, the gradient and the function value in X, G and Y, and the step
+// length in Alpha, which is NaN if the last evaluation failed.
+type DenseLine struct {
+  F     func(x, gradient DenseFloat64Vector) (float64, error)
+  X0    DenseFloat64Vector
+  P     DenseFloat64Vector
+  X     DenseFloat64Vector
+  G     DenseFloat64Vector
+  Y     float64
+  Alpha float64
+}
+
+func NewDenseLine(f func(x, gradient DenseFloat64Vector) (float64, error), x0, p DenseFloat64Vector) *DenseLine {
+  n := x0.Dim()
+  return &DenseLine{f, x0, p, NullDenseFloat64Vector(n), NullDenseFloat64Vector(n), math.NaN(), math.NaN()}
+}
+
+func (obj *DenseLine) Eval(alpha float64) (float64, float64, error) {
+  for i := range obj.X {
+    obj.X[i] = obj.X0[i] + alpha*obj.P[i]
+  }
+  y, err := obj.F(obj.X, obj.G)
+  if err != nil {
+    obj.Alpha = math.NaN()
+    return 0.0, 0.0, err
+  }
+  obj.Alpha = alpha
+  obj.Y     = y
+  return y, Ddot(obj.G, obj.P), nil
+}
+
+// Evaluate phi at alpha unless alpha was the last evaluated step length.
+func (obj *DenseLine) Step(alpha float64) error {
+  if alpha != obj.Alpha {
+    if _, _, err := obj.Eval(alpha); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// Translate constraints on x0 + alpha p into constraints on alpha.
+func (obj *DenseLine) Constraints(constraints func(ConstVector) bool) Constraints {
+  if constraints == nil {
+    return Constraints{nil}
+  }
+  r := func(alpha ConstScalar) bool {
+    for i := range obj.X {
+      obj.X[i] = obj.X0[i] + alpha.GetFloat64()*obj.P[i]
+    }
+    return constraints(obj.X)
+  }
+  return Constraints{r}
+}
+
+// Line search along the direction p starting at x, where y and g are the
+// precomputed function value and gradient at x. The function f must
+// return the value at a given point and store the gradient in its second
+// argument. Only points x + alpha p with alpha > 0 are evaluated.
+func RunAlongDirection(f func(x, gradient DenseFloat64Vector) (float64, error), x ConstVector, y float64, g, p ConstVector, args ...interface{}) (float64, error) {
+  n := x.Dim()
+  if g.Dim() != n || p.Dim() != n {
+    return 0.0, fmt.Errorf("vector dimensions do not match")
+  }
+  P := AsDenseFloat64Vector(p)
+  line := NewDenseLine(f, AsDenseFloat64Vector(x), P)
+  return runWithInitialValue(line.Eval, y, Ddot(AsDenseFloat64Vector(g), P), args...)
+}
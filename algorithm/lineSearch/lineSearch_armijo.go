@@ -0,0 +1,64 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lineSearch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Backtracking line search that only enforces the sufficient decrease
+// condition. Trial step lengths are obtained by minimizing a quadratic
+// interpolation, safeguarded to [0.1 alpha, 0.5 alpha] [Nocedal and Wright
+// (2006), Section 3.5].
+func armijo(f objective, y0, g0 float64, opt options) (float64, error) {
+  if g0 >= 0.0 {
+    return 0.0, fmt.Errorf("line search failed: not a descent direction")
+  }
+  alpha := opt.parameters.Alpha1
+  for i := 0; i < opt.parameters.MaxEval; i++ {
+    // decrease alpha until constraints are satisfied
+    for !opt.constraints(alpha) {
+      alpha *= 0.5
+    }
+    if alpha == 0.0 {
+      break
+    }
+    y, g, err := f(alpha)
+    if err != nil {
+      return 0.0, err
+    }
+    // execute hook if available
+    if opt.hook.Value != nil && opt.hook.Value(ConstFloat64(alpha), ConstFloat64(y), ConstFloat64(g)) {
+      return alpha, nil
+    }
+    if y <= y0 + opt.wolfe.C1*alpha*g0 {
+      return alpha, nil
+    }
+    // minimizer of the quadratic interpolating y0, g0 and y
+    alpha_new := -g0*alpha*alpha/(2.0*(y - y0 - g0*alpha))
+    if math.IsNaN(alpha_new) {
+      alpha_new = 0.5*alpha
+    }
+    alpha = math.Min(math.Max(alpha_new, 0.1*alpha), 0.5*alpha)
+  }
+  return 0.0, fmt.Errorf("line search failed")
+}
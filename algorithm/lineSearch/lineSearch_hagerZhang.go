@@ -0,0 +1,211 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+/* Reference:
+ * Hager, William W., and Hongchao Zhang. "A new conjugate gradient method
+ * with guaranteed descent and an efficient line search." SIAM Journal on
+ * Optimization 16.1 (2005): 170-192.
+ */
+
+package lineSearch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+type hzPoint struct {
+  alpha, y, g float64
+}
+
+type hzState struct {
+  f       objective
+  opt     options
+  y0, g0  float64
+  // tolerance for the approximate Wolfe conditions
+  epsilon float64
+  nEval   int
+  // accepted step
+  result  hzPoint
+  done    bool
+}
+
+// Evaluate phi at alpha and check if the (approximate) Wolfe conditions
+// are satisfied.
+func (obj *hzState) eval(alpha float64) (hzPoint, error) {
+  if obj.nEval >= obj.opt.parameters.MaxEval {
+    return hzPoint{}, fmt.Errorf("line search failed: maximum number of evaluations reached")
+  }
+  obj.nEval++
+  y, g, err := obj.f(alpha)
+  if err != nil {
+    return hzPoint{}, err
+  }
+  c := hzPoint{alpha, y, g}
+  // execute hook if available
+  if obj.opt.hook.Value != nil && obj.opt.hook.Value(ConstFloat64(alpha), ConstFloat64(y), ConstFloat64(g)) {
+    obj.result, obj.done = c, true
+  }
+  delta := obj.opt.wolfe.C1
+  sigma := obj.opt.wolfe.C2
+  if g >= sigma*obj.g0 {
+    // original Wolfe conditions
+    if y - obj.y0 <= delta*alpha*obj.g0 {
+      obj.result, obj.done = c, true
+    }
+    // approximate Wolfe conditions
+    if (2.0*delta - 1.0)*obj.g0 >= g && y <= obj.y0 + obj.epsilon {
+      obj.result, obj.done = c, true
+    }
+  }
+  return c, nil
+}
+
+// Bisection step to find an interval satisfying the opposite slope
+// condition [procedure U3a-c].
+func (obj *hzState) bisect(a, b hzPoint) (hzPoint, hzPoint, error) {
+  const theta = 0.5
+  for !obj.done {
+    d, err := obj.eval((1.0 - theta)*a.alpha + theta*b.alpha)
+    if err != nil {
+      return a, b, err
+    }
+    if d.g >= 0.0 {
+      return a, d, nil
+    }
+    if d.y <= obj.y0 + obj.epsilon {
+      a = d
+    } else {
+      b = d
+    }
+  }
+  return a, b, nil
+}
+
+// Update the interval [a, b] with a new point c [procedure U0-U3].
+func (obj *hzState) update(a, b, c hzPoint) (hzPoint, hzPoint, error) {
+  if c.alpha <= a.alpha || c.alpha >= b.alpha {
+    return a, b, nil
+  }
+  if c.g >= 0.0 {
+    return a, c, nil
+  }
+  if c.y <= obj.y0 + obj.epsilon {
+    return c, b, nil
+  }
+  return obj.bisect(a, c)
+}
+
+func (obj *hzState) secant(a, b hzPoint) float64 {
+  return (a.alpha*b.g - b.alpha*a.g)/(b.g - a.g)
+}
+
+// Double secant step [procedure S1-S4].
+func (obj *hzState) secant2(a, b hzPoint) (hzPoint, hzPoint, error) {
+  c, err := obj.eval(obj.secant(a, b))
+  if err != nil || obj.done {
+    return a, b, err
+  }
+  A, B, err := obj.update(a, b, c)
+  if err != nil || obj.done {
+    return A, B, err
+  }
+  var t float64
+  switch c.alpha {
+  case B.alpha:
+    t = obj.secant(b, B)
+  case A.alpha:
+    t = obj.secant(a, A)
+  default:
+    return A, B, nil
+  }
+  if t <= A.alpha || t >= B.alpha || math.IsNaN(t) {
+    return A, B, nil
+  }
+  if c, err = obj.eval(t); err != nil || obj.done {
+    return A, B, err
+  }
+  return obj.update(A, B, c)
+}
+
+// Find an initial interval that satisfies the opposite slope condition
+// [procedure B1-B3].
+func (obj *hzState) bracket(c hzPoint) (hzPoint, hzPoint, error) {
+  const rho = 5.0
+  a := hzPoint{0.0, obj.y0, obj.g0}
+  for !obj.done {
+    if c.g >= 0.0 {
+      return a, c, nil
+    }
+    if c.y > obj.y0 + obj.epsilon {
+      return obj.bisect(hzPoint{0.0, obj.y0, obj.g0}, c)
+    }
+    a = c
+    alpha := rho*c.alpha
+    if !obj.opt.constraints(alpha) {
+      return a, c, fmt.Errorf("line search failed: constraints violated")
+    }
+    var err error
+    if c, err = obj.eval(alpha); err != nil {
+      return a, c, err
+    }
+  }
+  return a, c, nil
+}
+
+// Line search of Hager and Zhang, which accepts steps that satisfy either
+// the Wolfe or the approximate Wolfe conditions. The latter can be checked
+// with higher accuracy near a local minimum.
+func hagerZhang(f objective, y0, g0 float64, opt options) (float64, error) {
+  if g0 >= 0.0 {
+    return 0.0, fmt.Errorf("line search failed: not a descent direction")
+  }
+  const gamma = 0.66
+
+  obj := hzState{f: f, opt: opt, y0: y0, g0: g0, epsilon: 1e-6*math.Abs(y0)}
+
+  alpha := opt.parameters.Alpha1
+  // decrease alpha until constraints are satisfied
+  for !opt.constraints(alpha) {
+    alpha *= 0.5
+  }
+  c, err := obj.eval(alpha)
+  if err != nil {
+    return 0.0, err
+  }
+  a, b, err := obj.bracket(c)
+  for err == nil && !obj.done {
+    var A, B hzPoint
+    if A, B, err = obj.secant2(a, b); err != nil || obj.done {
+      break
+    }
+    if B.alpha - A.alpha > gamma*(b.alpha - a.alpha) {
+      if c, err = obj.eval((A.alpha + B.alpha)/2.0); err != nil || obj.done {
+        break
+      }
+      A, B, err = obj.update(A, B, c)
+    }
+    a, b = A, B
+  }
+  if obj.done {
+    return obj.result.alpha, nil
+  }
+  return 0.0, err
+}
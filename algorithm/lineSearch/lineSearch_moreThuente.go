@@ -0,0 +1,265 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+/* Reference:
+ * Moré, Jorge J., and David J. Thuente. "Line search algorithms with
+ * guaranteed sufficient decrease." ACM Transactions on Mathematical
+ * Software (TOMS) 20.3 (1994): 286-307.
+ */
+
+package lineSearch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// State of the interval of uncertainty, where x is the step with the
+// lowest function value so far and y is the other endpoint.
+type mtInterval struct {
+  stx, fx, dx float64
+  sty, fy, dy float64
+  brackt      bool
+}
+
+func max3(a, b, c float64) float64 {
+  return math.Max(a, math.Max(b, c))
+}
+
+// Compute a safeguarded step for the interval of uncertainty and update
+// the interval (subroutine dcstep of MINPACK-2).
+func (obj *mtInterval) step(stp, fp, dp, stpmin, stpmax float64) float64 {
+  var stpf float64
+  sgnd := dp*(obj.dx/math.Abs(obj.dx))
+  stx, fx, dx := obj.stx, obj.fx, obj.dx
+  sty, fy, dy := obj.sty, obj.fy, obj.dy
+
+  switch {
+  case fp > fx:
+    // higher function value, the minimum is bracketed
+    theta := 3.0*(fx - fp)/(stp - stx) + dx + dp
+    s     := max3(math.Abs(theta), math.Abs(dx), math.Abs(dp))
+    gamma := s*math.Sqrt((theta/s)*(theta/s) - (dx/s)*(dp/s))
+    if stp < stx {
+      gamma = -gamma
+    }
+    p    := (gamma - dx) + theta
+    q    := ((gamma - dx) + gamma) + dp
+    stpc := stx + p/q*(stp - stx)
+    stpq := stx + ((dx/((fx - fp)/(stp - stx) + dx))/2.0)*(stp - stx)
+    if math.Abs(stpc - stx) < math.Abs(stpq - stx) {
+      stpf = stpc
+    } else {
+      stpf = stpc + (stpq - stpc)/2.0
+    }
+    obj.brackt = true
+  case sgnd < 0.0:
+    // derivatives have opposite sign, the minimum is bracketed
+    theta := 3.0*(fx - fp)/(stp - stx) + dx + dp
+    s     := max3(math.Abs(theta), math.Abs(dx), math.Abs(dp))
+    gamma := s*math.Sqrt((theta/s)*(theta/s) - (dx/s)*(dp/s))
+    if stp > stx {
+      gamma = -gamma
+    }
+    p    := (gamma - dp) + theta
+    q    := ((gamma - dp) + gamma) + dx
+    stpc := stp + p/q*(stx - stp)
+    stpq := stp + (dp/(dp - dx))*(stx - stp)
+    if math.Abs(stpc - stp) > math.Abs(stpq - stp) {
+      stpf = stpc
+    } else {
+      stpf = stpq
+    }
+    obj.brackt = true
+  case math.Abs(dp) < math.Abs(dx):
+    // derivative decreases in magnitude
+    theta := 3.0*(fx - fp)/(stp - stx) + dx + dp
+    s     := max3(math.Abs(theta), math.Abs(dx), math.Abs(dp))
+    gamma := s*math.Sqrt(math.Max(0.0, (theta/s)*(theta/s) - (dx/s)*(dp/s)))
+    if stp > stx {
+      gamma = -gamma
+    }
+    p := (gamma - dp) + theta
+    q := (gamma + (dx - dp)) + gamma
+    r := p/q
+    var stpc float64
+    if r < 0.0 && gamma != 0.0 {
+      stpc = stp + r*(stx - stp)
+    } else
+    if stp > stx {
+      stpc = stpmax
+    } else {
+      stpc = stpmin
+    }
+    stpq := stp + (dp/(dp - dx))*(stx - stp)
+    if obj.brackt {
+      if math.Abs(stpc - stp) < math.Abs(stpq - stp) {
+        stpf = stpc
+      } else {
+        stpf = stpq
+      }
+      if stp > stx {
+        stpf = math.Min(stp + 0.66*(sty - stp), stpf)
+      } else {
+        stpf = math.Max(stp + 0.66*(sty - stp), stpf)
+      }
+    } else {
+      if math.Abs(stpc - stp) > math.Abs(stpq - stp) {
+        stpf = stpc
+      } else {
+        stpf = stpq
+      }
+      stpf = math.Max(stpmin, math.Min(stpmax, stpf))
+    }
+  default:
+    // derivative does not decrease in magnitude
+    if obj.brackt {
+      theta := 3.0*(fp - fy)/(sty - stp) + dy + dp
+      s     := max3(math.Abs(theta), math.Abs(dy), math.Abs(dp))
+      gamma := s*math.Sqrt((theta/s)*(theta/s) - (dy/s)*(dp/s))
+      if stp > sty {
+        gamma = -gamma
+      }
+      p := (gamma - dp) + theta
+      q := ((gamma - dp) + gamma) + dy
+      stpf = stp + p/q*(sty - stp)
+    } else
+    if stp > stx {
+      stpf = stpmax
+    } else {
+      stpf = stpmin
+    }
+  }
+  // update the interval of uncertainty
+  if fp > fx {
+    obj.sty, obj.fy, obj.dy = stp, fp, dp
+  } else {
+    if sgnd < 0.0 {
+      obj.sty, obj.fy, obj.dy = stx, fx, dx
+    }
+    obj.stx, obj.fx, obj.dx = stp, fp, dp
+  }
+  return stpf
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Line search of Moré and Thuente that finds a step satisfying the strong
+// Wolfe conditions (subroutine dcsrch of MINPACK-2).
+func moreThuente(f objective, y0, g0 float64, opt options) (float64, error) {
+  if g0 >= 0.0 {
+    return 0.0, fmt.Errorf("line search failed: not a descent direction")
+  }
+  const xtol   = 1e-10
+  const xtrapl = 1.1
+  const xtrapu = 4.0
+
+  ftol   := opt.wolfe.C1
+  gtol   := opt.wolfe.C2
+  stpmin := 0.0
+  stpmax := math.Inf(1)
+  stp    := opt.parameters.Alpha1
+  // decrease initial step until constraints are satisfied
+  for !opt.constraints(stp) {
+    stp *= 0.5
+  }
+  gtest  := ftol*g0
+  width  := stpmax - stpmin
+  width1 := 2.0*width
+  stage  := 1
+  stmin  := 0.0
+  stmax  := stp + xtrapu*stp
+
+  it := mtInterval{stx: 0.0, fx: y0, dx: g0, sty: 0.0, fy: y0, dy: g0}
+
+  for i := 0; i < opt.parameters.MaxEval; i++ {
+    if stp == 0.0 {
+      return 0.0, fmt.Errorf("line search failed")
+    }
+    y, g, err := f(stp)
+    if err != nil {
+      return 0.0, err
+    }
+    // execute hook if available
+    if opt.hook.Value != nil && opt.hook.Value(ConstFloat64(stp), ConstFloat64(y), ConstFloat64(g)) {
+      return stp, nil
+    }
+    ftest := y0 + stp*gtest
+    if stage == 1 && y <= ftest && g >= 0.0 {
+      stage = 2
+    }
+    // test for convergence
+    if y <= ftest && math.Abs(g) <= -gtol*g0 {
+      return stp, nil
+    }
+    // test for warnings, return best step so far
+    if it.brackt && (stp <= stmin || stp >= stmax || stmax - stmin <= xtol*stmax) {
+      break
+    }
+    if stp == stpmax && y <= ftest && g <= gtest {
+      return stp, nil
+    }
+    if stage == 1 && y <= it.fx && y > ftest {
+      // use the modified function psi(alpha) = phi(alpha) - ftol alpha phi'(0)
+      // as long as no step satisfies the sufficient decrease condition
+      mt := mtInterval{
+        stx: it.stx, fx: it.fx - it.stx*gtest, dx: it.dx - gtest,
+        sty: it.sty, fy: it.fy - it.sty*gtest, dy: it.dy - gtest,
+        brackt: it.brackt }
+      stp = mt.step(stp, y - stp*gtest, g - gtest, stmin, stmax)
+      it  = mtInterval{
+        stx: mt.stx, fx: mt.fx + mt.stx*gtest, dx: mt.dx + gtest,
+        sty: mt.sty, fy: mt.fy + mt.sty*gtest, dy: mt.dy + gtest,
+        brackt: mt.brackt }
+    } else {
+      stp = it.step(stp, y, g, stmin, stmax)
+    }
+    // decide if a bisection step is needed
+    if it.brackt {
+      if math.Abs(it.sty - it.stx) >= 0.66*width1 {
+        stp = it.stx + 0.5*(it.sty - it.stx)
+      }
+      width1 = width
+      width  = math.Abs(it.sty - it.stx)
+    }
+    // set the minimum and maximum steps allowed
+    if it.brackt {
+      stmin = math.Min(it.stx, it.sty)
+      stmax = math.Max(it.stx, it.sty)
+    } else {
+      stmin = stp + xtrapl*(stp - it.stx)
+      stmax = stp + xtrapu*(stp - it.stx)
+    }
+    stp = math.Max(stpmin, math.Min(stpmax, stp))
+    // decrease step until constraints are satisfied
+    for !opt.constraints(stp) {
+      stpmax = stp
+      stp    = it.stx + 0.5*(stp - it.stx)
+    }
+    if it.brackt && (stp <= stmin || stp >= stmax || stmax - stmin <= xtol*stmax) {
+      stp = it.stx
+    }
+  }
+  // return the best step if it satisfies the sufficient decrease condition
+  if it.stx > 0.0 && it.fx <= y0 + it.stx*gtest {
+    return it.stx, nil
+  }
+  return 0.0, fmt.Errorf("line search failed")
+}
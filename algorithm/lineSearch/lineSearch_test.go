@@ -58,3 +58,63 @@ func TestLineSearch(test *testing.T) {
     }
   }
 }
+
+func TestLineSearchMethods(test *testing.T) {
+  // phi(alpha) = f(1.7 + alpha) with the function from above
+  phi := func(alpha float64) (float64, float64, error) {
+    x := 1.7 + alpha
+    y := (x - 3.0)*math.Pow(x, 3.0)*math.Pow(x - 6.0, 4.0)
+    g := math.Pow(x, 3.0)*math.Pow(x - 6.0, 4.0) +
+      3.0*(x - 3.0)*math.Pow(x, 2.0)*math.Pow(x - 6.0, 4.0) +
+      4.0*(x - 3.0)*math.Pow(x, 3.0)*math.Pow(x - 6.0, 3.0)
+    return y, g, nil
+  }
+  y0, g0, _ := phi(0.0)
+
+  for _, method := range []string{"Wolfe", "Armijo", "MoreThuente", "HagerZhang"} {
+    for _, alpha1 := range []float64{1e-3, 1.0} {
+      alpha, err := RunFloat64(phi, Method{method}, Parameters{alpha1, 50})
+      if err != nil {
+        test.Error(err); continue
+      }
+      y, g, _ := phi(alpha)
+      // sufficient decrease
+      if y > y0 + 1e-4*alpha*g0 {
+        test.Errorf("sufficient decrease condition violated for method %s", method)
+      }
+      // strong Wolfe curvature condition
+      if (method == "Wolfe" || method == "MoreThuente") && math.Abs(g) > -0.9*g0 {
+        test.Errorf("curvature condition violated for method %s", method)
+      }
+      if method == "HagerZhang" && g < 0.9*g0 {
+        test.Errorf("curvature condition violated for method %s", method)
+      }
+    }
+  }
+}
+
+func TestLineSearchDirection(test *testing.T) {
+  // f(x) = (x1 - 1)^2 + 10 (x2 + 2)^2, exact line search along p gives
+  // alpha = 1/2 for the steepest descent direction at (0, -1)
+  f := func(x, g DenseFloat64Vector) (float64, error) {
+    g[0] = 2.0*(x[0] - 1.0)
+    g[1] = 0.0
+    return (x[0] - 1.0)*(x[0] - 1.0), nil
+  }
+  x := NewDenseFloat64Vector([]float64{0, -1})
+  g := NewDenseFloat64Vector([]float64{-2, 0})
+  p := NewDenseFloat64Vector([]float64{ 2, 0})
+  for _, method := range []string{"Wolfe", "Armijo", "MoreThuente", "HagerZhang"} {
+    alpha, err := RunAlongDirection(f, x, 1.0, g, p, Method{method}, Wolfe{1e-4, 0.1})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if method != "Armijo" && math.Abs(alpha - 0.5) > 0.05 {
+      test.Errorf("line search failed for method %s", method)
+    }
+  }
+  // not a descent direction
+  if _, err := RunAlongDirection(f, x, 1.0, g, g, Method{"MoreThuente"}); err == nil {
+    test.Error("test failed")
+  }
+}
@@ -0,0 +1,335 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lu
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/cmplx"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+// Pivots with an absolute value smaller or equal to Value times the
+// largest absolute value of the matrix are treated as zero
+type Epsilon struct {
+  Value float64
+}
+
+type InSitu struct {
+  LU Matrix
+  T  Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+// LU decomposition P A = L U of a square matrix A with partial pivoting,
+// where L is unit lower triangular and U is upper triangular. Both factors
+// are stored in a single matrix. Row i of P A is row Pivot[i] of A.
+type LU struct {
+  LU    Matrix
+  Pivot []int
+  // sign of the permutation
+  sign  int
+  // 1-norm of the original matrix
+  norm  float64
+  t     Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func abs(a ConstScalar) float64 {
+  return cmplx.Abs(a.GetComplex128())
+}
+
+/* -------------------------------------------------------------------------- */
+
+func Run(a ConstMatrix, args ...interface{}) (*LU, error) {
+  n, m := a.Dims()
+  if n != m {
+    panic("not a square matrix")
+  }
+  epsilon := 1e-15
+  inSitu  := &InSitu{}
+  // loop over optional arguments
+  for _, arg := range args {
+    switch tmp := arg.(type) {
+    case Epsilon:
+      epsilon = tmp.Value
+    case *InSitu:
+      inSitu = tmp
+    case InSitu:
+      panic("InSitu must be passed by reference")
+    }
+  }
+  if inSitu.LU == nil {
+    inSitu.LU = NullDenseMatrix(a.ElementType(), n, n)
+  }
+  if inSitu.T == nil {
+    inSitu.T = NullScalar(a.ElementType())
+  }
+  lu := inSitu.LU
+  t  := inSitu.T
+  lu.Set(a)
+
+  r := LU{LU: lu, Pivot: make([]int, n), sign: 1, t: t}
+  // compute 1-norm and largest absolute value
+  scale := 0.0
+  for j := 0; j < n; j++ {
+    s := 0.0
+    for i := 0; i < n; i++ {
+      v := abs(lu.ConstAt(i, j))
+      s += v
+      scale = math.Max(scale, v)
+    }
+    r.norm = math.Max(r.norm, s)
+  }
+  for i := 0; i < n; i++ {
+    r.Pivot[i] = i
+  }
+  for k := 0; k < n; k++ {
+    // find pivot
+    p := k
+    for i := k+1; i < n; i++ {
+      if abs(lu.ConstAt(i, k)) > abs(lu.ConstAt(p, k)) {
+        p = i
+      }
+    }
+    if v := abs(lu.ConstAt(p, k)); v <= epsilon*scale || math.IsNaN(v) {
+      return nil, fmt.Errorf("matrix is singular")
+    }
+    if p != k {
+      lu.SwapRows(k, p)
+      r.Pivot[k], r.Pivot[p] = r.Pivot[p], r.Pivot[k]
+      r.sign = -r.sign
+    }
+    // eliminate elements below the pivot
+    for i := k+1; i < n; i++ {
+      lik := lu.At(i, k)
+      lik.Div(lik, lu.ConstAt(k, k))
+      for j := k+1; j < n; j++ {
+        t.Mul(lik, lu.ConstAt(k, j))
+        lu.At(i, j).Sub(lu.At(i, j), t)
+      }
+    }
+  }
+  return &r, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Solve L U x = P b. The vector x must not share memory with b.
+func (obj *LU) solve(x Vector, b ConstVector) {
+  lu := obj.LU
+  t  := obj.t
+  n  := x.Dim()
+  for i := 0; i < n; i++ {
+    x.At(i).Set(b.ConstAt(obj.Pivot[i]))
+  }
+  // forward substitution
+  for i := 0; i < n; i++ {
+    for j := 0; j < i; j++ {
+      t.Mul(lu.ConstAt(i, j), x.ConstAt(j))
+      x.At(i).Sub(x.At(i), t)
+    }
+  }
+  // backward substitution
+  for i := n-1; i >= 0; i-- {
+    for j := i+1; j < n; j++ {
+      t.Mul(lu.ConstAt(i, j), x.ConstAt(j))
+      x.At(i).Sub(x.At(i), t)
+    }
+    x.At(i).Div(x.At(i), lu.ConstAt(i, i))
+  }
+}
+
+// Solve A x = b for x.
+func (obj *LU) Solve(b ConstVector) (Vector, error) {
+  n, _ := obj.LU.Dims()
+  if b.Dim() != n {
+    return nil, fmt.Errorf("vector has invalid dimension")
+  }
+  x := NullDenseVector(obj.LU.ElementType(), n)
+  obj.solve(x, b)
+  return x, nil
+}
+
+// Solve A X = B for X.
+func (obj *LU) SolveMatrix(b ConstMatrix) (Matrix, error) {
+  n, _ := obj.LU.Dims()
+  n1, m1 := b.Dims()
+  if n1 != n {
+    return nil, fmt.Errorf("matrix has invalid dimensions")
+  }
+  x := NullDenseMatrix(obj.LU.ElementType(), n, m1)
+  y := NullDenseVector(obj.LU.ElementType(), n)
+  for j := 0; j < m1; j++ {
+    obj.solve(y, b.ConstCol(j))
+    for i := 0; i < n; i++ {
+      x.At(i, j).Set(y.ConstAt(i))
+    }
+  }
+  return x, nil
+}
+
+// Compute the inverse of A.
+func (obj *LU) Inverse() (Matrix, error) {
+  n, _ := obj.LU.Dims()
+  id := NullDenseMatrix(obj.LU.ElementType(), n, n)
+  id.SetIdentity()
+  return obj.SolveMatrix(id)
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Compute the determinant of A.
+func (obj *LU) Det() Scalar {
+  n, _ := obj.LU.Dims()
+  r := NewScalar(obj.LU.ElementType(), float64(obj.sign))
+  for i := 0; i < n; i++ {
+    r.Mul(r, obj.LU.ConstAt(i, i))
+  }
+  return r
+}
+
+// Compute the logarithm of the absolute value of the determinant of A.
+// The second return value is the sign of the determinant. For complex
+// matrices the sign is always one and the first return value is the
+// principal logarithm of the determinant.
+func (obj *LU) LogDet() (Scalar, int) {
+  n, _ := obj.LU.Dims()
+  r := NewScalar(obj.LU.ElementType(), 0.0)
+  t := NullScalar(obj.LU.ElementType())
+  s := obj.sign
+  for i := 0; i < n; i++ {
+    u := obj.LU.ConstAt(i, i)
+    switch u.(type) {
+    case ConstComplex64, ConstComplex128, Complex64, Complex128:
+      t.Log(u)
+    default:
+      if u.GetFloat64() < 0.0 {
+        s = -s
+      }
+      t.Abs(u)
+      t.Log(t)
+    }
+    r.Add(r, t)
+  }
+  switch r.(type) {
+  case Complex64, Complex128:
+    if s < 0 {
+      r.Add(r, ConstComplex128(complex(0, math.Pi)))
+    }
+    s = 1
+  }
+  return r, s
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Solve L U x = P b for float64 values (transpose == false), or
+// (L U)^T P x = b (transpose == true).
+func (obj *LU) solveFloat64(x, b []float64, transpose bool) {
+  lu := obj.LU
+  n  := len(x)
+  if !transpose {
+    for i := 0; i < n; i++ {
+      x[i] = b[obj.Pivot[i]]
+    }
+    for i := 0; i < n; i++ {
+      for j := 0; j < i; j++ {
+        x[i] -= lu.Float64At(i, j)*x[j]
+      }
+    }
+    for i := n-1; i >= 0; i-- {
+      for j := i+1; j < n; j++ {
+        x[i] -= lu.Float64At(i, j)*x[j]
+      }
+      x[i] /= lu.Float64At(i, i)
+    }
+  } else {
+    y := make([]float64, n)
+    copy(y, b)
+    // U^T y = b
+    for i := 0; i < n; i++ {
+      for j := 0; j < i; j++ {
+        y[i] -= lu.Float64At(j, i)*y[j]
+      }
+      y[i] /= lu.Float64At(i, i)
+    }
+    // L^T z = y
+    for i := n-1; i >= 0; i-- {
+      for j := i+1; j < n; j++ {
+        y[i] -= lu.Float64At(j, i)*y[j]
+      }
+    }
+    for i := 0; i < n; i++ {
+      x[obj.Pivot[i]] = y[i]
+    }
+  }
+}
+
+// Estimate the condition number of A in the 1-norm. The norm of the
+// inverse is estimated with Hager's method [Higham (1988)], which requires
+// only a few solves with the factorization. The estimate is a lower bound
+// of the true condition number and uses only the real part of complex
+// matrices.
+func (obj *LU) Cond() float64 {
+  n, _ := obj.LU.Dims()
+  if n == 0 {
+    return 0.0
+  }
+  x := make([]float64, n)
+  y := make([]float64, n)
+  z := make([]float64, n)
+  for i := 0; i < n; i++ {
+    x[i] = 1.0/float64(n)
+  }
+  est := 0.0
+  for k := 0; k < 5; k++ {
+    obj.solveFloat64(y, x, false)
+    est = 0.0
+    for i := 0; i < n; i++ {
+      est += math.Abs(y[i])
+      if y[i] >= 0.0 {
+        y[i] = 1.0
+      } else {
+        y[i] = -1.0
+      }
+    }
+    obj.solveFloat64(z, y, true)
+    // check for convergence
+    j  := 0
+    zx := 0.0
+    for i := 0; i < n; i++ {
+      zx += z[i]*x[i]
+      if math.Abs(z[i]) > math.Abs(z[j]) {
+        j = i
+      }
+    }
+    if math.Abs(z[j]) <= zx || (k > 0 && x[j] == 1.0) {
+      break
+    }
+    for i := 0; i < n; i++ {
+      x[i] = 0.0
+    }
+    x[j] = 1.0
+  }
+  return obj.norm*est
+}
@@ -0,0 +1,170 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lu
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestLU1(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    0, 2, 1,
+    1, 1, 0,
+    3, 0, 1 }, 3, 3)
+  b := NewDenseFloat64Vector([]float64{7, 3, 6})
+
+  r, err := Run(a)
+  if err != nil {
+    test.Error(err); return
+  }
+  // check P A = L U
+  n, _ := a.Dims()
+  for i := 0; i < n; i++ {
+    for j := 0; j < n; j++ {
+      s := 0.0
+      for k := 0; k <= j && k <= i; k++ {
+        if k == i {
+          s += r.LU.Float64At(k, j)
+        } else {
+          s += r.LU.Float64At(i, k)*r.LU.Float64At(k, j)
+        }
+      }
+      if math.Abs(s - a.Float64At(r.Pivot[i], j)) > 1e-12 {
+        test.Error("test failed")
+      }
+    }
+  }
+  if x, err := r.Solve(b); err != nil {
+    test.Error(err)
+  } else {
+    if !x.Equals(NewDenseFloat64Vector([]float64{1, 2, 3}), 1e-12) {
+      test.Error("test failed")
+    }
+  }
+  if d := r.Det(); math.Abs(d.GetFloat64() + 5.0) > 1e-12 {
+    test.Error("test failed")
+  }
+  if d, s := r.LogDet(); math.Abs(d.GetFloat64() - math.Log(5.0)) > 1e-12 || s != -1 {
+    test.Error("test failed")
+  }
+}
+
+func TestLU2(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    4, 3, 2,
+    2, 1, 3,
+    3, 2, 1 }, 3, 3)
+  r, err := Run(a)
+  if err != nil {
+    test.Error(err); return
+  }
+  if d, s := r.LogDet(); math.Abs(d.GetFloat64() - math.Log(3.0)) > 1e-12 || s != 1 {
+    test.Error("test failed")
+  }
+  id := NullDenseFloat64Matrix(3, 3)
+  id.SetIdentity()
+  if x, err := r.Inverse(); err != nil {
+    test.Error(err)
+  } else {
+    if !id.Equals(id.MdotM(a, x), 1e-12) {
+      test.Error("test failed")
+    }
+  }
+  b := NewDenseFloat64Matrix([]float64{
+    1, 2,
+    3, 4,
+    5, 6 }, 3, 2)
+  if x, err := r.SolveMatrix(b); err != nil {
+    test.Error(err)
+  } else {
+    if !b.Equals(NullDenseFloat64Matrix(3, 2).MdotM(a, x), 1e-12) {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestLU3(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    1, 2, 3,
+    2, 4, 6,
+    1, 0, 1 }, 3, 3)
+  if _, err := Run(a); err == nil {
+    test.Error("test failed")
+  }
+}
+
+func TestLU4(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    1,   0, 0,
+    0, 100, 0,
+    0,   0, 2 }, 3, 3)
+  r, _ := Run(a)
+  if math.Abs(r.Cond() - 100.0) > 1e-10 {
+    test.Error("test failed")
+  }
+  a = NewDenseFloat64Matrix([]float64{
+    1, 1,
+    1, 1.0001 }, 2, 2)
+  r, _ = Run(a)
+  // exact condition number is 2.0001*20001
+  if math.Abs(r.Cond() - 2.0001*20001)/(2.0001*20001) > 1e-6 {
+    test.Error("test failed")
+  }
+}
+
+func TestLU5(test *testing.T) {
+  // gradient of the determinant is det(A) A^-T
+  a := NewDenseReal64Matrix([]float64{
+    4, 3, 2,
+    2, 1, 3,
+    3, 2, 1 }, 3, 3)
+  a.Variables(1)
+  r, err := Run(a)
+  if err != nil {
+    test.Error(err); return
+  }
+  d := r.Det()
+  x, _ := r.Inverse()
+  for i := 0; i < 3; i++ {
+    for j := 0; j < 3; j++ {
+      if math.Abs(d.GetDerivative(3*i+j) - d.GetFloat64()*x.Float64At(j, i)) > 1e-10 {
+        test.Error("test failed")
+      }
+    }
+  }
+  // derivative of the solution with respect to b is A^-1
+  b := NewDenseReal64Vector([]float64{1, 2, 3})
+  b.Variables(1)
+  r, _ = Run(NewDenseReal64Matrix([]float64{
+    4, 3, 2,
+    2, 1, 3,
+    3, 2, 1 }, 3, 3))
+  y, _ := r.Solve(b)
+  for i := 0; i < 3; i++ {
+    for j := 0; j < 3; j++ {
+      if math.Abs(y.ConstAt(i).GetDerivative(j) - x.Float64At(i, j)) > 1e-10 {
+        test.Error("test failed")
+      }
+    }
+  }
+}
@@ -0,0 +1,380 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// References:
+// Sutskever, Ilya, et al. "On the importance of initialization and
+// momentum in deep learning." International conference on machine
+// learning. 2013.
+//
+// Duchi, John, Elad Hazan, and Yoram Singer. "Adaptive subgradient methods
+// for online learning and stochastic optimization." Journal of machine
+// learning research 12.7 (2011).
+//
+// Kingma, Diederik P., and Jimmy Ba. Adam: A method for stochastic
+// optimization. arXiv preprint arXiv:1412.6980 (2014).
+//
+// Loshchilov, Ilya, and Frank Hutter. "Decoupled weight decay
+// regularization." arXiv preprint arXiv:1711.05101 (2017).
+
+/* -------------------------------------------------------------------------- */
+
+package minibatch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+
+/* -------------------------------------------------------------------------- */
+
+// Loss of example i at x
+type Objective func(i int, x ConstVector) (MagicScalar, error)
+
+// Loss of example i at x, the gradient is stored in the last argument
+type DenseGradientF func(i int, x, gradient DenseFloat64Vector) (float64, error)
+
+// Update rule, which is either "SGD" (default), "AdaGrad", "RMSProp",
+// "Adam" or "AdamW"
+type Method struct {
+  Value string
+}
+
+type StepSize struct {
+  Value float64
+}
+
+// Number of examples per update
+type BatchSize struct {
+  Value int
+}
+
+// Momentum for SGD
+type Momentum struct {
+  Value float64
+}
+
+// Use Nesterov momentum for SGD
+type Nesterov struct {
+  Value bool
+}
+
+// Decay rate of the first moment (Adam, AdamW)
+type Beta1 struct {
+  Value float64
+}
+
+// Decay rate of the second moment (Adam, AdamW)
+type Beta2 struct {
+  Value float64
+}
+
+// Decay rate of the squared gradient average (RMSProp)
+type Rho struct {
+  Value float64
+}
+
+// Weight decay, which is decoupled from the gradient for AdamW and
+// equivalent to L2 regularization for all other methods
+type WeightDecay struct {
+  Value float64
+}
+
+// Learning rate schedule
+type Schedule struct {
+  Value ScheduleType
+}
+
+// Stop if the relative change of the parameters within one epoch is
+// smaller than epsilon
+type Epsilon struct {
+  Value float64
+}
+
+// Maximum number of epochs (default: 100)
+type MaxIterations struct {
+  Value int
+}
+
+type Seed struct {
+  Value int64
+}
+
+// The hook receives the parameters, the average loss of the last epoch and
+// the epoch number
+type Hook struct {
+  Value func(x ConstVector, loss ConstScalar, epoch int) bool
+}
+
+/* -------------------------------------------------------------------------- */
+
+type options struct {
+  method        Method
+  stepSize      StepSize
+  batchSize     BatchSize
+  momentum      Momentum
+  nesterov      Nesterov
+  beta1         Beta1
+  beta2         Beta2
+  rho           Rho
+  weightDecay   WeightDecay
+  schedule      Schedule
+  epsilon       Epsilon
+  maxIterations MaxIterations
+  seed          Seed
+  hook          Hook
+}
+
+func getOptions(args []interface{}) (options, error) {
+  opt := options{
+    method       : Method       {"SGD"},
+    stepSize     : StepSize     { 0.01},
+    batchSize    : BatchSize    {   32},
+    beta1        : Beta1        {  0.9},
+    beta2        : Beta2        {0.999},
+    rho          : Rho          {  0.9},
+    schedule     : Schedule     {ConstantSchedule{}},
+    epsilon      : Epsilon      { 1e-8},
+    maxIterations: MaxIterations{  100}}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Method:
+      opt.method = a
+    case StepSize:
+      opt.stepSize = a
+    case BatchSize:
+      opt.batchSize = a
+    case Momentum:
+      opt.momentum = a
+    case Nesterov:
+      opt.nesterov = a
+    case Beta1:
+      opt.beta1 = a
+    case Beta2:
+      opt.beta2 = a
+    case Rho:
+      opt.rho = a
+    case WeightDecay:
+      opt.weightDecay = a
+    case Schedule:
+      opt.schedule = a
+    case Epsilon:
+      opt.epsilon = a
+    case MaxIterations:
+      opt.maxIterations = a
+    case Seed:
+      opt.seed = a
+    case Hook:
+      opt.hook = a
+    default:
+      panic("Minibatch(): Invalid optional argument!")
+    }
+  }
+  switch opt.method.Value {
+  case "SGD", "AdaGrad", "RMSProp", "Adam", "AdamW":
+  default:
+    panic(fmt.Sprintf("Minibatch(): Invalid method `%s'!", opt.method.Value))
+  }
+  if opt.schedule.Value == nil {
+    opt.schedule.Value = ConstantSchedule{}
+  }
+  if err := checkSchedule(opt.schedule.Value); err != nil {
+    return opt, err
+  }
+  return opt, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// State of the update rule, m and v are the first and second moments
+// (or velocity and squared gradient sum, depending on the method)
+type updateRule struct {
+  opt     options
+  m       []float64
+  v       []float64
+  beta1_t float64
+  beta2_t float64
+}
+
+func newUpdateRule(opt options, n int) *updateRule {
+  return &updateRule{opt: opt, m: make([]float64, n), v: make([]float64, n), beta1_t: 1.0, beta2_t: 1.0}
+}
+
+// Update x given the average gradient g of a mini-batch and the step size.
+// The gradient is modified in-place.
+func (obj *updateRule) update(x, g DenseFloat64Vector, step float64) {
+  const eps = 1e-8
+  opt := obj.opt
+  wd  := opt.weightDecay.Value
+  if wd != 0.0 && opt.method.Value != "AdamW" {
+    for i := range g {
+      g[i] += wd*x[i]
+    }
+  }
+  switch opt.method.Value {
+  case "SGD":
+    mu := opt.momentum.Value
+    for i := range x {
+      obj.m[i] = mu*obj.m[i] + g[i]
+      if opt.nesterov.Value {
+        x[i] -= step*(g[i] + mu*obj.m[i])
+      } else {
+        x[i] -= step*obj.m[i]
+      }
+    }
+  case "AdaGrad":
+    for i := range x {
+      obj.v[i] += g[i]*g[i]
+      x[i] -= step*g[i]/(math.Sqrt(obj.v[i]) + eps)
+    }
+  case "RMSProp":
+    rho := opt.rho.Value
+    for i := range x {
+      obj.v[i] = rho*obj.v[i] + (1.0 - rho)*g[i]*g[i]
+      x[i] -= step*g[i]/(math.Sqrt(obj.v[i]) + eps)
+    }
+  case "Adam", "AdamW":
+    beta1 := opt.beta1.Value
+    beta2 := opt.beta2.Value
+    obj.beta1_t *= beta1
+    obj.beta2_t *= beta2
+    for i := range x {
+      obj.m[i] = beta1*obj.m[i] + (1.0 - beta1)*g[i]
+      obj.v[i] = beta2*obj.v[i] + (1.0 - beta2)*g[i]*g[i]
+      m_hat := obj.m[i]/(1.0 - obj.beta1_t)
+      v_hat := obj.v[i]/(1.0 - obj.beta2_t)
+      if opt.method.Value == "AdamW" {
+        x[i] -= step*wd*x[i]
+      }
+      x[i] -= step*m_hat/(math.Sqrt(v_hat) + eps)
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Relative change of x1 with respect to x0
+func relativeChange(x0, x1 DenseFloat64Vector) float64 {
+  max_x     := 0.0
+  max_delta := 0.0
+  for i := range x1 {
+    max_x     = math.Max(max_x    , math.Abs(x1[i]))
+    max_delta = math.Max(max_delta, math.Abs(x1[i] - x0[i]))
+  }
+  if max_x != 0.0 {
+    return max_delta/max_x
+  }
+  return max_delta
+}
+
+func minibatch(f DenseGradientF, n int, x0 ConstVector, opt options) (Vector, error) {
+  if n <= 0 {
+    return nil, fmt.Errorf("invalid number of examples")
+  }
+  if opt.batchSize.Value <= 0 {
+    return nil, fmt.Errorf("invalid batch size")
+  }
+  m  := x0.Dim()
+  x1 := AsDenseFloat64Vector(x0)
+  xs := AsDenseFloat64Vector(x0)
+  g  := NullDenseFloat64Vector(m)
+  gi := NullDenseFloat64Vector(m)
+  // permutation of examples
+  index := make([]int, n)
+  for i := range index {
+    index[i] = i
+  }
+  rule := newUpdateRule(opt, m)
+  rng  := rand.New(rand.NewSource(opt.seed.Value))
+  // number of updates
+  t := 0
+  for epoch := 0; epoch < opt.maxIterations.Value; epoch++ {
+    rng.Shuffle(n, func(i, j int) { index[i], index[j] = index[j], index[i] })
+    loss := 0.0
+    for k := 0; k < n; k += opt.batchSize.Value {
+      kn := k + opt.batchSize.Value
+      if kn > n {
+        kn = n
+      }
+      // average gradient of the mini-batch
+      for i := range g {
+        g[i] = 0.0
+      }
+      for _, j := range index[k:kn] {
+        y, err := f(j, x1, gi)
+        if err != nil {
+          return x1, err
+        }
+        loss += y
+        for i := range g {
+          g[i] += gi[i]
+        }
+      }
+      for i := range g {
+        g[i] /= float64(kn - k)
+      }
+      rule.update(x1, g, opt.stepSize.Value*opt.schedule.Value.Eval(t))
+      t++
+    }
+    for i := range x1 {
+      if math.IsNaN(x1[i]) {
+        return xs, fmt.Errorf("NaN value detected")
+      }
+    }
+    // execute hook if available
+    if opt.hook.Value != nil && opt.hook.Value(x1, ConstFloat64(loss/float64(n)), epoch) {
+      break
+    }
+    // evaluate stop criterion
+    if relativeChange(xs, x1) <= opt.epsilon.Value {
+      break
+    }
+    xs.Set(x1)
+  }
+  return x1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Minimize the average loss of n examples with mini-batch stochastic
+// gradient methods starting at x0. The objective function f is either an
+// Objective, which is differentiated automatically, or a DenseGradientF
+// that computes its own gradient. Examples are shuffled at the beginning
+// of each epoch.
+func Run(f interface{}, n int, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  opt, err := getOptions(args)
+  if err != nil {
+    return nil, err
+  }
+
+  switch a := f.(type) {
+  case Objective:
+    return minibatch(AutoGradientIndexed(a, GradientType(x0), x0.Dim()), n, x0, opt)
+  case func(int, ConstVector) (MagicScalar, error):
+    return minibatch(AutoGradientIndexed(a, GradientType(x0), x0.Dim()), n, x0, opt)
+  case DenseGradientF:
+    return minibatch(a, n, x0, opt)
+  case func(int, DenseFloat64Vector, DenseFloat64Vector) (float64, error):
+    return minibatch(a, n, x0, opt)
+  default:
+    panic("invalid objective function")
+  }
+}
@@ -0,0 +1,231 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package minibatch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/lbfgs"
+
+/* -------------------------------------------------------------------------- */
+
+func TestMinibatchRegression(test *testing.T) {
+  // data y = 2 x - 1
+  n := 100
+  x := make([]float64, n)
+  y := make([]float64, n)
+  for i := 0; i < n; i++ {
+    x[i] = float64(i)/float64(n) - 0.5
+    y[i] = 2.0*x[i] - 1.0
+  }
+  f := func(i int, theta ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    r.Mul(theta.ConstAt(0), ConstFloat64(x[i]))
+    r.Add(r, theta.ConstAt(1))
+    r.Sub(r, ConstFloat64(y[i]))
+    r.Mul(r, r)
+    return r, nil
+  }
+  for _, args := range [][]interface{}{
+    {Method{"SGD"    }, StepSize{0.1 }},
+    {Method{"SGD"    }, StepSize{0.05}, Momentum{0.9}},
+    {Method{"SGD"    }, StepSize{0.05}, Momentum{0.9}, Nesterov{true}},
+    {Method{"AdaGrad"}, StepSize{0.5 }},
+    {Method{"RMSProp"}, StepSize{0.01}, Schedule{CosineSchedule{2000, 0.0}}},
+    {Method{"Adam"   }, StepSize{0.05}, Schedule{WarmupSchedule{10, StepSchedule{200, 0.5}}}},
+    {Method{"AdamW"  }, StepSize{0.05}, Schedule{StepSchedule{200, 0.5}}} } {
+    theta, err := Run(f, n, NullDenseFloat64Vector(2), append(args, BatchSize{10}, MaxIterations{200}, Seed{1})...)
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(theta.Float64At(0) - 2.0) > 1e-3 || math.Abs(theta.Float64At(1) + 1.0) > 1e-3 {
+      test.Errorf("test failed for %v: %v", args, theta)
+    }
+  }
+}
+
+func TestMinibatchLogisticRegression(test *testing.T) {
+  // sample data from a logistic regression model
+  n := 500
+  r := rand.New(rand.NewSource(1))
+  x := make([]DenseFloat64Vector, n)
+  c := make([]float64, n)
+  for i := 0; i < n; i++ {
+    x[i] = NewDenseFloat64Vector([]float64{1.0, r.NormFloat64(), r.NormFloat64()})
+    if r.Float64() < 1.0/(1.0 + math.Exp(-(-1.0 + 2.0*x[i][1] - x[i][2]))) {
+      c[i] = 1.0
+    }
+  }
+  // negative log-likelihood of example i
+  f := func(i int, theta, gradient DenseFloat64Vector) (float64, error) {
+    t := 0.0
+    for k := range theta {
+      t += theta[k]*x[i][k]
+    }
+    p := 1.0/(1.0 + math.Exp(-t))
+    for k := range theta {
+      gradient[k] = (p - c[i])*x[i][k]
+    }
+    return math.Log1p(math.Exp(t)) - c[i]*t, nil
+  }
+  // full-batch solution
+  g := NullDenseFloat64Vector(3)
+  h := func(theta, gradient DenseFloat64Vector) (float64, error) {
+    y := 0.0
+    for k := range gradient {
+      gradient[k] = 0.0
+    }
+    for i := 0; i < n; i++ {
+      yi, _ := f(i, theta, g)
+      y += yi/float64(n)
+      for k := range gradient {
+        gradient[k] += g[k]/float64(n)
+      }
+    }
+    return y, nil
+  }
+  theta0, err := lbfgs.RunGradient(lbfgs.DenseGradientF(h), NullDenseFloat64Vector(3), lbfgs.Epsilon{1e-10})
+  if err != nil {
+    test.Error(err); return
+  }
+  epochs := 0
+  hook := func(x ConstVector, loss ConstScalar, epoch int) bool {
+    epochs++
+    return false
+  }
+  theta1, err := Run(DenseGradientF(f), n, NullDenseFloat64Vector(3),
+    Method       {"Adam"},
+    StepSize     {0.05},
+    BatchSize    {25},
+    Schedule     {CosineSchedule{20*100, 0.0}},
+    MaxIterations{100},
+    Hook         {hook})
+  if err != nil {
+    test.Error(err); return
+  }
+  for k := 0; k < 3; k++ {
+    if math.Abs(theta0.Float64At(k) - theta1.Float64At(k)) > 5e-3 {
+      test.Errorf("test failed: %v %v", theta0, theta1); break
+    }
+  }
+  if epochs == 0 {
+    test.Error("test failed")
+  }
+  // results are reproducible for the same seed
+  theta2, _ := Run(DenseGradientF(f), n, NullDenseFloat64Vector(3), BatchSize{25}, MaxIterations{10}, Seed{2})
+  theta3, _ := Run(DenseGradientF(f), n, NullDenseFloat64Vector(3), BatchSize{25}, MaxIterations{10}, Seed{2})
+  for k := 0; k < 3; k++ {
+    if theta2.Float64At(k) != theta3.Float64At(k) {
+      test.Error("test failed"); break
+    }
+  }
+}
+
+func TestMinibatchSchedule(test *testing.T) {
+  if r := (StepSchedule{10, 0.5}).Eval(25); r != 0.25 {
+    test.Error("test failed")
+  }
+  if r := (CosineSchedule{10, 0.1}).Eval(5); math.Abs(r - 0.55) > 1e-12 {
+    test.Error("test failed")
+  }
+  if r := (WarmupSchedule{4, nil}).Eval(1); r != 0.5 {
+    test.Error("test failed")
+  }
+  if r := (WarmupSchedule{4, StepSchedule{10, 0.5}}).Eval(14); r != 0.5 {
+    test.Error("test failed")
+  }
+}
+
+func TestMinibatchInvalidSchedule(test *testing.T) {
+  f := func(i int, x, gradient DenseFloat64Vector) (float64, error) {
+    gradient[0] = x[0]
+    return 0.5*x[0]*x[0], nil
+  }
+  for _, schedule := range []ScheduleType{
+    StepSchedule  {0, 0.5},
+    CosineSchedule{0, 0.1},
+    WarmupSchedule{0, nil},
+    WarmupSchedule{4, StepSchedule{0, 0.5}} } {
+    if _, err := Run(DenseGradientF(f), 10, NewDenseFloat64Vector([]float64{1.0}), Schedule{schedule}); err == nil {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestMinibatchReverse(test *testing.T) {
+  // data y = 2 x - 1
+  n := 100
+  x := make([]float64, n)
+  y := make([]float64, n)
+  for i := 0; i < n; i++ {
+    x[i] = float64(i)/float64(n) - 0.5
+    y[i] = 2.0*x[i] - 1.0
+  }
+  // the gradient is computed with the scalar type of x0
+  f := func(i int, theta ConstVector) (MagicScalar, error) {
+    if theta.ElementType() != ReverseReal64Type {
+      return nil, fmt.Errorf("invalid scalar type")
+    }
+    r := NullReverseReal64()
+    r.Mul(theta.ConstAt(0), ConstFloat64(x[i]))
+    r.Add(r, theta.ConstAt(1))
+    r.Sub(r, ConstFloat64(y[i]))
+    r.Mul(r, r)
+    return r, nil
+  }
+  theta, err := Run(f, n, NullDenseReverseReal64Vector(2), StepSize{0.1}, BatchSize{10}, MaxIterations{200})
+  if err != nil {
+    test.Error(err); return
+  }
+  if math.Abs(theta.Float64At(0) - 2.0) > 1e-3 || math.Abs(theta.Float64At(1) + 1.0) > 1e-3 {
+    test.Error("test failed")
+  }
+}
+
+func TestMinibatchDefaults(test *testing.T) {
+  // noisy data, the parameters keep changing with a constant step size
+  n := 100
+  r := rand.New(rand.NewSource(1))
+  x := make([]float64, n)
+  y := make([]float64, n)
+  for i := 0; i < n; i++ {
+    x[i] = float64(i)/float64(n) - 0.5
+    y[i] = 2.0*x[i] - 1.0 + r.NormFloat64()
+  }
+  f := func(i int, theta, gradient DenseFloat64Vector) (float64, error) {
+    d := theta[0]*x[i] + theta[1] - y[i]
+    gradient[0] = 2.0*d*x[i]
+    gradient[1] = 2.0*d
+    return d*d, nil
+  }
+  epochs := 0
+  hook := func(x ConstVector, loss ConstScalar, epoch int) bool {
+    epochs++
+    return false
+  }
+  if _, err := Run(DenseGradientF(f), n, NullDenseFloat64Vector(2), Hook{hook}); err != nil {
+    test.Error(err)
+  }
+  if epochs != 100 {
+    test.Error("test failed")
+  }
+}
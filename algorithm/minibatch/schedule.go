@@ -0,0 +1,109 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package minibatch
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+/* -------------------------------------------------------------------------- */
+
+// A learning rate schedule returns the factor by which the step size is
+// multiplied at update t = 0, 1, 2, ..., where t counts mini-batches.
+type ScheduleType interface {
+  Eval(t int) float64
+}
+
+// Check the parameters of the schedules defined in this package
+func checkSchedule(schedule ScheduleType) error {
+  switch a := schedule.(type) {
+  case StepSchedule:
+    if a.Length <= 0 {
+      return fmt.Errorf("invalid length of step schedule: %d", a.Length)
+    }
+  case CosineSchedule:
+    if a.Length <= 0 {
+      return fmt.Errorf("invalid length of cosine schedule: %d", a.Length)
+    }
+  case WarmupSchedule:
+    if a.Length <= 0 {
+      return fmt.Errorf("invalid length of warmup schedule: %d", a.Length)
+    }
+    if a.Schedule != nil {
+      return checkSchedule(a.Schedule)
+    }
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Constant step size
+type ConstantSchedule struct {
+}
+
+func (obj ConstantSchedule) Eval(t int) float64 {
+  return 1.0
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Multiply the step size by Gamma every Length updates (Length > 0)
+type StepSchedule struct {
+  Length int
+  Gamma  float64
+}
+
+func (obj StepSchedule) Eval(t int) float64 {
+  return math.Pow(obj.Gamma, float64(t/obj.Length))
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Cosine annealing from 1 to MinFactor within Length updates (Length > 0).
+// The factor stays at MinFactor afterwards.
+type CosineSchedule struct {
+  Length    int
+  MinFactor float64
+}
+
+func (obj CosineSchedule) Eval(t int) float64 {
+  if t >= obj.Length {
+    return obj.MinFactor
+  }
+  return obj.MinFactor + 0.5*(1.0 - obj.MinFactor)*(1.0 + math.Cos(math.Pi*float64(t)/float64(obj.Length)))
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Linear warmup within Length updates (Length > 0), followed by the given
+// schedule (which may be nil for a constant step size)
+type WarmupSchedule struct {
+  Length   int
+  Schedule ScheduleType
+}
+
+func (obj WarmupSchedule) Eval(t int) float64 {
+  if t < obj.Length {
+    return float64(t+1)/float64(obj.Length)
+  }
+  if obj.Schedule == nil {
+    return 1.0
+  }
+  return obj.Schedule.Eval(t - obj.Length)
+}
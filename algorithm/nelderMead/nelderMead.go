@@ -0,0 +1,271 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Nelder, John A., and Roger Mead. "A simplex method for function
+// minimization." The computer journal 7.4 (1965): 308-313.
+//
+// Gao, Fuchang, and Lixing Han. "Implementing the Nelder-Mead simplex
+// algorithm with adaptive parameters." Computational Optimization and
+// Applications 51.1 (2012): 259-277.
+
+/* -------------------------------------------------------------------------- */
+
+package nelderMead
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "sort"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+type Objective func(ConstVector) (ConstScalar, error)
+
+// Convergence is reached if the function values and the vertices of the
+// simplex differ by less than epsilon from the best vertex
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+type Hook struct {
+  Value func(x ConstVector, y ConstScalar) bool
+}
+
+// Use the adaptive parameters of Gao and Han, which depend on the number
+// of variables (default: true)
+type Adaptive struct {
+  Value bool
+}
+
+// Relative size of the initial simplex (default: 0.05). Zero elements of
+// the initial value are perturbed by 0.00025.
+type InitialStep struct {
+  Value float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+type vertex struct {
+  x DenseFloat64Vector
+  y float64
+}
+
+type simplex []vertex
+
+func (s simplex) Len() int {
+  return len(s)
+}
+
+func (s simplex) Less(i, j int) bool {
+  return s[i].y < s[j].y
+}
+
+func (s simplex) Swap(i, j int) {
+  s[i], s[j] = s[j], s[i]
+}
+
+// Check if function values and vertices are within epsilon of the best
+// vertex.
+func (s simplex) converged(epsilon float64) bool {
+  for i := 1; i < len(s); i++ {
+    if math.Abs(s[i].y - s[0].y) > epsilon {
+      return false
+    }
+    for j := range s[i].x {
+      if math.Abs(s[i].x[j] - s[0].x[j]) > epsilon {
+        return false
+      }
+    }
+  }
+  return true
+}
+
+/* -------------------------------------------------------------------------- */
+
+func nelderMead(f Objective, x0 ConstVector,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook,
+  adaptive Adaptive,
+  initialStep InitialStep) (Vector, error) {
+
+  n := x0.Dim()
+  if n == 0 {
+    return nil, fmt.Errorf("empty initial value")
+  }
+  // coefficients for reflection, expansion, contraction and shrinkage
+  alpha, beta, gamma, delta := 1.0, 2.0, 0.5, 0.5
+  // the adaptive parameters are defined for n >= 2, for n = 1 the shrink
+  // coefficient would be zero and collapse the simplex
+  if adaptive.Value && n >= 2 {
+    beta  = 1.0 + 2.0/float64(n)
+    gamma = 0.75 - 1.0/(2.0*float64(n))
+    delta = 1.0 - 1.0/float64(n)
+  }
+  eval := func(x DenseFloat64Vector) (float64, error) {
+    y, err := f(x)
+    if err != nil {
+      return 0.0, err
+    }
+    if r := y.GetFloat64(); math.IsNaN(r) {
+      return math.Inf(1), nil
+    } else {
+      return r, nil
+    }
+  }
+  // initial simplex
+  s := make(simplex, n+1)
+  for i := 0; i <= n; i++ {
+    s[i].x = AsDenseFloat64Vector(x0)
+    if i > 0 {
+      if x := s[i].x[i-1]; x != 0.0 {
+        s[i].x[i-1] = (1.0 + initialStep.Value)*x
+      } else {
+        s[i].x[i-1] = 0.00025
+      }
+    }
+    if y, err := eval(s[i].x); err != nil {
+      return nil, err
+    } else {
+      s[i].y = y
+    }
+  }
+  // centroid and trial points
+  xc := NullDenseFloat64Vector(n)
+  xr := NullDenseFloat64Vector(n)
+  xt := NullDenseFloat64Vector(n)
+  // compute xc + t (xc - x_n)
+  point := func(r DenseFloat64Vector, t float64) {
+    for j := 0; j < n; j++ {
+      r[j] = xc[j] + t*(xc[j] - s[n].x[j])
+    }
+  }
+  for i := 0; i < maxIterations.Value; i++ {
+    sort.Sort(s)
+    // execute hook if available
+    if hook.Value != nil && hook.Value(s[0].x, ConstFloat64(s[0].y)) {
+      break
+    }
+    // evaluate stop criterion
+    if s.converged(epsilon.Value) {
+      break
+    }
+    // centroid of all but the worst vertex
+    for j := 0; j < n; j++ {
+      xc[j] = 0.0
+      for k := 0; k < n; k++ {
+        xc[j] += s[k].x[j]
+      }
+      xc[j] /= float64(n)
+    }
+    // reflection
+    point(xr, alpha)
+    yr, err := eval(xr)
+    if err != nil {
+      return s[0].x, err
+    }
+    switch {
+    case yr < s[0].y:
+      // expansion
+      point(xt, alpha*beta)
+      yt, err := eval(xt)
+      if err != nil {
+        return s[0].x, err
+      }
+      if yt < yr {
+        s[n].x.Set(xt); s[n].y = yt
+      } else {
+        s[n].x.Set(xr); s[n].y = yr
+      }
+      continue
+    case yr < s[n-1].y:
+      s[n].x.Set(xr); s[n].y = yr
+      continue
+    case yr < s[n].y:
+      // outside contraction
+      point(xt, alpha*gamma)
+      yt, err := eval(xt)
+      if err != nil {
+        return s[0].x, err
+      }
+      if yt <= yr {
+        s[n].x.Set(xt); s[n].y = yt
+        continue
+      }
+    default:
+      // inside contraction
+      point(xt, -gamma)
+      yt, err := eval(xt)
+      if err != nil {
+        return s[0].x, err
+      }
+      if yt < s[n].y {
+        s[n].x.Set(xt); s[n].y = yt
+        continue
+      }
+    }
+    // shrink simplex towards the best vertex
+    for k := 1; k <= n; k++ {
+      for j := 0; j < n; j++ {
+        s[k].x[j] = s[0].x[j] + delta*(s[k].x[j] - s[0].x[j])
+      }
+      if s[k].y, err = eval(s[k].x); err != nil {
+        return s[0].x, err
+      }
+    }
+  }
+  sort.Sort(s)
+  return s[0].x, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Minimize f with the Nelder-Mead simplex algorithm starting at x0. The
+// objective function is not differentiated.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  epsilon       := Epsilon      {1e-8}
+  maxIterations := MaxIterations{int(^uint(0) >> 1)}
+  hook          := Hook         { nil}
+  adaptive      := Adaptive     {true}
+  initialStep   := InitialStep  {0.05}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Epsilon:
+      epsilon = a
+    case MaxIterations:
+      maxIterations = a
+    case Hook:
+      hook = a
+    case Adaptive:
+      adaptive = a
+    case InitialStep:
+      initialStep = a
+    default:
+      panic("NelderMead(): Invalid optional argument!")
+    }
+  }
+  return nelderMead(f, x0, epsilon, maxIterations, hook, adaptive, initialStep)
+}
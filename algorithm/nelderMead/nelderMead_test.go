@@ -0,0 +1,95 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package nelderMead
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestNelderMeadRosenbrock(test *testing.T) {
+  // f(x1, x2) = (1 - x1)^2 + 100 (x2 - x1^2)^2
+  f := func(x ConstVector) (ConstScalar, error) {
+    x1 := x.Float64At(0)
+    x2 := x.Float64At(1)
+    return ConstFloat64((1.0 - x1)*(1.0 - x1) + 100.0*(x2 - x1*x1)*(x2 - x1*x1)), nil
+  }
+  for _, adaptive := range []bool{false, true} {
+    xn, err := Run(f, NewDenseFloat64Vector([]float64{-1.2, 1}), Adaptive{adaptive}, Epsilon{1e-10})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(xn.Float64At(0) - 1.0) > 1e-6 || math.Abs(xn.Float64At(1) - 1.0) > 1e-6 {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestNelderMeadQuadratic(test *testing.T) {
+  // f(x) = sum_i i (x_i - i)^2
+  n := 10
+  f := func(x ConstVector) (ConstScalar, error) {
+    r := 0.0
+    for i := 0; i < n; i++ {
+      d := x.Float64At(i) - float64(i)
+      r += float64(i+1)*d*d
+    }
+    return ConstFloat64(r), nil
+  }
+  k  := 0
+  xn, err := Run(f, NullDenseFloat64Vector(n), Epsilon{1e-10},
+    Hook{func(x ConstVector, y ConstScalar) bool { k++; return false }})
+  if err != nil {
+    test.Error(err); return
+  }
+  for i := 0; i < n; i++ {
+    if math.Abs(xn.Float64At(i) - float64(i)) > 1e-4 {
+      test.Error("test failed"); break
+    }
+  }
+  if k == 0 {
+    test.Error("test failed")
+  }
+}
+
+func TestNelderMeadShrink(test *testing.T) {
+  // f(x) = (x - 1.01)^2 + 0.01 exp(-(x - 1.019)^2/10^-4), the initial
+  // simplex {1, 1.05} must be shrunk in the first iteration since the
+  // reflected and the contracted points lie on the walls of the bump
+  g := func(x float64) float64 {
+    return (x - 1.01)*(x - 1.01) + 0.01*math.Exp(-(x - 1.019)*(x - 1.019)/1e-4)
+  }
+  f := func(x ConstVector) (ConstScalar, error) {
+    return ConstFloat64(g(x.Float64At(0))), nil
+  }
+  for _, adaptive := range []bool{false, true} {
+    xn, err := Run(f, NewDenseFloat64Vector([]float64{1}), Adaptive{adaptive}, Epsilon{1e-10})
+    if err != nil {
+      test.Error(err); continue
+    }
+    // check that a local minimum is reached
+    x := xn.Float64At(0)
+    if d := (g(x + 1e-6) - g(x - 1e-6))/2e-6; math.Abs(d) > 1e-6 {
+      test.Error("test failed")
+    }
+  }
+}
@@ -22,6 +22,7 @@ import   "fmt"
 import   "math"
 
 import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
 import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
 import   "github.com/pbenner/autodiff/algorithm/cholesky"
 import   "github.com/pbenner/autodiff/algorithm/lineSearch"
@@ -32,7 +33,8 @@ import   "github.com/pbenner/autodiff/algorithm/qrAlgorithm"
 type objective_root func(ConstVector) (Vector, Matrix, error)
 type objective_crit func(ConstVector) (Vector, Matrix, error)
 type objective_min  func(ConstVector) (Scalar, Vector, Matrix, error)
-type objective_line func(ConstScalar) (MagicScalar, error)
+type objective_line func(x, gradient DenseFloat64Vector) (float64, error)
+type objective_hvp  func(Vector, ConstVector, ConstVector) error
 
 type Epsilon struct {
   Value float64
@@ -60,9 +62,34 @@ type MaxIterations struct {
   Value int
 }
 
+// Compute search directions with the truncated conjugate gradient method
+// (Newton-CG). The Hessian is never computed, instead Hessian-vector
+// products are obtained with automatic differentiation. This is suited
+// for problems with many variables. Hooks receive a nil Hessian.
+type NewtonCG struct {
+  Value bool
+}
+
+// Use a trust-region method instead of a line search. The subproblem
+// is solved either with the dogleg method ("Dogleg"), which requires a
+// Hessian, or with the conjugate gradient method of Steihaug
+// ("SteihaugCG"). The default is "None".
+type TrustRegion struct {
+  Value string
+}
+
+// Initial and maximal radius of the trust region
+type TrustRegionRadius struct {
+  Initial float64
+  Max     float64
+}
+
 type InSitu struct {
   T1 Vector
   T2 Scalar
+  T3 Vector
+  T4 Vector
+  T5 Vector
   QR       qrAlgorithm.InSitu
   Cholesky cholesky.InSitu
   Inverse  matrixInverse.InSitu
@@ -133,6 +160,65 @@ func getDirection(r, g Vector, H Matrix, hessianModification HessianModification
   return nil
 }
 
+// Approximately solve H r = g with the conjugate gradient method, where
+// products with the Hessian H at x are computed by hvp. The iteration
+// stops early if the residual is small or if a direction of negative
+// curvature is detected [Nocedal and Wright (2006), Algorithm 7.1].
+func getDirectionCG(r Vector, x, g ConstVector, hvp objective_hvp, inSitu *InSitu) error {
+  n := g.Dim()
+  if inSitu.T3 == nil {
+    inSitu.T3 = NullDenseVector(g.ElementType(), n)
+  }
+  if inSitu.T4 == nil {
+    inSitu.T4 = NullDenseVector(g.ElementType(), n)
+  }
+  if inSitu.T5 == nil {
+    inSitu.T5 = NullDenseVector(g.ElementType(), n)
+  }
+  // residual, search direction and Hessian-vector product
+  s := inSitu.T3
+  d := inSitu.T4
+  q := inSitu.T5
+  t := inSitu.T2
+  // tolerance for the residual
+  gnorm   := t.Vnorm(g).GetFloat64()
+  epsilon := math.Min(0.5, math.Sqrt(gnorm))*gnorm
+
+  r.Reset()
+  s.Set(g)
+  d.Set(g)
+  ss := t.VdotV(s, s).GetFloat64()
+  for j := 0; j < n; j++ {
+    if err := hvp(q, x, d); err != nil {
+      return err
+    }
+    dq := t.VdotV(d, q).GetFloat64()
+    if dq <= 0.0 {
+      // negative curvature, use steepest descent if this is the first
+      // iteration
+      if j == 0 {
+        r.Set(g)
+      }
+      break
+    }
+    alpha := ss/dq
+    for i := 0; i < n; i++ {
+      r.At(i).SetFloat64(r.Float64At(i) + alpha*d.Float64At(i))
+      s.At(i).SetFloat64(s.Float64At(i) - alpha*q.Float64At(i))
+    }
+    ss_new := t.VdotV(s, s).GetFloat64()
+    if math.Sqrt(ss_new) < epsilon {
+      break
+    }
+    beta := ss_new/ss
+    for i := 0; i < n; i++ {
+      d.At(i).SetFloat64(s.Float64At(i) + beta*d.Float64At(i))
+    }
+    ss = ss_new
+  }
+  return nil
+}
+
 /* Newton's method for root finding
  * -------------------------------------------------------------------------- */
 
@@ -225,7 +311,8 @@ func newton_root(f objective_root, x ConstVector,
 func newton_min(
   f  objective_min,
   x ConstVector,
-  getPhi func(x, p ConstVector) objective_line,
+  fLine objective_line,
+  hvp objective_hvp,
   epsilon Epsilon,
   maxIterations MaxIterations,
   hook HookMin,
@@ -263,13 +350,16 @@ func newton_min(
   // temporary variables
   t1 := inSitu.T1
   t2 := inSitu.T2
+  // search direction p = -t1 for the line search
+  p  := NullDenseFloat64Vector(x1.Dim())
   // constraints function for the line search algorithm
   var constraints_line func(alpha ConstScalar) bool
 
   if constraints.Value != nil {
     constraints_line = func(alpha ConstScalar) bool {
-      t1.VmulS(t1, alpha)
-      x2.VsubV(x1, t1)
+      for i := range p {
+        x2[i] = x1[i] + alpha.GetFloat64()*p[i]
+      }
       return constraints.Value(x2)
     }
   }
@@ -287,21 +377,29 @@ func newton_min(
     if math.IsNaN(t2.GetFloat64()) {
       return x1, fmt.Errorf("NaN value detected")
     }
-    if err := getDirection(t1, g, H, hessianModification, inSitu); err != nil {
-      return nil, err
+    if hvp != nil {
+      if err := getDirectionCG(t1, x1, g, hvp, inSitu); err != nil {
+        return nil, err
+      }
+    } else {
+      if err := getDirection(t1, g, H, hessianModification, inSitu); err != nil {
+        return nil, err
+      }
     }
 
-    if getPhi != nil {
-      // get line search objective function
-      phi := getPhi(x1, t1)
+    if fLine != nil {
+      for i := range p {
+        p[i] = -t1.ConstAt(i).GetFloat64()
+      }
       // execute line search and update x
-      if alpha, err := lineSearch.Run(phi, Float64Type,
+      if alpha, err := lineSearch.RunAlongDirection(fLine, x1, y1.GetFloat64(), g, p, append([]interface{}{
           lineSearch.Constraints{constraints_line},
-          lineSearch.Parameters {1, 20}); err != nil {
+          lineSearch.Parameters {1, 20}}, options...)...); err != nil {
         return x1, err
       } else {
-        t1.VmulS(t1, alpha)
-        x2.VsubV(x1, t1)
+        for i := range p {
+          x2[i] = x1[i] + alpha*p[i]
+        }
       }
     } else {
       for {
@@ -367,13 +465,15 @@ func run_root(f objective_root, x ConstVector, args ...interface{}) (Vector, err
   return newton_root(f, x, epsilon, maxIterations, hook, constraints, hessianModification, inSitu, options)
 }
 
-func run_min(f objective_min, x ConstVector, getPhi func(x, p ConstVector) objective_line, args ...interface{}) (Vector, error) {
+func run_min(f objective_min, x ConstVector, fLine objective_line, hvp objective_hvp, args ...interface{}) (Vector, error) {
 
   hook                := HookMin            {   nil}
   epsilon             := Epsilon            {  1e-8}
   constraints         := Constraints        {   nil}
   hessianModification := HessianModification{"None"}
   maxIterations       := MaxIterations      {int(^uint(0) >> 1)}
+  trustRegion         := TrustRegion        {"None"}
+  trustRegionRadius   := TrustRegionRadius  {1.0, 1000.0}
   inSitu              := &InSitu            {}
   options             := make([]interface{}, 0)
 
@@ -389,6 +489,14 @@ func run_min(f objective_min, x ConstVector, getPhi func(x, p ConstVector) objec
       hessianModification = a
     case MaxIterations:
       maxIterations = a
+    case NewtonCG:
+      if !a.Value {
+        hvp = nil
+      }
+    case TrustRegion:
+      trustRegion = a
+    case TrustRegionRadius:
+      trustRegionRadius = a
     case *InSitu:
       inSitu = a
     case InSitu:
@@ -398,7 +506,10 @@ func run_min(f objective_min, x ConstVector, getPhi func(x, p ConstVector) objec
     }
   }
 
-  return newton_min(f, x, getPhi, epsilon, maxIterations, hook, constraints, hessianModification, inSitu, options)
+  if trustRegion.Value != "None" {
+    return newton_trustRegion(f, x, hvp, epsilon, maxIterations, hook, constraints, trustRegion, trustRegionRadius)
+  }
+  return newton_min(f, x, fLine, hvp, epsilon, maxIterations, hook, constraints, hessianModification, inSitu, options)
 }
 
 /* -------------------------------------------------------------------------- */
@@ -473,39 +584,76 @@ func RunCrit(f_ func(ConstVector) (MagicScalar, error), x ConstVector, args ...i
 
 func RunMin(f_ func(ConstVector) (MagicScalar, error), x ConstVector, args ...interface{}) (Vector, error) {
 
+  newtonCG := false
+  for _, arg := range args {
+    if a, ok := arg.(NewtonCG); ok {
+      newtonCG = a.Value
+    }
+  }
   n := x.Dim()
   y := NullFloat64()
   g := NullDenseFloat64Vector(n)
-  H := NullDenseFloat64Matrix(n, n)
   // copy of x for computing derivatives
   X := AsDenseReal64Vector(x)
-  P := AsDenseReal64Vector(x)
   // objective function
-  f := func(x ConstVector) (Scalar, Vector, Matrix, error) {
-    X.Set(x)
-    if err := X.Variables(2); err != nil {
-      return nil, nil, nil, err
+  var f objective_min
+  var hvp objective_hvp
+  if newtonCG {
+    f = func(x ConstVector) (Scalar, Vector, Matrix, error) {
+      X.Set(x)
+      if err := X.Variables(1); err != nil {
+        return nil, nil, nil, err
+      }
+      // evaluate objective function
+      Y, err := f_(X)
+      if err != nil {
+        return nil, nil, nil, err
+      }
+      // copy function value to y
+      y.SetFloat64(Y.GetFloat64())
+      // copy gradient
+      CopyGradient(g, Y)
+      return y, g, nil, nil
+    }
+    // Hessian-vector products
+    hvp = func(r Vector, x, v ConstVector) error {
+      var err error
+      X.Set(x)
+      h := func(x ConstVector) ConstScalar {
+        Y, e := f_(x)
+        if e != nil {
+          err = e
+          return ConstFloat64(0.0)
+        }
+        return Y
+      }
+      if e := HessianVectorProduct(r, h, X, v); e != nil {
+        return e
+      }
+      return err
     }
-    // evaluate objective function
-    Y, err := f_(X)
-    if err != nil {
-      return nil, nil, nil, err
+  } else {
+    H := NullDenseFloat64Matrix(n, n)
+    f = func(x ConstVector) (Scalar, Vector, Matrix, error) {
+      X.Set(x)
+      if err := X.Variables(2); err != nil {
+        return nil, nil, nil, err
+      }
+      // evaluate objective function
+      Y, err := f_(X)
+      if err != nil {
+        return nil, nil, nil, err
+      }
+      // copy function value to y
+      y.SetFloat64(Y.GetFloat64())
+      // copy gradient and hessian
+      CopyGradient(g, Y)
+      CopyHessian (H, Y)
+      return y, g, H, nil
     }
-    // copy function value to y
-    y.SetFloat64(Y.GetFloat64())
-    // copy gradient and hessian
-    CopyGradient(g, Y)
-    CopyHessian (H, Y)
-    return y, g, H, nil
   }
   // objective function for line-search
-  getPhi := func(x, p ConstVector) objective_line {
-    phi := func(alpha ConstScalar) (MagicScalar, error) {
-      P.VmulS(p, alpha)
-      X.VsubV(x, P)
-      return f_(X)
-    }
-    return phi
-  }
-  return run_min(f, x, getPhi, args...)
+  fLine := AutoGradient(f_, Real64Type, n)
+
+  return run_min(f, x, fLine, hvp, args...)
 }
@@ -23,6 +23,7 @@ import   "math"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/lineSearch"
 
 /* -------------------------------------------------------------------------- */
 
@@ -108,3 +109,158 @@ func TestNewtonCrit2(test *testing.T) {
     }
   }
 }
+
+func TestNewtonCG(test *testing.T) {
+  t := NewFloat64(0.0)
+  // Rosenbrock function
+  f := func(x ConstVector) (MagicScalar, error) {
+    r  := NullReal64()
+    t1 := NullReal64()
+    t2 := NullReal64()
+    for i := 0; i+1 < x.Dim(); i++ {
+      // 100 (x_{i+1} - x_i^2)^2 + (1 - x_i)^2
+      t1.Mul(x.ConstAt(i), x.ConstAt(i))
+      t1.Sub(x.ConstAt(i+1), t1)
+      t1.Mul(t1, t1)
+      t1.Mul(t1, ConstFloat64(100.0))
+      t2.Sub(ConstFloat64(1.0), x.ConstAt(i))
+      t2.Mul(t2, t2)
+      r.Add(r, t1)
+      r.Add(r, t2)
+    }
+    return r, nil
+  }
+  v1 := NewDenseFloat64Vector([]float64{-1.2, 1, -1.2, 1, 0.5, 0.5})
+  v2 := NewDenseFloat64Vector([]float64{1, 1, 1, 1, 1, 1})
+  v3, err := RunMin(f, v1, Epsilon{1e-8}, NewtonCG{true})
+  if err != nil {
+    test.Error(err)
+  } else {
+    if t.Vnorm(v2.VsubV(v2, v3)).GetFloat64() > 1e-6  {
+      test.Error("Newton method failed!")
+    }
+  }
+}
+
+func TestNewtonLineSearch(test *testing.T) {
+  t := NewFloat64(0.0)
+  // Rosenbrock function
+  f := func(x ConstVector) (MagicScalar, error) {
+    r  := NullReal64()
+    t1 := NullReal64()
+    t2 := NullReal64()
+    t1.Mul(x.ConstAt(0), x.ConstAt(0))
+    t1.Sub(x.ConstAt(1), t1)
+    t1.Mul(t1, t1)
+    t1.Mul(t1, ConstFloat64(100.0))
+    t2.Sub(ConstFloat64(1.0), x.ConstAt(0))
+    t2.Mul(t2, t2)
+    r.Add(t1, t2)
+    return r, nil
+  }
+  v2 := NewDenseFloat64Vector([]float64{1, 1})
+  for _, method := range []string{"Armijo", "MoreThuente", "HagerZhang"} {
+    v1 := NewDenseFloat64Vector([]float64{-1.2, 1})
+    v3, err := RunMin(f, v1, Epsilon{1e-8}, HessianModification{"LDL"}, lineSearch.Method{method})
+    if err != nil {
+      test.Error(err)
+    } else {
+      if t.Vnorm(v3.VsubV(v3, v2)).GetFloat64() > 1e-6  {
+        test.Errorf("Newton method failed for line search method %s", method)
+      }
+    }
+  }
+}
+
+func TestNewtonTrustRegion1(test *testing.T) {
+  t := NewFloat64(0.0)
+  // Rosenbrock function
+  f := func(x ConstVector) (MagicScalar, error) {
+    r  := NullReal64()
+    t1 := NullReal64()
+    t2 := NullReal64()
+    for i := 0; i+1 < x.Dim(); i++ {
+      t1.Mul(x.ConstAt(i), x.ConstAt(i))
+      t1.Sub(x.ConstAt(i+1), t1)
+      t1.Mul(t1, t1)
+      t1.Mul(t1, ConstFloat64(100.0))
+      t2.Sub(ConstFloat64(1.0), x.ConstAt(i))
+      t2.Mul(t2, t2)
+      r.Add(r, t1)
+      r.Add(r, t2)
+    }
+    return r, nil
+  }
+  v2 := NewDenseFloat64Vector([]float64{1, 1})
+  for _, args := range [][]interface{}{
+    {TrustRegion{"Dogleg"}},
+    {TrustRegion{"SteihaugCG"}},
+    {TrustRegion{"SteihaugCG"}, NewtonCG{true}} } {
+    v1 := NewDenseFloat64Vector([]float64{-1.2, 1})
+    v3, err := RunMin(f, v1, append(args, Epsilon{1e-8})...)
+    if err != nil {
+      test.Error(err)
+    } else {
+      if t.Vnorm(v3.VsubV(v3, v2)).GetFloat64() > 1e-6  {
+        test.Errorf("trust region method failed for %v", args)
+      }
+    }
+  }
+}
+
+func TestNewtonTrustRegion2(test *testing.T) {
+  // f(x) = x1^4 - x1^2 + 1000 x2^2 has an indefinite Hessian close to
+  // x1 = 0 and minima at x1 = +/- 1/sqrt(2), x2 = 0
+  f := func(x ConstVector) (MagicScalar, error) {
+    r := NullReal64()
+    t := NullReal64()
+    r.Pow(x.ConstAt(0), ConstFloat64(4.0))
+    t.Mul(x.ConstAt(0), x.ConstAt(0))
+    r.Sub(r, t)
+    t.Mul(x.ConstAt(1), x.ConstAt(1))
+    t.Mul(t, ConstFloat64(1000.0))
+    r.Add(r, t)
+    return r, nil
+  }
+  for _, method := range []string{"Dogleg", "SteihaugCG"} {
+    x, err := RunMin(f, NewDenseFloat64Vector([]float64{0.01, 1}), TrustRegion{method}, Epsilon{1e-10})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if math.Abs(x.Float64At(0) - 1.0/math.Sqrt(2.0)) > 1e-6 || math.Abs(x.Float64At(1)) > 1e-6 {
+      test.Errorf("trust region method %s failed", method)
+    }
+  }
+  if _, err := RunMin(f, NewDenseFloat64Vector([]float64{0.01, 1}), TrustRegion{"Dogleg"}, NewtonCG{true}); err == nil {
+    test.Error("test failed")
+  }
+}
+
+func TestNewtonLineSearchEvaluations(test *testing.T) {
+  n := 0
+  // f(x) = (x1 - 1)^2 + 4 (x2 + 2)^2 is minimized by a single Newton step
+  f := func(x ConstVector) (MagicScalar, error) {
+    n++
+    r := NullReal64()
+    t := NullReal64()
+    r.Sub(x.ConstAt(0), ConstFloat64(1.0))
+    r.Mul(r, r)
+    t.Add(x.ConstAt(1), ConstFloat64(2.0))
+    t.Mul(t, t)
+    t.Mul(t, ConstFloat64(4.0))
+    r.Add(r, t)
+    return r, nil
+  }
+  x, err := RunMin(f, NewDenseFloat64Vector([]float64{0, 0}), lineSearch.Method{"Wolfe"})
+  if err != nil {
+    test.Error(err); return
+  }
+  if math.Abs(x.Float64At(0) - 1.0) > 1e-8 || math.Abs(x.Float64At(1) + 2.0) > 1e-8 {
+    test.Error("test failed")
+  }
+  // evaluations at x0, by the line search at the Newton step, and at the
+  // new position
+  if n != 3 {
+    test.Error("test failed")
+  }
+}
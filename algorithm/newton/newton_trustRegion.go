@@ -0,0 +1,351 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package newton
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
+
+/* -------------------------------------------------------------------------- */
+
+func boundaryStep(z, d DenseFloat64Vector, delta float64) float64 {
+  a := Ddot(d, d)
+  b := Ddot(z, d)
+  c := Ddot(z, z) - delta*delta
+  return (-b + math.Sqrt(math.Max(0.0, b*b - a*c)))/a
+}
+
+/* -------------------------------------------------------------------------- */
+
+type trustRegionSubproblem struct {
+  n      int
+  g      DenseFloat64Vector
+  // Hessian-vector product
+  hv     func(r, v DenseFloat64Vector) error
+  // Hessian matrix, which is nil if the Hessian is not available
+  H      Matrix
+  // temporary memory
+  t1, t2, t3, t4 DenseFloat64Vector
+  chol   cholesky.InSitu
+}
+
+func newTrustRegionSubproblem(n int) *trustRegionSubproblem {
+  return &trustRegionSubproblem{
+    n : n,
+    t1: NullDenseFloat64Vector(n),
+    t2: NullDenseFloat64Vector(n),
+    t3: NullDenseFloat64Vector(n),
+    t4: NullDenseFloat64Vector(n) }
+}
+
+// Value of the quadratic model m(p) - m(0) = g^T p + 1/2 p^T H p.
+func (obj *trustRegionSubproblem) model(p DenseFloat64Vector) (float64, error) {
+  if err := obj.hv(obj.t1, p); err != nil {
+    return 0.0, err
+  }
+  return Ddot(obj.g, p) + 0.5*Ddot(p, obj.t1), nil
+}
+
+// Cauchy point, i.e. the minimizer of the model along the steepest descent
+// direction within the trust region [Nocedal and Wright (2006), Algorithm
+// 4.2].
+func (obj *trustRegionSubproblem) cauchyPoint(p DenseFloat64Vector, delta float64) error {
+  g := obj.g
+  if err := obj.hv(obj.t1, g); err != nil {
+    return err
+  }
+  gnorm := Dnorm(g)
+  gHg   := Ddot(g, obj.t1)
+  tau   := 1.0
+  if gHg > 0.0 {
+    tau = math.Min(gnorm*gnorm*gnorm/(delta*gHg), 1.0)
+  }
+  for i := range p {
+    p[i] = -tau*delta/gnorm*g[i]
+  }
+  return nil
+}
+
+// Solve H p = -g with the Cholesky decomposition of H. Returns false if H
+// is not positive definite.
+func (obj *trustRegionSubproblem) newtonStep(p DenseFloat64Vector) bool {
+  L, _, err := cholesky.Run(obj.H, &obj.chol)
+  if err != nil {
+    return false
+  }
+  n := obj.n
+  for i := 0; i < n; i++ {
+    if L.Float64At(i, i) <= 0.0 {
+      return false
+    }
+  }
+  // forward substitution L z = -g
+  for i := 0; i < n; i++ {
+    s := -obj.g[i]
+    for k := 0; k < i; k++ {
+      s -= L.Float64At(i, k)*p[k]
+    }
+    p[i] = s/L.Float64At(i, i)
+  }
+  // backward substitution L^T p = z
+  for i := n-1; i >= 0; i-- {
+    s := p[i]
+    for k := i+1; k < n; k++ {
+      s -= L.Float64At(k, i)*p[k]
+    }
+    p[i] = s/L.Float64At(i, i)
+  }
+  return true
+}
+
+// Dogleg method [Nocedal and Wright (2006), Section 4.1]. If the Hessian
+// is not positive definite, the Cauchy point is used.
+func (obj *trustRegionSubproblem) dogleg(p DenseFloat64Vector, delta float64) error {
+  g  := obj.g
+  pb := obj.t2
+  pu := obj.t3
+  if !obj.newtonStep(pb) {
+    return obj.cauchyPoint(p, delta)
+  }
+  // full Newton step is within the trust region
+  if Dnorm(pb) <= delta {
+    p.Set(pb)
+    return nil
+  }
+  // unconstrained minimizer along the steepest descent direction
+  if err := obj.hv(obj.t1, g); err != nil {
+    return err
+  }
+  s := -Ddot(g, g)/Ddot(g, obj.t1)
+  for i := range pu {
+    pu[i] = s*g[i]
+  }
+  if Dnorm(pu) >= delta {
+    s := delta/Dnorm(g)
+    for i := range p {
+      p[i] = -s*g[i]
+    }
+    return nil
+  }
+  // intersection of the path pu + tau (pb - pu) with the boundary
+  d := obj.t4
+  for i := range d {
+    d[i] = pb[i] - pu[i]
+  }
+  tau := boundaryStep(pu, d, delta)
+  for i := range p {
+    p[i] = pu[i] + tau*d[i]
+  }
+  return nil
+}
+
+// Conjugate gradient method of Steihaug, which terminates at the boundary
+// of the trust region or if a direction of negative curvature is found
+// [Nocedal and Wright (2006), Algorithm 7.2].
+func (obj *trustRegionSubproblem) steihaugCG(z DenseFloat64Vector, delta float64) error {
+  r  := obj.t2
+  d  := obj.t3
+  Hd := obj.t4
+  // tolerance for the residual
+  gnorm   := Dnorm(obj.g)
+  epsilon := math.Min(0.5, math.Sqrt(gnorm))*gnorm
+
+  for i := range z {
+    z[i] =  0.0
+    r[i] =  obj.g[i]
+    d[i] = -obj.g[i]
+  }
+  rr := Ddot(r, r)
+  for j := 0; j < obj.n; j++ {
+    if err := obj.hv(Hd, d); err != nil {
+      return err
+    }
+    dHd := Ddot(d, Hd)
+    if dHd <= 0.0 {
+      // negative curvature, move to the boundary
+      tau := boundaryStep(z, d, delta)
+      for i := range z {
+        z[i] += tau*d[i]
+      }
+      return nil
+    }
+    alpha := rr/dHd
+    // check if the next iterate leaves the trust region
+    zz := 0.0
+    for i := range z {
+      zi := z[i] + alpha*d[i]
+      zz += zi*zi
+    }
+    if math.Sqrt(zz) >= delta {
+      tau := boundaryStep(z, d, delta)
+      for i := range z {
+        z[i] += tau*d[i]
+      }
+      return nil
+    }
+    for i := range z {
+      z[i] += alpha*d[i]
+      r[i] += alpha*Hd[i]
+    }
+    rr_new := Ddot(r, r)
+    if math.Sqrt(rr_new) < epsilon {
+      break
+    }
+    beta := rr_new/rr
+    for i := range d {
+      d[i] = -r[i] + beta*d[i]
+    }
+    rr = rr_new
+  }
+  return nil
+}
+
+/* Trust-region Newton method
+ * -------------------------------------------------------------------------- */
+
+// Trust-region Newton method [Nocedal and Wright (2006), Algorithm 4.1].
+// Steps that violate the constraints are rejected and the radius is
+// decreased.
+func newton_trustRegion(
+  f  objective_min,
+  x ConstVector,
+  hvp objective_hvp,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook HookMin,
+  constraints Constraints,
+  trustRegion TrustRegion,
+  trustRegionRadius TrustRegionRadius) (Vector, error) {
+
+  // minimal ratio of actual to predicted reduction for accepting a step
+  eta := 1e-4
+
+  switch trustRegion.Value {
+  case "Dogleg":
+    if hvp != nil {
+      return nil, fmt.Errorf("dogleg method requires the Hessian, use NewtonCG{false}")
+    }
+  case "SteihaugCG":
+  default:
+    panic(fmt.Sprintf("invalid trust region method: %s", trustRegion.Value))
+  }
+  if trustRegionRadius.Initial <= 0.0 || trustRegionRadius.Max < trustRegionRadius.Initial {
+    return nil, fmt.Errorf("invalid trust region radius")
+  }
+  n     := x.Dim()
+  delta := trustRegionRadius.Initial
+  x1    := AsDenseFloat64Vector(x)
+  x2    := AsDenseFloat64Vector(x)
+  g1    := NullDenseFloat64Vector(n)
+  p     := NullDenseFloat64Vector(n)
+
+  // check initial value
+  if constraints.Value != nil && !constraints.Value(x1) {
+    return x1, fmt.Errorf("invalid initial value: %v", x1)
+  }
+  // evaluate objective function
+  y, g, H, err := f(x1)
+  if err != nil {
+    return nil, err
+  }
+  y1 := y.CloneScalar()
+  g1.Set(g)
+  var H1 Matrix
+  if H != nil {
+    H1 = H.CloneMatrix()
+  }
+  s := newTrustRegionSubproblem(n)
+  s.g = g1
+  s.H = H1
+  if hvp != nil {
+    s.hv = func(r, v DenseFloat64Vector) error {
+      return hvp(r, x1, v)
+    }
+  } else {
+    s.hv = func(r, v DenseFloat64Vector) error {
+      for i := 0; i < n; i++ {
+        r[i] = 0.0
+        for j := 0; j < n; j++ {
+          r[i] += H1.Float64At(i, j)*v[j]
+        }
+      }
+      return nil
+    }
+  }
+  for i := 0; i < maxIterations.Value; i++ {
+    // execute hook if available
+    if hook.Value != nil && hook.Value(x1, g1, H1, y1) {
+      break
+    }
+    // evaluate stop criterion
+    if gnorm := Dnorm(g1); gnorm < epsilon.Value {
+      break
+    } else
+    if math.IsNaN(gnorm) {
+      return x1, fmt.Errorf("NaN value detected")
+    }
+    // solve trust region subproblem
+    switch trustRegion.Value {
+    case "Dogleg":
+      err = s.dogleg(p, delta)
+    case "SteihaugCG":
+      err = s.steihaugCG(p, delta)
+    }
+    if err != nil {
+      return x1, err
+    }
+    m, err := s.model(p)
+    if err != nil {
+      return x1, err
+    }
+    for i := range x2 {
+      x2[i] = x1[i] + p[i]
+    }
+    // ratio of actual to predicted reduction
+    rho := math.Inf(-1)
+    if constraints.Value == nil || constraints.Value(x2) {
+      if y, g, H, err = f(x2); err != nil {
+        return x1, err
+      }
+      rho = (y1.GetFloat64() - y.GetFloat64())/(-m)
+    }
+    // update trust region radius
+    if pnorm := Dnorm(p); rho < 0.25 {
+      delta = 0.25*pnorm
+    } else
+    if rho > 0.75 && pnorm >= 0.99*delta {
+      delta = math.Min(2.0*delta, trustRegionRadius.Max)
+    }
+    if rho > eta {
+      x1, x2 = x2, x1
+      y1.Set(y)
+      g1.Set(g)
+      if H1 != nil {
+        H1.Set(H)
+      }
+    } else
+    if delta <= 1e-15*(1.0 + Dnorm(x1)) {
+      return x1, fmt.Errorf("trust region radius too small")
+    }
+  }
+  return x1, nil
+}
@@ -0,0 +1,372 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Powell, Michael JD. "An efficient method for finding the minimum of a
+// function of several variables without calculating derivatives." The
+// computer journal 7.2 (1964): 155-162.
+//
+// Press, William H., et al. Numerical recipes 3rd edition: The art of
+// scientific computing. Cambridge university press, 2007.
+
+/* -------------------------------------------------------------------------- */
+
+package powell
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/algorithm"
+
+/* -------------------------------------------------------------------------- */
+
+type Objective func(ConstVector) (ConstScalar, error)
+
+// Convergence is reached if the relative decrease of the function value
+// within one iteration is smaller than epsilon
+type Epsilon struct {
+  Value float64
+}
+
+type MaxIterations struct {
+  Value int
+}
+
+type Hook struct {
+  Value func(x ConstVector, y ConstScalar) bool
+}
+
+// Lower bounds on the variables. Elements may be -Inf if a variable is
+// not bounded from below.
+type LowerBounds struct {
+  Value ConstVector
+}
+
+// Upper bounds on the variables. Elements may be +Inf if a variable is
+// not bounded from above.
+type UpperBounds struct {
+  Value ConstVector
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Compute the interval [a, b] of step sizes such that x + t d is within
+// the bounds for all t in [a, b].
+func stepBounds(x, d DenseFloat64Vector, l, u []float64) (float64, float64) {
+  a := math.Inf(-1)
+  b := math.Inf( 1)
+  for i := range x {
+    switch {
+    case d[i] > 0.0:
+      a = math.Max(a, (l[i] - x[i])/d[i])
+      b = math.Min(b, (u[i] - x[i])/d[i])
+    case d[i] < 0.0:
+      a = math.Max(a, (u[i] - x[i])/d[i])
+      b = math.Min(b, (l[i] - x[i])/d[i])
+    }
+  }
+  return math.Min(a, 0.0), math.Max(b, 0.0)
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Bracket a minimum of f within [lo, hi] starting at t = 0, where y0 = f(0).
+// Returns a <= b <= c with f(b) <= f(a) and f(b) <= f(c), or b at one of the
+// bounds if f is monotone.
+func bracket(f func(float64) (float64, error), y0, lo, hi float64) (float64, float64, float64, float64, error) {
+  const gold  = 1.618034
+  const limit = 100
+  a, ya := 0.0, y0
+  b     := math.Min(1.0, hi)
+  if b <= 0.0 {
+    b = math.Max(-1.0, lo)
+  }
+  if b == a {
+    return a, a, a, ya, nil
+  }
+  yb, err := f(b)
+  if err != nil {
+    return 0, 0, 0, 0, err
+  }
+  if yb > ya {
+    // search in the opposite direction
+    if c := math.Max(math.Min(-b, hi), lo); c != a {
+      yc, err := f(c)
+      if err != nil {
+        return 0, 0, 0, 0, err
+      }
+      if yc >= ya {
+        return math.Min(b, c), a, math.Max(b, c), ya, nil
+      }
+      b, yb = c, yc
+    } else {
+      return math.Min(a, b), a, math.Max(a, b), ya, nil
+    }
+  }
+  // a = 0, f(b) < f(a); expand in direction of b
+  for k := 0; k < limit; k++ {
+    c := math.Max(math.Min(b + gold*(b - a), hi), lo)
+    if c == b {
+      // minimum at the boundary
+      return b, b, b, yb, nil
+    }
+    yc, err := f(c)
+    if err != nil {
+      return 0, 0, 0, 0, err
+    }
+    if yc >= yb {
+      return math.Min(a, c), b, math.Max(a, c), yb, nil
+    }
+    a, b, yb = b, c, yc
+  }
+  return b, b, b, yb, nil
+}
+
+// Minimize f on [a, c] with Brent's method, where b is a point in [a, c]
+// with f(b) = yb. Returns the minimizer and the function value.
+func brent(f func(float64) (float64, error), a, b, c, yb float64) (float64, float64, error) {
+  const maxIter = 100
+  const cgold   = 0.3819660
+  const zeps    = 1e-12
+  const tol     = 1.5e-8
+  if a == c {
+    return b, yb, nil
+  }
+  x, w, v    := b, b, b
+  fx, fw, fv := yb, yb, yb
+  d, e := 0.0, 0.0
+  for k := 0; k < maxIter; k++ {
+    xm   := 0.5*(a + c)
+    tol1 := tol*math.Abs(x) + zeps
+    tol2 := 2.0*tol1
+    if math.Abs(x - xm) <= tol2 - 0.5*(c - a) {
+      break
+    }
+    parabolic := false
+    if math.Abs(e) > tol1 {
+      // try parabolic interpolation
+      r := (x - w)*(fx - fv)
+      q := (x - v)*(fx - fw)
+      p := (x - v)*q - (x - w)*r
+      q  = 2.0*(q - r)
+      if q > 0.0 {
+        p = -p
+      }
+      q = math.Abs(q)
+      if math.Abs(p) < math.Abs(0.5*q*e) && p > q*(a - x) && p < q*(c - x) {
+        e = d
+        d = p/q
+        if u := x + d; u - a < tol2 || c - u < tol2 {
+          d = math.Copysign(tol1, xm - x)
+        }
+        parabolic = true
+      }
+    }
+    if !parabolic {
+      // golden section step
+      if x >= xm {
+        e = a - x
+      } else {
+        e = c - x
+      }
+      d = cgold*e
+    }
+    var u float64
+    if math.Abs(d) >= tol1 {
+      u = x + d
+    } else {
+      u = x + math.Copysign(tol1, d)
+    }
+    fu, err := f(u)
+    if err != nil {
+      return x, fx, err
+    }
+    if fu <= fx {
+      if u >= x {
+        a = x
+      } else {
+        c = x
+      }
+      v, w, x    = w, x, u
+      fv, fw, fx = fw, fx, fu
+    } else {
+      if u < x {
+        a = u
+      } else {
+        c = u
+      }
+      if fu <= fw || w == x {
+        v, w   = w, u
+        fv, fw = fw, fu
+      } else
+      if fu <= fv || v == x || v == w {
+        v, fv = u, fu
+      }
+    }
+  }
+  return x, fx, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func powell(f Objective, x0 ConstVector, l, u []float64,
+  epsilon Epsilon,
+  maxIterations MaxIterations,
+  hook Hook) (Vector, error) {
+
+  n := x0.Dim()
+  if n == 0 {
+    return nil, fmt.Errorf("empty initial value")
+  }
+  eval := func(x DenseFloat64Vector) (float64, error) {
+    y, err := f(x)
+    if err != nil {
+      return 0.0, err
+    }
+    if r := y.GetFloat64(); math.IsNaN(r) {
+      return math.Inf(1), nil
+    } else {
+      return r, nil
+    }
+  }
+  x1 := AsDenseFloat64Vector(x0)
+  x2 := NullDenseFloat64Vector(n)
+  x3 := NullDenseFloat64Vector(n)
+  xt := NullDenseFloat64Vector(n)
+  // project initial value onto the box
+  for i := 0; i < n; i++ {
+    x1[i] = math.Min(math.Max(x1[i], l[i]), u[i])
+  }
+  // set of directions, initialized with unit vectors
+  directions := make([]DenseFloat64Vector, n)
+  for i := 0; i < n; i++ {
+    directions[i] = NullDenseFloat64Vector(n)
+    directions[i][i] = 1.0
+  }
+  // minimize f along direction d starting at x, where y = f(x)
+  lineMinimize := func(x, d DenseFloat64Vector, y float64) (float64, error) {
+    phi := func(t float64) (float64, error) {
+      for i := range xt {
+        xt[i] = math.Min(math.Max(x[i] + t*d[i], l[i]), u[i])
+      }
+      return eval(xt)
+    }
+    lo, hi := stepBounds(x, d, l, u)
+    a, b, c, yb, err := bracket(phi, y, lo, hi)
+    if err != nil {
+      return y, err
+    }
+    t, yt, err := brent(phi, a, b, c, yb)
+    if err != nil {
+      return y, err
+    }
+    if yt < y {
+      for i := range x {
+        x[i] = math.Min(math.Max(x[i] + t*d[i], l[i]), u[i])
+      }
+      return yt, nil
+    }
+    return y, nil
+  }
+  y1, err := eval(x1)
+  if err != nil {
+    return nil, fmt.Errorf("invalid initial value: %s", err)
+  }
+  for i := 0; i < maxIterations.Value; i++ {
+    // execute hook if available
+    if hook.Value != nil && hook.Value(x1, ConstFloat64(y1)) {
+      break
+    }
+    x2.Set(x1)
+    y2 := y1
+    // direction with the largest decrease
+    kmax, dmax := 0, 0.0
+    for k := 0; k < n; k++ {
+      y := y1
+      if y1, err = lineMinimize(x1, directions[k], y1); err != nil {
+        return x1, err
+      }
+      if y - y1 > dmax {
+        kmax, dmax = k, y - y1
+      }
+    }
+    // evaluate stop criterion
+    if 2.0*(y2 - y1) <= epsilon.Value*(math.Abs(y2) + math.Abs(y1)) + 1e-25 {
+      break
+    }
+    // new direction and extrapolated point
+    d := NullDenseFloat64Vector(n)
+    for j := 0; j < n; j++ {
+      d [j] = x1[j] - x2[j]
+      x3[j] = math.Min(math.Max(2.0*x1[j] - x2[j], l[j]), u[j])
+    }
+    y3, err := eval(x3)
+    if err != nil {
+      return x1, err
+    }
+    if y3 < y2 {
+      t := 2.0*(y2 - 2.0*y1 + y3)*(y2 - y1 - dmax)*(y2 - y1 - dmax) - dmax*(y2 - y3)*(y2 - y3)
+      if t < 0.0 {
+        if y1, err = lineMinimize(x1, d, y1); err != nil {
+          return x1, err
+        }
+        // replace the direction with the largest decrease
+        directions[kmax] = directions[n-1]
+        directions[n-1]  = d
+      }
+    }
+  }
+  return x1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Minimize f with Powell's conjugate direction method starting at x0. The
+// objective function is not differentiated. Bounds on the variables are
+// enforced by restricting all line minimizations to the feasible box.
+func Run(f Objective, x0 ConstVector, args ...interface{}) (Vector, error) {
+
+  epsilon       := Epsilon      {1e-8}
+  maxIterations := MaxIterations{int(^uint(0) >> 1)}
+  hook          := Hook         { nil}
+  lowerBounds   := LowerBounds  { nil}
+  upperBounds   := UpperBounds  { nil}
+
+  for _, arg := range args {
+    switch a := arg.(type) {
+    case Epsilon:
+      epsilon = a
+    case MaxIterations:
+      maxIterations = a
+    case Hook:
+      hook = a
+    case LowerBounds:
+      lowerBounds = a
+    case UpperBounds:
+      upperBounds = a
+    default:
+      panic("Powell(): Invalid optional argument!")
+    }
+  }
+  l, u, err := BoxBounds(x0.Dim(), lowerBounds.Value, upperBounds.Value)
+  if err != nil {
+    return nil, err
+  }
+  return powell(f, x0, l, u, epsilon, maxIterations, hook)
+}
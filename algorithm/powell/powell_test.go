@@ -0,0 +1,65 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package powell
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func rosenbrock(x ConstVector) (ConstScalar, error) {
+  x1 := x.Float64At(0)
+  x2 := x.Float64At(1)
+  return ConstFloat64((1.0 - x1)*(1.0 - x1) + 100.0*(x2 - x1*x1)*(x2 - x1*x1)), nil
+}
+
+func TestPowellRosenbrock(test *testing.T) {
+  xn, err := Run(rosenbrock, NewDenseFloat64Vector([]float64{-1.2, 1}), Epsilon{1e-12})
+  if err != nil {
+    test.Error(err); return
+  }
+  if math.Abs(xn.Float64At(0) - 1.0) > 1e-6 || math.Abs(xn.Float64At(1) - 1.0) > 1e-6 {
+    test.Error("test failed")
+  }
+}
+
+func TestPowellBounds(test *testing.T) {
+  // minimum subject to x1 <= 0.5 is at the boundary
+  xn, err := Run(rosenbrock, NewDenseFloat64Vector([]float64{-1.5, 2}), Epsilon{1e-12},
+    LowerBounds{NewDenseFloat64Vector([]float64{-2, math.Inf(-1)})},
+    UpperBounds{NewDenseFloat64Vector([]float64{0.5, math.Inf(1)})})
+  if err != nil {
+    test.Error(err); return
+  }
+  if math.Abs(xn.Float64At(0) - 0.5) > 1e-6 || math.Abs(xn.Float64At(1) - 0.25) > 1e-6 {
+    test.Error("test failed")
+  }
+  // initial value outside of the box
+  xn, err = Run(rosenbrock, NewDenseFloat64Vector([]float64{3, 3}), Epsilon{1e-12},
+    LowerBounds{NewDenseFloat64Vector([]float64{1.5, 1.5})})
+  if err != nil {
+    test.Error(err); return
+  }
+  if math.Abs(xn.Float64At(0) - 1.5) > 1e-6 || math.Abs(xn.Float64At(1) - 2.25) > 1e-6 {
+    test.Error("test failed")
+  }
+}
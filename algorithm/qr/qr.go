@@ -0,0 +1,256 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package qr
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/householder"
+
+/* -------------------------------------------------------------------------- */
+
+// Use column pivoting, i.e. compute A P = Q R where the diagonal of R is
+// non-increasing in absolute value
+type Pivoting struct {
+  Value bool
+}
+
+// Diagonal elements of R with an absolute value smaller or equal to Value
+// times the largest diagonal element are treated as zero when computing
+// the rank
+type Epsilon struct {
+  Value float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+// QR decomposition A P = Q R of an m x n matrix A computed with Householder
+// reflections. R is stored in the upper triangle of QR and the essential
+// parts of the Householder vectors below the diagonal. Column j of A P is
+// column Pivot[j] of A.
+type QR struct {
+  QR       Matrix
+  Beta     Vector
+  Pivot    []int
+  rank     int
+  pivoting bool
+}
+
+/* -------------------------------------------------------------------------- */
+
+func iMin(a, b int) int {
+  if a < b {
+    return a
+  } else {
+    return b
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func Run(a ConstMatrix, args ...interface{}) (*QR, error) {
+  m, n := a.Dims()
+  if m == 0 || n == 0 {
+    return nil, fmt.Errorf("empty matrix")
+  }
+  pivoting := false
+  epsilon  := 1e-12
+  // loop over optional arguments
+  for _, arg := range args {
+    switch tmp := arg.(type) {
+    case Pivoting:
+      pivoting = tmp.Value
+    case Epsilon:
+      epsilon = tmp.Value
+    }
+  }
+  t  := a.ElementType()
+  k  := iMin(m, n)
+  r  := NullDenseMatrix(t, m, n)
+  r.Set(a)
+  t1 := NullScalar(t)
+  t2 := NullScalar(t)
+  t3 := NullScalar(t)
+
+  obj := QR{QR: r, Beta: NullDenseVector(t, k), Pivot: make([]int, n), pivoting: pivoting}
+  for j := 0; j < n; j++ {
+    obj.Pivot[j] = j
+  }
+  // squared column norms used for pivoting
+  norms := make([]float64, n)
+
+  for j := 0; j < k; j++ {
+    if pivoting {
+      p := j
+      for l := j; l < n; l++ {
+        norms[l] = 0.0
+        for i := j; i < m; i++ {
+          norms[l] += r.Float64At(i, l)*r.Float64At(i, l)
+        }
+        if norms[l] > norms[p] {
+          p = l
+        }
+      }
+      if p != j {
+        for i := 0; i < m; i++ {
+          r.Swap(i, j, i, p)
+        }
+        obj.Pivot[j], obj.Pivot[p] = obj.Pivot[p], obj.Pivot[j]
+      }
+    }
+    x  := NullDenseVector(t, m-j)
+    nu := NullDenseVector(t, m-j)
+    for i := j; i < m; i++ {
+      x.At(i-j).Set(r.ConstAt(i, j))
+    }
+    beta := obj.Beta.At(j)
+    householder.Run(x, beta, nu, t1, t2, t3)
+    householder.ApplyLeft(r.Slice(j, m, j, n), beta, nu, NullDenseVector(t, n-j), t1)
+    // store essential part of the Householder vector
+    for i := j+1; i < m; i++ {
+      r.At(i, j).Set(nu.ConstAt(i-j))
+    }
+  }
+  // determine numerical rank
+  max := 0.0
+  for j := 0; j < k; j++ {
+    max = math.Max(max, math.Abs(r.Float64At(j, j)))
+  }
+  for j := 0; j < k; j++ {
+    if math.Abs(r.Float64At(j, j)) <= epsilon*max {
+      break
+    }
+    obj.rank++
+  }
+  return &obj, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Apply Q^T (transpose == true) or Q (transpose == false) to x in-place.
+// The number of rows of x must be m.
+func (obj *QR) apply(x Matrix, transpose bool) {
+  m, n := obj.QR.Dims()
+  _, c := x.Dims()
+  k    := iMin(m, n)
+  t    := obj.QR.ElementType()
+  t1   := NullScalar(t)
+  for l := 0; l < k; l++ {
+    j := l
+    if !transpose {
+      j = k-l-1
+    }
+    nu := NullDenseVector(t, m-j)
+    nu.At(0).SetFloat64(1.0)
+    for i := j+1; i < m; i++ {
+      nu.At(i-j).Set(obj.QR.ConstAt(i, j))
+    }
+    householder.ApplyLeft(x.Slice(j, m, 0, c), obj.Beta.At(j), nu, NullDenseVector(t, c), t1)
+  }
+}
+
+// Returns the numerical rank of A. Without column pivoting, the rank is
+// the number of leading non-zero diagonal elements of R.
+func (obj *QR) Rank() int {
+  return obj.rank
+}
+
+// Returns the orthogonal matrix Q. If thin is true, only the first
+// min(m, n) columns of Q are returned.
+func (obj *QR) Q(thin bool) Matrix {
+  m, n := obj.QR.Dims()
+  c    := m
+  if thin {
+    c = iMin(m, n)
+  }
+  q := NullDenseMatrix(obj.QR.ElementType(), m, c)
+  for i := 0; i < c; i++ {
+    q.At(i, i).SetFloat64(1.0)
+  }
+  obj.apply(q, false)
+  return q
+}
+
+// Returns the upper triangular matrix R. If thin is true, only the first
+// min(m, n) rows of R are returned.
+func (obj *QR) R(thin bool) Matrix {
+  m, n := obj.QR.Dims()
+  c    := m
+  if thin {
+    c = iMin(m, n)
+  }
+  r := NullDenseMatrix(obj.QR.ElementType(), c, n)
+  for i := 0; i < c; i++ {
+    for j := i; j < n; j++ {
+      r.At(i, j).Set(obj.QR.ConstAt(i, j))
+    }
+  }
+  return r
+}
+
+// Solve the linear least-squares problem min_x ||A x - b||. If A has
+// numerical rank r < n, the basic solution is returned, i.e. the solution
+// that uses only the first r columns of A P. The factorization should be
+// computed with column pivoting in this case.
+func (obj *QR) LeastSquares(b ConstVector) (Vector, error) {
+  m, n := obj.QR.Dims()
+  if b.Dim() != m {
+    return nil, fmt.Errorf("vector has invalid dimension")
+  }
+  if !obj.pivoting && obj.rank < iMin(m, n) {
+    return nil, fmt.Errorf("matrix is rank deficient")
+  }
+  t := obj.QR.ElementType()
+  // compute Q^T b
+  y := NullDenseMatrix(t, m, 1)
+  for i := 0; i < m; i++ {
+    y.At(i, 0).Set(b.ConstAt(i))
+  }
+  obj.apply(y, true)
+  // solve R x = Q^T b by backward substitution
+  z  := NullDenseVector(t, n)
+  t1 := NullScalar(t)
+  for i := obj.rank-1; i >= 0; i-- {
+    z.At(i).Set(y.ConstAt(i, 0))
+    for j := i+1; j < obj.rank; j++ {
+      t1.Mul(obj.QR.ConstAt(i, j), z.ConstAt(j))
+      z.At(i).Sub(z.At(i), t1)
+    }
+    z.At(i).Div(z.At(i), obj.QR.ConstAt(i, i))
+  }
+  x := NullDenseVector(t, n)
+  for j := 0; j < n; j++ {
+    x.At(obj.Pivot[j]).Set(z.ConstAt(j))
+  }
+  return x, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Solve the linear least-squares problem min_x ||A x - b|| using a QR
+// decomposition with column pivoting. The solution is differentiable with
+// respect to A and b if the elements are of type Real64.
+func LeastSquares(a ConstMatrix, b ConstVector, args ...interface{}) (Vector, error) {
+  if r, err := Run(a, append([]interface{}{Pivoting{true}}, args...)...); err != nil {
+    return nil, err
+  } else {
+    return r.LeastSquares(b)
+  }
+}
@@ -0,0 +1,165 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package qr
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func permuteColumns(a ConstMatrix, pivot []int) Matrix {
+  m, n := a.Dims()
+  r := NullDenseFloat64Matrix(m, n)
+  for i := 0; i < m; i++ {
+    for j := 0; j < n; j++ {
+      r.At(i, j).Set(a.ConstAt(i, pivot[j]))
+    }
+  }
+  return r
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestQR1(test *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+    12, -51,   4,
+     6, 167, -68,
+    -4,  24, -41,
+     1,   2,   3 }, 4, 3)
+  for _, pivoting := range []bool{false, true} {
+    r, err := Run(a, Pivoting{pivoting})
+    if err != nil {
+      test.Error(err); continue
+    }
+    if r.Rank() != 3 {
+      test.Error("test failed")
+    }
+    ap := permuteColumns(a, r.Pivot)
+    for _, thin := range []bool{false, true} {
+      q := r.Q(thin)
+      s := r.R(thin)
+      if !ap.Equals(NullDenseFloat64Matrix(4, 3).MdotM(q, s), 1e-10) {
+        test.Error("test failed")
+      }
+      // Q has orthonormal columns
+      _, c := q.Dims()
+      id := NullDenseFloat64Matrix(c, c)
+      id.SetIdentity()
+      if !id.Equals(NullDenseFloat64Matrix(c, c).MdotM(q.T(), q), 1e-10) {
+        test.Error("test failed")
+      }
+    }
+    if pivoting {
+      for j := 1; j < 3; j++ {
+        if math.Abs(r.QR.Float64At(j, j)) > math.Abs(r.QR.Float64At(j-1, j-1)) {
+          test.Error("test failed")
+        }
+      }
+    }
+  }
+}
+
+func TestQR2(test *testing.T) {
+  // fit a line to points on y = 2x + 1 with symmetric noise
+  a := NewDenseFloat64Matrix([]float64{
+    0, 1,
+    1, 1,
+    2, 1,
+    3, 1 }, 4, 2)
+  b := NewDenseFloat64Vector([]float64{1.1, 2.9, 5.1, 6.9})
+  x, err := LeastSquares(a, b)
+  if err != nil {
+    test.Error(err); return
+  }
+  // solution of the normal equations
+  if !x.Equals(NewDenseFloat64Vector([]float64{1.96, 1.06}), 1e-10) {
+    test.Error("test failed")
+  }
+}
+
+func TestQR3(test *testing.T) {
+  // rank deficient matrix, where the third column is the sum of the
+  // first two columns
+  a := NewDenseFloat64Matrix([]float64{
+    1, 0, 1,
+    0, 1, 1,
+    1, 1, 2,
+    2, 1, 3 }, 4, 3)
+  b := NewDenseFloat64Vector([]float64{1, 2, 3, 4})
+  r, _ := Run(a, Pivoting{true})
+  if r.Rank() != 2 {
+    test.Error("test failed")
+  }
+  x, err := r.LeastSquares(b)
+  if err != nil {
+    test.Error(err); return
+  }
+  // basic solution has a single zero
+  if x.Float64At(0) != 0.0 && x.Float64At(1) != 0.0 && x.Float64At(2) != 0.0 {
+    test.Error("test failed")
+  }
+  // residual is orthogonal to the columns of A
+  e := NullDenseFloat64Vector(4)
+  e.VsubV(e.MdotV(a, x), b)
+  if !NullDenseFloat64Vector(3).VdotM(e, a).Equals(NullDenseFloat64Vector(3), 1e-10) {
+    test.Error("test failed")
+  }
+  // without pivoting the problem cannot be solved
+  r, _ = Run(a)
+  if _, err := r.LeastSquares(b); err == nil {
+    test.Error("test failed")
+  }
+}
+
+func TestQR4(test *testing.T) {
+  values := []float64{
+    1, 2,
+    3, 5,
+    4, 4,
+    1, 0,
+    // b
+    1, -1, 2, 3 }
+  f := func(a ConstMatrix, b ConstVector) Vector {
+    x, err := LeastSquares(a, b)
+    if err != nil {
+      panic(err)
+    }
+    return x
+  }
+  // derivatives with respect to the elements of A and b
+  v := NewDenseReal64Vector(values)
+  v.Variables(1)
+  x := f(v.Slice(0, 8).AsMatrix(4, 2), v.Slice(8, 12))
+  // compare with finite differences
+  h := 1e-6
+  for k := 0; k < 12; k++ {
+    u := NullDenseFloat64Vector(12)
+    u.Set(v)
+    u.At(k).SetFloat64(u.Float64At(k) + h)
+    y := f(u.Slice(0, 8).AsMatrix(4, 2), u.Slice(8, 12))
+    for i := 0; i < 2; i++ {
+      if d := (y.Float64At(i) - x.Float64At(i))/h; math.Abs(d - x.ConstAt(i).GetDerivative(k)) > 1e-4 {
+        test.Error("test failed")
+      }
+    }
+  }
+}
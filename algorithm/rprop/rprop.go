@@ -61,8 +61,8 @@ func rprop(f func(ConstVector) (MagicScalar, error), x0 ConstVector, step_init f
 
   n := x0.Dim()
   // copy variables
-  x1 := AsDenseReal64Vector(x0)
-  x2 := AsDenseReal64Vector(x0)
+  x1 := AsDenseMagicVector(GradientType(x0), x0)
+  x2 := AsDenseMagicVector(GradientType(x0), x0)
   // step size for each variable
   step := make([]float64, n)
   // gradients
@@ -20,6 +20,8 @@ package rprop
 
 //import   "fmt"
 //import   "os"
+import   "math"
+import   "runtime"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -131,3 +133,44 @@ func TestRPropRosenbrockGradient(t *testing.T) {
     t.Error("Rosenbrock test failed!")
   }
 }
+
+/* -------------------------------------------------------------------------- */
+
+func TestRPropReverse(t *testing.T) {
+  // f(x) = sum_i (x_i - i/n)^2
+  f := func(x ConstVector) (MagicScalar, error) {
+    n := x.Dim()
+    r := NullReverseReal64()
+    s := NullReverseReal64()
+    for i := 0; i < n; i++ {
+      s.Sub(x.ConstAt(i), ConstFloat64(float64(i)/float64(n)))
+      s.Mul(s, s)
+      r.Add(r, s)
+    }
+    return r, nil
+  }
+  // memory allocated by an optimization with n variables
+  alloc := func(n int) uint64 {
+    var m1, m2 runtime.MemStats
+    runtime.ReadMemStats(&m1)
+    if _, err := Run(f, NullDenseReverseReal64Vector(n), 0.01, []float64{1.2, 0.8}, MaxIterations{5}); err != nil {
+      t.Fatal(err)
+    }
+    runtime.ReadMemStats(&m2)
+    return m2.TotalAlloc - m1.TotalAlloc
+  }
+  // cost must be linear in the number of variables
+  if a1, a2 := alloc(5000), alloc(20000); float64(a2) > 6.0*float64(a1) {
+    t.Error("test failed")
+  }
+  n := 1000
+  x, err := Run(f, NullDenseReverseReal64Vector(n), 0.01, []float64{1.2, 0.8}, Epsilon{1e-8})
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := 0; i < n; i++ {
+    if math.Abs(x.Float64At(i) - float64(i)/float64(n)) > 1e-6 {
+      t.Error("test failed"); break
+    }
+  }
+}
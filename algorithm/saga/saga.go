@@ -182,6 +182,105 @@ func (obj *ProximalOperatorTi) Eval(x DenseFloat64Vector, w DenseFloat64Vector)
 
 /* -------------------------------------------------------------------------- */
 
+// Proximal operator of the elastic-net penalty
+//   lambda (alpha ||x||_1 + (1-alpha)/2 ||x||_2^2)
+type ProximalOperatorElasticNet struct {
+  Lambda float64
+  Alpha  float64
+}
+
+func (obj *ProximalOperatorElasticNet) GetLambda() float64 {
+  return obj.Lambda
+}
+
+func (obj *ProximalOperatorElasticNet) SetLambda(lambda float64) {
+  obj.Lambda = lambda
+}
+
+func (obj *ProximalOperatorElasticNet) Eval(x DenseFloat64Vector, w DenseFloat64Vector) {
+  l1 := obj.Lambda*obj.Alpha
+  l2 := obj.Lambda*(1.0 - obj.Alpha)
+  for i := 0; i < x.Dim(); i++ {
+    if wi := w[i]; wi < -l1 {
+      x[i] = (wi + l1)/(1.0 + l2)
+    } else
+    if wi > l1 {
+      x[i] = (wi - l1)/(1.0 + l2)
+    } else {
+      x[i] = 0.0
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Proximal operator of the group-lasso penalty lambda sum_g ||x_g||_2,
+// where each group is given by a set of indices. Variables that are not
+// part of any group are not penalized.
+type ProximalOperatorGroupL1 struct {
+  Lambda float64
+  Groups [][]int
+}
+
+func (obj *ProximalOperatorGroupL1) GetLambda() float64 {
+  return obj.Lambda
+}
+
+func (obj *ProximalOperatorGroupL1) SetLambda(lambda float64) {
+  obj.Lambda = lambda
+}
+
+func (obj *ProximalOperatorGroupL1) Eval(x DenseFloat64Vector, w DenseFloat64Vector) {
+  x.Set(w)
+  for _, group := range obj.Groups {
+    r := 0.0
+    for _, i := range group {
+      r += w[i]*w[i]
+    }
+    r = math.Sqrt(r)
+    // shrink group towards zero
+    t := 0.0
+    if r > obj.Lambda {
+      t = 1.0 - obj.Lambda/r
+    }
+    for _, i := range group {
+      x[i] = t*w[i]
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Projection onto the box [Lower, Upper], i.e. the proximal operator of
+// the indicator function of the box. Lambda has no effect.
+type ProximalOperatorBox struct {
+  Lambda float64
+  Lower  DenseFloat64Vector
+  Upper  DenseFloat64Vector
+}
+
+func (obj *ProximalOperatorBox) GetLambda() float64 {
+  return obj.Lambda
+}
+
+func (obj *ProximalOperatorBox) SetLambda(lambda float64) {
+  obj.Lambda = lambda
+}
+
+func (obj *ProximalOperatorBox) Eval(x DenseFloat64Vector, w DenseFloat64Vector) {
+  for i := 0; i < x.Dim(); i++ {
+    x[i] = w[i]
+    if obj.Lower != nil {
+      x[i] = math.Max(x[i], obj.Lower[i])
+    }
+    if obj.Upper != nil {
+      x[i] = math.Min(x[i], obj.Upper[i])
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
 type JitUpdateL1 struct {
   Lambda float64
 }
@@ -18,8 +18,11 @@ package algorithm
 
 /* -------------------------------------------------------------------------- */
 
+import   "fmt"
 import   "math"
 
+import . "github.com/pbenner/autodiff"
+
 /* -------------------------------------------------------------------------- */
 
 func Norm(v []float64) float64 {
@@ -36,3 +39,131 @@ func ScaleVectorLength(v []float64, length float64) {
     v[i] *= c
   }
 }
+
+/* -------------------------------------------------------------------------- */
+
+// Scalar type for evaluating the gradient of an objective function at x.
+// Reverse-mode types are kept so that the gradient is computed with a
+// single backward pass, all other types are replaced by Real64.
+func GradientType(x ConstVector) ScalarType {
+  switch t := x.ElementType(); t {
+  case ReverseReal32Type, ReverseReal64Type:
+    return t
+  default:
+    return Real64Type
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Wrap an objective function f of n variables into a function that stores
+// the gradient at x in its second argument and returns the function value.
+// The gradient is computed by automatic differentiation with variables of
+// scalar type t.
+func AutoGradient(f func(ConstVector) (MagicScalar, error), t ScalarType, n int) func(x, gradient DenseFloat64Vector) (float64, error) {
+  g := AutoGradientIndexed(func(i int, x ConstVector) (MagicScalar, error) { return f(x) }, t, n)
+  return func(x, gradient DenseFloat64Vector) (float64, error) {
+    return g(0, x, gradient)
+  }
+}
+
+// Same as AutoGradient for objective functions that depend on an index i,
+// e.g. the loss of example i
+func AutoGradientIndexed(f func(int, ConstVector) (MagicScalar, error), t ScalarType, n int) func(i int, x, gradient DenseFloat64Vector) (float64, error) {
+  X := NullDenseMagicVector(t, n)
+  return func(i int, x, gradient DenseFloat64Vector) (float64, error) {
+    X.Set(x)
+    if err := X.Variables(1); err != nil {
+      return 0.0, err
+    }
+    y, err := f(i, X)
+    if err != nil {
+      return 0.0, err
+    }
+    if y.GetN() == n {
+      for k := 0; k < n; k++ {
+        gradient[k] = y.GetDerivative(k)
+      }
+    } else {
+      for k := 0; k < n; k++ {
+        gradient[k] = 0.0
+      }
+    }
+    return y.GetFloat64(), nil
+  }
+}
+
+// Combine constraints on mutable and constant vectors into a single
+// function. The result is nil if both arguments are nil.
+func JoinConstraints(c1 func(Vector) bool, c2 func(ConstVector) bool) func(ConstVector) bool {
+  if c1 == nil {
+    return c2
+  }
+  r := func(x ConstVector) bool {
+    if c2 != nil && !c2(x) {
+      return false
+    }
+    if v, ok := x.(Vector); ok {
+      return c1(v)
+    } else {
+      return c1(AsDenseFloat64Vector(x))
+    }
+  }
+  return r
+}
+
+// Convert optional lower and upper bounds on n variables into slices,
+// where missing bounds are replaced by -Inf and +Inf respectively.
+func BoxBounds(n int, lower, upper ConstVector) ([]float64, []float64, error) {
+  l := make([]float64, n)
+  u := make([]float64, n)
+  for i := 0; i < n; i++ {
+    l[i] = math.Inf(-1)
+    u[i] = math.Inf( 1)
+  }
+  if lower != nil {
+    if lower.Dim() != n {
+      return nil, nil, fmt.Errorf("lower bounds have invalid dimension")
+    }
+    for i := 0; i < n; i++ {
+      l[i] = lower.Float64At(i)
+    }
+  }
+  if upper != nil {
+    if upper.Dim() != n {
+      return nil, nil, fmt.Errorf("upper bounds have invalid dimension")
+    }
+    for i := 0; i < n; i++ {
+      u[i] = upper.Float64At(i)
+    }
+  }
+  for i := 0; i < n; i++ {
+    if l[i] > u[i] {
+      return nil, nil, fmt.Errorf("lower bound exceeds upper bound for variable `%d'", i)
+    }
+  }
+  return l, u, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Inner product of a and b
+func Ddot(a, b DenseFloat64Vector) float64 {
+  r := 0.0
+  for i := range a {
+    r += a[i]*b[i]
+  }
+  return r
+}
+
+// Euclidean norm of a
+func Dnorm(a DenseFloat64Vector) float64 {
+  return math.Sqrt(Ddot(a, a))
+}
+
+// r = r + s a
+func Daxpy(r DenseFloat64Vector, s float64, a DenseFloat64Vector) {
+  for i := range r {
+    r[i] += s*a[i]
+  }
+}
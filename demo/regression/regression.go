@@ -21,6 +21,7 @@ package main
 import   "fmt"
 import   "math/rand"
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/qr"
 import   "github.com/pbenner/autodiff/algorithm/rprop"
 
 /* -------------------------------------------------------------------------- */
@@ -65,6 +66,21 @@ func gradientDescent(x, y Vector, l *Line) *Line {
   return l
 }
 
+func leastSquares(x, y Vector) *Line {
+
+  // design matrix with columns x and 1
+  a := NullDenseReal64Matrix(x.Dim(), 2)
+  for i := 0; i < x.Dim(); i++ {
+    a.At(i, 0).Set(x.At(i))
+    a.At(i, 1).SetFloat64(1.0)
+  }
+  r, err := qr.LeastSquares(a, y)
+  if err != nil {
+    panic(err)
+  }
+  return NewLine(r.At(0), r.At(1))
+}
+
 func main() {
 
   const n = 1000
@@ -83,4 +99,9 @@ func main() {
   l  = gradientDescent(x, y, l)
 
   fmt.Println("slope: ", l.Slope().GetFloat64(), "intercept: ", l.Intercept().GetFloat64())
+
+  // exact solution
+  l  = leastSquares(x, y)
+
+  fmt.Println("slope: ", l.Slope().GetFloat64(), "intercept: ", l.Intercept().GetFloat64())
 }
@@ -0,0 +1,364 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+import "fmt"
+import "sort"
+
+/* Jacobian-vector and Hessian-vector products
+ * -------------------------------------------------------------------------- */
+
+// Compute the product J v, where J is the Jacobian of f at x_. The
+// product is the directional derivative of f in direction v, which is
+// obtained from a single evaluation of f with one variable. The elements
+// of x_ must be forward-mode scalars, e.g. Real64 or TaylorReal64. The
+// result is stored in r.
+func JacobianVectorProduct(r Vector, f func(ConstVector) ConstVector, x_ MagicVector, v ConstVector) error {
+  if x_.Dim() != v.Dim() {
+    return fmt.Errorf("vector dimensions do not match")
+  }
+  x := x_.CloneMagicVector()
+  for i := 0; i < x.Dim(); i++ {
+    s := x.MagicAt(i)
+    s.ResetDerivatives()
+    s.Alloc(1, 1)
+    s.SetDerivative(0, v.Float64At(i))
+  }
+  y := f(x)
+  if y.Dim() != r.Dim() {
+    return fmt.Errorf("vector dimensions do not match")
+  }
+  for i := 0; i < y.Dim(); i++ {
+    if s := y.ConstAt(i); s.GetN() == 1 {
+      r.At(i).SetFloat64(s.GetDerivative(0))
+    } else {
+      r.At(i).SetFloat64(0.0)
+    }
+  }
+  return nil
+}
+
+// Compute the product H v, where H is the Hessian of f at x_. If the
+// elements of x_ are reverse-mode scalars, e.g. ReverseReal64, the product
+// is computed from the tape of a single evaluation of f. For forward-mode
+// scalars that support second order derivatives, e.g. Real64, the ith
+// element of H v is the mixed second derivative of
+//
+//   f(x_ + s v + t e_i)
+//
+// with respect to s and t, where e_i denotes the ith unit vector. Each
+// element then requires an evaluation of f with two variables, so that
+// the total cost is similar to computing the gradient of f in
+// forward-mode. In both cases the Hessian is never allocated. The result
+// is stored in r.
+func HessianVectorProduct(r Vector, f func(ConstVector) ConstScalar, x_ MagicVector, v ConstVector) error {
+  if x_.Dim() != v.Dim() || x_.Dim() != r.Dim() {
+    return fmt.Errorf("vector dimensions do not match")
+  }
+  return hessianVectorProduct(r, f, x_, v, nil)
+}
+
+// Compute H v as in HessianVectorProduct(). For forward-mode scalars,
+// only elements i with rows[i] == true are computed if rows is not nil.
+func hessianVectorProduct(r Vector, f func(ConstVector) ConstScalar, x_ MagicVector, v ConstVector, rows []bool) error {
+  if t := x_.ElementType(); t == ReverseReal32Type || t == ReverseReal64Type {
+    x := x_.CloneMagicVector()
+    if err := x.Variables(1); err != nil {
+      return err
+    }
+    y, ok := f(x).(reverseScalar)
+    if !ok {
+      return fmt.Errorf("function value is not a reverse-mode scalar")
+    }
+    if node := y.getReverseNode(); node == nil || node.n != x.Dim() {
+      r.Reset()
+    } else {
+      w := make([]float64, x.Dim())
+      for i := range w {
+        w[i] = v.Float64At(i)
+      }
+      for i, ri := range node.hessianVectorProduct(w) {
+        r.At(i).SetFloat64(ri)
+      }
+    }
+    return nil
+  }
+  x := x_.CloneMagicVector()
+  for i := 0; i < x.Dim(); i++ {
+    s := x.MagicAt(i)
+    s.ResetDerivatives()
+    s.Alloc(2, 2)
+    s.SetDerivative(0, v.Float64At(i))
+  }
+  for i := 0; i < x.Dim(); i++ {
+    r.At(i).SetFloat64(0.0)
+    if rows != nil && !rows[i] {
+      continue
+    }
+    x.MagicAt(i).SetDerivative(1, 1.0)
+    if y := f(x); y.GetOrder() >= 2 && y.GetN() == 2 {
+      r.At(i).SetFloat64(y.GetHessian(0, 1))
+    }
+    x.MagicAt(i).SetDerivative(1, 0.0)
+  }
+  return nil
+}
+
+/* sparse Jacobian and Hessian matrices
+ * -------------------------------------------------------------------------- */
+
+// Sparsity pattern of a matrix, i.e. the column indices of structurally
+// non-zero elements in each row.
+type sparsityPattern struct {
+  rows [][]int
+  cols [][]int
+}
+
+func newSparsityPattern(pattern ConstMatrix) sparsityPattern {
+  n, m := pattern.Dims()
+  p := sparsityPattern{make([][]int, n), make([][]int, m)}
+  for it := pattern.ConstIterator(); it.Ok(); it.Next() {
+    if it.GetConst().GetFloat64() != 0.0 {
+      i, j := it.Index()
+      p.rows[i] = append(p.rows[i], j)
+      p.cols[j] = append(p.cols[j], i)
+    }
+  }
+  return p
+}
+
+// Union of a square pattern with its transpose.
+func (p sparsityPattern) symmetric() sparsityPattern {
+  n := len(p.rows)
+  r := sparsityPattern{make([][]int, n), make([][]int, n)}
+  for i := 0; i < n; i++ {
+    m := make(map[int]bool)
+    for _, j := range p.rows[i] {
+      m[j] = true
+    }
+    for _, j := range p.cols[i] {
+      m[j] = true
+    }
+    for j := range m {
+      r.rows[i] = append(r.rows[i], j)
+    }
+    sort.Ints(r.rows[i])
+    // the pattern is symmetric, hence columns equal rows
+    r.cols[i] = r.rows[i]
+  }
+  return r
+}
+
+// Greedy coloring of the columns such that no two columns of the same
+// color share a non-zero row [Curtis, Powell and Reid (1974)]. Columns
+// are colored in the order of decreasing number of non-zero elements.
+// The function returns the color of each column and the number of colors.
+func (p sparsityPattern) columnColoring() ([]int, int) {
+  m := len(p.cols)
+  order := make([]int, m)
+  for j := 0; j < m; j++ {
+    order[j] = j
+  }
+  sort.SliceStable(order, func(a, b int) bool {
+    return len(p.cols[order[a]]) > len(p.cols[order[b]])
+  })
+  colors    := make([]int, m)
+  forbidden := make([]int, m+1)
+  k := 0
+  for j := 0; j < m; j++ {
+    colors[j] = -1
+  }
+  for c := range forbidden {
+    forbidden[c] = -1
+  }
+  for _, j := range order {
+    for _, i := range p.cols[j] {
+      for _, l := range p.rows[i] {
+        if colors[l] >= 0 {
+          forbidden[colors[l]] = j
+        }
+      }
+    }
+    c := 0
+    for forbidden[c] == j {
+      c++
+    }
+    colors[j] = c
+    if c+1 > k {
+      k = c+1
+    }
+  }
+  return colors, k
+}
+
+// Detect the sparsity pattern of the Jacobian of f at x_. This requires
+// a single evaluation of f with all variables. Elements that are zero
+// at x_ by coincidence are not detected, therefore the pattern should be
+// computed at a generic point and reused for subsequent evaluations.
+func JacobianSparsity(f func(ConstVector) ConstVector, x_ MagicVector) *CsrFloat64Matrix {
+  x := x_.CloneMagicVector()
+  x.Variables(1)
+  y := f(x)
+  e := elementsCsrFloat64Matrix{}
+  for i := 0; i < y.Dim(); i++ {
+    if s := y.ConstAt(i); s.GetOrder() >= 1 {
+      for j := 0; j < s.GetN(); j++ {
+        if s.GetDerivative(j) != 0.0 {
+          e.add(i, j, NewFloat64(1.0))
+        }
+      }
+    }
+  }
+  r := NullCsrFloat64Matrix(y.Dim(), x.Dim())
+  sort.Sort(&e)
+  r.assign(&e)
+  return r
+}
+
+// Detect the sparsity pattern of the Hessian of f at x_. The same
+// restrictions as for JacobianSparsity apply.
+func HessianSparsity(f func(ConstVector) ConstScalar, x_ MagicVector) *CsrFloat64Matrix {
+  x := x_.CloneMagicVector()
+  x.Variables(2)
+  y := f(x)
+  e := elementsCsrFloat64Matrix{}
+  if y.GetOrder() >= 2 {
+    for i := 0; i < y.GetN(); i++ {
+      for j := 0; j < y.GetN(); j++ {
+        if y.GetHessian(i, j) != 0.0 {
+          e.add(i, j, NewFloat64(1.0))
+        }
+      }
+    }
+  }
+  r := NullCsrFloat64Matrix(x.Dim(), x.Dim())
+  sort.Sort(&e)
+  r.assign(&e)
+  return r
+}
+
+// Compute the Jacobian of f at x_ for a given sparsity pattern, where
+// non-zero elements of pattern mark the structurally non-zero elements
+// of the Jacobian. If pattern is nil, it is detected with
+// JacobianSparsity(). Columns of the Jacobian are colored such that
+// columns of the same color do not share a non-zero row, which allows
+// to evaluate f with a single variable per color. The elements of x_
+// must be forward-mode scalars, e.g. Real64.
+func SparseJacobian(f func(ConstVector) ConstVector, x_ MagicVector, pattern ConstMatrix) (*CsrFloat64Matrix, error) {
+  if pattern == nil {
+    pattern = JacobianSparsity(f, x_)
+  }
+  n, m := pattern.Dims()
+  if m != x_.Dim() {
+    return nil, fmt.Errorf("sparsity pattern has invalid dimensions")
+  }
+  p := newSparsityPattern(pattern)
+  colors, k := p.columnColoring()
+  // seed one direction per color
+  x := x_.CloneMagicVector()
+  for j := 0; j < m; j++ {
+    s := x.MagicAt(j)
+    s.ResetDerivatives()
+    s.Alloc(k, 1)
+    s.SetDerivative(colors[j], 1.0)
+  }
+  y := f(x)
+  if y.Dim() != n {
+    return nil, fmt.Errorf("sparsity pattern has invalid dimensions")
+  }
+  e := elementsCsrFloat64Matrix{}
+  for i := 0; i < n; i++ {
+    s := y.ConstAt(i)
+    if s.GetOrder() < 1 || s.GetN() != k {
+      continue
+    }
+    for _, j := range p.rows[i] {
+      e.add(i, j, NewFloat64(s.GetDerivative(colors[j])))
+    }
+  }
+  r := NullCsrFloat64Matrix(n, m)
+  sort.Sort(&e)
+  r.assign(&e)
+  return r, nil
+}
+
+// Compute the Hessian of f at x_ for a given sparsity pattern. If pattern
+// is nil, it is detected with HessianSparsity(), which requires
+// forward-mode scalars. The pattern is made
+// symmetric and its columns are colored such that columns of the same
+// color do not share a non-zero row. For each color c, a single
+// Hessian-vector product H d_c with the seed direction d_c is computed,
+// which contains H[i,l] in row i for the unique column l of color c with
+// a non-zero element in row i. Only elements with i <= l are read from
+// the products, H[l,i] is recovered by symmetry. With reverse-mode
+// scalars, e.g. ReverseReal64, each product requires a single evaluation
+// of f. With forward-mode scalars, e.g. Real64, one evaluation is required
+// for each row of the product that is read (see HessianVectorProduct()).
+func SparseHessian(f func(ConstVector) ConstScalar, x_ MagicVector, pattern ConstMatrix) (*CsrFloat64Matrix, error) {
+  if pattern == nil {
+    pattern = HessianSparsity(f, x_)
+  }
+  n := x_.Dim()
+  if n1, n2 := pattern.Dims(); n1 != n || n2 != n {
+    return nil, fmt.Errorf("sparsity pattern has invalid dimensions")
+  }
+  p := newSparsityPattern(pattern).symmetric()
+  colors, k := p.columnColoring()
+  d    := NullDenseFloat64Vector(n)
+  hv   := NullDenseFloat64Vector(n)
+  rows := make([]bool, n)
+  e    := elementsCsrFloat64Matrix{}
+  for c := 0; c < k; c++ {
+    // seed direction and rows of H d_c that are required
+    for j := 0; j < n; j++ {
+      d[j]    = 0.0
+      rows[j] = false
+      if colors[j] == c {
+        d[j] = 1.0
+      }
+    }
+    for i := 0; i < n; i++ {
+      for _, l := range p.rows[i] {
+        if colors[l] == c && i <= l {
+          rows[i] = true
+        }
+      }
+    }
+    if err := hessianVectorProduct(hv, f, x_, d, rows); err != nil {
+      return nil, err
+    }
+    for i := 0; i < n; i++ {
+      if !rows[i] {
+        continue
+      }
+      for _, l := range p.rows[i] {
+        if colors[l] == c {
+          e.add(i, l, NewFloat64(hv[i]))
+          if i != l {
+            e.add(l, i, NewFloat64(hv[i]))
+          }
+        }
+      }
+    }
+  }
+  r := NullCsrFloat64Matrix(n, n)
+  sort.Sort(&e)
+  r.assign(&e)
+  return r, nil
+}
@@ -0,0 +1,269 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+import "math"
+import "testing"
+
+/* -------------------------------------------------------------------------- */
+
+func TestHessianVectorProduct(t *testing.T) {
+  f := func(x ConstVector) ConstScalar {
+    // f(x) = exp(x0*x1) + x1^3 x2 + lgamma(x2)
+    r := NullReal64()
+    s := NullReal64()
+    r.Mul(x.ConstAt(0), x.ConstAt(1))
+    r.Exp(r)
+    s.Pow(x.ConstAt(1), ConstFloat64(3.0))
+    s.Mul(s, x.ConstAt(2))
+    r.Add(r, s)
+    s.Lgamma(x.ConstAt(2))
+    r.Add(r, s)
+    return r
+  }
+  x := NewDenseReal64Vector([]float64{0.5, 1.2, 2.3})
+  v := NewDenseFloat64Vector([]float64{1.0, -2.0, 0.5})
+  // compare with the full Hessian
+  H  := NullDenseFloat64Matrix(3, 3)
+  H.Hessian(f, x)
+  r1 := NullDenseFloat64Vector(3)
+  r1.MdotV(H, v)
+  r2 := NullDenseFloat64Vector(3)
+  if err := HessianVectorProduct(r2, f, x, v); err != nil {
+    t.Fatal(err)
+  }
+  if !r1.Equals(r2, 1e-10) {
+    t.Error("test failed")
+  }
+  // x must not be modified
+  if x.At(0).GetOrder() != 0 || x.At(0).GetFloat64() != 0.5 {
+    t.Error("test failed")
+  }
+}
+
+func TestJacobianVectorProduct(t *testing.T) {
+  f := func(x ConstVector) ConstVector {
+    y := NullDenseReal64Vector(3)
+    y.At(0).Mul(x.ConstAt(0), x.ConstAt(1))
+    y.At(1).Sin(x.ConstAt(1))
+    y.At(2).SetFloat64(4.0)
+    return y
+  }
+  x := NewDenseReal64Vector([]float64{2.0, 3.0})
+  v := NewDenseFloat64Vector([]float64{0.5, -1.0})
+  r := NullDenseFloat64Vector(3)
+  if err := JacobianVectorProduct(r, f, x, v); err != nil {
+    t.Fatal(err)
+  }
+  if !r.Equals(NewDenseFloat64Vector([]float64{-0.5, -math.Cos(3.0), 0.0}), 1e-12) {
+    t.Error("test failed")
+  }
+}
+
+func TestSparseJacobian(t *testing.T) {
+  n := 10
+  f := func(x ConstVector) ConstVector {
+    // y_i = x_{i-1} x_i + sin(x_{i+1})
+    y := NullDenseReal64Vector(n)
+    s := NullReal64()
+    for i := 0; i < n; i++ {
+      if i > 0 {
+        y.At(i).Mul(x.ConstAt(i-1), x.ConstAt(i))
+      }
+      if i+1 < n {
+        s.Sin(x.ConstAt(i+1))
+        y.At(i).Add(y.At(i), s)
+      }
+    }
+    return y
+  }
+  x := NullDenseReal64Vector(n)
+  for i := 0; i < n; i++ {
+    x.At(i).SetFloat64(float64(i+1)/3.0)
+  }
+  J := NullDenseFloat64Matrix(n, n)
+  J.Jacobian(f, x)
+
+  p := JacobianSparsity(f, x)
+  if p.Nnz() != 3*n-3 {
+    t.Error("test failed")
+  }
+  if _, k := newSparsityPattern(p).columnColoring(); k != 3 {
+    t.Error("test failed")
+  }
+  for _, pattern := range []ConstMatrix{nil, p, AsDenseFloat64Matrix(p)} {
+    r, err := SparseJacobian(f, x, pattern)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !r.Equals(J, 1e-12) {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestSparseHessian(t *testing.T) {
+  n := 8
+  f := func(x ConstVector) ConstScalar {
+    // Rosenbrock function
+    r  := NullReal64()
+    t1 := NullReal64()
+    t2 := NullReal64()
+    for i := 0; i+1 < x.Dim(); i++ {
+      t1.Mul(x.ConstAt(i), x.ConstAt(i))
+      t1.Sub(x.ConstAt(i+1), t1)
+      t1.Mul(t1, t1)
+      t1.Mul(t1, ConstFloat64(100.0))
+      t2.Sub(ConstFloat64(1.0), x.ConstAt(i))
+      t2.Mul(t2, t2)
+      r.Add(r, t1)
+      r.Add(r, t2)
+    }
+    return r
+  }
+  x := NullDenseReal64Vector(n)
+  for i := 0; i < n; i++ {
+    x.At(i).SetFloat64(math.Sin(float64(i+1)))
+  }
+  H := NullDenseFloat64Matrix(n, n)
+  H.Hessian(f, x)
+
+  for _, pattern := range []ConstMatrix{nil, HessianSparsity(f, x)} {
+    r, err := SparseHessian(f, x, pattern)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !r.Equals(H, 1e-10) || r.Nnz() != 3*n-2 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestHessianVectorProductReverse(t *testing.T) {
+  f := func(x ConstVector) ConstScalar {
+    // f(x) = exp(x0*x1)/x2 + x1^3 x2 + lgamma(x2) + log(1 + exp(x0 - x2))
+    //      + sqrt(x1) tanh(x0) + sin(x1 x2)
+    r  := NullScalar(x.ElementType())
+    s  := NullScalar(x.ElementType())
+    t1 := NullScalar(x.ElementType())
+    r.Mul(x.ConstAt(0), x.ConstAt(1))
+    r.Exp(r)
+    r.Div(r, x.ConstAt(2))
+    s.Pow(x.ConstAt(1), ConstFloat64(3.0))
+    s.Mul(s, x.ConstAt(2))
+    r.Add(r, s)
+    s.Lgamma(x.ConstAt(2))
+    r.Add(r, s)
+    s.LogAdd(ConstFloat64(0.0), s.Sub(x.ConstAt(0), x.ConstAt(2)), t1)
+    r.Add(r, s)
+    s .Sqrt(x.ConstAt(1))
+    t1.Tanh(x.ConstAt(0))
+    s .Mul(s, t1)
+    r .Add(r, s)
+    s .Mul(x.ConstAt(1), x.ConstAt(2))
+    s .Sin(s)
+    r .Add(r, s)
+    return r
+  }
+  x1 := NewDenseReal64Vector([]float64{0.5, 1.2, 2.3})
+  x2 := NewDenseReverseReal64Vector([]float64{0.5, 1.2, 2.3})
+  v  := NewDenseFloat64Vector([]float64{1.0, -2.0, 0.5})
+  // compare with the full Hessian
+  H  := NullDenseFloat64Matrix(3, 3)
+  H.Hessian(f, x1)
+  r1 := NullDenseFloat64Vector(3)
+  r1.MdotV(H, v)
+  r2 := NullDenseFloat64Vector(3)
+  if err := HessianVectorProduct(r2, f, x2, v); err != nil {
+    t.Fatal(err)
+  }
+  if !r1.Equals(r2, 1e-10) {
+    t.Error("test failed")
+  }
+}
+
+func TestSparseHessianReverse(t *testing.T) {
+  n := 100
+  k := 0
+  f := func(x ConstVector) ConstScalar {
+    k++
+    // Rosenbrock function
+    r  := NullScalar(x.ElementType())
+    t1 := NullScalar(x.ElementType())
+    t2 := NullScalar(x.ElementType())
+    for i := 0; i+1 < x.Dim(); i++ {
+      t1.Mul(x.ConstAt(i), x.ConstAt(i))
+      t1.Sub(x.ConstAt(i+1), t1)
+      t1.Mul(t1, t1)
+      t1.Mul(t1, ConstFloat64(100.0))
+      t2.Sub(ConstFloat64(1.0), x.ConstAt(i))
+      t2.Mul(t2, t2)
+      r.Add(r, t1)
+      r.Add(r, t2)
+    }
+    return r
+  }
+  x1 := NullDenseReal64Vector(n)
+  x2 := NullDenseReverseReal64Vector(n)
+  for i := 0; i < n; i++ {
+    x1.At(i).SetFloat64(math.Sin(float64(i+1)))
+    x2.At(i).SetFloat64(math.Sin(float64(i+1)))
+  }
+  H := NullDenseFloat64Matrix(n, n)
+  H.Hessian(f, x1)
+  p := HessianSparsity(f, x1)
+
+  k = 0
+  r, err := SparseHessian(f, x2, p)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !r.Equals(H, 1e-8) || r.Nnz() != 3*n-2 {
+    t.Error("test failed")
+  }
+  // a single evaluation for each of the three colors
+  if k != 3 {
+    t.Error("test failed")
+  }
+  // forward-mode requires one evaluation for each element of the upper
+  // triangular part
+  k = 0
+  if r, err := SparseHessian(f, x1, p); err != nil {
+    t.Fatal(err)
+  } else if !r.Equals(H, 1e-8) {
+    t.Error("test failed")
+  }
+  if k != 2*n-1 {
+    t.Error("test failed")
+  }
+  // non-symmetric patterns are completed by symmetry
+  q := NullDenseFloat64Matrix(n, n)
+  for i := 0; i < n; i++ {
+    q.At(i, i).SetFloat64(1.0)
+    if i+1 < n {
+      q.At(i, i+1).SetFloat64(1.0)
+    }
+  }
+  if r, err := SparseHessian(f, x2, q); err != nil {
+    t.Fatal(err)
+  } else if !r.Equals(H, 1e-8) {
+    t.Error("test failed")
+  }
+}
@@ -18,6 +18,58 @@
 
 package autodiff
 
+//go:generate cpp -P -C -nostdinc -include matrix_csc_complex128.h matrix_compressed_template.in       -o matrix_csc_complex128.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_complex128.h matrix_compressed_template_math.in  -o matrix_csc_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_complex64.h matrix_compressed_template.in       -o matrix_csc_complex64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_complex64.h matrix_compressed_template_math.in  -o matrix_csc_complex64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_float32.h matrix_compressed_template.in       -o matrix_csc_float32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_float32.h matrix_compressed_template_math.in  -o matrix_csc_float32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_float64.h matrix_compressed_template.in       -o matrix_csc_float64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_float64.h matrix_compressed_template_math.in  -o matrix_csc_float64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int16.h matrix_compressed_template.in       -o matrix_csc_int16.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int16.h matrix_compressed_template_math.in  -o matrix_csc_int16_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int32.h matrix_compressed_template.in       -o matrix_csc_int32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int32.h matrix_compressed_template_math.in  -o matrix_csc_int32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int64.h matrix_compressed_template.in       -o matrix_csc_int64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int64.h matrix_compressed_template_math.in  -o matrix_csc_int64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int8.h matrix_compressed_template.in       -o matrix_csc_int8.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int8.h matrix_compressed_template_math.in  -o matrix_csc_int8_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int.h matrix_compressed_template.in       -o matrix_csc_int.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_int.h matrix_compressed_template_math.in  -o matrix_csc_int_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real32.h matrix_compressed_template.in       -o matrix_csc_real32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real32.h matrix_compressed_template_math.in  -o matrix_csc_real32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real32.h matrix_compressed_template_magic.in -o matrix_csc_real32_magic.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real64.h matrix_compressed_template.in       -o matrix_csc_real64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real64.h matrix_compressed_template_math.in  -o matrix_csc_real64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csc_real64.h matrix_compressed_template_magic.in -o matrix_csc_real64_magic.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_complex128.h matrix_compressed_template.in       -o matrix_csr_complex128.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_complex128.h matrix_compressed_template_math.in  -o matrix_csr_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_complex64.h matrix_compressed_template.in       -o matrix_csr_complex64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_complex64.h matrix_compressed_template_math.in  -o matrix_csr_complex64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_float32.h matrix_compressed_template.in       -o matrix_csr_float32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_float32.h matrix_compressed_template_math.in  -o matrix_csr_float32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_float64.h matrix_compressed_template.in       -o matrix_csr_float64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_float64.h matrix_compressed_template_math.in  -o matrix_csr_float64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int16.h matrix_compressed_template.in       -o matrix_csr_int16.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int16.h matrix_compressed_template_math.in  -o matrix_csr_int16_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int32.h matrix_compressed_template.in       -o matrix_csr_int32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int32.h matrix_compressed_template_math.in  -o matrix_csr_int32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int64.h matrix_compressed_template.in       -o matrix_csr_int64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int64.h matrix_compressed_template_math.in  -o matrix_csr_int64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int8.h matrix_compressed_template.in       -o matrix_csr_int8.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int8.h matrix_compressed_template_math.in  -o matrix_csr_int8_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int.h matrix_compressed_template.in       -o matrix_csr_int.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_int.h matrix_compressed_template_math.in  -o matrix_csr_int_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real32.h matrix_compressed_template.in       -o matrix_csr_real32.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real32.h matrix_compressed_template_math.in  -o matrix_csr_real32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real32.h matrix_compressed_template_magic.in -o matrix_csr_real32_magic.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real64.h matrix_compressed_template.in       -o matrix_csr_real64.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real64.h matrix_compressed_template_math.in  -o matrix_csr_real64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_csr_real64.h matrix_compressed_template_magic.in -o matrix_csr_real64_magic.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_complex128.h matrix_dense_template.in      -o matrix_dense_complex128.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_complex128.h matrix_dense_template_math.in -o matrix_dense_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_complex64.h matrix_dense_template.in      -o matrix_dense_complex64.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_complex64.h matrix_dense_template_math.in -o matrix_dense_complex64_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_dense_float32.h matrix_dense_template.in      -o matrix_dense_float32.go
 //go:generate cpp -P -C -nostdinc -include matrix_dense_float32.h matrix_dense_template_math.in -o matrix_dense_float32_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_dense_float64.h matrix_dense_template.in      -o matrix_dense_float64.go
@@ -36,6 +88,18 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include matrix_dense_real32.h matrix_dense_real_template_math.in -o matrix_dense_real32_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_dense_real64.h matrix_dense_real_template.in -o matrix_dense_real64.go
 //go:generate cpp -P -C -nostdinc -include matrix_dense_real64.h matrix_dense_real_template_math.in -o matrix_dense_real64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_reverse_real32.h matrix_dense_real_template.in -o matrix_dense_reverse_real32.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_reverse_real32.h matrix_dense_real_template_math.in -o matrix_dense_reverse_real32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_reverse_real64.h matrix_dense_real_template.in -o matrix_dense_reverse_real64.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_reverse_real64.h matrix_dense_real_template_math.in -o matrix_dense_reverse_real64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_taylor_real32.h matrix_dense_real_template.in -o matrix_dense_taylor_real32.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_taylor_real32.h matrix_dense_real_template_math.in -o matrix_dense_taylor_real32_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_taylor_real64.h matrix_dense_real_template.in -o matrix_dense_taylor_real64.go
+//go:generate cpp -P -C -nostdinc -include matrix_dense_taylor_real64.h matrix_dense_real_template_math.in -o matrix_dense_taylor_real64_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_sparse_complex128.h matrix_sparse_template.in      -o matrix_sparse_complex128.go
+//go:generate cpp -P -C -nostdinc -include matrix_sparse_complex128.h matrix_sparse_template_math.in -o matrix_sparse_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include matrix_sparse_complex64.h matrix_sparse_template.in      -o matrix_sparse_complex64.go
+//go:generate cpp -P -C -nostdinc -include matrix_sparse_complex64.h matrix_sparse_template_math.in -o matrix_sparse_complex64_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_float32.h matrix_sparse_template.in      -o matrix_sparse_float32.go
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_float32.h matrix_sparse_template_math.in -o matrix_sparse_float32_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_float64.h matrix_sparse_template.in      -o matrix_sparse_float64.go
@@ -54,6 +118,8 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_real32.h matrix_sparse_real_template_math.in -o matrix_sparse_real32_math.go
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_real64.h matrix_sparse_real_template.in      -o matrix_sparse_real64.go
 //go:generate cpp -P -C -nostdinc -include matrix_sparse_real64.h matrix_sparse_real_template_math.in -o matrix_sparse_real64_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_const_complex128.h scalar_const_complex_template.in -o scalar_const_complex128.go
+//go:generate cpp -P -C -nostdinc -include scalar_const_complex64.h scalar_const_complex_template.in -o scalar_const_complex64.go
 //go:generate cpp -P -C -nostdinc -include scalar_const_float32.h scalar_const_template.in -o scalar_const_float32.go
 //go:generate cpp -P -C -nostdinc -include scalar_const_float64.h scalar_const_template.in -o scalar_const_float64.go
 //go:generate cpp -P -C -nostdinc -include scalar_const_int16.h scalar_const_template.in -o scalar_const_int16.go
@@ -61,6 +127,12 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include scalar_const_int64.h scalar_const_template.in -o scalar_const_int64.go
 //go:generate cpp -P -C -nostdinc -include scalar_const_int8.h scalar_const_template.in -o scalar_const_int8.go
 //go:generate cpp -P -C -nostdinc -include scalar_const_int.h scalar_const_template.in -o scalar_const_int.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex128.h scalar_complex_template.in               -o scalar_complex128.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex128.h scalar_complex_template_math.in          -o scalar_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex128.h scalar_complex_template_math_concrete.in -o scalar_complex128_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex64.h scalar_complex_template.in               -o scalar_complex64.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex64.h scalar_complex_template_math.in          -o scalar_complex64_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_complex64.h scalar_complex_template_math_concrete.in -o scalar_complex64_math_concrete.go
 //go:generate cpp -P -C -nostdinc -include scalar_float32.h scalar_template.in               -o scalar_float32.go
 //go:generate cpp -P -C -nostdinc -include scalar_float32.h scalar_template_math.in          -o scalar_float32_math.go
 //go:generate cpp -P -C -nostdinc -include scalar_float32.h scalar_template_math_concrete.in -o scalar_float32_math_concrete.go
@@ -90,6 +162,24 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include scalar_real64.h scalar_real_template_derivative.in    -o scalar_real64_derivative.go
 //go:generate cpp -P -C -nostdinc -include scalar_real64.h scalar_real_template_math.in          -o scalar_real64_math.go
 //go:generate cpp -P -C -nostdinc -include scalar_real64.h scalar_real_template_math_concrete.in -o scalar_real64_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real32.h scalar_reverse_template.in            -o scalar_reverse_real32.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real32.h scalar_reverse_template_derivative.in -o scalar_reverse_real32_derivative.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real32.h scalar_real_template_math.in          -o scalar_reverse_real32_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real32.h scalar_real_template_math_concrete.in -o scalar_reverse_real32_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real64.h scalar_reverse_template.in            -o scalar_reverse_real64.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real64.h scalar_reverse_template_derivative.in -o scalar_reverse_real64_derivative.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real64.h scalar_real_template_math.in          -o scalar_reverse_real64_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_reverse_real64.h scalar_real_template_math_concrete.in -o scalar_reverse_real64_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real32.h scalar_taylor_template.in               -o scalar_taylor_real32.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real32.h scalar_taylor_template_math.in          -o scalar_taylor_real32_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real32.h scalar_taylor_template_math_concrete.in -o scalar_taylor_real32_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real64.h scalar_taylor_template.in               -o scalar_taylor_real64.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real64.h scalar_taylor_template_math.in          -o scalar_taylor_real64_math.go
+//go:generate cpp -P -C -nostdinc -include scalar_taylor_real64.h scalar_taylor_template_math_concrete.in -o scalar_taylor_real64_math_concrete.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_complex128.h vector_dense_template.in      -o vector_dense_complex128.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_complex128.h vector_dense_template_math.in -o vector_dense_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_complex64.h vector_dense_template.in      -o vector_dense_complex64.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_complex64.h vector_dense_template_math.in -o vector_dense_complex64_math.go
 //go:generate cpp -P -C -nostdinc -include vector_dense_float32.h vector_dense_template.in      -o vector_dense_float32.go
 //go:generate cpp -P -C -nostdinc -include vector_dense_float32.h vector_dense_template_math.in -o vector_dense_float32_math.go
 //go:generate cpp -P -C -nostdinc -include vector_dense_float64.h vector_dense_template.in      -o vector_dense_float64.go
@@ -108,6 +198,16 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include vector_dense_real32.h vector_dense_real_template_math.in -o vector_dense_real32_math.go
 //go:generate cpp -P -C -nostdinc -include vector_dense_real64.h vector_dense_real_template.in      -o vector_dense_real64.go
 //go:generate cpp -P -C -nostdinc -include vector_dense_real64.h vector_dense_real_template_math.in -o vector_dense_real64_math.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_reverse_real32.h vector_dense_real_template.in      -o vector_dense_reverse_real32.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_reverse_real32.h vector_dense_real_template_math.in -o vector_dense_reverse_real32_math.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_reverse_real64.h vector_dense_real_template.in      -o vector_dense_reverse_real64.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_reverse_real64.h vector_dense_real_template_math.in -o vector_dense_reverse_real64_math.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_taylor_real32.h vector_dense_real_template.in      -o vector_dense_taylor_real32.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_taylor_real32.h vector_dense_real_template_math.in -o vector_dense_taylor_real32_math.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_taylor_real64.h vector_dense_real_template.in      -o vector_dense_taylor_real64.go
+//go:generate cpp -P -C -nostdinc -include vector_dense_taylor_real64.h vector_dense_real_template_math.in -o vector_dense_taylor_real64_math.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_const_complex128.h vector_sparse_const_template.in -o vector_sparse_const_complex128.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_const_complex64.h vector_sparse_const_template.in -o vector_sparse_const_complex64.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_float32.h vector_sparse_const_template.in -o vector_sparse_const_float32.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_float64.h vector_sparse_const_template.in -o vector_sparse_const_float64.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_int16.h vector_sparse_const_template.in -o vector_sparse_const_int16.go
@@ -115,6 +215,10 @@ package autodiff
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_int64.h vector_sparse_const_template.in -o vector_sparse_const_int64.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_int8.h vector_sparse_const_template.in -o vector_sparse_const_int8.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_const_int.h vector_sparse_const_template.in -o vector_sparse_const_int.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_complex128.h vector_sparse_template.in      -o vector_sparse_complex128.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_complex128.h vector_sparse_template_math.in -o vector_sparse_complex128_math.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_complex64.h vector_sparse_template.in      -o vector_sparse_complex64.go
+//go:generate cpp -P -C -nostdinc -include vector_sparse_complex64.h vector_sparse_template_math.in -o vector_sparse_complex64_math.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_float32.h vector_sparse_template.in      -o vector_sparse_float32.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_float32.h vector_sparse_template_math.in -o vector_sparse_float32_math.go
 //go:generate cpp -P -C -nostdinc -include vector_sparse_float64.h vector_sparse_template.in      -o vector_sparse_float64.go
@@ -18,3 +18,15 @@
 #define NULL_MATRIX STR_CONCAT(Null, MATRIX_NAME)
 #define  NIL_MATRIX STR_CONCAT(nil,  MATRIX_NAME)
 #define   AS_MATRIX STR_CONCAT(As,   MATRIX_NAME)
+
+#ifdef COMPLEX
+#define STORED_REAL(x) real(x)
+#define STORED_FROM_REAL(x) STORED_TYPE(complex(x, 0))
+#else
+#define STORED_REAL(x) x
+#define STORED_FROM_REAL(x) STORED_TYPE(x)
+#endif
+
+#ifndef STORED_JSON_SLICE
+#define STORED_JSON_SLICE []STORED_TYPE
+#endif
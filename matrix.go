@@ -181,10 +181,22 @@ func NullDenseMatrix(t ScalarType, rows, cols int) Matrix {
     return NullDenseFloat32Matrix(rows, cols)
   case Float64Type:
     return NullDenseFloat64Matrix(rows, cols)
+  case Complex64Type:
+    return NullDenseComplex64Matrix(rows, cols)
+  case Complex128Type:
+    return NullDenseComplex128Matrix(rows, cols)
   case Real32Type:
     return NullDenseReal32Matrix(rows, cols)
   case Real64Type:
     return NullDenseReal64Matrix(rows, cols)
+  case ReverseReal32Type:
+    return NullDenseReverseReal32Matrix(rows, cols)
+  case ReverseReal64Type:
+    return NullDenseReverseReal64Matrix(rows, cols)
+  case TaylorReal32Type:
+    return NullDenseTaylorReal32Matrix(rows, cols)
+  case TaylorReal64Type:
+    return NullDenseTaylorReal64Matrix(rows, cols)
   default:
     panic("unknown type")
   }
@@ -206,10 +218,22 @@ func AsDenseMatrix(t ScalarType, m ConstMatrix) Matrix {
     return AsDenseFloat32Matrix(m)
   case Float64Type:
     return AsDenseFloat64Matrix(m)
+  case Complex64Type:
+    return AsDenseComplex64Matrix(m)
+  case Complex128Type:
+    return AsDenseComplex128Matrix(m)
   case Real32Type:
     return AsDenseReal32Matrix(m)
   case Real64Type:
     return AsDenseReal64Matrix(m)
+  case ReverseReal32Type:
+    return AsDenseReverseReal32Matrix(m)
+  case ReverseReal64Type:
+    return AsDenseReverseReal64Matrix(m)
+  case TaylorReal32Type:
+    return AsDenseTaylorReal32Matrix(m)
+  case TaylorReal64Type:
+    return AsDenseTaylorReal64Matrix(m)
   default:
     panic("unknown type")
   }
@@ -221,6 +245,14 @@ func NullDenseMagicMatrix(t ScalarType, rows, cols int) MagicMatrix {
     return NullDenseReal32Matrix(rows, cols)
   case Real64Type:
     return NullDenseReal64Matrix(rows, cols)
+  case ReverseReal32Type:
+    return NullDenseReverseReal32Matrix(rows, cols)
+  case ReverseReal64Type:
+    return NullDenseReverseReal64Matrix(rows, cols)
+  case TaylorReal32Type:
+    return NullDenseTaylorReal32Matrix(rows, cols)
+  case TaylorReal64Type:
+    return NullDenseTaylorReal64Matrix(rows, cols)
   default:
     panic("unknown type")
   }
@@ -232,6 +264,14 @@ func AsDenseMagicMatrix(t ScalarType, m ConstMatrix) MagicMatrix {
     return AsDenseReal32Matrix(m)
   case Real64Type:
     return AsDenseReal64Matrix(m)
+  case ReverseReal32Type:
+    return AsDenseReverseReal32Matrix(m)
+  case ReverseReal64Type:
+    return AsDenseReverseReal64Matrix(m)
+  case TaylorReal32Type:
+    return AsDenseTaylorReal32Matrix(m)
+  case TaylorReal64Type:
+    return AsDenseTaylorReal64Matrix(m)
   default:
     panic("unknown type")
   }
@@ -253,6 +293,10 @@ func NullSparseMatrix(t ScalarType, rows, cols int) Matrix {
     return NullSparseFloat32Matrix(rows, cols)
   case Float64Type:
     return NullSparseFloat64Matrix(rows, cols)
+  case Complex64Type:
+    return NullSparseComplex64Matrix(rows, cols)
+  case Complex128Type:
+    return NullSparseComplex128Matrix(rows, cols)
   case Real32Type:
     return NullSparseReal32Matrix(rows, cols)
   case Real64Type:
@@ -278,6 +322,10 @@ func AsSparseMatrix(t ScalarType, m ConstMatrix) Matrix {
     return AsSparseFloat32Matrix(m)
   case Float64Type:
     return AsSparseFloat64Matrix(m)
+  case Complex64Type:
+    return AsSparseComplex64Matrix(m)
+  case Complex128Type:
+    return AsSparseComplex128Matrix(m)
   case Real32Type:
     return AsSparseReal32Matrix(m)
   case Real64Type:
@@ -309,6 +357,166 @@ func AsSparseMagicMatrix(t ScalarType, m ConstMatrix) MagicMatrix {
   }
 }
 
+func NullCsrMatrix(t ScalarType, rows, cols int) Matrix {
+  switch t {
+  case Int8Type:
+    return NullCsrInt8Matrix(rows, cols)
+  case Int16Type:
+    return NullCsrInt16Matrix(rows, cols)
+  case Int32Type:
+    return NullCsrInt32Matrix(rows, cols)
+  case Int64Type:
+    return NullCsrInt64Matrix(rows, cols)
+  case IntType:
+    return NullCsrIntMatrix(rows, cols)
+  case Float32Type:
+    return NullCsrFloat32Matrix(rows, cols)
+  case Float64Type:
+    return NullCsrFloat64Matrix(rows, cols)
+  case Complex64Type:
+    return NullCsrComplex64Matrix(rows, cols)
+  case Complex128Type:
+    return NullCsrComplex128Matrix(rows, cols)
+  case Real32Type:
+    return NullCsrReal32Matrix(rows, cols)
+  case Real64Type:
+    return NullCsrReal64Matrix(rows, cols)
+  default:
+    panic("unknown type")
+  }
+}
+
+func AsCsrMatrix(t ScalarType, m ConstMatrix) Matrix {
+  switch t {
+  case Int8Type:
+    return AsCsrInt8Matrix(m)
+  case Int16Type:
+    return AsCsrInt16Matrix(m)
+  case Int32Type:
+    return AsCsrInt32Matrix(m)
+  case Int64Type:
+    return AsCsrInt64Matrix(m)
+  case IntType:
+    return AsCsrIntMatrix(m)
+  case Float32Type:
+    return AsCsrFloat32Matrix(m)
+  case Float64Type:
+    return AsCsrFloat64Matrix(m)
+  case Complex64Type:
+    return AsCsrComplex64Matrix(m)
+  case Complex128Type:
+    return AsCsrComplex128Matrix(m)
+  case Real32Type:
+    return AsCsrReal32Matrix(m)
+  case Real64Type:
+    return AsCsrReal64Matrix(m)
+  default:
+    panic("unknown type")
+  }
+}
+
+func NullCsrMagicMatrix(t ScalarType, rows, cols int) MagicMatrix {
+  switch t {
+  case Real32Type:
+    return NullCsrReal32Matrix(rows, cols)
+  case Real64Type:
+    return NullCsrReal64Matrix(rows, cols)
+  default:
+    panic("unknown type")
+  }
+}
+
+func AsCsrMagicMatrix(t ScalarType, m ConstMatrix) MagicMatrix {
+  switch t {
+  case Real32Type:
+    return AsCsrReal32Matrix(m)
+  case Real64Type:
+    return AsCsrReal64Matrix(m)
+  default:
+    panic("unknown type")
+  }
+}
+
+func NullCscMatrix(t ScalarType, rows, cols int) Matrix {
+  switch t {
+  case Int8Type:
+    return NullCscInt8Matrix(rows, cols)
+  case Int16Type:
+    return NullCscInt16Matrix(rows, cols)
+  case Int32Type:
+    return NullCscInt32Matrix(rows, cols)
+  case Int64Type:
+    return NullCscInt64Matrix(rows, cols)
+  case IntType:
+    return NullCscIntMatrix(rows, cols)
+  case Float32Type:
+    return NullCscFloat32Matrix(rows, cols)
+  case Float64Type:
+    return NullCscFloat64Matrix(rows, cols)
+  case Complex64Type:
+    return NullCscComplex64Matrix(rows, cols)
+  case Complex128Type:
+    return NullCscComplex128Matrix(rows, cols)
+  case Real32Type:
+    return NullCscReal32Matrix(rows, cols)
+  case Real64Type:
+    return NullCscReal64Matrix(rows, cols)
+  default:
+    panic("unknown type")
+  }
+}
+
+func AsCscMatrix(t ScalarType, m ConstMatrix) Matrix {
+  switch t {
+  case Int8Type:
+    return AsCscInt8Matrix(m)
+  case Int16Type:
+    return AsCscInt16Matrix(m)
+  case Int32Type:
+    return AsCscInt32Matrix(m)
+  case Int64Type:
+    return AsCscInt64Matrix(m)
+  case IntType:
+    return AsCscIntMatrix(m)
+  case Float32Type:
+    return AsCscFloat32Matrix(m)
+  case Float64Type:
+    return AsCscFloat64Matrix(m)
+  case Complex64Type:
+    return AsCscComplex64Matrix(m)
+  case Complex128Type:
+    return AsCscComplex128Matrix(m)
+  case Real32Type:
+    return AsCscReal32Matrix(m)
+  case Real64Type:
+    return AsCscReal64Matrix(m)
+  default:
+    panic("unknown type")
+  }
+}
+
+func NullCscMagicMatrix(t ScalarType, rows, cols int) MagicMatrix {
+  switch t {
+  case Real32Type:
+    return NullCscReal32Matrix(rows, cols)
+  case Real64Type:
+    return NullCscReal64Matrix(rows, cols)
+  default:
+    panic("unknown type")
+  }
+}
+
+func AsCscMagicMatrix(t ScalarType, m ConstMatrix) MagicMatrix {
+  switch t {
+  case Real32Type:
+    return AsCscReal32Matrix(m)
+  case Real64Type:
+    return AsCscReal64Matrix(m)
+  default:
+    panic("unknown type")
+  }
+}
+
 /* constructors for special types of matrices
  * -------------------------------------------------------------------------- */
 
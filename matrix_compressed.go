@@ -0,0 +1,129 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+
+/* compressed sparse matrices
+ * -------------------------------------------------------------------------- */
+
+// Products with compressed sparse row (CSR) and column (CSC) matrices
+// that require only O(nnz) operations. Dense vectors and matrices use
+// these methods when one of the arguments is a compressed matrix.
+type compressedConstMatrix interface {
+  ConstMatrix
+  // r = A b
+  mdotv(r Vector, b ConstVector)
+  // r = a^T A
+  vdotm(r Vector, a ConstVector)
+  // r = A b
+  mdotm(r Matrix, b ConstMatrix)
+  // r = a A
+  mdotmLeft(r Matrix, a ConstMatrix)
+}
+
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+
+// Iterate jointly over two matrices. Both iterators must visit elements in
+// row-major order. If an element is missing in the first matrix, the first
+// scalar is nil. If it is missing in the second matrix, the second scalar is
+// zero.
+type matrixJointIterator struct {
+  it1  MatrixConstIterator
+  it2  MatrixConstIterator
+  i, j int
+  s1   ConstScalar
+  s2   ConstScalar
+  ok   bool
+}
+
+func newMatrixJointIterator(it1 MatrixConstIterator, it2 MatrixConstIterator) *matrixJointIterator {
+  r := matrixJointIterator{it1: it1, it2: it2}
+  r.Next()
+  return &r
+}
+
+func (obj *matrixJointIterator) Index() (int, int) {
+  return obj.i, obj.j
+}
+
+func (obj *matrixJointIterator) Ok() bool {
+  return obj.ok
+}
+
+func (obj *matrixJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1 = nil
+  obj.s2 = nil
+  obj.ok = ok1 || ok2
+  if ok1 {
+    obj.i, obj.j = obj.it1.Index()
+    obj.s1       = obj.it1.GetConst()
+  }
+  if ok2 {
+    i, j := obj.it2.Index()
+    switch {
+    case !ok1 || obj.i > i || (obj.i == i && obj.j > j):
+      obj.i, obj.j = i, j
+      obj.s1       = nil
+      obj.s2       = obj.it2.GetConst()
+    case obj.i == i && obj.j == j:
+      obj.s2       = obj.it2.GetConst()
+    }
+  }
+  if obj.s1 != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstFloat64(0.0)
+  }
+}
+
+// The first iterator must return elements that implement the Scalar
+// interface.
+func (obj *matrixJointIterator) Get() (Scalar, ConstScalar) {
+  if obj.s1 == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1.(Scalar), obj.s2
+  }
+}
+
+func (obj *matrixJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  return obj.s1, obj.s2
+}
+
+func (obj *matrixJointIterator) Clone() *matrixJointIterator {
+  r := *obj
+  r.it1 = obj.it1.CloneConstIterator()
+  r.it2 = obj.it2.CloneConstIterator()
+  return &r
+}
+
+func (obj *matrixJointIterator) CloneJointIterator() MatrixJointIterator {
+  return obj.Clone()
+}
+
+func (obj *matrixJointIterator) CloneConstJointIterator() MatrixConstJointIterator {
+  return obj.Clone()
+}
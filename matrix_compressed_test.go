@@ -0,0 +1,231 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+import "encoding/json"
+import "math"
+import "math/rand"
+import "testing"
+
+/* -------------------------------------------------------------------------- */
+
+func randomSparseDenseFloat64Matrix(rows, cols int, p float64, rng *rand.Rand) *DenseFloat64Matrix {
+  m := NullDenseFloat64Matrix(rows, cols)
+  for i := 0; i < rows; i++ {
+    for j := 0; j < cols; j++ {
+      if rng.Float64() < p {
+        m.At(i, j).SetFloat64(rng.NormFloat64())
+      }
+    }
+  }
+  return m
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestCompressedMatrixConversion(t *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+     1,  2,  0,
+     0,  0,  6,
+     7,  0,  9,
+     0, 11,  0}, 4, 3)
+  for _, m := range []Matrix{AsCsrFloat64Matrix(a), AsCscFloat64Matrix(a), AsCsrReal64Matrix(a), AsCscReal64Matrix(a)} {
+    if !m.Equals(a, 1e-12) || !a.Equals(m, 1e-12) {
+      t.Error("test failed")
+    }
+    if n := m.(interface{ Nnz() int }).Nnz(); n != 6 {
+      t.Error("test failed")
+    }
+    // iterators visit elements in row-major order
+    k := 0
+    for it := m.ConstIterator(); it.Ok(); it.Next() {
+      i, j := it.Index()
+      if i*3+j < k {
+        t.Error("test failed")
+      }
+      k = i*3+j
+    }
+    if !AsSparseFloat64Matrix(m).Equals(a, 1e-12) {
+      t.Error("test failed")
+    }
+    if !AsDenseFloat64Matrix(m).Equals(a, 1e-12) {
+      t.Error("test failed")
+    }
+    if !m.T().T().Equals(a, 1e-12) {
+      t.Error("test failed")
+    }
+  }
+  r := NewCscFloat64Matrix([]int{2, 0, 1}, []int{0, 0, 2}, []float64{7, 1, 6}, 4, 3)
+  if r.Float64At(0, 0) != 1 || r.Float64At(2, 0) != 7 || r.Float64At(1, 2) != 6 || r.Float64At(3, 1) != 0 {
+    t.Error("test failed")
+  }
+}
+
+func TestCompressedMatrixRowCol(t *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{
+     1,  2,  3,
+     4,  0,  0,
+     7,  8,  9,
+     0, 11, 12}, 4, 3)
+  for _, m := range []Matrix{AsCsrFloat64Matrix(a), AsCscFloat64Matrix(a)} {
+    if !m.Row(3).Equals(a.Row(3), 1e-12) || !m.Col(1).Equals(a.Col(1), 1e-12) {
+      t.Error("test failed")
+    }
+    // rows and columns are copies
+    m.Row(1).At(0).SetFloat64(100.0)
+    if m.ConstAt(1, 0).GetFloat64() != 4.0 {
+      t.Error("test failed")
+    }
+    // slices are views
+    s := m.Slice(1, 3, 1, 3)
+    s.At(0, 0).SetFloat64(5.0)
+    if m.ConstAt(1, 1).GetFloat64() != 5.0 || s.ConstAt(1, 1).GetFloat64() != 9.0 {
+      t.Error("test failed")
+    }
+    s.Set(NewDenseFloat64Matrix([]float64{-1, -2, -3, -4}, 2, 2))
+    if m.ConstAt(2, 2).GetFloat64() != -4.0 || m.ConstAt(3, 2).GetFloat64() != 12.0 {
+      t.Error("test failed")
+    }
+    m.Tip()
+    if n, k := m.Dims(); n != 3 || k != 4 || m.ConstAt(2, 3).GetFloat64() != 12.0 {
+      t.Error("test failed")
+    }
+    if err := m.Slice(0, 3, 0, 3).SwapRows(0, 2); err != nil {
+      t.Error(err)
+    }
+    if m.ConstAt(0, 1).GetFloat64() != -2.0 || m.ConstAt(2, 1).GetFloat64() != 4.0 || m.ConstAt(2, 3).GetFloat64() != 12.0 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestCompressedMatrixProducts(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  a := randomSparseDenseFloat64Matrix(20, 30, 0.2, rng)
+  b := randomSparseDenseFloat64Matrix(30, 10, 0.2, rng)
+  x := NullDenseFloat64Vector(30)
+  y := NullDenseFloat64Vector(20)
+  for i := 0; i < x.Dim(); i++ {
+    x.At(i).SetFloat64(rng.NormFloat64())
+  }
+  for i := 0; i < y.Dim(); i++ {
+    y.At(i).SetFloat64(rng.NormFloat64())
+  }
+  ab := NullDenseFloat64Matrix(20, 10)
+  ab.MdotM(a, b)
+  ax := NullDenseFloat64Vector(20)
+  ax.MdotV(a, x)
+  ya := NullDenseFloat64Vector(30)
+  ya.VdotM(y, a)
+
+  for _, c := range []ConstMatrix{AsCsrFloat64Matrix(a), AsCscFloat64Matrix(a)} {
+    for _, d := range []ConstMatrix{b, AsCsrFloat64Matrix(b), AsCscFloat64Matrix(b)} {
+      r1 := NullCsrFloat64Matrix(20, 10)
+      r1.MdotM(c, d)
+      r2 := NullDenseFloat64Matrix(20, 10)
+      r2.MdotM(c, d)
+      r3 := NullDenseFloat64Matrix(20, 10)
+      r3.MdotM(a, d)
+      if !r1.Equals(ab, 1e-10) || !r2.Equals(ab, 1e-10) || !r3.Equals(ab, 1e-10) {
+        t.Error("test failed")
+      }
+    }
+    r1 := NullDenseFloat64Vector(20)
+    r1.MdotV(c, x)
+    if !r1.Equals(ax, 1e-10) {
+      t.Error("test failed")
+    }
+    r2 := NullDenseFloat64Vector(30)
+    r2.VdotM(y, c)
+    if !r2.Equals(ya, 1e-10) {
+      t.Error("test failed")
+    }
+  }
+  // result may share storage with an argument
+  c := AsCsrFloat64Matrix(randomSparseDenseFloat64Matrix(10, 10, 0.3, rng))
+  d := NullDenseFloat64Matrix(10, 10)
+  d.MdotM(c, c)
+  c.MdotM(c, c)
+  if !c.Equals(d, 1e-10) {
+    t.Error("test failed")
+  }
+}
+
+func TestCompressedMatrixElementwise(t *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{1, 0, 3, 0, 5, 0}, 2, 3)
+  b := NewDenseFloat64Matrix([]float64{0, 2, 3, 0, 1, 0}, 2, 3)
+  for _, m := range []Matrix{NullCsrFloat64Matrix(2, 3), NullCscReal64Matrix(2, 3)} {
+    m.MaddM(AsCsrFloat64Matrix(a), AsCscFloat64Matrix(b))
+    if !m.Equals(NewDenseFloat64Matrix([]float64{1, 2, 6, 0, 6, 0}, 2, 3), 1e-12) {
+      t.Error("test failed")
+    }
+    m.MmulM(a, b)
+    if !m.Equals(NewDenseFloat64Matrix([]float64{0, 0, 9, 0, 5, 0}, 2, 3), 1e-12) {
+      t.Error("test failed")
+    }
+    if m.(interface{ Nnz() int }).Nnz() != 2 {
+      t.Error("test failed")
+    }
+    m.MsubS(m, ConstFloat64(1.0))
+    if !m.Equals(NewDenseFloat64Matrix([]float64{-1, -1, 8, -1, 4, -1}, 2, 3), 1e-12) {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestCompressedMatrixJson(t *testing.T) {
+  a := NewDenseFloat64Matrix([]float64{1, 0, 3, 0, 5, 0}, 2, 3)
+  for _, m := range []Matrix{AsCsrFloat64Matrix(a), AsCscReal64Matrix(a)} {
+    data, err := json.Marshal(m)
+    if err != nil {
+      t.Error(err); continue
+    }
+    r := m.CloneMatrix()
+    r.Reset()
+    if err := json.Unmarshal(data, r); err != nil {
+      t.Error(err)
+    }
+    if !r.Equals(a, 1e-12) {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestCompressedMatrixJacobian(t *testing.T) {
+  f := func(x ConstVector) ConstVector {
+    y := NullDenseReal64Vector(2)
+    y.At(0).Mul(x.ConstAt(0), x.ConstAt(1))
+    y.At(1).Exp(x.ConstAt(2))
+    return y
+  }
+  x := NewDenseReal64Vector([]float64{2, 3, 0})
+  for _, m := range []MagicMatrix{NullCsrReal64Matrix(2, 3), NullCscReal64Matrix(2, 3)} {
+    m.Jacobian(f, x)
+    if !m.Equals(NewDenseFloat64Matrix([]float64{3, 2, 0, 0, 0, 1}, 2, 3), 1e-12) {
+      t.Error("test failed")
+    }
+    m.Variables(1)
+    r := NullReal64()
+    r.Mnorm(m)
+    if math.Abs(r.GetDerivative(0) - 6.0) > 1e-12 || r.GetDerivative(2) != 0.0 {
+      t.Error("test failed")
+    }
+  }
+}
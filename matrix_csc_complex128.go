@@ -0,0 +1,922 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bytes"
+import "bufio"
+import "compress/gzip"
+import "encoding/json"
+import "io"
+import "os"
+import "sort"
+import "strconv"
+import "strings"
+import "unsafe"
+/* compressed storage
+ * -------------------------------------------------------------------------- */
+// Elements are stored along the major dimension, which is given by the rows
+// of a CSR and the columns of a CSC matrix. Minor indices of the pth major
+// element are stored in ascending order in indices[ptr[p]:ptr[p+1]].
+type storageCscComplex128Matrix struct {
+  n int
+  m int
+  ptr []int
+  indices []int
+  values []Complex128
+}
+func newStorageCscComplex128Matrix(n, m int) *storageCscComplex128Matrix {
+  return &storageCscComplex128Matrix{n: n, m: m, ptr: make([]int, n+1)}
+}
+func (s *storageCscComplex128Matrix) clone() *storageCscComplex128Matrix {
+  r := storageCscComplex128Matrix{n: s.n, m: s.m}
+  r.ptr = make([]int, len(s.ptr))
+  r.indices = make([]int, len(s.indices))
+  r.values = make([]Complex128, len(s.values))
+  copy(r.ptr, s.ptr)
+  copy(r.indices, s.indices)
+  for k, v := range s.values {
+    r.values[k] = v.Clone()
+  }
+  return &r
+}
+// Returns the position of element (p, q) and true if the element is
+// stored. Otherwise, the position where the element must be inserted is
+// returned.
+func (s *storageCscComplex128Matrix) find(p, q int) (int, bool) {
+  lo := s.ptr[p]
+  hi := s.ptr[p+1]
+  k := lo + sort.SearchInts(s.indices[lo:hi], q)
+  return k, k < hi && s.indices[k] == q
+}
+// Returns the range of positions of all elements (p, q) with q0 <= q < q1.
+func (s *storageCscComplex128Matrix) span(p, q0, q1 int) (int, int) {
+  lo := s.ptr[p]
+  hi := s.ptr[p+1]
+  if q1 < s.m {
+    hi = lo + sort.SearchInts(s.indices[lo:hi], q1)
+  }
+  if q0 > 0 {
+    lo = lo + sort.SearchInts(s.indices[lo:hi], q0)
+  }
+  return lo, hi
+}
+// Insert element (p, q) at position k. This operation requires O(nnz)
+// time.
+func (s *storageCscComplex128Matrix) insert(p, q, k int, v Complex128) {
+  s.indices = append(s.indices, 0)
+  s.values = append(s.values, v)
+  copy(s.indices[k+1:], s.indices[k:])
+  copy(s.values [k+1:], s.values [k:])
+  s.indices[k] = q
+  s.values [k] = v
+  for i := p+1; i <= s.n; i++ {
+    s.ptr[i]++
+  }
+}
+// Exchange major and minor dimensions. The new storage refers to the same
+// scalars.
+func (s *storageCscComplex128Matrix) transpose() *storageCscComplex128Matrix {
+  r := newStorageCscComplex128Matrix(s.m, s.n)
+  r.indices = make([]int, len(s.indices))
+  r.values = make([]Complex128, len(s.values))
+  for _, q := range s.indices {
+    r.ptr[q+1]++
+  }
+  for q := 0; q < r.n; q++ {
+    r.ptr[q+1] += r.ptr[q]
+  }
+  next := make([]int, r.n)
+  copy(next, r.ptr)
+  for p := 0; p < s.n; p++ {
+    for k := s.ptr[p]; k < s.ptr[p+1]; k++ {
+      q := s.indices[k]
+      l := next[q]
+      r.indices[l] = p
+      r.values [l] = s.values[k]
+      next[q]++
+    }
+  }
+  return r
+}
+/* list of matrix elements
+ * -------------------------------------------------------------------------- */
+type elementsCscComplex128Matrix struct {
+  is []int
+  js []int
+  values []Complex128
+}
+// Append element (i, j). Zero elements without derivatives are dropped.
+func (obj *elementsCscComplex128Matrix) add(i, j int, v Complex128) {
+  if !v.nullScalar() {
+    obj.is = append(obj.is, i)
+    obj.js = append(obj.js, j)
+    obj.values = append(obj.values, v)
+  }
+}
+func (obj *elementsCscComplex128Matrix) Len() int {
+  return len(obj.values)
+}
+func (obj *elementsCscComplex128Matrix) Less(k, l int) bool {
+  return obj.is[k] < obj.is[l] || (obj.is[k] == obj.is[l] && obj.js[k] < obj.js[l])
+}
+func (obj *elementsCscComplex128Matrix) Swap(k, l int) {
+  obj.is [k], obj.is [l] = obj.is [l], obj.is [k]
+  obj.js [k], obj.js [l] = obj.js [l], obj.js [k]
+  obj.values[k], obj.values[l] = obj.values[l], obj.values[k]
+}
+/* matrix type declaration
+ * -------------------------------------------------------------------------- */
+type CscComplex128Matrix struct {
+  s *storageCscComplex128Matrix
+  rows int
+  cols int
+  rowOffset int
+  colOffset int
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+func NewCscComplex128Matrix(rowIndices, colIndices []int, values []complex128, rows, cols int) *CscComplex128Matrix {
+  m := NullCscComplex128Matrix(rows, cols)
+  if len(rowIndices) != len(colIndices) || len(colIndices) != len(values) {
+    panic("number of row/col-indices does not match number of values")
+  }
+  e := elementsCscComplex128Matrix{}
+  for k := 0; k < len(values); k++ {
+    i := rowIndices[k]
+    j := colIndices[k]
+    if i < 0 || j < 0 || i >= rows || j >= cols {
+      panic(fmt.Errorf("index (%d,%d) out of bounds for matrix of dimension %dx%d", i, j, rows, cols))
+    }
+    e.add(i, j, NewComplex128(values[k]))
+  }
+  sort.Sort(&e)
+  for k := 1; k < e.Len(); k++ {
+    if e.is[k-1] == e.is[k] && e.js[k-1] == e.js[k] {
+      panic("index appeared multiple times")
+    }
+  }
+  m.assign(&e)
+  return m
+}
+func NullCscComplex128Matrix(rows, cols int) *CscComplex128Matrix {
+  m := CscComplex128Matrix{}
+  m.s = newStorageCscComplex128Matrix(cols, rows)
+  m.rows = rows
+  m.cols = cols
+  return &m
+}
+// Convert matrix type. Only non-zero elements are stored, which requires
+// O(nnz) operations if the iterator of the given matrix visits only stored
+// elements.
+func AsCscComplex128Matrix(matrix ConstMatrix) *CscComplex128Matrix {
+  switch matrix_ := matrix.(type) {
+  case *CscComplex128Matrix:
+    return matrix_.Clone()
+  }
+  n, m := matrix.Dims()
+  r := NullCscComplex128Matrix(n, m)
+  r.Set(matrix)
+  return r
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+// Clone matrix including data.
+func (matrix *CscComplex128Matrix) Clone() *CscComplex128Matrix {
+  return &CscComplex128Matrix{
+    s : matrix.s.clone(),
+    rows : matrix.rows,
+    cols : matrix.cols,
+    rowOffset : matrix.rowOffset,
+    colOffset : matrix.colOffset }
+}
+/* indexing
+ * -------------------------------------------------------------------------- */
+// Convert matrix indices to (major, minor) storage indices.
+func (matrix *CscComplex128Matrix) storageIndex(i, j int) (int, int) {
+  if i < 0 || j < 0 || i >= matrix.rows || j >= matrix.cols {
+    panic(fmt.Errorf("index (%d,%d) out of bounds for matrix of dimension %dx%d", i, j, matrix.rows, matrix.cols))
+  }
+  return matrix.colOffset + j, matrix.rowOffset + i
+}
+// Convert (major, minor) storage indices to matrix indices.
+func (matrix *CscComplex128Matrix) matrixIndex(p, q int) (int, int) {
+  return q - matrix.rowOffset, p - matrix.colOffset
+}
+// Range of major and minor storage indices covered by this matrix.
+func (matrix *CscComplex128Matrix) storageRange() (int, int, int, int) {
+  return matrix.colOffset, matrix.colOffset + matrix.cols, matrix.rowOffset, matrix.rowOffset + matrix.rows
+}
+// Storage of all elements in row-major order, which is used by iterators.
+func (matrix *CscComplex128Matrix) rowMajorStorage() *storageCscComplex128Matrix {
+  return matrix.s.transpose()
+}
+// True if the matrix is not a slice of a larger matrix.
+func (matrix *CscComplex128Matrix) isFull() bool {
+  p0, p1, q0, q1 := matrix.storageRange()
+  return p0 == 0 && q0 == 0 && p1 == matrix.s.n && q1 == matrix.s.m
+}
+// Returns the storage restricted to the elements of this matrix.
+func (matrix *CscComplex128Matrix) compact() *storageCscComplex128Matrix {
+  if matrix.isFull() {
+    return matrix.s
+  }
+  p0, p1, q0, q1 := matrix.storageRange()
+  s := newStorageCscComplex128Matrix(p1-p0, q1-q0)
+  for p := p0; p < p1; p++ {
+    lo, hi := matrix.s.span(p, q0, q1)
+    for k := lo; k < hi; k++ {
+      s.indices = append(s.indices, matrix.s.indices[k]-q0)
+      s.values = append(s.values, matrix.s.values [k])
+    }
+    s.ptr[p-p0+1] = len(s.indices)
+  }
+  return s
+}
+// Call f for all stored elements in storage order.
+func (matrix *CscComplex128Matrix) forEach(f func(i, j int, v Complex128)) {
+  p0, p1, q0, q1 := matrix.storageRange()
+  for p := p0; p < p1; p++ {
+    lo, hi := matrix.s.span(p, q0, q1)
+    for k := lo; k < hi; k++ {
+      i, j := matrix.matrixIndex(p, matrix.s.indices[k])
+      f(i, j, matrix.s.values[k])
+    }
+  }
+}
+// Replace all elements of the matrix. Elements must be given in row-major
+// order. The scalars are stored without copying.
+func (matrix *CscComplex128Matrix) assign(e *elementsCscComplex128Matrix) {
+  if matrix.isFull() {
+    s := newStorageCscComplex128Matrix(matrix.rows, matrix.cols)
+    s.indices = make([]int, e.Len())
+    s.values = make([]Complex128, e.Len())
+    for k := 0; k < e.Len(); k++ {
+      s.ptr[e.is[k]+1]++
+      s.indices[k] = e.js[k]
+      s.values [k] = e.values[k]
+    }
+    for p := 0; p < s.n; p++ {
+      s.ptr[p+1] += s.ptr[p]
+    }
+    s = s.transpose()
+    *matrix.s = *s
+  } else {
+    matrix.forEach(func(i, j int, v Complex128) {
+      v.Reset()
+    })
+    for k := 0; k < e.Len(); k++ {
+      matrix.AT(e.is[k], e.js[k]).Set(e.values[k])
+    }
+  }
+}
+/* native matrix methods
+ * -------------------------------------------------------------------------- */
+// Returns element (i, j). If the element is not stored, it is inserted,
+// which requires O(nnz) time.
+func (matrix *CscComplex128Matrix) AT(i, j int) Complex128 {
+  p, q := matrix.storageIndex(i, j)
+  k, ok := matrix.s.find(p, q)
+  if !ok {
+    matrix.s.insert(p, q, k, NullComplex128())
+  }
+  return matrix.s.values[k]
+}
+// Copy all elements with major index p into a sparse vector.
+func (matrix *CscComplex128Matrix) majorVector(p int) *SparseComplex128Vector {
+  p0, p1, q0, q1 := matrix.storageRange()
+  if p < 0 || p >= p1 - p0 {
+    panic("index out of bounds")
+  }
+  v := nilSparseComplex128Vector(q1-q0)
+  lo, hi := matrix.s.span(p0+p, q0, q1)
+  for k := lo; k < hi; k++ {
+    v.AT(matrix.s.indices[k]-q0).Set(matrix.s.values[k])
+  }
+  return v
+}
+// Copy all elements with minor index q into a sparse vector.
+func (matrix *CscComplex128Matrix) minorVector(q int) *SparseComplex128Vector {
+  p0, p1, q0, q1 := matrix.storageRange()
+  if q < 0 || q >= q1 - q0 {
+    panic("index out of bounds")
+  }
+  v := nilSparseComplex128Vector(p1-p0)
+  for p := p0; p < p1; p++ {
+    if k, ok := matrix.s.find(p, q0+q); ok {
+      v.AT(p-p0).Set(matrix.s.values[k])
+    }
+  }
+  return v
+}
+func (matrix *CscComplex128Matrix) ROW(i int) *SparseComplex128Vector {
+  return matrix.minorVector(i)
+}
+func (matrix *CscComplex128Matrix) COL(j int) *SparseComplex128Vector {
+  return matrix.majorVector(j)
+}
+func (matrix *CscComplex128Matrix) DIAG() *SparseComplex128Vector {
+  n, m := matrix.Dims()
+  if n != m {
+    panic("Diag(): not a square matrix!")
+  }
+  v := nilSparseComplex128Vector(n)
+  for i := 0; i < n; i++ {
+    p, q := matrix.storageIndex(i, i)
+    if k, ok := matrix.s.find(p, q); ok {
+      v.AT(i).Set(matrix.s.values[k])
+    }
+  }
+  return v
+}
+func (matrix *CscComplex128Matrix) SLICE(rfrom, rto, cfrom, cto int) *CscComplex128Matrix {
+  m := *matrix
+  m.rowOffset += rfrom
+  m.rows = rto - rfrom
+  m.colOffset += cfrom
+  m.cols = cto - cfrom
+  return &m
+}
+// Returns all stored elements as a sparse vector. The elements of the
+// vector refer to the same scalars as the matrix.
+func (matrix *CscComplex128Matrix) AsSparseComplex128Vector() *SparseComplex128Vector {
+  v := nilSparseComplex128Vector(matrix.rows*matrix.cols)
+  matrix.forEach(func(i, j int, s Complex128) {
+    v.values[i*matrix.cols + j] = s
+    v.indexInsert(i*matrix.cols + j)
+  })
+  return v
+}
+/* matrix interface
+ * -------------------------------------------------------------------------- */
+func (matrix *CscComplex128Matrix) CloneMatrix() Matrix {
+  return matrix.Clone()
+}
+func (matrix *CscComplex128Matrix) At(i, j int) Scalar {
+  return matrix.AT(i, j)
+}
+func (a *CscComplex128Matrix) Set(b ConstMatrix) {
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n2 || m1 != m2 {
+    panic("Copy(): Matrix dimension does not match!")
+  }
+  e := elementsCscComplex128Matrix{}
+  for it := b.ConstIterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    v := NullComplex128()
+    v.Set(it.GetConst())
+    e.add(i, j, v)
+  }
+  a.assign(&e)
+}
+func (matrix *CscComplex128Matrix) SetIdentity() {
+  n, m := matrix.Dims()
+  e := elementsCscComplex128Matrix{}
+  for i := 0; i < n && i < m; i++ {
+    e.add(i, i, NewComplex128(1))
+  }
+  matrix.assign(&e)
+}
+// Set all elements to zero. The storage is released.
+func (matrix *CscComplex128Matrix) Reset() {
+  matrix.assign(&elementsCscComplex128Matrix{})
+}
+func (matrix *CscComplex128Matrix) Row(i int) Vector {
+  return matrix.ROW(i)
+}
+func (matrix *CscComplex128Matrix) Col(j int) Vector {
+  return matrix.COL(j)
+}
+func (matrix *CscComplex128Matrix) Diag() Vector {
+  return matrix.DIAG()
+}
+func (matrix *CscComplex128Matrix) Slice(rfrom, rto, cfrom, cto int) Matrix {
+  return matrix.SLICE(rfrom, rto, cfrom, cto)
+}
+func (matrix *CscComplex128Matrix) Swap(i1, j1, i2, j2 int) {
+  s1 := matrix.AT(i1, j1)
+  s2 := matrix.AT(i2, j2)
+  t := s1.Clone()
+  s1.SET(s2)
+  s2.SET(t)
+}
+func (matrix *CscComplex128Matrix) T() Matrix {
+  s := matrix.compact().transpose()
+  for k, v := range s.values {
+    s.values[k] = v.Clone()
+  }
+  return &CscComplex128Matrix{
+    s : s,
+    rows : matrix.cols,
+    cols : matrix.rows }
+}
+func (matrix *CscComplex128Matrix) Tip() {
+  matrix.s = matrix.compact().transpose()
+  matrix.rows , matrix.cols = matrix.cols, matrix.rows
+  matrix.rowOffset , matrix.colOffset = 0, 0
+}
+func (matrix *CscComplex128Matrix) AsVector() Vector {
+  return matrix.AsSparseComplex128Vector()
+}
+func (matrix *CscComplex128Matrix) storageLocation() uintptr {
+  return uintptr(unsafe.Pointer(matrix.s))
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (matrix *CscComplex128Matrix) CloneConstMatrix() ConstMatrix {
+  return matrix.Clone()
+}
+func (matrix *CscComplex128Matrix) Dims() (int, int) {
+  if matrix == nil {
+    return 0, 0
+  } else {
+    return matrix.rows, matrix.cols
+  }
+}
+// Number of stored elements.
+func (matrix *CscComplex128Matrix) Nnz() int {
+  if matrix.isFull() {
+    return len(matrix.s.values)
+  }
+  return len(matrix.compact().values)
+}
+func (matrix *CscComplex128Matrix) Int8At(i, j int) int8 {
+  return matrix.ConstAt(i, j).GetInt8()
+}
+func (matrix *CscComplex128Matrix) Int16At(i, j int) int16 {
+  return matrix.ConstAt(i, j).GetInt16()
+}
+func (matrix *CscComplex128Matrix) Int32At(i, j int) int32 {
+  return matrix.ConstAt(i, j).GetInt32()
+}
+func (matrix *CscComplex128Matrix) Int64At(i, j int) int64 {
+  return matrix.ConstAt(i, j).GetInt64()
+}
+func (matrix *CscComplex128Matrix) IntAt(i, j int) int {
+  return matrix.ConstAt(i, j).GetInt()
+}
+func (matrix *CscComplex128Matrix) Float32At(i, j int) float32 {
+  return matrix.ConstAt(i, j).GetFloat32()
+}
+func (matrix *CscComplex128Matrix) Float64At(i, j int) float64 {
+  return matrix.ConstAt(i, j).GetFloat64()
+}
+// Returns element (i, j) without inserting it if it is not stored.
+func (matrix *CscComplex128Matrix) ConstAt(i, j int) ConstScalar {
+  p, q := matrix.storageIndex(i, j)
+  if k, ok := matrix.s.find(p, q); ok {
+    return matrix.s.values[k]
+  }
+  return ConstComplex128(0)
+}
+func (matrix *CscComplex128Matrix) ConstSlice(rfrom, rto, cfrom, cto int) ConstMatrix {
+  return matrix.Slice(rfrom, rto, cfrom, cto)
+}
+func (matrix *CscComplex128Matrix) ConstRow(i int) ConstVector {
+  return matrix.ROW(i)
+}
+func (matrix *CscComplex128Matrix) ConstCol(i int) ConstVector {
+  return matrix.COL(i)
+}
+func (matrix *CscComplex128Matrix) ConstDiag() ConstVector {
+  return matrix.DIAG()
+}
+func (matrix *CscComplex128Matrix) IsSymmetric(epsilon float64) bool {
+  if n, m := matrix.Dims(); n != m {
+    return false
+  }
+  r := true
+  matrix.forEach(func(i, j int, v Complex128) {
+    if r && !v.Equals(matrix.ConstAt(j, i), epsilon) {
+      r = false
+    }
+  })
+  return r
+}
+func (matrix *CscComplex128Matrix) AsConstVector() ConstVector {
+  return matrix.AsSparseComplex128Vector()
+}
+/* implement ScalarContainer
+ * -------------------------------------------------------------------------- */
+// Apply f to all stored elements.
+func (matrix *CscComplex128Matrix) Map(f func(Scalar)) {
+  matrix.forEach(func(i, j int, v Complex128) {
+    f(v)
+  })
+}
+func (matrix *CscComplex128Matrix) MapSet(f func(ConstScalar) Scalar) {
+  matrix.forEach(func(i, j int, v Complex128) {
+    v.Set(f(v))
+  })
+}
+func (matrix *CscComplex128Matrix) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  matrix.forEach(func(i, j int, v Complex128) {
+    r = f(r, v)
+  })
+  return r
+}
+func (matrix *CscComplex128Matrix) ElementType() ScalarType {
+  return Complex128Type
+}
+/* permutations
+ * -------------------------------------------------------------------------- */
+// Move element (i, j) to (pr[i], pc[j]). A nil slice denotes the identity.
+func (matrix *CscComplex128Matrix) permute(pr, pc []int) {
+  e := elementsCscComplex128Matrix{}
+  matrix.forEach(func(i, j int, v Complex128) {
+    if pr != nil {
+      i = pr[i]
+    }
+    if pc != nil {
+      j = pc[j]
+    }
+    e.add(i, j, v.Clone())
+  })
+  sort.Sort(&e)
+  matrix.assign(&e)
+}
+func (matrix *CscComplex128Matrix) swapPermutation(n, i, j int) []int {
+  pi := make([]int, n)
+  for k := 0; k < n; k++ {
+    pi[k] = k
+  }
+  pi[i], pi[j] = j, i
+  return pi
+}
+func (matrix *CscComplex128Matrix) SwapRows(i, j int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  matrix.permute(matrix.swapPermutation(n, i, j), nil)
+  return nil
+}
+func (matrix *CscComplex128Matrix) SwapColumns(i, j int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  matrix.permute(nil, matrix.swapPermutation(m, i, j))
+  return nil
+}
+func (matrix *CscComplex128Matrix) PermuteRows(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("PermuteRows(): matrix is not a square matrix")
+  }
+  if len(pi) != n {
+    return fmt.Errorf("PermuteRows(): permutation vector has invalid length")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, n)
+  for i := 0; i < n; i++ {
+    if pi[i] < 0 || pi[i] >= n || visited[pi[i]] {
+      return fmt.Errorf("PermuteRows(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that row i is
+  // replaced by row pi[i]
+  for i := 0; i < n; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapRows(j, pi[j])
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+func (matrix *CscComplex128Matrix) PermuteColumns(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("PermuteColumns(): matrix is not a square matrix")
+  }
+  if len(pi) != m {
+    return fmt.Errorf("PermuteColumns(): permutation vector has invalid length")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, m)
+  for i := 0; i < m; i++ {
+    if pi[i] < 0 || pi[i] >= m || visited[pi[i]] {
+      return fmt.Errorf("PermuteColumns(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that column i is
+  // replaced by column pi[i]
+  for i := 0; i < m; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapColumns(j, pi[j])
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+func (matrix *CscComplex128Matrix) SymmetricPermutation(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  for i := 0; i < n; i++ {
+    if pi[i] < 0 || pi[i] > n {
+      return fmt.Errorf("SymmetricPermutation(): invalid permutation")
+    }
+    if pi[i] > i {
+      // permute rows
+      matrix.SwapRows(i, pi[i])
+      // permute colums
+      matrix.SwapColumns(i, pi[i])
+    }
+  }
+  return nil
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (m *CscComplex128Matrix) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString("[")
+  for i := 0; i < m.rows; i++ {
+    if i != 0 {
+      buffer.WriteString(",\n ")
+    }
+    buffer.WriteString("[")
+    for j := 0; j < m.cols; j++ {
+      if j != 0 {
+        buffer.WriteString(", ")
+      }
+      buffer.WriteString(m.ConstAt(i,j).String())
+    }
+    buffer.WriteString("]")
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (a *CscComplex128Matrix) Table() string {
+  var buffer bytes.Buffer
+  n, m := a.Dims()
+  for i := 0; i < n; i++ {
+    if i != 0 {
+      buffer.WriteString("\n")
+    }
+    for j := 0; j < m; j++ {
+      if j != 0 {
+        buffer.WriteString(" ")
+      }
+      buffer.WriteString(a.ConstAt(i,j).String())
+    }
+  }
+  return buffer.String()
+}
+func (m *CscComplex128Matrix) Export(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  w := bufio.NewWriter(f)
+  defer w.Flush()
+  if _, err := fmt.Fprintf(w, "%d %d\n", m.rows, m.cols); err != nil {
+    return err
+  }
+  for it := m.ITERATOR(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    if _, err := fmt.Fprintf(w, "%d %d %v\n", i, j, it.GET()); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+func (m *CscComplex128Matrix) Import(filename string) error {
+  rows := 0
+  cols := 0
+  rowIndices := []int{}
+  colIndices := []int{}
+  values := []complex128{}
+  var reader *bufio.Reader
+  // open file
+  f, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  isgzip, err := isGzip(filename)
+  if err != nil {
+    return err
+  }
+  // check if file is gzipped
+  if isgzip {
+    g, err := gzip.NewReader(f)
+    if err != nil {
+      return err
+    }
+    defer g.Close()
+    reader = bufio.NewReader(g)
+  } else {
+    reader = bufio.NewReader(f)
+  }
+  // scan header
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 2 {
+      return fmt.Errorf("invalid sparse matrix format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      rows = int(v)
+    }
+    if v, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+      return err
+    } else {
+      cols = int(v)
+    }
+    break
+  }
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 3 {
+      return fmt.Errorf("invalid sparse matrix format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      rowIndices = append(rowIndices, int(v))
+    }
+    if v, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+      return err
+    } else {
+      colIndices = append(colIndices, int(v))
+    }
+    if v, err := strconv.ParseFloat(fields[2], 64); err != nil {
+      return err
+    } else {
+      values = append(values, complex128(complex(v, 0)))
+    }
+  }
+  *m = *NewCscComplex128Matrix(rowIndices, colIndices, values, rows, cols)
+  return nil
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj *CscComplex128Matrix) MarshalJSON() ([]byte, error) {
+  s := obj.compact()
+  v := make([]complex128, len(s.values))
+  for k, value := range s.values {
+    v[k] = complex128(value.GetComplex128())
+  }
+  r := struct{Ptr []int; Index []int; Value complex128JsonSlice; Rows int; Cols int}{}
+  r.Ptr = s.ptr
+  r.Index = s.indices
+  r.Value = v
+  r.Rows = obj.rows
+  r.Cols = obj.cols
+  return json.MarshalIndent(r, "", "  ")
+}
+func (obj *CscComplex128Matrix) UnmarshalJSON(data []byte) error {
+  r := struct{Ptr []int; Index []int; Value complex128JsonSlice; Rows int; Cols int}{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  m := NullCscComplex128Matrix(r.Rows, r.Cols)
+  if len(r.Ptr) != len(m.s.ptr) || len(r.Index) != len(r.Value) || r.Ptr[len(r.Ptr)-1] != len(r.Value) {
+    return fmt.Errorf("invalid compressed sparse matrix")
+  }
+  m.s.ptr = r.Ptr
+  m.s.indices = r.Index
+  m.s.values = make([]Complex128, len(r.Value))
+  for k, v := range r.Value {
+    m.s.values[k] = NewComplex128(v)
+  }
+  *obj = *m
+  return nil
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj *CscComplex128Matrix) ConstIterator() MatrixConstIterator {
+  return obj.ITERATOR()
+}
+func (obj *CscComplex128Matrix) ConstIteratorFrom(i, j int) MatrixConstIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+func (obj *CscComplex128Matrix) Iterator() MatrixIterator {
+  return obj.ITERATOR()
+}
+func (obj *CscComplex128Matrix) IteratorFrom(i, j int) MatrixIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+func (obj *CscComplex128Matrix) JointIterator(b ConstMatrix) MatrixJointIterator {
+  return newMatrixJointIterator(obj.ITERATOR(), b.ConstIterator())
+}
+func (obj *CscComplex128Matrix) ITERATOR() *CscComplex128MatrixIterator {
+  return obj.ITERATOR_FROM(0, 0)
+}
+func (obj *CscComplex128Matrix) ITERATOR_FROM(i, j int) *CscComplex128MatrixIterator {
+  r := CscComplex128MatrixIterator{}
+  r.m = obj
+  r.s = obj.rowMajorStorage()
+  r.i = obj.rowOffset + i
+  r.i1 = obj.rowOffset + obj.rows
+  r.j0 = obj.colOffset
+  r.j1 = obj.colOffset + obj.cols
+  if r.i < r.i1 {
+    r.k, r.k1 = r.s.span(r.i, r.j0 + j, r.j1)
+  }
+  r.skip()
+  return &r
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+// The iterator visits all stored elements in row-major order.
+type CscComplex128MatrixIterator struct {
+  m *CscComplex128Matrix
+  // storage in row-major order
+  s *storageCscComplex128Matrix
+  // current row and position
+  i, i1 int
+  k, k1 int
+  // column range
+  j0, j1 int
+}
+// Advance to the next row with stored elements if the current row is
+// exhausted.
+func (obj *CscComplex128MatrixIterator) skip() {
+  for obj.k >= obj.k1 && obj.i < obj.i1 {
+    if obj.i++; obj.i < obj.i1 {
+      obj.k, obj.k1 = obj.s.span(obj.i, obj.j0, obj.j1)
+    }
+  }
+}
+func (obj *CscComplex128MatrixIterator) GetConst() ConstScalar {
+  return obj.s.values[obj.k]
+}
+func (obj *CscComplex128MatrixIterator) Get() Scalar {
+  return obj.s.values[obj.k]
+}
+func (obj *CscComplex128MatrixIterator) GET() Complex128 {
+  return obj.s.values[obj.k]
+}
+func (obj *CscComplex128MatrixIterator) Ok() bool {
+  return obj.i < obj.i1
+}
+func (obj *CscComplex128MatrixIterator) Next() {
+  obj.k++
+  obj.skip()
+}
+func (obj *CscComplex128MatrixIterator) Index() (int, int) {
+  return obj.i - obj.m.rowOffset, obj.s.indices[obj.k] - obj.m.colOffset
+}
+func (obj *CscComplex128MatrixIterator) Clone() *CscComplex128MatrixIterator {
+  r := *obj
+  return &r
+}
+func (obj *CscComplex128MatrixIterator) CloneConstIterator() MatrixConstIterator {
+  return obj.Clone()
+}
+func (obj *CscComplex128MatrixIterator) CloneIterator() MatrixIterator {
+  return obj.Clone()
+}
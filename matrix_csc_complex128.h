@@ -0,0 +1,19 @@
+
+#define STORE_PTR 1
+#define COLUMN_MAJOR 1
+
+#define CONST_SCALAR_NAME ConstComplex128
+#define   GET_METHOD_NAME GetComplex128
+#define   SET_METHOD_NAME SetComplex128
+#define       SCALAR_NAME Complex128
+#define       MATRIX_NAME CscComplex128Matrix
+#define       VECTOR_NAME SparseComplex128Vector
+
+#define       STORED_TYPE complex128
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
+
+#define COMPLEX 1
+#define STORED_JSON_SLICE complex128JsonSlice
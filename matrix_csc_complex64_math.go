@@ -0,0 +1,399 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+import "sort"
+/* -------------------------------------------------------------------------- */
+// True if matrix a equals b.
+func (a *CscComplex64Matrix) Equals(b ConstMatrix, epsilon float64) bool {
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n2 || m1 != m2 {
+    panic("MEqual(): matrix dimensions do not match!")
+  }
+  for it := a.JointIterator(b); it.Ok(); it.Next() {
+    s1, s2 := it.GetConst()
+    if s1 == nil {
+      s1 = ConstComplex64(0)
+    }
+    if !s1.Equals(s2, epsilon) {
+      return false
+    }
+  }
+  return true
+}
+/* -------------------------------------------------------------------------- */
+// Element-wise addition of two matrices. The result is stored in r.
+func (r *CscComplex64Matrix) MaddM(a, b ConstMatrix) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n || m1 != m || n2 != n || m2 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for it := newMatrixJointIterator(a.ConstIterator(), b.ConstIterator()); it.Ok(); it.Next() {
+    s_a, s_b := it.GetConst()
+    if s_a == nil {
+      s_a = ConstComplex64(0)
+    }
+    s_r := NullComplex64()
+    s_r.Add(s_a, s_b)
+    e.add(it.i, it.j, s_r)
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Add scalar b to all elements of a. The result is stored in r.
+func (r *CscComplex64Matrix) MaddS(a ConstMatrix, b ConstScalar) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  if n1 != n || m1 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      s_r := NullComplex64()
+      s_r.Add(a.ConstAt(i, j), b)
+      e.add(i, j, s_r)
+    }
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Element-wise substraction of two matrices. The result is stored in r.
+func (r *CscComplex64Matrix) MsubM(a, b ConstMatrix) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n || m1 != m || n2 != n || m2 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for it := newMatrixJointIterator(a.ConstIterator(), b.ConstIterator()); it.Ok(); it.Next() {
+    s_a, s_b := it.GetConst()
+    if s_a == nil {
+      s_a = ConstComplex64(0)
+    }
+    s_r := NullComplex64()
+    s_r.Sub(s_a, s_b)
+    e.add(it.i, it.j, s_r)
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Substract b from all elements of a. The result is stored in r.
+func (r *CscComplex64Matrix) MsubS(a ConstMatrix, b ConstScalar) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  if n1 != n || m1 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      s_r := NullComplex64()
+      s_r.Sub(a.ConstAt(i, j), b)
+      e.add(i, j, s_r)
+    }
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Element-wise multiplication of two matrices. The result is stored in r.
+func (r *CscComplex64Matrix) MmulM(a, b ConstMatrix) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n || m1 != m || n2 != n || m2 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for it := newMatrixJointIterator(a.ConstIterator(), b.ConstIterator()); it.Ok(); it.Next() {
+    s_a, s_b := it.GetConst()
+    if s_a != nil {
+      s_r := NullComplex64()
+      s_r.Mul(s_a, s_b)
+      e.add(it.i, it.j, s_r)
+    }
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Multiply all elements of a with b. The result is stored in r.
+func (r *CscComplex64Matrix) MmulS(a ConstMatrix, b ConstScalar) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  if n1 != n || m1 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    s_r := NullComplex64()
+    s_r.Mul(it.GetConst(), b)
+    e.add(i, j, s_r)
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Element-wise division of two matrices. The result is stored in r.
+func (r *CscComplex64Matrix) MdivM(a, b ConstMatrix) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n || m1 != m || n2 != n || m2 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      s_r := NullComplex64()
+      s_r.Div(a.ConstAt(i, j), b.ConstAt(i, j))
+      e.add(i, j, s_r)
+    }
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Divide all elements of a by b. The result is stored in r.
+func (r *CscComplex64Matrix) MdivS(a ConstMatrix, b ConstScalar) Matrix {
+  n, m := r.Dims()
+  n1, m1 := a.Dims()
+  if n1 != n || m1 != m {
+    panic("matrix dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  if b.GetComplex64() == complex64(0) {
+    for i := 0; i < n; i++ {
+      for j := 0; j < m; j++ {
+        s_r := NullComplex64()
+        s_r.Div(a.ConstAt(i, j), b)
+        e.add(i, j, s_r)
+      }
+    }
+  } else {
+    for it := a.ConstIterator(); it.Ok(); it.Next() {
+      i, j := it.Index()
+      s_r := NullComplex64()
+      s_r.Div(it.GetConst(), b)
+      e.add(i, j, s_r)
+    }
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Matrix product of a and b. The result is stored in r. Both matrices are
+// accessed through their iterators, which must visit elements in row-major
+// order. The product is computed row by row with a dense accumulator
+// (Gustavson's algorithm), hence r may share its storage with a or b.
+func (r *CscComplex64Matrix) MdotM(a, b ConstMatrix) Matrix {
+  n , m := r.Dims()
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n || m2 != m || m1 != n2 {
+    panic("matrix dimensions do not match!")
+  }
+  // collect rows of b
+  bj := make([][]int, n2)
+  bv := make([][]ConstScalar, n2)
+  for it := b.ConstIterator(); it.Ok(); it.Next() {
+    p, q := it.Index()
+    bj[p] = append(bj[p], q)
+    bv[p] = append(bv[p], it.GetConst())
+  }
+  e := elementsCscComplex64Matrix{}
+  t := NullComplex64()
+  // accumulator for the current row, which is only valid at
+  // positions q where marker[q] equals the current row
+  acc := make([]Complex64, m)
+  marker := make([]int, m)
+  cols := []int{}
+  for q := 0; q < m; q++ {
+    marker[q] = -1
+  }
+  flush := func(i int) {
+    sort.Ints(cols)
+    for _, q := range cols {
+      e.add(i, q, acc[q])
+    }
+    cols = cols[0:0]
+  }
+  i0 := -1
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    i, k := it.Index()
+    if i != i0 {
+      if i0 >= 0 {
+        flush(i0)
+      }
+      i0 = i
+    }
+    s_a := it.GetConst()
+    for l, q := range bj[k] {
+      if marker[q] != i {
+        marker[q] = i
+        acc [q] = NullComplex64()
+        acc [q].Mul(s_a, bv[k][l])
+        cols = append(cols, q)
+      } else {
+        t.Mul(s_a, bv[k][l])
+        acc[q].Add(acc[q], t)
+      }
+    }
+  }
+  if i0 >= 0 {
+    flush(i0)
+  }
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Compute r = A b, which requires O(nnz) operations.
+func (a *CscComplex64Matrix) mdotv(r Vector, b ConstVector) {
+  t := NullScalar(r.ElementType())
+  r.Reset()
+  a.forEach(func(i, j int, v Complex64) {
+    s := r.At(i)
+    t.Mul(v, b.ConstAt(j))
+    s.Add(s, t)
+  })
+}
+// Compute r = a^T A, which requires O(nnz) operations.
+func (b *CscComplex64Matrix) vdotm(r Vector, a ConstVector) {
+  t := NullScalar(r.ElementType())
+  r.Reset()
+  b.forEach(func(i, j int, v Complex64) {
+    s := r.At(j)
+    t.Mul(a.ConstAt(i), v)
+    s.Add(s, t)
+  })
+}
+// Compute r = A b for a dense matrix r, which requires O(nnz m)
+// operations where m is the number of columns of b.
+func (a *CscComplex64Matrix) mdotm(r Matrix, b ConstMatrix) {
+  _, m := b.Dims()
+  t := NullScalar(r.ElementType())
+  r.Reset()
+  a.forEach(func(i, k int, v Complex64) {
+    for q := 0; q < m; q++ {
+      s := r.At(i, q)
+      t.Mul(v, b.ConstAt(k, q))
+      s.Add(s, t)
+    }
+  })
+}
+// Compute r = a B for a dense matrix r, which requires O(nnz n)
+// operations where n is the number of rows of a.
+func (b *CscComplex64Matrix) mdotmLeft(r Matrix, a ConstMatrix) {
+  n, _ := a.Dims()
+  t := NullScalar(r.ElementType())
+  r.Reset()
+  b.forEach(func(k, q int, v Complex64) {
+    for i := 0; i < n; i++ {
+      s := r.At(i, q)
+      t.Mul(a.ConstAt(i, k), v)
+      s.Add(s, t)
+    }
+  })
+}
+/* -------------------------------------------------------------------------- */
+// Outer product of two vectors. The result is stored in r.
+func (r *CscComplex64Matrix) Outer(a, b ConstVector) Matrix {
+  n, m := r.Dims()
+  if a.Dim() != n || b.Dim() != m {
+    panic("matrix/vector dimensions do not match!")
+  }
+  e := elementsCscComplex64Matrix{}
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    i := it.Index()
+    p := it.GetConst()
+    for is := b.ConstIterator(); is.Ok(); is.Next() {
+      j := is.Index()
+      q := is.GetConst()
+      s := NullComplex64()
+      s.Mul(p, q)
+      e.add(i, j, s)
+    }
+  }
+  sort.Sort(&e)
+  r.assign(&e)
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Compute the Jacobian of f at x_. The result is stored in r.
+func (r *CscComplex64Matrix) Jacobian(f func(ConstVector) ConstVector, x_ MagicVector) Matrix {
+  n, m := r.Dims()
+  x := x_.CloneMagicVector()
+  x.Variables(1)
+  // compute Jacobian
+  y := f(x)
+  // reallocate matrix if dimensions do not match
+  if r == nil || x.Dim() != m || y.Dim() != n {
+     n = y.Dim()
+     m = x.Dim()
+    *r = *NullCscComplex64Matrix(n, m)
+  }
+  // copy derivatives
+  e := elementsCscComplex64Matrix{}
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      if s := y.ConstAt(i).GetDerivative(j); s != 0.0 {
+        e.add(i, j, NewComplex64(complex64(complex(s, 0))))
+      }
+    }
+  }
+  r.assign(&e)
+  return r
+}
+// Compute the Hessian of f at x_. The result is stored in r.
+func (r *CscComplex64Matrix) Hessian(f func(ConstVector) ConstScalar, x_ MagicVector) Matrix {
+  n, m := r.Dims()
+  // reallocate matrix if dimensions do not match
+  if r == nil || x_.Dim() != n || n != m {
+     n = x_.Dim()
+     m = x_.Dim()
+    *r = *NullCscComplex64Matrix(n, m)
+  }
+  x := x_.CloneMagicVector()
+  x.Variables(2)
+  // evaluate function
+  y := f(x)
+  // copy second derivatives
+  e := elementsCscComplex64Matrix{}
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      if s := y.GetHessian(i, j); s != 0.0 {
+        e.add(i, j, NewComplex64(complex64(complex(s, 0))))
+      }
+    }
+  }
+  r.assign(&e)
+  return r
+}
@@ -0,0 +1,16 @@
+
+#define STORE_PTR 1
+#define COLUMN_MAJOR 1
+
+#define CONST_SCALAR_NAME ConstFloat32
+#define   GET_METHOD_NAME GetFloat32
+#define   SET_METHOD_NAME SetFloat32
+#define       SCALAR_NAME Float32
+#define       MATRIX_NAME CscFloat32Matrix
+#define       VECTOR_NAME SparseFloat32Vector
+
+#define       STORED_TYPE float32
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
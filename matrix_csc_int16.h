@@ -0,0 +1,16 @@
+
+#define STORE_PTR 1
+#define COLUMN_MAJOR 1
+
+#define CONST_SCALAR_NAME ConstInt16
+#define   GET_METHOD_NAME GetInt16
+#define   SET_METHOD_NAME SetInt16
+#define       SCALAR_NAME Int16
+#define       MATRIX_NAME CscInt16Matrix
+#define       VECTOR_NAME SparseInt16Vector
+
+#define       STORED_TYPE int16
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
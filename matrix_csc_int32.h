@@ -0,0 +1,16 @@
+
+#define STORE_PTR 1
+#define COLUMN_MAJOR 1
+
+#define CONST_SCALAR_NAME ConstInt32
+#define   GET_METHOD_NAME GetInt32
+#define   SET_METHOD_NAME SetInt32
+#define       SCALAR_NAME Int32
+#define       MATRIX_NAME CscInt32Matrix
+#define       VECTOR_NAME SparseInt32Vector
+
+#define       STORED_TYPE int32
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
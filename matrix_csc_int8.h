@@ -0,0 +1,16 @@
+
+#define STORE_PTR 1
+#define COLUMN_MAJOR 1
+
+#define CONST_SCALAR_NAME ConstInt8
+#define   GET_METHOD_NAME GetInt8
+#define   SET_METHOD_NAME SetInt8
+#define       SCALAR_NAME Int8
+#define       MATRIX_NAME CscInt8Matrix
+#define       VECTOR_NAME SparseInt8Vector
+
+#define       STORED_TYPE int8
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
@@ -0,0 +1,79 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+/* magic interface
+ * -------------------------------------------------------------------------- */
+func (matrix *CscReal64Matrix) CloneMagicMatrix() MagicMatrix {
+  return matrix.Clone()
+}
+func (matrix *CscReal64Matrix) MagicAt(i, j int) MagicScalar {
+  return matrix.AT(i, j)
+}
+func (matrix *CscReal64Matrix) MagicSlice(rfrom, rto, cfrom, cto int) MagicMatrix {
+  return matrix.SLICE(rfrom, rto, cfrom, cto)
+}
+// Returns the transposed matrix. Stored elements refer to the same
+// scalars as the original matrix.
+func (matrix *CscReal64Matrix) MagicT() MagicMatrix {
+  return &CscReal64Matrix{
+    s : matrix.compact().transpose(),
+    rows : matrix.cols,
+    cols : matrix.rows }
+}
+func (matrix *CscReal64Matrix) ResetDerivatives() {
+  matrix.forEach(func(i, j int, v *Real64) {
+    v.ResetDerivatives()
+  })
+}
+func (matrix *CscReal64Matrix) AsMagicVector() MagicVector {
+  return matrix.AsSparseReal64Vector()
+}
+/* implement MagicScalarContainer
+ * -------------------------------------------------------------------------- */
+// Treat all stored elements as variables for automatic differentiation. The
+// variable index of element (i, j) is i*m + j, where m is the number of
+// columns.
+func (matrix *CscReal64Matrix) Variables(order int) error {
+  var err error
+  matrix.forEach(func(i, j int, v *Real64) {
+    if err == nil {
+      err = v.SetVariable(i*matrix.cols + j, matrix.rows*matrix.cols, order)
+    }
+  })
+  return err
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj *CscReal64Matrix) MagicIterator() MatrixMagicIterator {
+  return obj.ITERATOR()
+}
+func (obj *CscReal64Matrix) MagicIteratorFrom(i, j int) MatrixMagicIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+func (obj *CscReal64MatrixIterator) GetMagic() MagicScalar {
+  return obj.s.values[obj.k]
+}
+func (obj *CscReal64MatrixIterator) CloneMagicIterator() MatrixMagicIterator {
+  return obj.Clone()
+}
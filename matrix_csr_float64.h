@@ -0,0 +1,15 @@
+
+#define STORE_PTR 1
+
+#define CONST_SCALAR_NAME ConstFloat64
+#define   GET_METHOD_NAME GetFloat64
+#define   SET_METHOD_NAME SetFloat64
+#define       SCALAR_NAME Float64
+#define       MATRIX_NAME CsrFloat64Matrix
+#define       VECTOR_NAME SparseFloat64Vector
+
+#define       STORED_TYPE float64
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
@@ -0,0 +1,15 @@
+
+#define STORE_PTR 1
+
+#define CONST_SCALAR_NAME ConstInt
+#define   GET_METHOD_NAME GetInt
+#define   SET_METHOD_NAME SetInt
+#define       SCALAR_NAME Int
+#define       MATRIX_NAME CsrIntMatrix
+#define       VECTOR_NAME SparseIntVector
+
+#define       STORED_TYPE int
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
@@ -0,0 +1,15 @@
+
+#define STORE_PTR 1
+
+#define CONST_SCALAR_NAME ConstInt64
+#define   GET_METHOD_NAME GetInt64
+#define   SET_METHOD_NAME SetInt64
+#define       SCALAR_NAME Int64
+#define       MATRIX_NAME CsrInt64Matrix
+#define       VECTOR_NAME SparseInt64Vector
+
+#define       STORED_TYPE int64
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE      *MATRIX_NAME
+#define       VECTOR_TYPE      *VECTOR_NAME
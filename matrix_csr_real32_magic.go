@@ -0,0 +1,79 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+/* magic interface
+ * -------------------------------------------------------------------------- */
+func (matrix *CsrReal32Matrix) CloneMagicMatrix() MagicMatrix {
+  return matrix.Clone()
+}
+func (matrix *CsrReal32Matrix) MagicAt(i, j int) MagicScalar {
+  return matrix.AT(i, j)
+}
+func (matrix *CsrReal32Matrix) MagicSlice(rfrom, rto, cfrom, cto int) MagicMatrix {
+  return matrix.SLICE(rfrom, rto, cfrom, cto)
+}
+// Returns the transposed matrix. Stored elements refer to the same
+// scalars as the original matrix.
+func (matrix *CsrReal32Matrix) MagicT() MagicMatrix {
+  return &CsrReal32Matrix{
+    s : matrix.compact().transpose(),
+    rows : matrix.cols,
+    cols : matrix.rows }
+}
+func (matrix *CsrReal32Matrix) ResetDerivatives() {
+  matrix.forEach(func(i, j int, v *Real32) {
+    v.ResetDerivatives()
+  })
+}
+func (matrix *CsrReal32Matrix) AsMagicVector() MagicVector {
+  return matrix.AsSparseReal32Vector()
+}
+/* implement MagicScalarContainer
+ * -------------------------------------------------------------------------- */
+// Treat all stored elements as variables for automatic differentiation. The
+// variable index of element (i, j) is i*m + j, where m is the number of
+// columns.
+func (matrix *CsrReal32Matrix) Variables(order int) error {
+  var err error
+  matrix.forEach(func(i, j int, v *Real32) {
+    if err == nil {
+      err = v.SetVariable(i*matrix.cols + j, matrix.rows*matrix.cols, order)
+    }
+  })
+  return err
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj *CsrReal32Matrix) MagicIterator() MatrixMagicIterator {
+  return obj.ITERATOR()
+}
+func (obj *CsrReal32Matrix) MagicIteratorFrom(i, j int) MatrixMagicIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+func (obj *CsrReal32MatrixIterator) GetMagic() MagicScalar {
+  return obj.s.values[obj.k]
+}
+func (obj *CsrReal32MatrixIterator) CloneMagicIterator() MatrixMagicIterator {
+  return obj.Clone()
+}
@@ -0,0 +1,17 @@
+#define STORE_PTR 1
+
+#define CONST_SCALAR_NAME ConstComplex64
+#define       SCALAR_NAME Complex64
+#define   GET_METHOD_NAME GetComplex64
+#define   SET_METHOD_NAME SetComplex64
+#define       MATRIX_NAME DenseComplex64Matrix
+#define       VECTOR_NAME DenseComplex64Vector
+
+#define       STORED_TYPE complex64
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE  SCALAR_NAME
+#define       MATRIX_TYPE *MATRIX_NAME
+#define       VECTOR_TYPE  VECTOR_NAME
+
+#define COMPLEX 1
+#define STORED_JSON_SLICE complex64JsonSlice
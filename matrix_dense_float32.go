@@ -387,15 +387,29 @@ func (matrix *DenseFloat32Matrix) SwapColumns(i, j int) error {
 func (matrix *DenseFloat32Matrix) PermuteRows(pi []int) error {
   n, m := matrix.Dims()
   if n != m {
-    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+    return fmt.Errorf("PermuteRows(): matrix is not a square matrix")
+  }
+  if len(pi) != n {
+    return fmt.Errorf("PermuteRows(): permutation vector has invalid length")
   }
-  // permute matrix
+  // check that pi is a permutation
+  visited := make([]bool, n)
   for i := 0; i < n; i++ {
-    if pi[i] < 0 || pi[i] > n {
-      return fmt.Errorf("SymmetricPermutation(): invalid permutation")
+    if pi[i] < 0 || pi[i] >= n || visited[pi[i]] {
+      return fmt.Errorf("PermuteRows(): invalid permutation")
     }
-    if i != pi[i] && pi[i] > i {
-      matrix.SwapRows(i, pi[i])
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that row i is
+  // replaced by row pi[i]
+  for i := 0; i < n; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapRows(j, pi[j])
+      visited[pi[j]] = false
     }
   }
   return nil
@@ -403,15 +417,29 @@ func (matrix *DenseFloat32Matrix) PermuteRows(pi []int) error {
 func (matrix *DenseFloat32Matrix) PermuteColumns(pi []int) error {
   n, m := matrix.Dims()
   if n != m {
-    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+    return fmt.Errorf("PermuteColumns(): matrix is not a square matrix")
+  }
+  if len(pi) != m {
+    return fmt.Errorf("PermuteColumns(): permutation vector has invalid length")
   }
-  // permute matrix
+  // check that pi is a permutation
+  visited := make([]bool, m)
   for i := 0; i < m; i++ {
-    if pi[i] < 0 || pi[i] > n {
-      return fmt.Errorf("SymmetricPermutation(): invalid permutation")
+    if pi[i] < 0 || pi[i] >= m || visited[pi[i]] {
+      return fmt.Errorf("PermuteColumns(): invalid permutation")
     }
-    if i != pi[i] && pi[i] > i {
-      matrix.SwapColumns(i, pi[i])
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that column i is
+  // replaced by column pi[i]
+  for i := 0; i < m; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapColumns(j, pi[j])
+      visited[pi[j]] = false
     }
   }
   return nil
@@ -560,7 +588,7 @@ func (a *DenseFloat32Matrix) UnmarshalJSON(data []byte) error {
   if err := json.Unmarshal(data, &r); err != nil {
     return err
   }
-  a.values = r.Values
+  a.values = []float32(r.Values)
   a.rows = r.Rows
   a.rowMax = r.Rows
   a.rowOffset = 0
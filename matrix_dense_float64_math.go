@@ -293,6 +293,14 @@ func (r *DenseFloat64Matrix) MdotM(a, b ConstMatrix) Matrix {
   if n1 != n || m2 != m || m1 != n2 {
     panic("matrix dimensions do not match!")
   }
+  if a, ok := a.(compressedConstMatrix); ok && r.storageLocation() != b.storageLocation() {
+    a.mdotm(r, b)
+    return r
+  }
+  if b, ok := b.(compressedConstMatrix); ok && r.storageLocation() != a.storageLocation() {
+    b.mdotmLeft(r, a)
+    return r
+  }
   t1 := float64(0)
   t2 := float64(0)
   if r.storageLocation() == b.storageLocation() {
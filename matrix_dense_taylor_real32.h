@@ -0,0 +1,15 @@
+
+#define STORE_PTR 1
+
+#define CONST_SCALAR_NAME ConstFloat32
+#define       SCALAR_NAME TaylorReal32
+#define   GET_METHOD_NAME GetFloat32
+#define   SET_METHOD_NAME SetFloat32
+#define       MATRIX_NAME DenseTaylorReal32Matrix
+#define       VECTOR_NAME DenseTaylorReal32Vector
+
+#define       STORED_TYPE float32
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE *SCALAR_NAME
+#define       MATRIX_TYPE *MATRIX_NAME
+#define       VECTOR_TYPE  VECTOR_NAME
@@ -401,3 +401,30 @@ func TestMatrixTransposeInPlace(t *testing.T) {
     t.Error("test failed")
   }
 }
+
+func TestMatrixPermute(t *testing.T) {
+
+  m1 := NewDenseFloat64Matrix([]float64{1,2,3,4,5,6,7,8,9}, 3, 3)
+  m2 := NewDenseFloat64Matrix([]float64{1,2,3,4,5,6,7,8,9}, 3, 3)
+  // pi is a cycle and not a product of disjoint transpositions
+  pi := []int{2, 0, 1}
+
+  if err := m1.PermuteColumns(pi); err != nil {
+    t.Error(err)
+  }
+  if !m1.Equals(NewDenseFloat64Matrix([]float64{3,1,2,6,4,5,9,7,8}, 3, 3), 1e-12) {
+    t.Error("test failed")
+  }
+  if err := m2.PermuteRows(pi); err != nil {
+    t.Error(err)
+  }
+  if !m2.Equals(NewDenseFloat64Matrix([]float64{7,8,9,1,2,3,4,5,6}, 3, 3), 1e-12) {
+    t.Error("test failed")
+  }
+  if err := m1.PermuteColumns([]int{0, 0, 1}); err == nil {
+    t.Error("test failed")
+  }
+  if err := m1.PermuteColumns([]int{0, 1, 3}); err == nil {
+    t.Error("test failed")
+  }
+}
@@ -0,0 +1,895 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bytes"
+import "bufio"
+import "compress/gzip"
+import "encoding/json"
+import "io"
+import "os"
+import "strconv"
+import "strings"
+import "unsafe"
+/* matrix type declaration
+ * -------------------------------------------------------------------------- */
+type SparseComplex128Matrix struct {
+  values *SparseComplex128Vector
+  rows int
+  cols int
+  rowOffset int
+  rowMax int
+  colOffset int
+  colMax int
+  tmp1 *SparseComplex128Vector
+  tmp2 *SparseComplex128Vector
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+func NewSparseComplex128Matrix(rowIndices, colIndices []int, values []complex128, rows, cols int) *SparseComplex128Matrix {
+  m := NullSparseComplex128Matrix(rows, cols)
+  if len(rowIndices) != len(colIndices) || len(colIndices) != len(values) {
+    panic("number of row/col-indices does not match number of values")
+  }
+  for i := 0; i < len(colIndices); i++ {
+    j1 := rowIndices[i]
+    j2 := colIndices[i]
+    if values[i] != 0.0 {
+      m.At(j1, j2).SetComplex128(values[i])
+    }
+  }
+  return m
+}
+func NullSparseComplex128Matrix(rows, cols int) *SparseComplex128Matrix {
+  m := SparseComplex128Matrix{}
+  m.values = NullSparseComplex128Vector(rows*cols)
+  m.rows = rows
+  m.cols = cols
+  m.rowOffset = 0
+  m.rowMax = rows
+  m.colOffset = 0
+  m.colMax = cols
+  m.initTmp()
+  return &m
+}
+func nilSparseComplex128Matrix(rows, cols int) *SparseComplex128Matrix {
+  m := SparseComplex128Matrix{}
+  m.values = nilSparseComplex128Vector(rows*cols)
+  m.rows = rows
+  m.cols = cols
+  m.rowOffset = 0
+  m.rowMax = rows
+  m.colOffset = 0
+  m.colMax = cols
+  return &m
+}
+func AsSparseComplex128Matrix(matrix ConstMatrix) *SparseComplex128Matrix {
+  switch matrix_ := matrix.(type) {
+  case *SparseComplex128Matrix:
+    return matrix_.Clone()
+  }
+  n, m := matrix.Dims()
+  r := NullSparseComplex128Matrix(n, m)
+  for it := matrix.ConstIterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    r.AT(i,j).Set(it.GetConst())
+  }
+  return r
+}
+func (matrix *SparseComplex128Matrix) initTmp() {
+  if matrix.tmp1 == nil || matrix.tmp1.Dim() < matrix.rows {
+    matrix.tmp1 = NullSparseComplex128Vector(matrix.rows)
+  } else {
+    matrix.tmp1 = matrix.tmp1.Slice(0, matrix.rows).(*SparseComplex128Vector)
+  }
+  if matrix.tmp2 == nil || matrix.tmp2.Dim() < matrix.cols {
+    matrix.tmp2 = NullSparseComplex128Vector(matrix.cols)
+  } else {
+    matrix.tmp2 = matrix.tmp2.Slice(0, matrix.cols).(*SparseComplex128Vector)
+  }
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+// Clone matrix including data.
+func (matrix *SparseComplex128Matrix) Clone() *SparseComplex128Matrix {
+  return &SparseComplex128Matrix{
+    values : matrix.values.Clone(),
+    rows : matrix.rows,
+    cols : matrix.cols,
+    rowOffset : matrix.rowOffset,
+    rowMax : matrix.rowMax,
+    colOffset : matrix.colOffset,
+    colMax : matrix.colMax,
+    tmp1 : matrix.tmp1.Clone(),
+    tmp2 : matrix.tmp2.Clone() }
+}
+/* indexing
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) index(i, j int) int {
+  if i < 0 || j < 0 || i >= matrix.rows || j >= matrix.cols {
+    panic(fmt.Errorf("index (%d,%d) out of bounds for matrix of dimension %dx%d", i, j, matrix.rows, matrix.cols))
+  }
+  return (matrix.rowOffset + i)*matrix.colMax + (matrix.colOffset + j)
+}
+func (matrix *SparseComplex128Matrix) ij(k int) (int, int) {
+  i := (k/matrix.colMax) - matrix.rowOffset
+  j := (k%matrix.colMax) - matrix.colOffset
+  return i, j
+}
+/* native matrix methods
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) AT(i, j int) Complex128 {
+  return matrix.values.AT(matrix.index(i, j))
+}
+func (matrix *SparseComplex128Matrix) ROW(i int) *SparseComplex128Vector {
+  var v *SparseComplex128Vector
+  v = nilSparseComplex128Vector(matrix.cols)
+  for j := 0; j < matrix.cols; j++ {
+    if s := matrix.values.AT_(matrix.index(i, j)); !s.nullScalar() {
+      v.AT(j).SET(s)
+    }
+  }
+  return v
+}
+func (matrix *SparseComplex128Matrix) COL(j int) *SparseComplex128Vector {
+  var v *SparseComplex128Vector
+  v = nilSparseComplex128Vector(matrix.rows)
+  for i := 0; i < matrix.rows; i++ {
+    if s := matrix.values.AT_(matrix.index(i, j)); !s.nullScalar() {
+      v.AT(i).SET(s)
+    }
+  }
+  return v
+}
+func (matrix *SparseComplex128Matrix) DIAG() *SparseComplex128Vector {
+  n, m := matrix.Dims()
+  if n != m {
+    panic("Diag(): not a square matrix!")
+  }
+  v := nilSparseComplex128Vector(n)
+  for i := 0; i < n; i++ {
+    if s := matrix.values.AT_(matrix.index(i, i)); !s.nullScalar() {
+      v.AT(i).SET(s)
+    }
+  }
+  return v
+}
+func (matrix *SparseComplex128Matrix) SLICE(rfrom, rto, cfrom, cto int) *SparseComplex128Matrix {
+  m := *matrix
+  m.rowOffset += rfrom
+  m.rows = rto - rfrom
+  m.colOffset += cfrom
+  m.cols = cto - cfrom
+  // crop tmp vectors
+  m.initTmp()
+  return &m
+}
+func (matrix *SparseComplex128Matrix) AsSparseComplex128Vector() *SparseComplex128Vector {
+  if matrix.cols < matrix.colMax - matrix.colOffset ||
+    (matrix.rows < matrix.rowMax - matrix.rowOffset) {
+    n, m := matrix.Dims()
+    v := nilSparseComplex128Vector(n*m)
+    for it := matrix.ConstIterator(); it.Ok(); it.Next() {
+      i, j := it.Index()
+      v.At(i*matrix.cols + j).Set(matrix.ConstAt(i, j))
+    }
+    return v
+  } else {
+    return matrix.values
+  }
+}
+/* matrix interface
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) CloneMatrix() Matrix {
+  return matrix.Clone()
+}
+func (matrix *SparseComplex128Matrix) At(i, j int) Scalar {
+  return matrix.AT(i, j)
+}
+func (a *SparseComplex128Matrix) Set(b ConstMatrix) {
+  n1, m1 := a.Dims()
+  n2, m2 := b.Dims()
+  if n1 != n2 || m1 != m2 {
+    panic("Copy(): Matrix dimension does not match!")
+  }
+  for it := a.Iterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    it.Get().Set(b.ConstAt(i, j))
+  }
+}
+func (matrix *SparseComplex128Matrix) SetIdentity() {
+  c := NewScalar(matrix.ElementType(), 1.0)
+  for it := matrix.Iterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    if i == j {
+      it.Get().Set(c)
+    } else {
+      it.Get().Reset()
+    }
+  }
+}
+func (matrix *SparseComplex128Matrix) Reset() {
+  for it := matrix.Iterator(); it.Ok(); it.Next() {
+    it.Get().Reset()
+  }
+}
+func (matrix *SparseComplex128Matrix) Row(i int) Vector {
+  return matrix.ROW(i)
+}
+func (matrix *SparseComplex128Matrix) Col(j int) Vector {
+  return matrix.COL(j)
+}
+func (matrix *SparseComplex128Matrix) Diag() Vector {
+  return matrix.DIAG()
+}
+func (matrix *SparseComplex128Matrix) Slice(rfrom, rto, cfrom, cto int) Matrix {
+  return matrix.SLICE(rfrom, rto, cfrom, cto)
+}
+func (matrix *SparseComplex128Matrix) Swap(i1, j1, i2, j2 int) {
+  k1 := matrix.index(i1, j1)
+  k2 := matrix.index(i2, j2)
+  matrix.values.Swap(k1, k2)
+}
+func (matrix *SparseComplex128Matrix) T() Matrix {
+  m := &SparseComplex128Matrix{
+    values : NullSparseComplex128Vector(matrix.values.Dim()),
+    rows : matrix.cols,
+    cols : matrix.rows,
+    rowOffset : matrix.colOffset,
+    rowMax : matrix.colMax,
+    colOffset : matrix.rowOffset,
+    colMax : matrix.rowMax,
+    tmp1 : matrix.tmp2,
+    tmp2 : matrix.tmp1 }
+  for k1, value := range matrix.values.values {
+    // transform indices so that iterators operate correctly
+    i1, j1 := matrix.ij(k1)
+    k2 := m.index(j1, i1)
+    m.values.values[k2] = value
+    m.values.indexInsert(k2)
+  }
+  return m
+}
+func (matrix *SparseComplex128Matrix) Tip() {
+  mn := matrix.values.Dim()
+  visited := make([]bool, mn)
+  k := 0
+  for cycle := 1; cycle < mn; cycle++ {
+    if visited[cycle] {
+      continue
+    }
+    k = cycle
+    for {
+      if k != mn-1 {
+        k = matrix.rows*k % (mn-1)
+      }
+      visited[k] = true
+      // swap
+      matrix.values.Swap(k, cycle)
+      if k == cycle {
+        break
+      }
+    }
+  }
+  matrix.rows, matrix.cols = matrix.cols, matrix.rows
+  matrix.rowOffset, matrix.colOffset = matrix.colOffset, matrix.rowOffset
+  matrix.rowMax, matrix.colMax = matrix.colMax, matrix.rowMax
+  matrix.tmp1, matrix.tmp2 = matrix.tmp2, matrix.tmp1
+}
+func (matrix *SparseComplex128Matrix) AsVector() Vector {
+  return matrix.AsSparseComplex128Vector()
+}
+func (matrix *SparseComplex128Matrix) storageLocation() uintptr {
+  return uintptr(unsafe.Pointer(matrix.values.AT(0).ptr))
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) CloneConstMatrix() ConstMatrix {
+  return matrix.Clone()
+}
+func (matrix *SparseComplex128Matrix) Dims() (int, int) {
+  if matrix == nil {
+    return 0, 0
+  } else {
+    return matrix.rows, matrix.cols
+  }
+}
+func (matrix *SparseComplex128Matrix) Int8At(i, j int) int8 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetInt8()
+}
+func (matrix *SparseComplex128Matrix) Int16At(i, j int) int16 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetInt16()
+}
+func (matrix *SparseComplex128Matrix) Int32At(i, j int) int32 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetInt32()
+}
+func (matrix *SparseComplex128Matrix) Int64At(i, j int) int64 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetInt64()
+}
+func (matrix *SparseComplex128Matrix) IntAt(i, j int) int {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetInt()
+}
+func (matrix *SparseComplex128Matrix) Float32At(i, j int) float32 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetFloat32()
+}
+func (matrix *SparseComplex128Matrix) Float64At(i, j int) float64 {
+  return matrix.values.ConstAt(matrix.index(i, j)).GetFloat64()
+}
+func (matrix *SparseComplex128Matrix) ConstAt(i, j int) ConstScalar {
+  return matrix.values.ConstAt(matrix.index(i, j))
+}
+func (matrix *SparseComplex128Matrix) ConstSlice(rfrom, rto, cfrom, cto int) ConstMatrix {
+  return matrix.Slice(rfrom, rto, cfrom, cto)
+}
+func (matrix *SparseComplex128Matrix) ConstRow(i int) ConstVector {
+  var v *SparseComplex128Vector
+  i = matrix.index(i, 0)
+  v = matrix.values.Slice(i, i + matrix.cols).(*SparseComplex128Vector)
+  return v
+}
+func (matrix *SparseComplex128Matrix) ConstCol(i int) ConstVector {
+  return matrix.COL(i)
+}
+func (matrix *SparseComplex128Matrix) ConstDiag() ConstVector {
+  return matrix.DIAG()
+}
+func (matrix *SparseComplex128Matrix) IsSymmetric(epsilon float64) bool {
+  if n, m := matrix.Dims(); n != m {
+    return false
+  }
+  for it := matrix.ConstIterator(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    if !matrix.ConstAt(i,j).Equals(matrix.ConstAt(j,i), 1e-12) {
+      return false
+    }
+  }
+  return true
+}
+func (matrix *SparseComplex128Matrix) AsConstVector() ConstVector {
+  return matrix.AsSparseComplex128Vector()
+}
+/* implement ScalarContainer
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) Map(f func(Scalar)) {
+  n, m := matrix.Dims()
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      f(matrix.At(i, j))
+    }
+  }
+}
+func (matrix *SparseComplex128Matrix) MapSet(f func(ConstScalar) Scalar) {
+  n, m := matrix.Dims()
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      matrix.At(i,j).Set(f(matrix.ConstAt(i, j)))
+    }
+  }
+}
+func (matrix *SparseComplex128Matrix) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  n, m := matrix.Dims()
+  for i := 0; i < n; i++ {
+    for j := 0; j < m; j++ {
+      r = f(r, matrix.ConstAt(i, j))
+    }
+  }
+  return r
+}
+func (matrix *SparseComplex128Matrix) ElementType() ScalarType {
+  return Complex128Type
+}
+/* permutations
+ * -------------------------------------------------------------------------- */
+func (matrix *SparseComplex128Matrix) SwapRows(i, j int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  for k := 0; k < m; k++ {
+    matrix.Swap(i, k, j, k)
+  }
+  return nil
+}
+func (matrix *SparseComplex128Matrix) SwapColumns(i, j int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  for k := 0; k < n; k++ {
+    matrix.Swap(k, i, k, j)
+  }
+  return nil
+}
+func (matrix *SparseComplex128Matrix) PermuteRows(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("PermuteRows(): matrix is not a square matrix")
+  }
+  if len(pi) != n {
+    return fmt.Errorf("PermuteRows(): permutation vector has invalid length")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, n)
+  for i := 0; i < n; i++ {
+    if pi[i] < 0 || pi[i] >= n || visited[pi[i]] {
+      return fmt.Errorf("PermuteRows(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that row i is
+  // replaced by row pi[i]
+  for i := 0; i < n; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapRows(j, pi[j])
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+func (matrix *SparseComplex128Matrix) PermuteColumns(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("PermuteColumns(): matrix is not a square matrix")
+  }
+  if len(pi) != m {
+    return fmt.Errorf("PermuteColumns(): permutation vector has invalid length")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, m)
+  for i := 0; i < m; i++ {
+    if pi[i] < 0 || pi[i] >= m || visited[pi[i]] {
+      return fmt.Errorf("PermuteColumns(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute matrix by following the cycles of pi, such that column i is
+  // replaced by column pi[i]
+  for i := 0; i < m; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      matrix.SwapColumns(j, pi[j])
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+func (matrix *SparseComplex128Matrix) SymmetricPermutation(pi []int) error {
+  n, m := matrix.Dims()
+  if n != m {
+    return fmt.Errorf("SymmetricPermutation(): matrix is not a square matrix")
+  }
+  for i := 0; i < n; i++ {
+    if pi[i] < 0 || pi[i] > n {
+      return fmt.Errorf("SymmetricPermutation(): invalid permutation")
+    }
+    if pi[i] > i {
+      // permute rows
+      matrix.SwapRows(i, pi[i])
+      // permute colums
+      matrix.SwapColumns(i, pi[i])
+    }
+  }
+  return nil
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (m *SparseComplex128Matrix) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString("[")
+  for i := 0; i < m.rows; i++ {
+    if i != 0 {
+      buffer.WriteString(",\n ")
+    }
+    buffer.WriteString("[")
+    for j := 0; j < m.cols; j++ {
+      if j != 0 {
+        buffer.WriteString(", ")
+      }
+      buffer.WriteString(m.ConstAt(i,j).String())
+    }
+    buffer.WriteString("]")
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (a *SparseComplex128Matrix) Table() string {
+  var buffer bytes.Buffer
+  n, m := a.Dims()
+  for i := 0; i < n; i++ {
+    if i != 0 {
+      buffer.WriteString("\n")
+    }
+    for j := 0; j < m; j++ {
+      if j != 0 {
+        buffer.WriteString(" ")
+      }
+      buffer.WriteString(a.ConstAt(i,j).String())
+    }
+  }
+  return buffer.String()
+}
+func (m *SparseComplex128Matrix) Export(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  w := bufio.NewWriter(f)
+  defer w.Flush()
+  if _, err := fmt.Fprintf(w, "%d %d\n", m.rows, m.cols); err != nil {
+    return err
+  }
+  for it := m.ITERATOR(); it.Ok(); it.Next() {
+    i, j := it.Index()
+    if _, err := fmt.Fprintf(w, "%d %d %v\n", i, j, it.GET()); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+func (m *SparseComplex128Matrix) Import(filename string) error {
+  rows := 0
+  cols := 0
+  rowIndices := []int{}
+  colIndices := []int{}
+  values := []complex128{}
+  var reader *bufio.Reader
+  // open file
+  f, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  isgzip, err := isGzip(filename)
+  if err != nil {
+    return err
+  }
+  // check if file is gzipped
+  if isgzip {
+    g, err := gzip.NewReader(f)
+    if err != nil {
+      return err
+    }
+    defer g.Close()
+    reader = bufio.NewReader(g)
+  } else {
+    reader = bufio.NewReader(f)
+  }
+  // scan header
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 2 {
+      return fmt.Errorf("invalid sparse matrix format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      rows = int(v)
+    }
+    if v, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+      return err
+    } else {
+      cols = int(v)
+    }
+    break
+  }
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 3 {
+      return fmt.Errorf("invalid sparse matrix format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      rowIndices = append(rowIndices, int(v))
+    }
+    if v, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+      return err
+    } else {
+      colIndices = append(colIndices, int(v))
+    }
+    if v, err := strconv.ParseFloat(fields[2], 64); err != nil {
+      return err
+    } else {
+      values = append(values, complex128(complex(v, 0)))
+    }
+  }
+  *m = *NewSparseComplex128Matrix(rowIndices, colIndices, values, rows, cols)
+  return nil
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Matrix) MarshalJSON() ([]byte, error) {
+  if obj.rowMax > obj.rows || obj.colMax > obj.cols {
+    n, m := obj.Dims()
+    tmp := NullSparseComplex128Matrix(n, m)
+    tmp.Set(obj)
+    obj = tmp
+  }
+  k := []int{}
+  v := []complex128{}
+  r := struct{Index []int; Value complex128JsonSlice; Rows int; Cols int}{}
+  for it := obj.values.ConstIterator(); it.Ok(); it.Next() {
+    k = append(k, it.Index())
+    v = append(v, complex128(it.GetConst().GetComplex128()))
+  }
+  r.Index = k
+  r.Value = v
+  r.Rows = obj.rows
+  r.Cols = obj.cols
+  return json.MarshalIndent(r, "", "  ")
+}
+func (obj *SparseComplex128Matrix) UnmarshalJSON(data []byte) error {
+  r := struct{Index []int; Value complex128JsonSlice; Rows int; Cols int}{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  if len(r.Index) != len(r.Value) {
+    return fmt.Errorf("invalid sparse vector")
+  }
+  obj.values = NewSparseComplex128Vector(r.Index, r.Value, r.Rows*r.Cols)
+  obj.rows = r.Rows
+  obj.rowMax = r.Rows
+  obj.rowOffset = 0
+  obj.cols = r.Cols
+  obj.colMax = r.Cols
+  obj.colOffset = 0
+  obj.initTmp()
+  return nil
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Matrix) ConstIterator() MatrixConstIterator {
+  return obj.ITERATOR()
+}
+func (obj *SparseComplex128Matrix) ConstIteratorFrom(i, j int) MatrixConstIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+func (obj *SparseComplex128Matrix) Iterator() MatrixIterator {
+  return obj.ITERATOR()
+}
+func (obj *SparseComplex128Matrix) IteratorFrom(i, j int) MatrixIterator {
+  return obj.ITERATOR_FROM(i, j)
+}
+func (obj *SparseComplex128Matrix) JointIterator(b ConstMatrix) MatrixJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj *SparseComplex128Matrix) ITERATOR() *SparseComplex128MatrixIterator {
+  r := SparseComplex128MatrixIterator{*obj.values.ITERATOR(), obj}
+  return &r
+}
+func (obj *SparseComplex128Matrix) ITERATOR_FROM(i, j int) *SparseComplex128MatrixIterator {
+  k := obj.index(i, j)
+  r := SparseComplex128MatrixIterator{*obj.values.ITERATOR_FROM(k), obj}
+  return &r
+}
+func (obj *SparseComplex128Matrix) JOINT_ITERATOR(b ConstMatrix) *SparseComplex128MatrixJointIterator {
+  r := SparseComplex128MatrixJointIterator{obj.ITERATOR(), b.ConstIterator(), -1, -1, Complex128{}, nil}
+  r.Next()
+  return &r
+}
+func (obj *SparseComplex128Matrix) JOINT3_ITERATOR(b, c ConstMatrix) *SparseComplex128MatrixJoint3Iterator {
+  r := SparseComplex128MatrixJoint3Iterator{obj.ITERATOR(), b.ConstIterator(), c.ConstIterator(), -1, -1, Complex128{}, nil, nil}
+  r.Next()
+  return &r
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128MatrixIterator struct {
+  SparseComplex128VectorIterator
+  m *SparseComplex128Matrix
+}
+func (obj *SparseComplex128MatrixIterator) Index() (int, int) {
+  return obj.m.ij(obj.SparseComplex128VectorIterator.Index())
+}
+func (obj *SparseComplex128MatrixIterator) Clone() *SparseComplex128MatrixIterator {
+  return &SparseComplex128MatrixIterator{*obj.SparseComplex128VectorIterator.Clone(), obj.m}
+}
+func (obj *SparseComplex128MatrixIterator) CloneConstIterator() MatrixConstIterator {
+  return &SparseComplex128MatrixIterator{*obj.SparseComplex128VectorIterator.Clone(), obj.m}
+}
+func (obj *SparseComplex128MatrixIterator) CloneIterator() MatrixIterator {
+  return &SparseComplex128MatrixIterator{*obj.SparseComplex128VectorIterator.Clone(), obj.m}
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128MatrixJointIterator struct {
+  it1 *SparseComplex128MatrixIterator
+  it2 MatrixConstIterator
+  i, j int
+  s1 Complex128
+  s2 ConstScalar
+}
+func (obj *SparseComplex128MatrixJointIterator) Index() (int, int) {
+  return obj.i, obj.j
+}
+func (obj *SparseComplex128MatrixJointIterator) Ok() bool {
+  return !(obj.s1.ptr == nil || obj.s1.GetComplex128() == complex128(0)) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == complex128(0))
+}
+func (obj *SparseComplex128MatrixJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1.ptr = nil
+  obj.s2 = nil
+  if ok1 {
+    obj.i, obj.j = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    i, j := obj.it2.Index()
+    switch {
+    case obj.i > i || (obj.i == i && obj.j > j) || !ok1:
+      obj.i, obj.j = i, j
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.i == i && obj.j == j:
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+}
+func (obj *SparseComplex128MatrixJointIterator) Get() (Scalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *SparseComplex128MatrixJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *SparseComplex128MatrixJointIterator) GET() (Complex128, ConstScalar) {
+  return obj.s1, obj.s2
+}
+func (obj *SparseComplex128MatrixJointIterator) Clone() *SparseComplex128MatrixJointIterator {
+  r := SparseComplex128MatrixJointIterator{}
+  r.it1 = obj.it1.Clone()
+  r.it2 = obj.it2.CloneConstIterator()
+  r.i = obj.i
+  r.j = obj.j
+  r.s1 = obj.s1
+  r.s2 = obj.s2
+  return &r
+}
+func (obj *SparseComplex128MatrixJointIterator) CloneJointIterator() MatrixJointIterator {
+  return obj.Clone()
+}
+func (obj *SparseComplex128MatrixJointIterator) CloneConstJointIterator() MatrixConstJointIterator {
+  return obj.Clone()
+}
+/* joint3 iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128MatrixJoint3Iterator struct {
+  it1 *SparseComplex128MatrixIterator
+  it2 MatrixConstIterator
+  it3 MatrixConstIterator
+  i, j int
+  s1 Complex128
+  s2 ConstScalar
+  s3 ConstScalar
+}
+func (obj *SparseComplex128MatrixJoint3Iterator) Index() (int, int) {
+  return obj.i, obj.j
+}
+func (obj *SparseComplex128MatrixJoint3Iterator) Ok() bool {
+  return !(obj.s1.ptr == nil || obj.s1.GetComplex128() == 0.0) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == 0.0) ||
+         !(obj.s3 == nil || obj.s3.GetComplex128() == 0.0)
+}
+func (obj *SparseComplex128MatrixJoint3Iterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  ok3 := obj.it3.Ok()
+  obj.s1.ptr = nil
+  obj.s2 = nil
+  obj.s3 = nil
+  if ok1 {
+    obj.i, obj.j = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    i, j := obj.it2.Index()
+    switch {
+    case obj.i > i || (obj.i == i && obj.j > j) || !ok1:
+      obj.i = i
+      obj.j = j
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.i == i && obj.j == j:
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if ok3 {
+    i, j := obj.it3.Index()
+    switch {
+    case obj.i > i || (obj.i == i && obj.j > j) || (!ok1 && !ok2):
+      obj.i = i
+      obj.j = j
+      obj.s1.ptr = nil
+      obj.s2 = nil
+      obj.s3 = obj.it3.GetConst()
+    case obj.i == i && obj.j == j:
+      obj.s3 = obj.it3.GetConst()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+  if obj.s3 != nil {
+    obj.it3.Next()
+  } else {
+    obj.s3 = ConstComplex128(0.0)
+  }
+}
+func (obj *SparseComplex128MatrixJoint3Iterator) Get() (Scalar, ConstScalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2, obj.s3
+  } else {
+    return obj.s1, obj.s2, obj.s3
+  }
+}
+func (obj *SparseComplex128MatrixJoint3Iterator) GET() (Complex128, ConstScalar, ConstScalar) {
+  return obj.s1, obj.s2, obj.s3
+}
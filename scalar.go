@@ -35,6 +35,8 @@ type ConstScalar interface {
   GetInt            ()           int
   GetFloat32        ()           float32
   GetFloat64        ()           float64
+  GetComplex64      ()           complex64
+  GetComplex128     ()           complex128
   // magic access
   GetOrder          ()           int
   GetDerivative     (int)        float64
@@ -70,6 +72,8 @@ type Scalar interface {
   SetInt       (int)
   SetFloat32   (float32)
   SetFloat64   (float64)
+  SetComplex64 (complex64)
+  SetComplex128(complex128)
   setInt8      (int8)
   setInt16     (int16)
   setInt32     (int32)
@@ -77,6 +81,8 @@ type Scalar interface {
   setInt       (int)
   setFloat32   (float32)
   setFloat64   (float64)
+  setComplex64 (complex64)
+  setComplex128(complex128)
   // type reflections
   ConvertScalar(ScalarType) Scalar
   // some basic operations on scalars
@@ -0,0 +1,138 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+import "encoding/json"
+import "math/cmplx"
+
+/* complex numbers
+ * -------------------------------------------------------------------------- */
+
+// Evaluate a real function f at z, which is only defined if z has no
+// imaginary part.
+func complexReal(z complex128, f func(float64) float64) complex128 {
+  if imag(z) != 0.0 {
+    return cmplx.NaN()
+  }
+  return complex(f(real(z)), 0)
+}
+
+func complexEquals(a, b complex128, epsilon float64) bool {
+  return cmplx.Abs(a - b) < epsilon ||
+        (cmplx.IsNaN(a) && cmplx.IsNaN(b)) ||
+        (cmplx.IsInf(a) && cmplx.IsInf(b) && a == b)
+}
+
+/* json
+ * -------------------------------------------------------------------------- */
+
+// Complex numbers are not supported by the json package. They are
+// encoded as pairs [real, imag]. Real numbers are accepted when
+// decoding.
+func complexMarshalJSON(v complex128) ([]byte, error) {
+  return json.Marshal([2]float64{real(v), imag(v)})
+}
+
+func complexUnmarshalJSON(data []byte) (complex128, error) {
+  r := [2]float64{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    if err := json.Unmarshal(data, &r[0]); err != nil {
+      return 0, err
+    }
+  }
+  return complex(r[0], r[1]), nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+type complex64Json complex64
+
+func (obj complex64Json) MarshalJSON() ([]byte, error) {
+  return complexMarshalJSON(complex128(obj))
+}
+
+func (obj *complex64Json) UnmarshalJSON(data []byte) error {
+  v, err := complexUnmarshalJSON(data)
+  if err != nil {
+    return err
+  }
+  *obj = complex64Json(v)
+  return nil
+}
+
+type complex128Json complex128
+
+func (obj complex128Json) MarshalJSON() ([]byte, error) {
+  return complexMarshalJSON(complex128(obj))
+}
+
+func (obj *complex128Json) UnmarshalJSON(data []byte) error {
+  v, err := complexUnmarshalJSON(data)
+  if err != nil {
+    return err
+  }
+  *obj = complex128Json(v)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+type complex64JsonSlice []complex64
+
+func (obj complex64JsonSlice) MarshalJSON() ([]byte, error) {
+  r := make([]complex64Json, len(obj))
+  for i, v := range obj {
+    r[i] = complex64Json(v)
+  }
+  return json.Marshal(r)
+}
+
+func (obj *complex64JsonSlice) UnmarshalJSON(data []byte) error {
+  r := []complex64Json{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  *obj = make(complex64JsonSlice, len(r))
+  for i, v := range r {
+    (*obj)[i] = complex64(v)
+  }
+  return nil
+}
+
+type complex128JsonSlice []complex128
+
+func (obj complex128JsonSlice) MarshalJSON() ([]byte, error) {
+  r := make([]complex128Json, len(obj))
+  for i, v := range obj {
+    r[i] = complex128Json(v)
+  }
+  return json.Marshal(r)
+}
+
+func (obj *complex128JsonSlice) UnmarshalJSON(data []byte) error {
+  r := []complex128Json{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  *obj = make(complex128JsonSlice, len(r))
+  for i, v := range r {
+    (*obj)[i] = complex128(v)
+  }
+  return nil
+}
@@ -0,0 +1,9 @@
+#define SCALAR_NAME  Complex128
+#define SCALAR_CONST ConstComplex128
+#define SCALAR_TYPE  complex128
+#define REAL_TYPE    float64
+#define GET_METHOD_NAME GetComplex128
+#define SET_METHOD_NAME SetComplex128
+
+#define CONST_SCALAR_REFLECT_TYPE ConstComplex128Type
+#define STORED_JSON_TYPE complex128Json
@@ -0,0 +1,143 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+import "math"
+import "math/cmplx"
+/* -------------------------------------------------------------------------- */
+func (a Complex128) EQUALS(b Complex128, epsilon float64) bool {
+  return complexEquals(a.GetComplex128(), b.GetComplex128(), epsilon)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex128) GREATER(b Complex128) bool {
+  return real(*a.ptr) > real(*b.ptr)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex128) SMALLER(b Complex128) bool {
+  return real(*a.ptr) < real(*b.ptr)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex128) SIGN() int {
+  return a.Sign()
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex128) MIN(a, b Complex128) Scalar {
+  if a.SMALLER(b) {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex128) MAX(a, b Complex128) Scalar {
+  if a.GREATER(b) {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) ABS(a Complex128) Scalar {
+  return c.Abs(a)
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) NEG(a Complex128) Complex128 {
+  *c.ptr = -*a.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) ADD(a, b Complex128) Complex128 {
+  *c.ptr = *a.ptr + *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) SUB(a, b Complex128) Complex128 {
+  *c.ptr = *a.ptr - *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) MUL(a, b Complex128) Complex128 {
+  *c.ptr = *a.ptr * *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) DIV(a, b Complex128) Complex128 {
+  *c.ptr = *a.ptr / *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) LOGADD(a, b, t Complex128) Complex128 {
+  if a.GREATER(b) {
+    // swap
+    a, b = b, a
+  }
+  if math.IsInf(a.GetFloat64(), -1) {
+    c.SET(b)
+    return c
+  }
+  t.SUB(a, b)
+  t.EXP(t)
+  t.LOG1P(t)
+  c.ADD(t, b)
+  return c
+}
+func (c Complex128) LOGSUB(a, b, t Complex128) Complex128 {
+  if math.IsInf(b.GetFloat64(), -1) {
+    c.SET(a)
+    return c
+  }
+  t.SUB(b, a)
+  t.EXP(t)
+  t.NEG(t)
+  t.LOG1P(t)
+  c.ADD(t, a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) POW(a, k Complex128) Complex128 {
+  x := a.GetComplex128()
+  y := k.GetComplex128()
+  c.SetComplex128(cmplx.Pow(x, y))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) SQRT(a Complex128) Complex128 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Sqrt(x))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex128) EXP(a Complex128) Complex128 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Exp(x))
+  return c
+}
+func (c Complex128) LOG(a Complex128) Complex128 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Log(x))
+  return c
+}
+func (c Complex128) LOG1P(a Complex128) Complex128 {
+  c.Log1p(a)
+  return c
+}
@@ -0,0 +1,223 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "encoding/json"
+import "reflect"
+/* -------------------------------------------------------------------------- */
+// Complex scalars store a complex number. Methods that return real
+// values, e.g. GetFloat64(), return the real part. Complex scalars do
+// not implement the MagicScalar interface, since derivatives are
+// real-valued.
+type Complex64 struct {
+  ptr *complex64
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+func NewComplex64(v complex64) Complex64 {
+  return Complex64{&v}
+}
+func NullComplex64() Complex64 {
+  v := complex64(0.0)
+  return Complex64{&v}
+}
+/* register scalar type
+ * -------------------------------------------------------------------------- */
+var Complex64Type ScalarType = (Complex64{}).Type()
+func init() {
+  f := func(value float64) Scalar { return NewComplex64(complex64(complex(value, 0))) }
+  RegisterScalar(Complex64Type, f)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) Clone() Complex64 {
+  r := NewComplex64(0.0)
+  r.Set(a)
+  return r
+}
+func (a Complex64) CloneConstScalar() ConstScalar {
+  return a.Clone()
+}
+func (a Complex64) CloneScalar() Scalar {
+  return a.Clone()
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) Type() ScalarType {
+  return reflect.TypeOf(a)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) ConvertConstScalar(t ScalarType) ConstScalar {
+  switch t {
+  case Complex64Type:
+    return a
+  case ConstComplex64Type:
+    return ConstComplex64(*a.ptr)
+  default:
+    return NewConstScalar(t, a.GetFloat64())
+  }
+}
+func (a Complex64) ConvertScalar(t ScalarType) Scalar {
+  switch t {
+  case Complex64Type:
+    return a
+  default:
+    r := NullScalar(t)
+    r.Set(a)
+    return r
+  }
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (a Complex64) String() string {
+  return fmt.Sprintf("%v", a.GetComplex64())
+}
+/* read access
+ * -------------------------------------------------------------------------- */
+func (a Complex64) GetInt8() int8 {
+  return int8(real(*a.ptr))
+}
+func (a Complex64) GetInt16() int16 {
+  return int16(real(*a.ptr))
+}
+func (a Complex64) GetInt32() int32 {
+  return int32(real(*a.ptr))
+}
+func (a Complex64) GetInt64() int64 {
+  return int64(real(*a.ptr))
+}
+func (a Complex64) GetInt() int {
+  return int(real(*a.ptr))
+}
+func (a Complex64) GetFloat32() float32 {
+  return float32(real(*a.ptr))
+}
+func (a Complex64) GetFloat64() float64 {
+  return float64(real(*a.ptr))
+}
+func (a Complex64) GetComplex64() complex64 {
+  return complex64(*a.ptr)
+}
+func (a Complex64) GetComplex128() complex128 {
+  return complex128(*a.ptr)
+}
+func (a Complex64) GetOrder() int {
+  return 0
+}
+func (a Complex64) GetDerivative(i int) float64 {
+  return 0.0
+}
+func (a Complex64) GetHessian(i, j int) float64 {
+  return 0.0
+}
+func (a Complex64) GetN() int {
+  return 0
+}
+// Returns the real part.
+func (a Complex64) Real() float64 {
+  return float64(real(*a.ptr))
+}
+// Returns the imaginary part.
+func (a Complex64) Imag() float64 {
+  return float64(imag(*a.ptr))
+}
+/* write access
+ * -------------------------------------------------------------------------- */
+func (a Complex64) Reset() {
+  *a.ptr = 0.0
+}
+// Set the state to b. The imaginary part is zero if b is a real scalar.
+func (a Complex64) Set(b ConstScalar) {
+  *a.ptr = b.GetComplex64()
+}
+func (a Complex64) SET(b Complex64) {
+  *a.ptr = *b.ptr
+}
+func (a Complex64) SetInt8(v int8) {
+  a.setInt8(v)
+}
+func (a Complex64) setInt8(v int8) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetInt16(v int16) {
+  a.setInt16(v)
+}
+func (a Complex64) setInt16(v int16) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetInt32(v int32) {
+  a.setInt32(v)
+}
+func (a Complex64) setInt32(v int32) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetInt64(v int64) {
+  a.setInt64(v)
+}
+func (a Complex64) setInt64(v int64) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetInt(v int) {
+  a.setInt(v)
+}
+func (a Complex64) setInt(v int) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetFloat32(v float32) {
+  a.setFloat32(v)
+}
+func (a Complex64) setFloat32(v float32) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetFloat64(v float64) {
+  a.setFloat64(v)
+}
+func (a Complex64) setFloat64(v float64) {
+  *a.ptr = complex(float32(v), 0)
+}
+func (a Complex64) SetComplex64(v complex64) {
+  a.setComplex64(v)
+}
+func (a Complex64) setComplex64(v complex64) {
+  *a.ptr = complex64(v)
+}
+func (a Complex64) SetComplex128(v complex128) {
+  a.setComplex128(v)
+}
+func (a Complex64) setComplex128(v complex128) {
+  *a.ptr = complex64(v)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) nullScalar() bool {
+  if a.ptr == nil {
+    return true
+  }
+  if *a.ptr != 0 {
+    return false
+  }
+  return true
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj Complex64) MarshalJSON() ([]byte, error) {
+  return json.Marshal(complex64Json(*obj.ptr))
+}
+func (obj Complex64) UnmarshalJSON(data []byte) error {
+  return json.Unmarshal(data, (*complex64Json)(obj.ptr))
+}
@@ -0,0 +1,9 @@
+#define SCALAR_NAME  Complex64
+#define SCALAR_CONST ConstComplex64
+#define SCALAR_TYPE  complex64
+#define REAL_TYPE    float32
+#define GET_METHOD_NAME GetComplex64
+#define SET_METHOD_NAME SetComplex64
+
+#define CONST_SCALAR_REFLECT_TYPE ConstComplex64Type
+#define STORED_JSON_TYPE complex64Json
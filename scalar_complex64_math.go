@@ -0,0 +1,374 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+import "math"
+import "math/cmplx"
+import "github.com/pbenner/autodiff/special"
+/* -------------------------------------------------------------------------- */
+// Two complex numbers are equal if the modulus of their difference is
+// smaller than epsilon.
+func (a Complex64) Equals(b ConstScalar, epsilon float64) bool {
+  return complexEquals(a.GetComplex128(), b.GetComplex128(), epsilon)
+}
+/* -------------------------------------------------------------------------- */
+// Complex numbers are compared by their real parts.
+func (a Complex64) Greater(b ConstScalar) bool {
+  return a.GetFloat64() > b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+// Complex numbers are compared by their real parts.
+func (a Complex64) Smaller(b ConstScalar) bool {
+  return a.GetFloat64() < b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+// Sign of the real part.
+func (a Complex64) Sign() int {
+  if a.GetFloat64() < 0.0 {
+    return -1
+  }
+  if a.GetFloat64() > 0.0 {
+    return 1
+  }
+  return 0
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex64) Min(a, b ConstScalar) Scalar {
+  if a.GetFloat64() < b.GetFloat64() {
+    r.Set(a)
+  } else {
+    r.Set(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex64) Max(a, b ConstScalar) Scalar {
+  if a.GetFloat64() > b.GetFloat64() {
+    r.Set(a)
+  } else {
+    r.Set(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+// Modulus of a.
+func (c Complex64) Abs(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetFloat64(cmplx.Abs(x))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Neg(a ConstScalar) Scalar {
+  x := a.GetComplex64()
+  c.SetComplex64(-x)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Add(a, b ConstScalar) Scalar {
+  x := a.GetComplex64()
+  y := b.GetComplex64()
+  c.SetComplex64(x+y)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Sub(a, b ConstScalar) Scalar {
+  x := a.GetComplex64()
+  y := b.GetComplex64()
+  c.SetComplex64(x-y)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Mul(a, b ConstScalar) Scalar {
+  x := a.GetComplex64()
+  y := b.GetComplex64()
+  c.SetComplex64(x*y)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Div(a, b ConstScalar) Scalar {
+  x := a.GetComplex64()
+  y := b.GetComplex64()
+  c.SetComplex64(x/y)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+// log(exp(a) + exp(b)), where the principal branch of the logarithm is
+// used.
+func (c Complex64) LogAdd(a, b ConstScalar, t Scalar) Scalar {
+  if a.Greater(b) {
+    // swap
+    a, b = b, a
+  }
+  if math.IsInf(a.GetFloat64(), -1) {
+    c.Set(b)
+    return c
+  }
+  t.Sub(a, b)
+  t.Exp(t)
+  t.Log1p(t)
+  c.Add(t, b)
+  return c
+}
+// log(exp(a) - exp(b)), where the principal branch of the logarithm is
+// used.
+func (c Complex64) LogSub(a, b ConstScalar, t Scalar) Scalar {
+  if math.IsInf(b.GetFloat64(), -1) {
+    c.Set(a)
+    return c
+  }
+  t.Sub(b, a)
+  t.Exp(t)
+  t.Neg(t)
+  t.Log1p(t)
+  c.Add(t, a)
+  return c
+}
+func (c Complex64) Log1pExp(a ConstScalar) Scalar {
+  c.Exp(a)
+  c.Log1p(c)
+  return c
+}
+func (c Complex64) Sigmoid(a ConstScalar, t Scalar) Scalar {
+  c.Neg(a)
+  c.Exp(c)
+  c.Add(c, ConstComplex64(1.0))
+  c.Div(ConstComplex64(1.0), c)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Pow(a, k ConstScalar) Scalar {
+  x := a.GetComplex128()
+  y := k.GetComplex128()
+  c.SetComplex128(cmplx.Pow(x, y))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Sqrt(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Sqrt(x))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) Sin(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Sin(x))
+  return c
+}
+func (c Complex64) Sinh(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Sinh(x))
+  return c
+}
+func (c Complex64) Cos(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Cos(x))
+  return c
+}
+func (c Complex64) Cosh(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Cosh(x))
+  return c
+}
+func (c Complex64) Tan(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Tan(x))
+  return c
+}
+func (c Complex64) Tanh(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Tanh(x))
+  return c
+}
+func (c Complex64) Exp(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Exp(x))
+  return c
+}
+// Principal branch of the logarithm.
+func (c Complex64) Log(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Log(x))
+  return c
+}
+func (c Complex64) Log1p(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  if imag(x) == 0.0 && real(x) > -1.0 {
+    c.SetFloat64(math.Log1p(real(x)))
+  } else {
+    c.SetComplex128(cmplx.Log(1.0 + x))
+  }
+  return c
+}
+func (c Complex64) Logistic(a ConstScalar) Scalar {
+  c.Neg(a)
+  c.Exp(c)
+  c.Add(ConstComplex64(1.0), c)
+  c.Div(ConstComplex64(1.0), c)
+  return c
+}
+/* special functions are only defined on the real line, the result is
+ * NaN if the argument has a non-zero imaginary part
+ * -------------------------------------------------------------------------- */
+func (c Complex64) Erf(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, math.Erf))
+  return c
+}
+func (c Complex64) Erfc(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, math.Erfc))
+  return c
+}
+func (c Complex64) LogErfc(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, special.LogErfc))
+  return c
+}
+func (c Complex64) Gamma(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, math.Gamma))
+  return c
+}
+func (c Complex64) Lgamma(a ConstScalar) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, func(x float64) float64 {
+    v0, s := math.Lgamma(x)
+    if s == -1 {
+      v0 = math.NaN()
+    }
+    return v0
+  }))
+  return c
+}
+func (c Complex64) Mlgamma(a ConstScalar, k int) Scalar {
+  x := a.GetComplex128()
+  c.SetComplex128(complexReal(x, func(x float64) float64 {
+    return special.Mlgamma(x, k)
+  }))
+  return c
+}
+func (c Complex64) GammaP(a float64, b ConstScalar) Scalar {
+  x := b.GetComplex128()
+  c.SetComplex128(complexReal(x, func(x float64) float64 {
+    return special.GammaP(a, x)
+  }))
+  return c
+}
+func (c Complex64) BesselI(v float64, b ConstScalar) Scalar {
+  x := b.GetComplex128()
+  c.SetComplex128(complexReal(x, func(x float64) float64 {
+    return special.BesselI(v, x)
+  }))
+  return c
+}
+func (c Complex64) LogBesselI(v float64, b ConstScalar) Scalar {
+  x := b.GetComplex128()
+  c.SetComplex128(complexReal(x, func(x float64) float64 {
+    return special.LogBesselI(v, x)
+  }))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex64) SmoothMax(x ConstVector, alpha ConstFloat64, t [2]Scalar) Scalar {
+  r .Reset()
+  t[1].Reset()
+  for i := 0; i < x.Dim(); i++ {
+    t[0].Mul(alpha, x.ConstAt(i))
+    t[0].Exp(t[0])
+    t[1].Add(t[1], t[0])
+    t[0].Mul(t[0], x.ConstAt(i))
+    r .Add(r , t[0])
+  }
+  r.Div(r, t[1])
+  return r
+}
+func (r Complex64) LogSmoothMax(x ConstVector, alpha ConstFloat64, t [3]Scalar) Scalar {
+  r .Reset()
+  t[2].SetFloat64(math.Inf(-1))
+  for i := 0; i < x.Dim(); i++ {
+    t[0].Mul(x.ConstAt(i), alpha)
+    t[2].LogAdd(t[2], t[0], t[1])
+    t[1].Log(x.ConstAt(i))
+    t[0].Add(t[0], t[1])
+    r.LogAdd(r, t[0], t[1])
+  }
+  r.Sub(r, t[2])
+  r.Exp(r)
+  return r
+}
+func (r Complex64) Vmean(a ConstVector) Scalar {
+  r.Reset()
+  for i := 0; i < a.Dim(); i++ {
+    r.Add(r, a.ConstAt(i))
+  }
+  return r.Div(r, ConstComplex64(complex(float64(a.Dim()), 0)))
+}
+// Bilinear product sum_i a_i b_i without complex conjugation.
+func (r Complex64) VdotV(a, b ConstVector) Scalar {
+  if a.Dim() != b.Dim() {
+    panic("vector dimensions do not match")
+  }
+  r.Reset()
+  t := NullComplex64()
+  for i := 0; i < a.Dim(); i++ {
+    t.Mul(a.ConstAt(i), b.ConstAt(i))
+    r.Add(r, t)
+  }
+  return r
+}
+// Euclidean norm, i.e. the square root of the sum of squared moduli.
+func (r Complex64) Vnorm(a ConstVector) Scalar {
+  s := 0.0
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    x := cmplx.Abs(it.GetConst().GetComplex128())
+    s += x*x
+  }
+  r.SetFloat64(math.Sqrt(s))
+  return r
+}
+func (r Complex64) Mtrace(a ConstMatrix) Scalar {
+  n, m := a.Dims()
+  if n != m {
+    panic("not a square matrix")
+  }
+  if n == 0 {
+    return nil
+  }
+  r.Reset()
+  for i := 0; i < n; i++ {
+    r.Add(r, a.ConstAt(i,i))
+  }
+  return r
+}
+// Frobenius norm.
+func (r Complex64) Mnorm(a ConstMatrix) Scalar {
+  n, m := a.Dims()
+  if n == 0 || m == 0 {
+    return nil
+  }
+  s := 0.0
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    x := cmplx.Abs(it.GetConst().GetComplex128())
+    s += x*x
+  }
+  r.SetFloat64(s)
+  return r
+}
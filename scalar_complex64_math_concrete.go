@@ -0,0 +1,143 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+import "math"
+import "math/cmplx"
+/* -------------------------------------------------------------------------- */
+func (a Complex64) EQUALS(b Complex64, epsilon float64) bool {
+  return complexEquals(a.GetComplex128(), b.GetComplex128(), epsilon)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) GREATER(b Complex64) bool {
+  return real(*a.ptr) > real(*b.ptr)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) SMALLER(b Complex64) bool {
+  return real(*a.ptr) < real(*b.ptr)
+}
+/* -------------------------------------------------------------------------- */
+func (a Complex64) SIGN() int {
+  return a.Sign()
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex64) MIN(a, b Complex64) Scalar {
+  if a.SMALLER(b) {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r Complex64) MAX(a, b Complex64) Scalar {
+  if a.GREATER(b) {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) ABS(a Complex64) Scalar {
+  return c.Abs(a)
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) NEG(a Complex64) Complex64 {
+  *c.ptr = -*a.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) ADD(a, b Complex64) Complex64 {
+  *c.ptr = *a.ptr + *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) SUB(a, b Complex64) Complex64 {
+  *c.ptr = *a.ptr - *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) MUL(a, b Complex64) Complex64 {
+  *c.ptr = *a.ptr * *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) DIV(a, b Complex64) Complex64 {
+  *c.ptr = *a.ptr / *b.ptr
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) LOGADD(a, b, t Complex64) Complex64 {
+  if a.GREATER(b) {
+    // swap
+    a, b = b, a
+  }
+  if math.IsInf(a.GetFloat64(), -1) {
+    c.SET(b)
+    return c
+  }
+  t.SUB(a, b)
+  t.EXP(t)
+  t.LOG1P(t)
+  c.ADD(t, b)
+  return c
+}
+func (c Complex64) LOGSUB(a, b, t Complex64) Complex64 {
+  if math.IsInf(b.GetFloat64(), -1) {
+    c.SET(a)
+    return c
+  }
+  t.SUB(b, a)
+  t.EXP(t)
+  t.NEG(t)
+  t.LOG1P(t)
+  c.ADD(t, a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) POW(a, k Complex64) Complex64 {
+  x := a.GetComplex128()
+  y := k.GetComplex128()
+  c.SetComplex128(cmplx.Pow(x, y))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) SQRT(a Complex64) Complex64 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Sqrt(x))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c Complex64) EXP(a Complex64) Complex64 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Exp(x))
+  return c
+}
+func (c Complex64) LOG(a Complex64) Complex64 {
+  x := a.GetComplex128()
+  c.SetComplex128(cmplx.Log(x))
+  return c
+}
+func (c Complex64) LOG1P(a Complex64) Complex64 {
+  c.Log1p(a)
+  return c
+}
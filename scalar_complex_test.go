@@ -0,0 +1,154 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+import "encoding/json"
+import "math"
+import "math/cmplx"
+import "testing"
+
+/* -------------------------------------------------------------------------- */
+
+func TestComplex1(t *testing.T) {
+  a := NewComplex128(complex(1, 2))
+  b := NewComplex128(complex(3, -1))
+  r := NullComplex128()
+
+  if r.Mul(a, b); r.GetComplex128() != complex(5, 5) {
+    t.Error("test failed")
+  }
+  if r.Div(a, b); !r.Equals(ConstComplex128(complex(0.1, 0.7)), 1e-12) {
+    t.Error("test failed")
+  }
+  if r.Exp(a); !r.Equals(ConstComplex128(cmplx.Exp(complex(1, 2))), 1e-12) {
+    t.Error("test failed")
+  }
+  if r.Abs(b); !r.Equals(ConstFloat64(math.Sqrt(10)), 1e-12) {
+    t.Error("test failed")
+  }
+  if r.Sqrt(ConstFloat64(-4)); !r.Equals(ConstComplex128(complex(0, 2)), 1e-12) {
+    t.Error("test failed")
+  }
+  // real-valued accessors return the real part
+  if a.GetFloat64() != 1.0 || a.Imag() != 2.0 {
+    t.Error("test failed")
+  }
+  // special functions are only defined on the real line
+  if r.Gamma(ConstComplex128(5)); r.GetFloat64() != 24.0 {
+    t.Error("test failed")
+  }
+  if r.Gamma(a); !cmplx.IsNaN(r.GetComplex128()) {
+    t.Error("test failed")
+  }
+}
+
+func TestComplex2(t *testing.T) {
+  a := NewComplex64(complex(1, 2))
+  b := NewFloat64(3)
+  // conversion between real and complex types
+  b.Set(a)
+  if b.GetFloat64() != 1.0 {
+    t.Error("test failed")
+  }
+  a.Set(b)
+  if a.GetComplex128() != complex(1, 0) {
+    t.Error("test failed")
+  }
+  if r := NewScalar(Complex128Type, 2.0); r.GetComplex128() != complex(2, 0) {
+    t.Error("test failed")
+  }
+}
+
+func TestComplexVector(t *testing.T) {
+  a := NewDenseComplex128Vector([]complex128{1+1i, 2, 3-1i})
+  b := NewDenseComplex128Vector([]complex128{1i, 1, 2})
+  r := NullDenseComplex128Vector(3)
+  r.VaddV(a, b)
+  if !r.Equals(NewDenseComplex128Vector([]complex128{1+2i, 3, 5-1i}), 1e-12) {
+    t.Error("test failed")
+  }
+  s := NullComplex128()
+  s.VdotV(a, b)
+  if !s.Equals(ConstComplex128(7-1i), 1e-12) {
+    t.Error("test failed")
+  }
+  s.Vnorm(a)
+  if !s.Equals(ConstFloat64(4.0), 1e-12) {
+    t.Error("test failed")
+  }
+  for _, v := range []Vector{AsSparseComplex128Vector(a), AsDenseComplex64Vector(a)} {
+    if !v.Equals(a, 1e-6) {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestComplexMatrix(t *testing.T) {
+  a := NewDenseComplex128Matrix([]complex128{1i, 1, 0, 2-1i}, 2, 2)
+  b := NewDenseComplex128Matrix([]complex128{1, 0, 1i, 1}, 2, 2)
+  r := NullDenseComplex128Matrix(2, 2)
+  r.MdotM(a, b)
+  if !r.Equals(NewDenseComplex128Matrix([]complex128{2i, 1, 1+2i, 2-1i}, 2, 2), 1e-12) {
+    t.Error("test failed")
+  }
+  s := NullSparseComplex128Matrix(2, 2)
+  s.MdotM(a, AsSparseComplex128Matrix(b))
+  if !s.Equals(r, 1e-12) {
+    t.Error("test failed")
+  }
+  c := NullCsrComplex128Matrix(2, 2)
+  c.MdotM(AsCscComplex128Matrix(a), AsCsrComplex128Matrix(b))
+  if !c.Equals(r, 1e-12) {
+    t.Error("test failed")
+  }
+}
+
+func TestComplexJson(t *testing.T) {
+  a := NewDenseComplex128Vector([]complex128{1+1i, 2, 3-1i})
+  b := AsSparseComplex64Vector(a)
+  m := AsDenseComplex128Matrix(NewDenseComplex128Vector([]complex128{1i, 1, 0, 2-1i}).AsMatrix(2, 2))
+  r1 := NullDenseComplex128Vector(0)
+  r2 := NullSparseComplex64Vector(0)
+  r3 := NullDenseComplex128Matrix(0, 0)
+  for i, x := range []interface{}{a, b, m} {
+    data, err := json.Marshal(x)
+    if err != nil {
+      t.Error(err); continue
+    }
+    switch i {
+    case 0: err = json.Unmarshal(data, &r1)
+    case 1: err = json.Unmarshal(data, r2)
+    case 2: err = json.Unmarshal(data, r3)
+    }
+    if err != nil {
+      t.Error(err)
+    }
+  }
+  if !r1.Equals(a, 1e-12) || !r2.Equals(a, 1e-6) || !r3.Equals(m, 1e-12) {
+    t.Error("test failed")
+  }
+  // real numbers are accepted
+  if err := json.Unmarshal([]byte("[1, [2, 3]]"), &r1); err != nil {
+    t.Error(err)
+  }
+  if !r1.Equals(NewDenseComplex128Vector([]complex128{1, 2+3i}), 1e-12) {
+    t.Error("test failed")
+  }
+}
@@ -0,0 +1,153 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "encoding/json"
+import "reflect"
+/* -------------------------------------------------------------------------- */
+type ConstComplex128 complex128
+/* register scalar type
+ * -------------------------------------------------------------------------- */
+var ConstComplex128Type ScalarType = NewConstComplex128(0.0).Type()
+func init() {
+  f := func(value float64) ConstScalar { return NewConstComplex128(complex128(complex(value, 0))) }
+  RegisterConstScalar(ConstComplex128Type, f)
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+func NewConstComplex128(v complex128) ConstComplex128 {
+  return ConstComplex128(v)
+}
+func NullConstComplex128() ConstComplex128 {
+  return ConstComplex128(0.0)
+}
+/* -------------------------------------------------------------------------- */
+func (a ConstComplex128) Clone() ConstComplex128 {
+  return ConstComplex128(a)
+}
+func (a ConstComplex128) CloneConstScalar() ConstScalar {
+  return a.Clone()
+}
+/* -------------------------------------------------------------------------- */
+func (a ConstComplex128) Type() ScalarType {
+  return reflect.TypeOf(a)
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (a ConstComplex128) ConvertConstScalar(t ScalarType) ConstScalar {
+  switch t {
+  case ConstComplex128Type:
+    return a
+  case ConstComplex64Type:
+    return ConstComplex64(a)
+  case ConstComplex128Type:
+    return ConstComplex128(a)
+  default:
+    return NewConstScalar(t, a.GetFloat64())
+  }
+}
+/* stringer
+ * -------------------------------------------------------------------------- */
+func (a ConstComplex128) String() string {
+  return fmt.Sprintf("%v", a.GetComplex128())
+}
+/* read access
+ * -------------------------------------------------------------------------- */
+func (a ConstComplex128) GetInt8() int8 {
+  return int8(real(a))
+}
+func (a ConstComplex128) GetInt16() int16 {
+  return int16(real(a))
+}
+func (a ConstComplex128) GetInt32() int32 {
+  return int32(real(a))
+}
+func (a ConstComplex128) GetInt64() int64 {
+  return int64(real(a))
+}
+func (a ConstComplex128) GetInt() int {
+  return int(real(a))
+}
+func (a ConstComplex128) GetFloat32() float32 {
+  return float32(real(a))
+}
+func (a ConstComplex128) GetFloat64() float64 {
+  return float64(real(a))
+}
+func (a ConstComplex128) GetComplex64() complex64 {
+  return complex64(a)
+}
+func (a ConstComplex128) GetComplex128() complex128 {
+  return complex128(a)
+}
+func (a ConstComplex128) GetOrder() int {
+  return 0
+}
+func (a ConstComplex128) GetDerivative(i int) float64 {
+  return 0.0
+}
+func (a ConstComplex128) GetHessian(i, j int) float64 {
+  return 0.0
+}
+func (a ConstComplex128) GetN() int {
+  return 0
+}
+// Returns the real part.
+func (a ConstComplex128) Real() float64 {
+  return float64(real(a))
+}
+// Returns the imaginary part.
+func (a ConstComplex128) Imag() float64 {
+  return float64(imag(a))
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj ConstComplex128) MarshalJSON() ([]byte, error) {
+  return json.Marshal(complex128Json(obj))
+}
+/* math
+ * -------------------------------------------------------------------------- */
+// Two complex numbers are equal if the modulus of their difference is
+// smaller than epsilon.
+func (a ConstComplex128) Equals(b ConstScalar, epsilon float64) bool {
+  return complexEquals(a.GetComplex128(), b.GetComplex128(), epsilon)
+}
+/* -------------------------------------------------------------------------- */
+// Complex numbers are compared by their real parts.
+func (a ConstComplex128) Greater(b ConstScalar) bool {
+  return a.GetFloat64() > b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+// Complex numbers are compared by their real parts.
+func (a ConstComplex128) Smaller(b ConstScalar) bool {
+  return a.GetFloat64() < b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+// Sign of the real part.
+func (a ConstComplex128) Sign() int {
+  if a.GetFloat64() < 0.0 {
+    return -1
+  }
+  if a.GetFloat64() > 0.0 {
+    return 1
+  }
+  return 0
+}
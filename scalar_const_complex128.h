@@ -0,0 +1,5 @@
+#define SCALAR_NAME ConstComplex128
+#define SCALAR_TYPE complex128
+#define GET_METHOD_NAME GetComplex128
+
+#define STORED_JSON_TYPE complex128Json
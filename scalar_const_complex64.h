@@ -0,0 +1,5 @@
+#define SCALAR_NAME ConstComplex64
+#define SCALAR_TYPE complex64
+#define GET_METHOD_NAME GetComplex64
+
+#define STORED_JSON_TYPE complex64Json
@@ -89,6 +89,12 @@ func (a ConstInt32) GetFloat32() float32 {
 func (a ConstInt32) GetFloat64() float64 {
   return float64(a)
 }
+func (a ConstInt32) GetComplex64() complex64 {
+  return complex(float32(a), 0)
+}
+func (a ConstInt32) GetComplex128() complex128 {
+  return complex(float64(a), 0)
+}
 func (a ConstInt32) GetOrder() int {
   return 0
 }
@@ -105,6 +105,12 @@ func (a Float32) GetFloat32() float32 {
 func (a Float32) GetFloat64() float64 {
   return float64(*a.ptr)
 }
+func (a Float32) GetComplex64() complex64 {
+  return complex(float32(*a.ptr), 0)
+}
+func (a Float32) GetComplex128() complex128 {
+  return complex(float64(*a.ptr), 0)
+}
 func (a Float32) GetOrder() int {
   return 0
 }
@@ -172,6 +178,18 @@ func (a Float32) SetFloat64(v float64) {
 func (a Float32) setFloat64(v float64) {
   *a.ptr = float32(v)
 }
+func (a Float32) SetComplex64(v complex64) {
+  a.setComplex64(v)
+}
+func (a Float32) setComplex64(v complex64) {
+  *a.ptr = float32(real(v))
+}
+func (a Float32) SetComplex128(v complex128) {
+  a.setComplex128(v)
+}
+func (a Float32) setComplex128(v complex128) {
+  *a.ptr = float32(real(v))
+}
 /* -------------------------------------------------------------------------- */
 func (a Float32) nullScalar() bool {
   if a.ptr == nil {
@@ -105,6 +105,12 @@ func (a Int) GetFloat32() float32 {
 func (a Int) GetFloat64() float64 {
   return float64(*a.ptr)
 }
+func (a Int) GetComplex64() complex64 {
+  return complex(float32(*a.ptr), 0)
+}
+func (a Int) GetComplex128() complex128 {
+  return complex(float64(*a.ptr), 0)
+}
 func (a Int) GetOrder() int {
   return 0
 }
@@ -172,6 +178,18 @@ func (a Int) SetFloat64(v float64) {
 func (a Int) setFloat64(v float64) {
   *a.ptr = int(v)
 }
+func (a Int) SetComplex64(v complex64) {
+  a.setComplex64(v)
+}
+func (a Int) setComplex64(v complex64) {
+  *a.ptr = int(real(v))
+}
+func (a Int) SetComplex128(v complex128) {
+  a.setComplex128(v)
+}
+func (a Int) setComplex128(v complex128) {
+  *a.ptr = int(real(v))
+}
 /* -------------------------------------------------------------------------- */
 func (a Int) nullScalar() bool {
   if a.ptr == nil {
@@ -105,6 +105,12 @@ func (a Int16) GetFloat32() float32 {
 func (a Int16) GetFloat64() float64 {
   return float64(*a.ptr)
 }
+func (a Int16) GetComplex64() complex64 {
+  return complex(float32(*a.ptr), 0)
+}
+func (a Int16) GetComplex128() complex128 {
+  return complex(float64(*a.ptr), 0)
+}
 func (a Int16) GetOrder() int {
   return 0
 }
@@ -172,6 +178,18 @@ func (a Int16) SetFloat64(v float64) {
 func (a Int16) setFloat64(v float64) {
   *a.ptr = int16(v)
 }
+func (a Int16) SetComplex64(v complex64) {
+  a.setComplex64(v)
+}
+func (a Int16) setComplex64(v complex64) {
+  *a.ptr = int16(real(v))
+}
+func (a Int16) SetComplex128(v complex128) {
+  a.setComplex128(v)
+}
+func (a Int16) setComplex128(v complex128) {
+  *a.ptr = int16(real(v))
+}
 /* -------------------------------------------------------------------------- */
 func (a Int16) nullScalar() bool {
   if a.ptr == nil {
@@ -105,6 +105,12 @@ func (a Int64) GetFloat32() float32 {
 func (a Int64) GetFloat64() float64 {
   return float64(*a.ptr)
 }
+func (a Int64) GetComplex64() complex64 {
+  return complex(float32(*a.ptr), 0)
+}
+func (a Int64) GetComplex128() complex128 {
+  return complex(float64(*a.ptr), 0)
+}
 func (a Int64) GetOrder() int {
   return 0
 }
@@ -172,6 +178,18 @@ func (a Int64) SetFloat64(v float64) {
 func (a Int64) setFloat64(v float64) {
   *a.ptr = int64(v)
 }
+func (a Int64) SetComplex64(v complex64) {
+  a.setComplex64(v)
+}
+func (a Int64) setComplex64(v complex64) {
+  *a.ptr = int64(real(v))
+}
+func (a Int64) SetComplex128(v complex128) {
+  a.setComplex128(v)
+}
+func (a Int64) setComplex128(v complex128) {
+  *a.ptr = int64(real(v))
+}
 /* -------------------------------------------------------------------------- */
 func (a Int64) nullScalar() bool {
   if a.ptr == nil {
@@ -159,6 +159,12 @@ func (a *Real64) GetFloat32() float32 {
 func (a *Real64) GetFloat64() float64 {
   return float64(a.Value)
 }
+func (a *Real64) GetComplex64() complex64 {
+  return complex(float32(a.Value), 0)
+}
+func (a *Real64) GetComplex128() complex128 {
+  return complex(float64(a.Value), 0)
+}
 // Indicates the maximal order of derivatives that are computed for this
 // variable. `0' means no derivatives, `1' only the first derivative, and
 // `2' the first and second derivative.
@@ -279,6 +285,20 @@ func (a *Real64) SetFloat64(v float64) {
 func (a *Real64) setFloat64(v float64) {
   a.Value = float64(v)
 }
+func (a *Real64) SetComplex64(v complex64) {
+  a.setComplex64(v)
+  a.ResetDerivatives()
+}
+func (a *Real64) setComplex64(v complex64) {
+  a.Value = float64(real(v))
+}
+func (a *Real64) SetComplex128(v complex128) {
+  a.setComplex128(v)
+  a.ResetDerivatives()
+}
+func (a *Real64) setComplex128(v complex128) {
+  a.Value = float64(real(v))
+}
 /* magic write access
  * -------------------------------------------------------------------------- */
 func (a *Real64) ResetDerivatives() {
@@ -0,0 +1,238 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+
+/* reverse mode automatic differentiation
+ * -------------------------------------------------------------------------- */
+
+// A reverseNode is an entry on the tape of a reverse-mode scalar. It
+// records the local partial derivatives of a single operation with respect
+// to its (at most two) arguments. Since nodes are immutable, a scalar that
+// is overwritten by a new operation simply points to a new node, while all
+// other scalars still refer to the old one. Second order partial
+// derivatives are recorded as well, which allows to compute Hessian-vector
+// products with a single forward and backward pass over the tape.
+type reverseNode struct {
+  // index of the variable if this node is a leaf, -1 otherwise
+  index      int
+  // number of variables
+  n          int
+  // arguments of the operation and partial derivatives
+  args     [2]*reverseNode
+  partials [2]float64
+  // second order partial derivatives with respect to (a,a), (a,b) and
+  // (b,b), where a and b denote the arguments
+  hessian  [3]float64
+  // gradient with respect to all variables, used for importing
+  // derivatives from forward-mode scalars
+  gradient []float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+type reverseScalar interface {
+  getReverseNode() *reverseNode
+}
+
+// Returns the tape node of a scalar. Scalars from other types that carry
+// first order derivatives are converted to a node with a fixed gradient.
+func reverseNodeOf(a ConstScalar) *reverseNode {
+  if a == nil {
+    return nil
+  }
+  if r, ok := a.(reverseScalar); ok {
+    return r.getReverseNode()
+  }
+  if a.GetOrder() >= 1 && a.GetN() > 0 {
+    node := reverseNode{index: -1, n: a.GetN()}
+    node.gradient = make([]float64, a.GetN())
+    for i := 0; i < a.GetN(); i++ {
+      node.gradient[i] = a.GetDerivative(i)
+    }
+    return &node
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func newReverseVariable(i, n int) *reverseNode {
+  return &reverseNode{index: i, n: n}
+}
+
+func newReverseGradient(g []float64) *reverseNode {
+  return &reverseNode{index: -1, n: len(g), gradient: g}
+}
+
+func newReverseMonadic(a *reverseNode, v1, v2 float64) *reverseNode {
+  if a == nil {
+    return nil
+  }
+  r := reverseNode{index: -1, n: a.n}
+  r.args    [0] = a
+  r.partials[0] = v1
+  r.hessian [0] = v2
+  return &r
+}
+
+func newReverseDyadic(a, b *reverseNode, v10, v01, v11, v20, v02 float64) *reverseNode {
+  if a == nil {
+    return newReverseMonadic(b, v01, v02)
+  }
+  if b == nil {
+    return newReverseMonadic(a, v10, v20)
+  }
+  if a.n != b.n {
+    panic("automatic differentiation failed: magic variables store different number of partial derivatives; this can be caused by a wrong usage of SetVariable() or by multiple calls of Variables()")
+  }
+  r := reverseNode{index: -1, n: a.n}
+  r.args    [0] = a
+  r.args    [1] = b
+  r.partials[0] = v10
+  r.partials[1] = v01
+  r.hessian [0] = v20
+  r.hessian [1] = v11
+  r.hessian [2] = v02
+  return &r
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Sort all nodes that can be reached from this node topologically, i.e.
+// every node is preceded by its arguments. The sort is implemented as an
+// iterative depth-first search, since recursion depth would otherwise
+// grow with the length of the tape.
+func (node *reverseNode) tape() ([]*reverseNode, map[*reverseNode]int) {
+  type frame struct {
+    node *reverseNode
+    k    int
+  }
+  tape  := []*reverseNode{}
+  index := make(map[*reverseNode]int)
+  stack := []frame{frame{node, 0}}
+  index[node] = -1
+  for len(stack) > 0 {
+    f := &stack[len(stack)-1]
+    if f.k < len(f.node.args) {
+      arg := f.node.args[f.k]
+      f.k++
+      if arg != nil {
+        if _, ok := index[arg]; !ok {
+          index[arg] = -1
+          stack = append(stack, frame{arg, 0})
+        }
+      }
+    } else {
+      index[f.node] = len(tape)
+      tape = append(tape, f.node)
+      stack = stack[0:len(stack)-1]
+    }
+  }
+  return tape, index
+}
+
+// Compute the gradient of this node with respect to all variables in a
+// single backward pass over the tape.
+func (node *reverseNode) backward() []float64 {
+  g := make([]float64, node.n)
+  tape, index := node.tape()
+  adjoint := make([]float64, len(tape))
+  adjoint[len(tape)-1] = 1.0
+  for k := len(tape)-1; k >= 0; k-- {
+    a := adjoint[k]
+    if a == 0.0 {
+      continue
+    }
+    v := tape[k]
+    if v.index >= 0 {
+      g[v.index] += a
+    }
+    for i, d := range v.gradient {
+      g[i] += a*d
+    }
+    for j, arg := range v.args {
+      if arg != nil {
+        adjoint[index[arg]] += a*v.partials[j]
+      }
+    }
+  }
+  return g
+}
+
+// Compute the product H v, where H is the Hessian of this node with respect
+// to all variables. A forward pass computes the directional derivatives of
+// all nodes in direction v, and a backward pass propagates the adjoints
+// together with their directional derivatives. Nodes that were imported
+// from forward-mode scalars contribute only first order derivatives.
+func (node *reverseNode) hessianVectorProduct(v []float64) []float64 {
+  r := make([]float64, node.n)
+  tape, index := node.tape()
+  // directional derivatives
+  tangent := make([]float64, len(tape))
+  for k, u := range tape {
+    if u.index >= 0 {
+      tangent[k] = v[u.index]
+    }
+    for i, d := range u.gradient {
+      tangent[k] += d*v[i]
+    }
+    for j, arg := range u.args {
+      if arg != nil {
+        tangent[k] += u.partials[j]*tangent[index[arg]]
+      }
+    }
+  }
+  // adjoints and their directional derivatives
+  adjoint1 := make([]float64, len(tape))
+  adjoint2 := make([]float64, len(tape))
+  adjoint1[len(tape)-1] = 1.0
+  for k := len(tape)-1; k >= 0; k-- {
+    a1 := adjoint1[k]
+    a2 := adjoint2[k]
+    if a1 == 0.0 && a2 == 0.0 {
+      continue
+    }
+    u := tape[k]
+    if u.index >= 0 {
+      r[u.index] += a2
+    }
+    for i, d := range u.gradient {
+      r[i] += a2*d
+    }
+    // second order terms
+    t := [2]float64{}
+    if arg := u.args[0]; arg != nil {
+      t[0] += u.hessian[0]*tangent[index[arg]]
+      t[1] += u.hessian[1]*tangent[index[arg]]
+    }
+    if arg := u.args[1]; arg != nil {
+      t[0] += u.hessian[1]*tangent[index[arg]]
+      t[1] += u.hessian[2]*tangent[index[arg]]
+    }
+    for j, arg := range u.args {
+      if arg != nil {
+        adjoint1[index[arg]] += a1*u.partials[j]
+        adjoint2[index[arg]] += a2*u.partials[j] + a1*t[j]
+      }
+    }
+  }
+  return r
+}
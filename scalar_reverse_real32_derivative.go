@@ -0,0 +1,92 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+/* derivatives of monadic functions
+ * -------------------------------------------------------------------------- */
+// Record c = f(a) on the tape, where
+// - a  = g(x0)
+// - v0 = f(a)
+// - v1 = d/dx f(x) | x=a
+// - v2 = d^2/dx^2 f(x) | x=a
+func (c *ReverseReal32) monadic(a ConstScalar, v0, v1, v2 float64) *ReverseReal32 {
+  c.node = newReverseMonadic(reverseNodeOf(a), v1, v2)
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) monadicLazy(a ConstScalar, v0 float64, f1, f2 func () float64) *ReverseReal32 {
+  if node := reverseNodeOf(a); node != nil {
+    c.node = newReverseMonadic(node, f1(), f2())
+  } else {
+    c.node = nil
+  }
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) realMonadic(a *ReverseReal32, v0, v1, v2 float64) *ReverseReal32 {
+  c.node = newReverseMonadic(a.node, v1, v2)
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) realMonadicLazy(a *ReverseReal32, v0 float64, f1, f2 func() float64) *ReverseReal32 {
+  if a.node != nil {
+    c.node = newReverseMonadic(a.node, f1(), f2())
+  } else {
+    c.node = nil
+  }
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+/* derivatives of dyadic functions
+ * -------------------------------------------------------------------------- */
+func (c *ReverseReal32) dyadic(a, b ConstScalar, v0, v10, v01, v11, v20, v02 float64) *ReverseReal32 {
+  c.node = newReverseDyadic(reverseNodeOf(a), reverseNodeOf(b), v10, v01, v11, v20, v02)
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) dyadicLazy(a, b ConstScalar, v0 float64, f1 func() (float64, float64), f2 func() (float64, float64, float64)) *ReverseReal32 {
+  na := reverseNodeOf(a)
+  nb := reverseNodeOf(b)
+  if na != nil || nb != nil {
+    v10, v01 := f1()
+    v11, v20, v02 := f2()
+    c.node = newReverseDyadic(na, nb, v10, v01, v11, v20, v02)
+  } else {
+    c.node = nil
+  }
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) realDyadic(a, b *ReverseReal32, v0, v10, v01, v11, v20, v02 float64) *ReverseReal32 {
+  c.node = newReverseDyadic(a.node, b.node, v10, v01, v11, v20, v02)
+  // compute new value
+  c.setFloat64(v0)
+  return c
+}
+func (c *ReverseReal32) realDyadicLazy(a, b ConstScalar, v0 float64, f1 func() (float64, float64), f2 func() (float64, float64, float64)) *ReverseReal32 {
+  return c.dyadicLazy(a, b, v0, f1, f2)
+}
@@ -0,0 +1,351 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "encoding/json"
+import "math"
+import "reflect"
+/* -------------------------------------------------------------------------- */
+// A reverse-mode scalar records all operations on a tape. The gradient
+// with respect to all variables is computed with a single backward pass
+// when the first derivative is requested. Only first order derivatives
+// are supported.
+type ReverseReal64 struct {
+  Value float64
+  node *reverseNode
+  // result of the last backward pass
+  gradient []float64
+  gradientNode *reverseNode
+}
+/* register scalar type
+ * -------------------------------------------------------------------------- */
+var ReverseReal64Type ScalarType = NewReverseReal64(0.0).Type()
+func init() {
+  f := func(value float64) Scalar { return NewReverseReal64(float64(value)) }
+  RegisterScalar(ReverseReal64Type, f)
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+// Create a new real constant or variable.
+func NewReverseReal64(v float64) *ReverseReal64 {
+  s := ReverseReal64{}
+  s.Value = v
+  return &s
+}
+func NullReverseReal64() *ReverseReal64 {
+  return NewReverseReal64(0.0)
+}
+/* -------------------------------------------------------------------------- */
+func (a *ReverseReal64) Clone() *ReverseReal64 {
+  r := NewReverseReal64(0.0)
+  r.Set(a)
+  return r
+}
+func (a *ReverseReal64) CloneConstScalar() ConstScalar {
+  return a.Clone()
+}
+func (a *ReverseReal64) CloneScalar() Scalar {
+  return a.Clone()
+}
+func (a *ReverseReal64) CloneMagicScalar() MagicScalar {
+  return a.Clone()
+}
+/* -------------------------------------------------------------------------- */
+func (a *ReverseReal64) Type() ScalarType {
+  return reflect.TypeOf(a)
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (a *ReverseReal64) ConvertScalar(t ScalarType) Scalar {
+  switch t {
+  case ReverseReal64Type:
+    return a
+  default:
+    r := NullScalar(t)
+    r.Set(a)
+    return r
+  }
+}
+func (a *ReverseReal64) ConvertMagicScalar(t ScalarType) MagicScalar {
+  switch t {
+  case ReverseReal64Type:
+    return a
+  default:
+    r, ok := NullScalar(t).(MagicScalar)
+    if !ok {
+      panic(fmt.Sprintf("invalid magic scalar type `%v'", t))
+    }
+    r.Set(a)
+    return r
+  }
+}
+func (a *ReverseReal64) ConvertConstScalar(t ScalarType) ConstScalar {
+  switch t {
+  case ReverseReal64Type:
+    return a
+  default:
+    return NewConstScalar(t, a.GetFloat64())
+  }
+}
+/* stringer
+ * -------------------------------------------------------------------------- */
+func (a *ReverseReal64) String() string {
+  return fmt.Sprintf("%v", a.GetFloat64())
+}
+/* -------------------------------------------------------------------------- */
+// Memory for derivatives is allocated by the backward pass, hence
+// there is nothing to do here.
+func (a *ReverseReal64) Alloc(n, order int) {
+}
+func (c *ReverseReal64) AllocForOne(a ConstScalar) {
+}
+func (c *ReverseReal64) AllocForTwo(a, b ConstScalar) {
+}
+/* read access
+ * -------------------------------------------------------------------------- */
+func (a *ReverseReal64) GetInt8() int8 {
+  return int8(a.Value)
+}
+func (a *ReverseReal64) GetInt16() int16 {
+  return int16(a.Value)
+}
+func (a *ReverseReal64) GetInt32() int32 {
+  return int32(a.Value)
+}
+func (a *ReverseReal64) GetInt64() int64 {
+  return int64(a.Value)
+}
+func (a *ReverseReal64) GetInt() int {
+  return int(a.Value)
+}
+func (a *ReverseReal64) GetFloat32() float32 {
+  return float32(a.Value)
+}
+func (a *ReverseReal64) GetFloat64() float64 {
+  return float64(a.Value)
+}
+func (a *ReverseReal64) GetComplex64() complex64 {
+  return complex(float32(a.Value), 0)
+}
+func (a *ReverseReal64) GetComplex128() complex128 {
+  return complex(float64(a.Value), 0)
+}
+// Indicates the maximal order of derivatives that are computed for this
+// variable. `0' means no derivatives and `1' the first derivative.
+func (a *ReverseReal64) GetOrder() int {
+  if a.node == nil {
+    return 0
+  }
+  return 1
+}
+// Returns the value of the variable on log scale.
+func (a *ReverseReal64) GetLogValue() float64 {
+  return math.Log(float64(a.Value))
+}
+// Returns the derivative of the ith variable. The full gradient is
+// computed by a backward pass over the tape when this method is called
+// for the first time after the scalar has changed.
+func (a *ReverseReal64) GetDerivative(i int) float64 {
+  if a.node == nil {
+    return 0.0
+  }
+  return a.getGradient()[i]
+}
+func (a *ReverseReal64) GetHessian(i, j int) float64 {
+  return 0.0
+}
+// Number of variables for which derivates are stored.
+func (a *ReverseReal64) GetN() int {
+  if a.node == nil {
+    return 0
+  }
+  return a.node.n
+}
+func (a *ReverseReal64) getGradient() []float64 {
+  if a.gradientNode != a.node {
+    a.gradient = a.node.backward()
+    a.gradientNode = a.node
+  }
+  return a.gradient
+}
+func (a *ReverseReal64) getReverseNode() *reverseNode {
+  return a.node
+}
+/* write access
+ * -------------------------------------------------------------------------- */
+func (a *ReverseReal64) Reset() {
+  a.Value = 0.0
+  a.ResetDerivatives()
+}
+// Set the state to b. This includes the value and the position on the tape.
+func (a *ReverseReal64) Set(b ConstScalar) {
+  a.node = reverseNodeOf(b)
+  a.Value = float64(b.GetFloat64())
+}
+func (a *ReverseReal64) SET(b *ReverseReal64) {
+  a.node = b.node
+  a.Value = b.Value
+}
+// Set the value of the variable. All derivatives are reset to zero.
+func (a *ReverseReal64) SetInt8(v int8) {
+  a.setInt8(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setInt8(v int8) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetInt16(v int16) {
+  a.setInt16(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setInt16(v int16) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetInt32(v int32) {
+  a.setInt32(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setInt32(v int32) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetInt64(v int64) {
+  a.setInt64(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setInt64(v int64) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetInt(v int) {
+  a.setInt(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setInt(v int) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetFloat32(v float32) {
+  a.setFloat32(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setFloat32(v float32) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetFloat64(v float64) {
+  a.setFloat64(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setFloat64(v float64) {
+  a.Value = float64(v)
+}
+func (a *ReverseReal64) SetComplex64(v complex64) {
+  a.setComplex64(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setComplex64(v complex64) {
+  a.Value = float64(real(v))
+}
+func (a *ReverseReal64) SetComplex128(v complex128) {
+  a.setComplex128(v)
+  a.ResetDerivatives()
+}
+func (a *ReverseReal64) setComplex128(v complex128) {
+  a.Value = float64(real(v))
+}
+/* magic write access
+ * -------------------------------------------------------------------------- */
+// Detach the scalar from the tape.
+func (a *ReverseReal64) ResetDerivatives() {
+  a.node = nil
+}
+// Set the derivative of the ith variable to v. The gradient is
+// computed and stored on a new node of the tape, which detaches
+// the scalar from all previous operations.
+func (a *ReverseReal64) SetDerivative(i int, v float64) {
+  g := make([]float64, a.GetN())
+  copy(g, a.getGradient())
+  g[i] = v
+  a.node = newReverseGradient(g)
+}
+func (a *ReverseReal64) SetHessian(i, j int, v float64) {
+  panic("second order derivatives are not supported by this type")
+}
+// Record the scalar as the ith of n variables on the tape.
+func (a *ReverseReal64) SetVariable(i, n, order int) error {
+  if order > 1 {
+    return fmt.Errorf("order `%d' not supported by this type", order)
+  }
+  if order > 0 {
+    a.node = newReverseVariable(i, n)
+  } else {
+    a.node = nil
+  }
+  return nil
+}
+/* -------------------------------------------------------------------------- */
+func (a *ReverseReal64) nullScalar() bool {
+  if a == nil {
+    return true
+  }
+  if a.Value != 0 {
+    return false
+  }
+  if a.GetOrder() >= 1 {
+    for i := 0; i < a.GetN(); i++ {
+      if v := a.GetDerivative(i); v != 0.0 {
+        return false
+      }
+    }
+  }
+  return true
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj *ReverseReal64) MarshalJSON() ([]byte, error) {
+  t1 := false
+  if obj.GetOrder() > 0 && obj.GetN() > 0 {
+    // check for non-zero derivatives
+    for i := 0; !t1 && i < obj.GetN(); i++ {
+      if obj.GetDerivative(i) != 0.0 {
+        t1 = true
+      }
+    }
+  }
+  if t1 {
+    r := struct{Value float64; Derivative []float64}{
+      obj.Value, obj.getGradient()}
+    return json.Marshal(r)
+  } else {
+    return json.Marshal(obj.Value)
+  }
+}
+func (obj *ReverseReal64) UnmarshalJSON(data []byte) error {
+  r := struct{Value float64; Derivative []float64}{}
+  if err := json.Unmarshal(data, &r); err == nil {
+    obj.Value = r.Value
+    if len(r.Derivative) != 0 {
+      obj.node = newReverseGradient(r.Derivative)
+    } else {
+      obj.node = nil
+    }
+    return nil
+  } else {
+    return json.Unmarshal(data, &obj.Value)
+  }
+}
@@ -0,0 +1,141 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+import "math"
+import "testing"
+
+/* -------------------------------------------------------------------------- */
+
+func TestReverseReal1(t *testing.T) {
+
+  f := func(x ConstVector, r Scalar) {
+    t1 := NullScalar(r.Type())
+    t2 := NullScalar(r.Type())
+    t3 := NullScalar(r.Type())
+    // r = exp(x0*x1)/x2 + lgamma(x0) + x1^x2
+    t1.Mul(x.ConstAt(0), x.ConstAt(1))
+    t1.Exp(t1)
+    t1.Div(t1, x.ConstAt(2))
+    t2.Lgamma(x.ConstAt(0))
+    t1.Add(t1, t2)
+    t2.Pow(x.ConstAt(1), x.ConstAt(2))
+    t1.Add(t1, t2)
+    // r = r + log(1 + exp(x2 - x0)) + sigmoid(x1)
+    t2.LogAdd(ConstFloat64(0.0), t2.Sub(x.ConstAt(2), x.ConstAt(0)), t3)
+    t1.Add(t1, t2)
+    t2.Sigmoid(x.ConstAt(1), t3)
+    t1.Add(t1, t2)
+    // r = r + besselI(2, x2) * tanh(x0)
+    t2.BesselI(2.0, x.ConstAt(2))
+    t3.Tanh(x.ConstAt(0))
+    r .Mul(t2, t3)
+    r .Add(r, t1)
+  }
+  x1 := NewDenseReal64Vector([]float64{1.2, 0.7, 2.3})
+  x2 := NewDenseReverseReal64Vector([]float64{1.2, 0.7, 2.3})
+  x1.Variables(1)
+  x2.Variables(1)
+
+  r1 := NullReal64()
+  r2 := NullReverseReal64()
+  f(x1, r1)
+  f(x2, r2)
+
+  if math.Abs(r1.GetFloat64() - r2.GetFloat64()) > 1e-12 {
+    t.Error("test failed")
+  }
+  if r2.GetN() != 3 || r2.GetOrder() != 1 {
+    t.Error("test failed")
+  }
+  for i := 0; i < 3; i++ {
+    if math.Abs(r1.GetDerivative(i) - r2.GetDerivative(i)) > 1e-10 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestReverseReal2(t *testing.T) {
+  n := 10000
+  x := NullDenseReverseReal64Vector(n)
+  for i := 0; i < n; i++ {
+    x[i].SetFloat64(float64(i)/float64(n))
+  }
+  x.Variables(1)
+  // r = sum_i x_i^2
+  r := NullReverseReal64()
+  s := NullReverseReal64()
+  for i := 0; i < n; i++ {
+    s.Mul(x[i], x[i])
+    r.Add(r, s)
+  }
+  for i := 0; i < n; i++ {
+    if math.Abs(r.GetDerivative(i) - 2.0*x[i].GetFloat64()) > 1e-12 {
+      t.Error("test failed"); break
+    }
+  }
+}
+
+func TestReverseReal3(t *testing.T) {
+  // import derivatives from a forward-mode scalar
+  a := NewReal64(2.0)
+  b := NewReal64(3.0)
+  Variables(1, a, b)
+  a.Mul(a, b)
+
+  r := NullReverseReal64()
+  r.Set(a)
+  r.Mul(r, r)
+
+  if math.Abs(r.GetDerivative(0) - 36.0) > 1e-12 ||
+     math.Abs(r.GetDerivative(1) - 24.0) > 1e-12 {
+    t.Error("test failed")
+  }
+  // overwrite single derivative
+  r.SetDerivative(1, 1.0)
+  if r.GetDerivative(0) != 36.0 || r.GetDerivative(1) != 1.0 {
+    t.Error("test failed")
+  }
+  // second order derivatives are not supported
+  if err := r.SetVariable(0, 1, 2); err == nil {
+    t.Error("test failed")
+  }
+  r.ResetDerivatives()
+  if r.GetOrder() != 0 || r.GetDerivative(0) != 0.0 {
+    t.Error("test failed")
+  }
+}
+
+func TestReverseReal4(t *testing.T) {
+  a := NewDenseReverseReal64Matrix([]float64{1, 2, 3, 4}, 2, 2)
+  x := NewDenseReverseReal64Vector([]float64{5, 6})
+  x.Variables(1)
+
+  // r = x^T A x
+  y := NullDenseReverseReal64Vector(2)
+  y.MdotV(a, x)
+  r := NullReverseReal64()
+  r.VdotV(x, y)
+
+  // gradient: (A + A^T) x
+  if r.GetDerivative(0) != 2*5+5*6 || r.GetDerivative(1) != 5*5+2*4*6 {
+    t.Error("test failed")
+  }
+}
@@ -0,0 +1,239 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+import "fmt"
+
+/* taylor mode automatic differentiation
+ * -------------------------------------------------------------------------- */
+
+// A Taylor-mode scalar represents a function t -> f(x + t v) by its
+// truncated Taylor polynomial
+//
+//   c[0] + c[1] t + c[2] t^2 + ... + c[K] t^K
+//
+// where c[k] = 1/k! d^k/dt^k f(x + t v) at t = 0. The following functions
+// operate on coefficient slices of length K+1.
+
+type taylorScalar interface {
+  getTaylor() []float64
+  SetDirection(order int, v float64)
+}
+
+// Returns the Taylor coefficients of a, including the value at index
+// zero. Scalars of other types that depend on a single variable are
+// converted using their first two derivatives.
+func taylorCoefficientsOf(a ConstScalar) []float64 {
+  if r, ok := a.(taylorScalar); ok {
+    return r.getTaylor()
+  }
+  if a.GetOrder() >= 1 && a.GetN() == 1 {
+    if a.GetOrder() >= 2 {
+      return []float64{a.GetFloat64(), a.GetDerivative(0), a.GetHessian(0, 0)/2.0}
+    }
+    return []float64{a.GetFloat64(), a.GetDerivative(0)}
+  }
+  return []float64{a.GetFloat64()}
+}
+
+// Returns the kth coefficient of a, which is zero if k exceeds the order
+// of a.
+func taylorAt(a []float64, k int) float64 {
+  if k < len(a) {
+    return a[k]
+  }
+  return 0.0
+}
+
+// Returns a copy of a with at least n coefficients.
+func taylorExtend(a []float64, n int) []float64 {
+  r := make([]float64, iMax(len(a), n))
+  copy(r, a)
+  return r
+}
+
+/* -------------------------------------------------------------------------- */
+
+func taylorAdd(a, b []float64, s float64) []float64 {
+  r := make([]float64, iMax(len(a), len(b)))
+  for k := range r {
+    r[k] = taylorAt(a, k) + s*taylorAt(b, k)
+  }
+  return r
+}
+
+func taylorScale(a []float64, s float64) []float64 {
+  r := make([]float64, len(a))
+  for k := range r {
+    r[k] = s*a[k]
+  }
+  return r
+}
+
+// Product of two polynomials truncated at order n-1.
+func taylorMul(a, b []float64, n int) []float64 {
+  r := make([]float64, n)
+  for k := 0; k < n; k++ {
+    for j := 0; j <= k; j++ {
+      r[k] += taylorAt(a, j)*taylorAt(b, k-j)
+    }
+  }
+  return r
+}
+
+func taylorDiv(a, b []float64, n int) []float64 {
+  r := make([]float64, n)
+  for k := 0; k < n; k++ {
+    s := taylorAt(a, k)
+    for j := 1; j <= k; j++ {
+      s -= taylorAt(b, j)*r[k-j]
+    }
+    r[k] = s/b[0]
+  }
+  return r
+}
+
+// Compute f(a) given the derivatives d[j] of f at a[0], i.e.
+//
+//   f(a) = sum_j d[j]/j! (a - a[0])^j
+func taylorCompose(a, d []float64) []float64 {
+  n := len(a)
+  r := make([]float64, n)
+  // h = (a - a[0])^j / j!
+  h := make([]float64, n)
+  h[0] = 1.0
+  r[0] = d[0]
+  for j := 1; j < n; j++ {
+    t := make([]float64, n)
+    for k := j; k < n; k++ {
+      for i := 1; i <= k-j+1; i++ {
+        t[k] += a[i]*h[k-i]
+      }
+      t[k] /= float64(j)
+    }
+    h = t
+    for k := j; k < n; k++ {
+      r[k] += d[j]*h[k]
+    }
+  }
+  return r
+}
+
+// Compute f(a) given f(a[0]) and the polynomial g = f'(a), which must be
+// known up to order n-2.
+func taylorIntegrate(a []float64, f0 float64, g []float64) []float64 {
+  n := len(a)
+  r := make([]float64, n)
+  r[0] = f0
+  for k := 1; k < n; k++ {
+    for j := 1; j <= k; j++ {
+      r[k] += float64(j)*a[j]*taylorAt(g, k-j)
+    }
+    r[k] /= float64(k)
+  }
+  return r
+}
+
+func taylorExp(a []float64, f0 float64) []float64 {
+  n := len(a)
+  r := make([]float64, n)
+  r[0] = f0
+  for k := 1; k < n; k++ {
+    for j := 1; j <= k; j++ {
+      r[k] += float64(j)*a[j]*r[k-j]
+    }
+    r[k] /= float64(k)
+  }
+  return r
+}
+
+func taylorLog(a []float64, f0 float64) []float64 {
+  n := len(a)
+  r := make([]float64, n)
+  r[0] = f0
+  for k := 1; k < n; k++ {
+    s := 0.0
+    for j := 1; j < k; j++ {
+      s += float64(j)*r[j]*a[k-j]
+    }
+    r[k] = (a[k] - s/float64(k))/a[0]
+  }
+  return r
+}
+
+// Compute f(a) for functions that satisfy f' = 1 + s f^2, i.e. tan (s = 1)
+// and tanh (s = -1).
+func taylorRiccati(a []float64, f0, s float64) []float64 {
+  n := len(a)
+  r := make([]float64, n)
+  g := make([]float64, n)
+  r[0] = f0
+  g[0] = 1.0 + s*f0*f0
+  for k := 1; k < n; k++ {
+    for j := 1; j <= k; j++ {
+      r[k] += float64(j)*a[j]*g[k-j]
+    }
+    r[k] /= float64(k)
+    for j := 0; j <= k; j++ {
+      g[k] += s*r[j]*r[k-j]
+    }
+  }
+  return r
+}
+
+// Returns the first n-1 derivatives of erf at x multiplied by s/erf'(x),
+// i.e. d[j] = s (-1)^(j-1) H_(j-1)(x), where H_j denotes the jth Hermite
+// polynomial. The value d[0] is left at zero.
+func taylorErfDerivatives(x float64, n int, s float64) []float64 {
+  d := make([]float64, n)
+  // Hermite polynomials H_(j-2) and H_(j-1)
+  h1, h2 := 0.0, 1.0
+  for j := 1; j < n; j++ {
+    if j > 1 {
+      h1, h2 = h2, 2.0*x*h2 - 2.0*float64(j-2)*h1
+    }
+    if j % 2 == 1 {
+      d[j] =  s*h2
+    } else {
+      d[j] = -s*h2
+    }
+  }
+  return d
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Treat x as a point on the line x + t v. Scalars computed from x then
+// hold the Taylor polynomial of f(x + t v) up to the given order, so that
+// the kth directional derivative of f at x in direction v is obtained with
+// GetDirectionalDerivative(k). All elements of x must be Taylor-mode
+// scalars.
+func SetTaylorDirection(x Vector, v ConstVector, order int) error {
+  if x.Dim() != v.Dim() {
+    return fmt.Errorf("vector dimensions do not match")
+  }
+  for i := 0; i < x.Dim(); i++ {
+    s, ok := x.At(i).(taylorScalar)
+    if !ok {
+      return fmt.Errorf("element `%d' is not a Taylor-mode scalar", i)
+    }
+    s.SetDirection(order, v.Float64At(i))
+  }
+  return nil
+}
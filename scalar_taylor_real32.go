@@ -0,0 +1,378 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "encoding/json"
+import "math"
+import "reflect"
+import "github.com/pbenner/autodiff/special"
+/* -------------------------------------------------------------------------- */
+// A Taylor-mode scalar propagates a truncated Taylor polynomial of
+// arbitrary order. It represents a function t -> f(x + t v), where v is
+// the direction set with SetDirection() or SetTaylorDirection(). From the
+// point of view of the MagicScalar interface, t is the only variable.
+type TaylorReal32 struct {
+  Value float32
+  // Taylor coefficients c[1], ..., c[K]
+  Taylor []float64
+}
+/* register scalar type
+ * -------------------------------------------------------------------------- */
+var TaylorReal32Type ScalarType = NewTaylorReal32(0.0).Type()
+func init() {
+  f := func(value float64) Scalar { return NewTaylorReal32(float32(value)) }
+  RegisterScalar(TaylorReal32Type, f)
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+// Create a new real constant or variable.
+func NewTaylorReal32(v float32) *TaylorReal32 {
+  s := TaylorReal32{}
+  s.Value = v
+  return &s
+}
+func NullTaylorReal32() *TaylorReal32 {
+  return NewTaylorReal32(0.0)
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Clone() *TaylorReal32 {
+  r := NewTaylorReal32(0.0)
+  r.Set(a)
+  return r
+}
+func (a *TaylorReal32) CloneConstScalar() ConstScalar {
+  return a.Clone()
+}
+func (a *TaylorReal32) CloneScalar() Scalar {
+  return a.Clone()
+}
+func (a *TaylorReal32) CloneMagicScalar() MagicScalar {
+  return a.Clone()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Type() ScalarType {
+  return reflect.TypeOf(a)
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (a *TaylorReal32) ConvertScalar(t ScalarType) Scalar {
+  switch t {
+  case TaylorReal32Type:
+    return a
+  default:
+    r := NullScalar(t)
+    r.Set(a)
+    return r
+  }
+}
+func (a *TaylorReal32) ConvertMagicScalar(t ScalarType) MagicScalar {
+  switch t {
+  case TaylorReal32Type:
+    return a
+  default:
+    r, ok := NullScalar(t).(MagicScalar)
+    if !ok {
+      panic(fmt.Sprintf("invalid magic scalar type `%v'", t))
+    }
+    r.Set(a)
+    return r
+  }
+}
+func (a *TaylorReal32) ConvertConstScalar(t ScalarType) ConstScalar {
+  switch t {
+  case TaylorReal32Type:
+    return a
+  default:
+    return NewConstScalar(t, a.GetFloat64())
+  }
+}
+/* stringer
+ * -------------------------------------------------------------------------- */
+func (a *TaylorReal32) String() string {
+  return fmt.Sprintf("%v", a.GetFloat32())
+}
+/* -------------------------------------------------------------------------- */
+// Allocate memory for Taylor coefficients up to the given order. Since
+// the scalar depends on a single variable, n is ignored.
+func (a *TaylorReal32) Alloc(n, order int) {
+  if len(a.Taylor) != order {
+    a.Taylor = make([]float64, order)
+  }
+}
+func (c *TaylorReal32) AllocForOne(a ConstScalar) {
+  c.Alloc(1, len(taylorCoefficientsOf(a))-1)
+}
+func (c *TaylorReal32) AllocForTwo(a, b ConstScalar) {
+  c.Alloc(1, iMax(len(taylorCoefficientsOf(a)), len(taylorCoefficientsOf(b)))-1)
+}
+/* read access
+ * -------------------------------------------------------------------------- */
+func (a *TaylorReal32) GetInt8() int8 {
+  return int8(a.Value)
+}
+func (a *TaylorReal32) GetInt16() int16 {
+  return int16(a.Value)
+}
+func (a *TaylorReal32) GetInt32() int32 {
+  return int32(a.Value)
+}
+func (a *TaylorReal32) GetInt64() int64 {
+  return int64(a.Value)
+}
+func (a *TaylorReal32) GetInt() int {
+  return int(a.Value)
+}
+func (a *TaylorReal32) GetFloat32() float32 {
+  return float32(a.Value)
+}
+func (a *TaylorReal32) GetFloat64() float64 {
+  return float64(a.Value)
+}
+func (a *TaylorReal32) GetComplex64() complex64 {
+  return complex(float32(a.Value), 0)
+}
+func (a *TaylorReal32) GetComplex128() complex128 {
+  return complex(float64(a.Value), 0)
+}
+// Indicates the order of the Taylor polynomial.
+func (a *TaylorReal32) GetOrder() int {
+  return len(a.Taylor)
+}
+// Returns the value of the variable on log scale.
+func (a *TaylorReal32) GetLogValue() float64 {
+  return math.Log(float64(a.Value))
+}
+// Returns the first derivative with respect to t. The scalar depends on
+// a single variable, hence i must be zero.
+func (a *TaylorReal32) GetDerivative(i int) float64 {
+  if i != 0 {
+    panic("index out of bounds")
+  }
+  return a.GetTaylorCoefficient(1)
+}
+// Returns the second derivative with respect to t. The scalar depends on
+// a single variable, hence i and j must be zero.
+func (a *TaylorReal32) GetHessian(i, j int) float64 {
+  if i != 0 || j != 0 {
+    panic("index out of bounds")
+  }
+  return 2.0*a.GetTaylorCoefficient(2)
+}
+// Number of variables for which derivates are stored.
+func (a *TaylorReal32) GetN() int {
+  if len(a.Taylor) == 0 {
+    return 0
+  }
+  return 1
+}
+// Returns the kth Taylor coefficient, i.e. the kth directional derivative
+// divided by k!. The coefficient is zero if k exceeds the order of the
+// polynomial.
+func (a *TaylorReal32) GetTaylorCoefficient(k int) float64 {
+  switch {
+  case k == 0:
+    return float64(a.Value)
+  case k <= len(a.Taylor):
+    return a.Taylor[k-1]
+  default:
+    return 0.0
+  }
+}
+// Returns the kth directional derivative d^k/dt^k f(x + t v) at t = 0.
+func (a *TaylorReal32) GetDirectionalDerivative(k int) float64 {
+  return special.Factorial(k)*a.GetTaylorCoefficient(k)
+}
+func (a *TaylorReal32) getTaylor() []float64 {
+  r := make([]float64, len(a.Taylor)+1)
+  r[0] = float64(a.Value)
+  copy(r[1:], a.Taylor)
+  return r
+}
+/* write access
+ * -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Reset() {
+  a.Value = 0.0
+  a.ResetDerivatives()
+}
+// Set the state to b. This includes the value and all Taylor coefficients.
+func (a *TaylorReal32) Set(b ConstScalar) {
+  a.setTaylor(taylorCoefficientsOf(b))
+}
+func (a *TaylorReal32) SET(b *TaylorReal32) {
+  a.Value = b.Value
+  a.Alloc(1, len(b.Taylor))
+  copy(a.Taylor, b.Taylor)
+}
+func (a *TaylorReal32) setTaylor(c []float64) {
+  a.Value = float32(c[0])
+  a.Alloc(1, len(c)-1)
+  copy(a.Taylor, c[1:])
+}
+// Set the value of the variable. All derivatives are reset to zero.
+func (a *TaylorReal32) SetInt8(v int8) {
+  a.setInt8(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setInt8(v int8) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetInt16(v int16) {
+  a.setInt16(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setInt16(v int16) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetInt32(v int32) {
+  a.setInt32(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setInt32(v int32) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetInt64(v int64) {
+  a.setInt64(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setInt64(v int64) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetInt(v int) {
+  a.setInt(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setInt(v int) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetFloat32(v float32) {
+  a.setFloat32(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setFloat32(v float32) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetFloat64(v float64) {
+  a.setFloat64(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setFloat64(v float64) {
+  a.Value = float32(v)
+}
+func (a *TaylorReal32) SetComplex64(v complex64) {
+  a.setComplex64(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setComplex64(v complex64) {
+  a.Value = float32(real(v))
+}
+func (a *TaylorReal32) SetComplex128(v complex128) {
+  a.setComplex128(v)
+  a.ResetDerivatives()
+}
+func (a *TaylorReal32) setComplex128(v complex128) {
+  a.Value = float32(real(v))
+}
+/* magic write access
+ * -------------------------------------------------------------------------- */
+func (a *TaylorReal32) ResetDerivatives() {
+  a.Taylor = nil
+}
+func (a *TaylorReal32) SetDerivative(i int, v float64) {
+  if i != 0 {
+    panic("index out of bounds")
+  }
+  a.SetTaylorCoefficient(1, v)
+}
+func (a *TaylorReal32) SetHessian(i, j int, v float64) {
+  if i != 0 || j != 0 {
+    panic("index out of bounds")
+  }
+  a.SetTaylorCoefficient(2, v/2.0)
+}
+// Set the kth Taylor coefficient. The order of the polynomial is
+// increased if necessary.
+func (a *TaylorReal32) SetTaylorCoefficient(k int, v float64) {
+  if k == 0 {
+    a.Value = float32(v)
+    return
+  }
+  if k > len(a.Taylor) {
+    t := make([]float64, k)
+    copy(t, a.Taylor)
+    a.Taylor = t
+  }
+  a.Taylor[k-1] = v
+}
+// Set the scalar to x + t v, where x is the current value. All Taylor
+// polynomials computed from this scalar are truncated at the given order.
+func (a *TaylorReal32) SetDirection(order int, v float64) {
+  a.Taylor = make([]float64, order)
+  if order > 0 {
+    a.Taylor[0] = v
+  }
+}
+// Taylor-mode scalars depend on a single variable t. Use SetDirection()
+// or SetTaylorDirection() for computing directional derivatives of
+// functions with multiple arguments.
+func (a *TaylorReal32) SetVariable(i, n, order int) error {
+  if n != 1 || i != 0 {
+    return fmt.Errorf("Taylor-mode scalars support only a single variable")
+  }
+  a.SetDirection(order, 1.0)
+  return nil
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) nullScalar() bool {
+  if a == nil {
+    return true
+  }
+  if a.Value != 0 {
+    return false
+  }
+  for _, v := range a.Taylor {
+    if v != 0.0 {
+      return false
+    }
+  }
+  return true
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj *TaylorReal32) MarshalJSON() ([]byte, error) {
+  if len(obj.Taylor) > 0 {
+    r := struct{Value float32; Taylor []float64}{
+      obj.Value, obj.Taylor}
+    return json.Marshal(r)
+  } else {
+    return json.Marshal(obj.Value)
+  }
+}
+func (obj *TaylorReal32) UnmarshalJSON(data []byte) error {
+  r := struct{Value float32; Taylor []float64}{}
+  if err := json.Unmarshal(data, &r); err == nil {
+    obj.Value = r.Value
+    obj.Taylor = r.Taylor
+    return nil
+  } else {
+    obj.Taylor = nil
+    return json.Unmarshal(data, &obj.Value)
+  }
+}
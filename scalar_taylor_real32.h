@@ -0,0 +1,5 @@
+#define SCALAR_NAME  TaylorReal32
+#define SCALAR_CONST ConstFloat32
+#define SCALAR_TYPE  float32
+#define GET_METHOD_NAME GetFloat32
+#define SET_METHOD_NAME SetFloat32
@@ -0,0 +1,469 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+import "math"
+import "github.com/pbenner/autodiff/special"
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Equals(b ConstScalar, epsilon float64) bool {
+  v1 := a.GetFloat64()
+  v2 := b.GetFloat64()
+  return math.Abs(v1 - v2) < epsilon ||
+        (math.IsNaN(v1) && math.IsNaN(v2)) ||
+        (math.IsInf(v1, 1) && math.IsInf(v2, 1)) ||
+        (math.IsInf(v1, -1) && math.IsInf(v2, -1))
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Greater(b ConstScalar) bool {
+  return a.GetFloat32() > b.GetFloat32()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Smaller(b ConstScalar) bool {
+  return a.GetFloat32() < b.GetFloat32()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) Sign() int {
+  if a.GetFloat32() < float32(0) {
+    return -1
+  }
+  if a.GetFloat32() > float32(0) {
+    return 1
+  }
+  return 0
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal32) Min(a, b ConstScalar) Scalar {
+  if a.GetFloat32() < b.GetFloat32() {
+    r.Set(a)
+  } else {
+    r.Set(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal32) Max(a, b ConstScalar) Scalar {
+  if a.GetFloat32() > b.GetFloat32() {
+    r.Set(a)
+  } else {
+    r.Set(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Abs(a ConstScalar) Scalar {
+  switch a.Sign() {
+  case -1: c.Neg(a)
+  case 0: c.Reset()
+  case 1: c.Set(a)
+  }
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Neg(a ConstScalar) Scalar {
+  c.setTaylor(taylorScale(taylorCoefficientsOf(a), -1.0))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Add(a, b ConstScalar) Scalar {
+  c.setTaylor(taylorAdd(taylorCoefficientsOf(a), taylorCoefficientsOf(b), 1.0))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Sub(a, b ConstScalar) Scalar {
+  c.setTaylor(taylorAdd(taylorCoefficientsOf(a), taylorCoefficientsOf(b), -1.0))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Mul(a, b ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  y := taylorCoefficientsOf(b)
+  c.setTaylor(taylorMul(x, y, iMax(len(x), len(y))))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Div(a, b ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  y := taylorCoefficientsOf(b)
+  c.setTaylor(taylorDiv(x, y, iMax(len(x), len(y))))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) LogAdd(a, b ConstScalar, t Scalar) Scalar {
+  if a.Greater(b) {
+    // swap
+    a, b = b, a
+  }
+  if math.IsInf(a.GetFloat64(), 0) {
+    // cases:
+    //  i) a = -Inf and b >= a    => c = b
+    // ii) a =  Inf and b  = Inf  => c = Inf
+    c.Set(b)
+    return c
+  }
+  t.Sub(a, b)
+  t.Exp(t)
+  t.Log1p(t)
+  c.Add(t, b)
+  return c
+}
+func (c *TaylorReal32) LogSub(a, b ConstScalar, t Scalar) Scalar {
+  if math.IsInf(b.GetFloat64(), -1) {
+    c.Set(a)
+    return c
+  }
+  //   log(exp(a) - exp(b))
+  // = log(1 - exp(b-a)) + a
+  t.Sub(b, a)
+  t.Exp(t)
+  t.Neg(t)
+  t.Log1p(t)
+  c.Add(t, a)
+  return c
+}
+func (c *TaylorReal32) Log1pExp(a ConstScalar) Scalar {
+  v := a.GetFloat64()
+  if v <= -37.0 {
+    c.Exp(a)
+  } else
+  if v <= 18.0 {
+    c.Exp(a)
+    c.Log1p(c)
+  } else
+  if v <= 33.3 {
+    c.Neg(a)
+    c.Exp(a)
+    c.Add(c, a)
+  } else {
+    c.Set(a)
+  }
+  return c
+}
+func (c *TaylorReal32) Sigmoid(a ConstScalar, t Scalar) Scalar {
+  if a.GetFloat64() >= 0 {
+    c.Neg(a)
+    c.Exp(c)
+    c.Add(c, ConstFloat32(1.0))
+    c.Div(ConstFloat32(1.0), c)
+  } else {
+    t.Exp(a)
+    c.Set(t)
+    t.Add(t, ConstFloat32(1.0))
+    c.Div(c, t)
+  }
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Pow(a, k ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  y := taylorCoefficientsOf(k)
+  if len(y) > 1 {
+    // a^k = exp(k log a)
+    x = taylorExtend(x, len(y))
+    r := taylorLog(x, math.Log(x[0]))
+    r = taylorMul(r, y, len(x))
+    c.setTaylor(taylorExp(r, math.Pow(x[0], y[0])))
+  } else {
+    // derivatives of x^y are given by falling factorials
+    d := make([]float64, len(x))
+    f := 1.0
+    for j := range d {
+      if f == 0.0 {
+        break
+      }
+      d[j] = f*math.Pow(x[0], y[0]-float64(j))
+      f *= y[0]-float64(j)
+    }
+    c.setTaylor(taylorCompose(x, d))
+  }
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Sqrt(a ConstScalar) Scalar {
+  return c.Pow(a, ConstFloat64(0.5))
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) Sin(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  f := [4]float64{math.Sin(x[0]), math.Cos(x[0]), -math.Sin(x[0]), -math.Cos(x[0])}
+  d := make([]float64, len(x))
+  for j := range d {
+    d[j] = f[j%4]
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Sinh(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  f := [2]float64{math.Sinh(x[0]), math.Cosh(x[0])}
+  d := make([]float64, len(x))
+  for j := range d {
+    d[j] = f[j%2]
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Cos(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  f := [4]float64{math.Cos(x[0]), -math.Sin(x[0]), -math.Cos(x[0]), math.Sin(x[0])}
+  d := make([]float64, len(x))
+  for j := range d {
+    d[j] = f[j%4]
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Cosh(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  f := [2]float64{math.Cosh(x[0]), math.Sinh(x[0])}
+  d := make([]float64, len(x))
+  for j := range d {
+    d[j] = f[j%2]
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Tan(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  // tan' = 1 + tan^2
+  c.setTaylor(taylorRiccati(x, math.Tan(x[0]), 1.0))
+  return c
+}
+func (c *TaylorReal32) Tanh(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  // tanh' = 1 - tanh^2
+  c.setTaylor(taylorRiccati(x, math.Tanh(x[0]), -1.0))
+  return c
+}
+func (c *TaylorReal32) Exp(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  c.setTaylor(taylorExp(x, math.Exp(x[0])))
+  return c
+}
+func (c *TaylorReal32) Log(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  c.setTaylor(taylorLog(x, math.Log(x[0])))
+  return c
+}
+func (c *TaylorReal32) Log1p(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  v := math.Log1p(x[0])
+  x[0] += 1.0
+  c.setTaylor(taylorLog(x, v))
+  return c
+}
+func (c *TaylorReal32) Logistic(a ConstScalar) Scalar {
+  c.Neg(a)
+  c.Exp(c)
+  c.Add(ConstFloat32(1.0), c)
+  c.Div(ConstFloat32(1.0), c)
+  return c
+}
+func (c *TaylorReal32) Erf(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  d := taylorErfDerivatives(x[0], len(x), 2.0/special.M_SQRTPI*math.Exp(-x[0]*x[0]))
+  d[0] = math.Erf(x[0])
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Erfc(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  d := taylorErfDerivatives(x[0], len(x), -2.0/special.M_SQRTPI*math.Exp(-x[0]*x[0]))
+  d[0] = math.Erfc(x[0])
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) LogErfc(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  v := special.LogErfc(x[0])
+  // compute the series of erfc(a)/erfc(x[0]) and take the logarithm, which
+  // avoids underflow for large x[0]
+  d := taylorErfDerivatives(x[0], len(x), -2.0/special.M_SQRTPI*math.Exp(-x[0]*x[0] - v))
+  d[0] = 1.0
+  c.setTaylor(taylorLog(taylorCompose(x, d), v))
+  return c
+}
+func (c *TaylorReal32) Gamma(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  d := make([]float64, len(x))
+  for j := 1; j < len(d); j++ {
+    d[j] = special.Polygamma(j-1, x[0])
+  }
+  // gamma(a) = sign(gamma(x[0])) exp(lgamma(a))
+  c.setTaylor(taylorExp(taylorCompose(x, d), math.Gamma(x[0])))
+  return c
+}
+func (c *TaylorReal32) Lgamma(a ConstScalar) Scalar {
+  x := taylorCoefficientsOf(a)
+  d := make([]float64, len(x))
+  v, s := math.Lgamma(x[0])
+  if s == -1 {
+    v = math.NaN()
+  }
+  d[0] = v
+  for j := 1; j < len(d); j++ {
+    d[j] = special.Polygamma(j-1, x[0])
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) Mlgamma(a ConstScalar, k int) Scalar {
+  x := taylorCoefficientsOf(a)
+  d := make([]float64, len(x))
+  d[0] = special.Mlgamma(x[0], k)
+  for j := 1; j < len(d); j++ {
+    for i := 1; i <= k; i++ {
+      d[j] += special.Polygamma(j-1, x[0] + float64(1-i)/2.0)
+    }
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) GammaP(a float64, b ConstScalar) Scalar {
+  x := taylorCoefficientsOf(b)
+  // derivative: exp((a-1) log b - b - lgamma(a))
+  v, _ := math.Lgamma(a)
+  g := taylorLog(x, math.Log(x[0]))
+  g = taylorAdd(taylorScale(g, a-1.0), x, -1.0)
+  g = taylorExp(g, math.Exp(g[0] - v))
+  c.setTaylor(taylorIntegrate(x, special.GammaP(a, x[0]), g))
+  return c
+}
+func (c *TaylorReal32) BesselI(v float64, b ConstScalar) Scalar {
+  x := taylorCoefficientsOf(b)
+  d := make([]float64, len(x))
+  d[0] = special.BesselI(v, x[0])
+  // d^j/dx^j I_v(x) = 2^-j sum_i binomial(j, i) I_{v-j+2i}(x)
+  for j := 1; j < len(d); j++ {
+    s := 0.0
+    z := 1.0
+    for i := 0; i <= j; i++ {
+      s += z*special.BesselI(v-float64(j-2*i), x[0])
+      z *= float64(j-i)/float64(i+1)
+    }
+    d[j] = math.Ldexp(s, -j)
+  }
+  c.setTaylor(taylorCompose(x, d))
+  return c
+}
+func (c *TaylorReal32) LogBesselI(v float64, b ConstScalar) Scalar {
+  x := taylorCoefficientsOf(b)
+  d := make([]float64, len(x))
+  w := special.LogBesselI(v, x[0])
+  // compute the series of I_v(b)/I_v(x[0]) and take the logarithm
+  d[0] = 1.0
+  for j := 1; j < len(d); j++ {
+    s := 0.0
+    z := 1.0
+    for i := 0; i <= j; i++ {
+      s += z*math.Exp(special.LogBesselI(v-float64(j-2*i), x[0]) - w)
+      z *= float64(j-i)/float64(i+1)
+    }
+    d[j] = math.Ldexp(s, -j)
+  }
+  c.setTaylor(taylorLog(taylorCompose(x, d), w))
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal32) SmoothMax(x ConstVector, alpha ConstFloat64, t [2]Scalar) Scalar {
+  r .Reset()
+  t[1].Reset()
+  for i := 0; i < x.Dim(); i++ {
+    t[0].Mul(alpha, x.ConstAt(i))
+    t[0].Exp(t[0])
+    t[1].Add(t[1], t[0])
+    t[0].Mul(t[0], x.ConstAt(i))
+    r .Add(r , t[0])
+  }
+  r.Div(r, t[1])
+  return r
+}
+func (r *TaylorReal32) LogSmoothMax(x ConstVector, alpha ConstFloat64, t [3]Scalar) Scalar {
+  r .Reset()
+  t[2].SetFloat64(math.Inf(-1))
+  for i := 0; i < x.Dim(); i++ {
+    t[0].Mul(x.ConstAt(i), alpha)
+    t[2].LogAdd(t[2], t[0], t[1])
+    t[1].Log(x.ConstAt(i))
+    t[0].Add(t[0], t[1])
+    r.LogAdd(r, t[0], t[1])
+  }
+  r.Sub(r, t[2])
+  r.Exp(r)
+  return r
+}
+func (r *TaylorReal32) Vmean(a ConstVector) Scalar {
+  r.Reset()
+  for i := 0; i < a.Dim(); i++ {
+    r.Add(r, a.ConstAt(i))
+  }
+  return r.Div(r, ConstFloat32(float64(a.Dim())))
+}
+func (r *TaylorReal32) VdotV(a, b ConstVector) Scalar {
+  if a.Dim() != b.Dim() {
+    panic("vector dimensions do not match")
+  }
+  r.Reset()
+  t := NullTaylorReal32()
+  for i := 0; i < a.Dim(); i++ {
+    t.Mul(a.ConstAt(i), b.ConstAt(i))
+    r.Add(r, t)
+  }
+  return r
+}
+func (r *TaylorReal32) Vnorm(a ConstVector) Scalar {
+  r.Reset()
+  t := NullTaylorReal32()
+  for it := a.ConstIterator(); it.Ok(); it.Next() {
+    t.Pow(it.GetConst(), ConstFloat32(2.0))
+    r.Add(r, t)
+  }
+  r.Sqrt(r)
+  return r
+}
+func (r *TaylorReal32) Mtrace(a ConstMatrix) Scalar {
+  n, m := a.Dims()
+  if n != m {
+    panic("not a square matrix")
+  }
+  if n == 0 {
+    return nil
+  }
+  r.Reset()
+  for i := 0; i < n; i++ {
+    r.Add(r, a.ConstAt(i,i))
+  }
+  return r
+}
+// Frobenius norm.
+func (r *TaylorReal32) Mnorm(a ConstMatrix) Scalar {
+  n, m := a.Dims()
+  if n == 0 || m == 0 {
+    return nil
+  }
+  t := NewScalar(r.Type(), 0.0)
+  v := a.AsConstVector()
+  r.Pow(v.ConstAt(0), ConstFloat32(2.0))
+  for i := 1; i < v.Dim(); i++ {
+    t.Pow(v.ConstAt(i), ConstFloat32(2.0))
+    r.Add(r, t)
+  }
+  return r
+}
@@ -0,0 +1,117 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) EQUALS(b *TaylorReal32, epsilon float64) bool {
+  return a.Equals(b, epsilon)
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) GREATER(b *TaylorReal32) bool {
+  return a.GetFloat32() > b.GetFloat32()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) SMALLER(b *TaylorReal32) bool {
+  return a.GetFloat32() < b.GetFloat32()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal32) SIGN() int {
+  return a.Sign()
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal32) MIN(a, b *TaylorReal32) Scalar {
+  if a.GetFloat32() < b.GetFloat32() {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal32) MAX(a, b *TaylorReal32) Scalar {
+  if a.GetFloat32() > b.GetFloat32() {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) ABS(a *TaylorReal32) Scalar {
+  return c.Abs(a)
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) NEG(a *TaylorReal32) *TaylorReal32 {
+  c.Neg(a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) ADD(a, b *TaylorReal32) *TaylorReal32 {
+  c.Add(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) SUB(a, b *TaylorReal32) *TaylorReal32 {
+  c.Sub(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) MUL(a, b *TaylorReal32) *TaylorReal32 {
+  c.Mul(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) DIV(a, b *TaylorReal32) *TaylorReal32 {
+  c.Div(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) LOGADD(a, b, t *TaylorReal32) *TaylorReal32 {
+  c.LogAdd(a, b, t)
+  return c
+}
+func (c *TaylorReal32) LOGSUB(a, b, t *TaylorReal32) *TaylorReal32 {
+  c.LogSub(a, b, t)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) POW(a, k *TaylorReal32) *TaylorReal32 {
+  c.Pow(a, k)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) SQRT(a *TaylorReal32) *TaylorReal32 {
+  c.Sqrt(a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal32) EXP(a *TaylorReal32) *TaylorReal32 {
+  c.Exp(a)
+  return c
+}
+func (c *TaylorReal32) LOG(a *TaylorReal32) *TaylorReal32 {
+  c.Log(a)
+  return c
+}
+func (c *TaylorReal32) LOG1P(a *TaylorReal32) *TaylorReal32 {
+  c.Log1p(a)
+  return c
+}
@@ -0,0 +1,5 @@
+#define SCALAR_NAME  TaylorReal64
+#define SCALAR_CONST ConstFloat64
+#define SCALAR_TYPE  float64
+#define GET_METHOD_NAME GetFloat64
+#define SET_METHOD_NAME SetFloat64
@@ -0,0 +1,117 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+//import "fmt"
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal64) EQUALS(b *TaylorReal64, epsilon float64) bool {
+  return a.Equals(b, epsilon)
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal64) GREATER(b *TaylorReal64) bool {
+  return a.GetFloat64() > b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal64) SMALLER(b *TaylorReal64) bool {
+  return a.GetFloat64() < b.GetFloat64()
+}
+/* -------------------------------------------------------------------------- */
+func (a *TaylorReal64) SIGN() int {
+  return a.Sign()
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal64) MIN(a, b *TaylorReal64) Scalar {
+  if a.GetFloat64() < b.GetFloat64() {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (r *TaylorReal64) MAX(a, b *TaylorReal64) Scalar {
+  if a.GetFloat64() > b.GetFloat64() {
+    r.SET(a)
+  } else {
+    r.SET(b)
+  }
+  return r
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) ABS(a *TaylorReal64) Scalar {
+  return c.Abs(a)
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) NEG(a *TaylorReal64) *TaylorReal64 {
+  c.Neg(a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) ADD(a, b *TaylorReal64) *TaylorReal64 {
+  c.Add(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) SUB(a, b *TaylorReal64) *TaylorReal64 {
+  c.Sub(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) MUL(a, b *TaylorReal64) *TaylorReal64 {
+  c.Mul(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) DIV(a, b *TaylorReal64) *TaylorReal64 {
+  c.Div(a, b)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) LOGADD(a, b, t *TaylorReal64) *TaylorReal64 {
+  c.LogAdd(a, b, t)
+  return c
+}
+func (c *TaylorReal64) LOGSUB(a, b, t *TaylorReal64) *TaylorReal64 {
+  c.LogSub(a, b, t)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) POW(a, k *TaylorReal64) *TaylorReal64 {
+  c.Pow(a, k)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) SQRT(a *TaylorReal64) *TaylorReal64 {
+  c.Sqrt(a)
+  return c
+}
+/* -------------------------------------------------------------------------- */
+func (c *TaylorReal64) EXP(a *TaylorReal64) *TaylorReal64 {
+  c.Exp(a)
+  return c
+}
+func (c *TaylorReal64) LOG(a *TaylorReal64) *TaylorReal64 {
+  c.Log(a)
+  return c
+}
+func (c *TaylorReal64) LOG1P(a *TaylorReal64) *TaylorReal64 {
+  c.Log1p(a)
+  return c
+}
@@ -0,0 +1,235 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package autodiff
+
+/* -------------------------------------------------------------------------- */
+
+//import "fmt"
+import "math"
+import "encoding/json"
+import "testing"
+
+import "github.com/pbenner/autodiff/special"
+
+/* -------------------------------------------------------------------------- */
+
+func TestTaylorReal1(t *testing.T) {
+
+  f := func(x ConstVector, r Scalar) {
+    t1 := NullScalar(r.Type())
+    t2 := NullScalar(r.Type())
+    t3 := NullScalar(r.Type())
+    // r = exp(x0*x1)/x2 + lgamma(x0) + x1^x2
+    t1.Mul(x.ConstAt(0), x.ConstAt(1))
+    t1.Exp(t1)
+    t1.Div(t1, x.ConstAt(2))
+    t2.Lgamma(x.ConstAt(0))
+    t1.Add(t1, t2)
+    t2.Pow(x.ConstAt(1), x.ConstAt(2))
+    t1.Add(t1, t2)
+    // r = r + log(1 + exp(x2 - x0)) + sigmoid(x1)
+    t2.LogAdd(ConstFloat64(0.0), t2.Sub(x.ConstAt(2), x.ConstAt(0)), t3)
+    t1.Add(t1, t2)
+    t2.Sigmoid(x.ConstAt(1), t3)
+    t1.Add(t1, t2)
+    // r = r + besselI(2, x2) * tanh(x0)
+    t2.BesselI(2.0, x.ConstAt(2))
+    t3.Tanh(x.ConstAt(0))
+    r .Mul(t2, t3)
+    r .Add(r, t1)
+  }
+  v  := NewDenseFloat64Vector([]float64{0.3, -1.1, 0.5})
+  x1 := NewDenseReal64Vector([]float64{1.2, 0.7, 2.3})
+  x2 := NewDenseTaylorReal64Vector([]float64{1.2, 0.7, 2.3})
+  x1.Variables(2)
+  if err := SetTaylorDirection(x2, v, 2); err != nil {
+    t.Fatal(err)
+  }
+  r1 := NullReal64()
+  r2 := NullTaylorReal64()
+  f(x1, r1)
+  f(x2, r2)
+
+  // first and second directional derivatives
+  d1 := 0.0
+  d2 := 0.0
+  for i := 0; i < 3; i++ {
+    d1 += r1.GetDerivative(i)*v.Float64At(i)
+    for j := 0; j < 3; j++ {
+      d2 += r1.GetHessian(i, j)*v.Float64At(i)*v.Float64At(j)
+    }
+  }
+  if math.Abs(r1.GetFloat64() - r2.GetFloat64()) > 1e-12 {
+    t.Error("test failed")
+  }
+  if r2.GetOrder() != 2 || r2.GetN() != 1 {
+    t.Error("test failed")
+  }
+  if math.Abs(d1 - r2.GetDirectionalDerivative(1)) > 1e-10 {
+    t.Error("test failed")
+  }
+  if math.Abs(d2 - r2.GetDirectionalDerivative(2)) > 1e-10 {
+    t.Error("test failed")
+  }
+}
+
+func TestTaylorReal2(t *testing.T) {
+  x := NewTaylorReal64(2.0)
+  x.SetVariable(0, 1, 4)
+
+  r := NullTaylorReal64()
+  // exp
+  r.Exp(x)
+  for k := 0; k <= 4; k++ {
+    if math.Abs(r.GetDirectionalDerivative(k) - math.Exp(2.0)) > 1e-10 {
+      t.Error("test failed")
+    }
+  }
+  // log
+  r.Log(x)
+  if math.Abs(r.GetDirectionalDerivative(3) - 2.0/8.0) > 1e-12 ||
+    (math.Abs(r.GetDirectionalDerivative(4) + 6.0/16.0) > 1e-12) {
+    t.Error("test failed")
+  }
+  // lgamma
+  r.Lgamma(x)
+  if math.Abs(r.GetDirectionalDerivative(3) - special.Polygamma(2, 2.0)) > 1e-10 {
+    t.Error("test failed")
+  }
+  // erf''' = (4x^2 - 2) erf'
+  r.Erf(x)
+  if math.Abs(r.GetDirectionalDerivative(3) - 14.0*2.0/special.M_SQRTPI*math.Exp(-4.0)) > 1e-12 {
+    t.Error("test failed")
+  }
+  // x^3
+  r.Pow(x, ConstFloat64(3.0))
+  if r.GetDirectionalDerivative(3) != 6.0 || r.GetDirectionalDerivative(4) != 0.0 {
+    t.Error("test failed")
+  }
+  // polynomial of order 4
+  r.Mul(x, x)
+  r.Mul(r, r)
+  if r.GetDirectionalDerivative(4) != 24.0 {
+    t.Error("test failed")
+  }
+  if r.GetTaylorCoefficient(5) != 0.0 {
+    t.Error("test failed")
+  }
+}
+
+func TestTaylorReal3(t *testing.T) {
+  x := NewTaylorReal64(1.3)
+  x.SetVariable(0, 1, 5)
+
+  r1 := NullTaylorReal64()
+  r2 := NullTaylorReal64()
+  r3 := NullTaylorReal64()
+  test := func() {
+    for k := 0; k <= 5; k++ {
+      if math.Abs(r1.GetTaylorCoefficient(k) - r2.GetTaylorCoefficient(k)) > 1e-8 {
+        t.Errorf("test failed for coefficient %d", k)
+      }
+    }
+  }
+  // tan = sin/cos
+  r1.Tan(x)
+  r2.Div(r3.Sin(x), r2.Cos(x))
+  test()
+  // tanh = sinh/cosh
+  r1.Tanh(x)
+  r2.Div(r3.Sinh(x), r2.Cosh(x))
+  test()
+  // sqrt
+  r1.Set(x)
+  r2.Sqrt(x)
+  r2.Mul(r2, r2)
+  test()
+  // gamma = exp(lgamma)
+  r1.Gamma(x)
+  r2.Exp(r2.Lgamma(x))
+  test()
+  // log erfc
+  r1.LogErfc(x)
+  r2.Log(r2.Erfc(x))
+  test()
+  // log besselI
+  r1.LogBesselI(1.5, x)
+  r2.Log(r2.BesselI(1.5, x))
+  test()
+  // log1p
+  r1.Log1p(x)
+  r2.Log(r2.Add(x, ConstFloat64(1.0)))
+  test()
+  // x^x
+  r1.Pow(x, x)
+  r2.Exp(r2.Mul(x, r2.Log(x)))
+  test()
+}
+
+func TestTaylorReal4(t *testing.T) {
+  // compare against finite differences of second derivatives
+  h := 1e-5
+  f := func(x float64, g func(r, x Scalar)) float64 {
+    a := NewReal64(x)
+    a.SetVariable(0, 1, 2)
+    r := NullReal64()
+    g(r, a)
+    return r.GetHessian(0, 0)
+  }
+  for _, g := range []func(r, x Scalar){
+    func(r, x Scalar) { r.BesselI(1.5, x) },
+    func(r, x Scalar) { r.GammaP(2.5, x) },
+    func(r, x Scalar) { r.Mlgamma(x, 3) } } {
+    x := NewTaylorReal64(2.1)
+    x.SetVariable(0, 1, 3)
+    r := NullTaylorReal64()
+    g(r, x)
+    d := (f(2.1+h, g) - f(2.1-h, g))/(2.0*h)
+    if math.Abs(r.GetDirectionalDerivative(3) - d) > 1e-6 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestTaylorReal5(t *testing.T) {
+  // f(x, y) = x y^3
+  x := NewDenseTaylorReal32Vector([]float32{2, 4})
+  SetTaylorDirection(x, NewDenseFloat64Vector([]float64{1, 2}), 4)
+
+  r := NullTaylorReal32()
+  r.Pow(x.At(1), ConstFloat64(3.0))
+  r.Mul(r, x.At(0))
+
+  if r.GetDirectionalDerivative(3) != 384.0 || r.GetDirectionalDerivative(4) != 192.0 {
+    t.Error("test failed")
+  }
+  if err := r.SetVariable(0, 2, 1); err == nil {
+    t.Error("test failed")
+  }
+  // json
+  data, err := json.Marshal(r)
+  if err != nil {
+    t.Fatal(err)
+  }
+  s := NullTaylorReal32()
+  if err := json.Unmarshal(data, s); err != nil {
+    t.Fatal(err)
+  }
+  if s.GetDirectionalDerivative(4) != 192.0 || s.GetFloat32() != 128.0 {
+    t.Error("test failed")
+  }
+}
@@ -2177,3 +2177,13 @@ func TestGammaP(t *testing.T) {
     }
   }
 }
+
+func TestGammaQ(t *testing.T) {
+  for _, a := range []float64{0.5, 1.0, 2.5, 5.0, 12.0} {
+    for _, x := range []float64{0.1, 0.7, 1.5, 3.5, 10.0, 30.0} {
+      if v := GammaP(a, x) + GammaQ(a, x); math.Abs(v - 1.0) > 1e-12 {
+        t.Errorf("GammaQ() failed for `(%f,%f)': P + Q = %e", a, x, v)
+      }
+    }
+  }
+}
@@ -3119,7 +3119,10 @@ func TestPolygamma(t *testing.T) {
     {   6, 1.850000, -1.02531030031873537922e+01},
     {   6, 1.900000, -8.53989177749651240390e+00},
     {   6, 1.950000, -7.14820938961587515337e+00},
-    {   6, 2.000000, -6.01147971498443567384e+00} }
+    {   6, 2.000000, -6.01147971498443567384e+00},
+    {   2, 1.100000, -1.86145737833256850000e+00},
+    {   2, 1.600000, -7.03572277877089000000e-01},
+    {   2, 2.100000, -3.58827776536463900000e-01} }
 
   for i := 0; i < len(r); i++ {
     epsilon := 1e-5*math.Pow(10,math.Floor(math.Log10(math.Abs(r[i][2]))))
@@ -19,6 +19,7 @@ package statistics
 /* -------------------------------------------------------------------------- */
 
 //import   "fmt"
+import   "math/rand"
 import   "reflect"
 
 import . "github.com/pbenner/autodiff"
@@ -63,6 +64,44 @@ type MatrixPdf interface {
 
 /* -------------------------------------------------------------------------- */
 
+// Distributions that allow to draw random samples. The sample is stored
+// in x, which must have the dimension of the distribution.
+
+type ScalarSampler interface {
+  Sample(x Scalar, r *rand.Rand) error
+}
+
+type VectorSampler interface {
+  Sample(x Vector, r *rand.Rand) error
+}
+
+type MatrixSampler interface {
+  Sample(x Matrix, r *rand.Rand) error
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Scalar distributions with a cumulative distribution function F(x) =
+// P(X <= x). The survival function is given by S(x) = 1 - F(x), which
+// is computed without cancellation in the upper tail. Results are
+// differentiable with respect to x and the parameters of the distribution.
+
+type ScalarCdf interface {
+  LogCdf     (r Scalar, x ConstScalar) error
+  Cdf        (r Scalar, x ConstScalar) error
+  LogSurvival(r Scalar, x ConstScalar) error
+}
+
+// Scalar distributions with a quantile function Q(p), i.e. the inverse of
+// the cumulative distribution function. For discrete distributions Q(p) is
+// the smallest x such that F(x) >= p.
+
+type ScalarQuantile interface {
+  Quantile(r Scalar, p ConstScalar) error
+}
+
+/* -------------------------------------------------------------------------- */
+
 var ScalarPdfRegistry map[string]ScalarPdf
 var VectorPdfRegistry map[string]VectorPdf
 var MatrixPdfRegistry map[string]MatrixPdf
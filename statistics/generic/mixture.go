@@ -21,6 +21,7 @@ package generic
 import   "fmt"
 import   "bytes"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff/statistics"
 
@@ -103,6 +104,17 @@ func (obj *Mixture) LogPdf(r Scalar, data MixtureDataRecord) error {
   return nil
 }
 
+// Draw the index of a mixture component according to the mixture weights.
+func (obj *Mixture) SampleComponent(r *rand.Rand) int {
+  u := r.Float64()
+  for j := 0; j < obj.NComponents(); j++ {
+    if u -= math.Exp(obj.LogWeights.At(j).GetFloat64()); u < 0.0 {
+      return j
+    }
+  }
+  return obj.NComponents()-1
+}
+
 func (obj *Mixture) Likelihood(r Scalar, data MixtureDataRecord, states []int) error {
   t1 := obj.t1
   t2 := obj.t2
@@ -1,4 +1,5 @@
 /* Copyright (C) 2016-2020 Philipp Benner
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
  *
  * This program is free software: you can redistribute it and/or modify
  * it under the terms of the GNU General Public License as published by
@@ -19,11 +20,14 @@ package matrixDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
-//import   "math"
+import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
 
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
 import   "github.com/pbenner/autodiff/algorithm/determinant"
 import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
 
@@ -156,7 +160,7 @@ func (obj *InverseWishartDistribution) LogPdf(r Scalar, x ConstMatrix) error {
   if err1 != nil { return err1 }
   if err2 != nil { return err2 }
   xDet.Log(xDet)
-  xInv.MmulM(obj.S, xInv)
+  xInv.MdotM(obj.S, xInv)
   t := obj.t
   t.Mtrace(xInv)
   t.Div(t, ConstFloat64(2.0))
@@ -171,6 +175,45 @@ func (obj *InverseWishartDistribution) LogPdf(r Scalar, x ConstMatrix) error {
   return nil
 }
 
+// Draw a sample using the Bartlett decomposition. If S = L L^T and A is
+// lower triangular with A_ii^2 ~ ChiSquared(nu-i) and A_ij ~ N(0,1), then
+// L (A A^T)^-1 L^T is inverse Wishart distributed.
+func (obj *InverseWishartDistribution) Sample(x Matrix, r *rand.Rand) error {
+  n := obj.dim()
+  if n1, n2 := x.Dims(); n1 != n || n2 != n {
+    return fmt.Errorf("matrix has invalid dimension")
+  }
+  L, _, err := cholesky.Run(obj.S)
+  if err != nil {
+    return err
+  }
+  L  = AsDenseFloat64Matrix(L)
+  a := NullDenseFloat64Matrix(n, n)
+  c := NullFloat64()
+  for i := 0; i < n; i++ {
+    if chi2, err := scalarDistribution.NewChiSquaredDistribution(Float64Type, obj.Nu.GetFloat64() - float64(i)); err != nil {
+      return err
+    } else {
+      if err := chi2.Sample(c, r); err != nil {
+        return err
+      }
+    }
+    a.At(i, i).SetFloat64(math.Sqrt(c.GetFloat64()))
+    for j := 0; j < i; j++ {
+      a.At(i, j).SetFloat64(r.NormFloat64())
+    }
+  }
+  w := NullDenseFloat64Matrix(n, n)
+  w.MdotM(a, a.T())
+  wInv, err := matrixInverse.Run(w, matrixInverse.PositiveDefinite{true})
+  if err != nil {
+    return err
+  }
+  w.MdotM(L, wInv)
+  x.MdotM(w, L.T())
+  return nil
+}
+
 func (obj *InverseWishartDistribution) Pdf(r Scalar, x ConstMatrix) error {
   if err := obj.LogPdf(r, x); err != nil {
     return err
@@ -20,6 +20,7 @@ package matrixDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -36,7 +37,34 @@ func TestInverseWishartDistribution1(t *testing.T) {
   wishart, _ := NewInverseWishartDistribution(nu, s)
   wishart.LogPdf(r, x)
 
-  if math.Abs(r.GetFloat64() - -9.25614036) > 1e-4 {
+  if math.Abs(r.GetFloat64() - -9.26751836) > 1e-4 {
     t.Error("Inverse Wishart LogPdf failed!")
   }
 }
+
+func TestInverseWishartDistributionSample(t *testing.T) {
+  nu := NewFloat64(8.0)
+  s  := NewDenseFloat64Matrix([]float64{1, +0.3, +0.3, 1}, 2, 2)
+
+  wishart, _ := NewInverseWishartDistribution(nu, s)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Matrix(2, 2)
+  m := NullDenseFloat64Matrix(2, 2)
+  n := 100000
+  for k := 0; k < n; k++ {
+    if err := wishart.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    m.MaddM(m, x)
+  }
+  m.MdivS(m, ConstFloat64(float64(n)))
+  // mean: S/(nu - p - 1)
+  for i := 0; i < 2; i++ {
+    for j := 0; j < 2; j++ {
+      if math.Abs(m.At(i, j).GetFloat64() - s.At(i, j).GetFloat64()/5.0) > 0.01 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
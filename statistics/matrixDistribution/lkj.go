@@ -0,0 +1,234 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Lewandowski, Daniel, Dorota Kurowicka, and Harry Joe. "Generating random
+// correlation matrices based on vines and extended onion method." Journal
+// of multivariate analysis 100.9 (2009): 1989-2001.
+
+/* -------------------------------------------------------------------------- */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import   "github.com/pbenner/autodiff/algorithm/determinant"
+
+/* -------------------------------------------------------------------------- */
+
+// LKJ distribution of n x n correlation matrices with density proportional
+// to |R|^(eta-1). The distribution is uniform for eta = 1 and concentrates
+// at the identity matrix for large eta.
+type LkjDistribution struct {
+  Eta Scalar
+  n   int
+  z   Scalar
+  // state
+  t   Scalar
+  inSituDet determinant.InSitu
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewLkjDistribution(eta Scalar, n int) (*LkjDistribution, error) {
+  if eta.GetFloat64() <= 0.0 {
+    return nil, fmt.Errorf("NewLkjDistribution(): eta must be positive")
+  }
+  if n < 1 {
+    return nil, fmt.Errorf("NewLkjDistribution(): invalid dimension")
+  }
+  t  := eta.Type()
+  t1 := NullScalar(t)
+  t2 := NullScalar(t)
+  t3 := NullScalar(t)
+  // negative log normalization constant
+  // log c = sum_k (2 eta - 2 + n - k)(n - k) log 2 + (n - k) log B(b_k, b_k)
+  // with b_k = eta + (n - k - 1)/2
+  z := NewScalar(t, 0.0)
+  for k := 1; k < n; k++ {
+    m := float64(n - k)
+    t1.Mul(eta, ConstFloat64(2.0))
+    t1.Add(t1, ConstFloat64(m - 2.0))
+    t1.Mul(t1, ConstFloat64(m*math.Ln2))
+    z.Sub(z, t1)
+    // log B(b, b) = 2 log Gamma(b) - log Gamma(2b)
+    t2.Add(eta, ConstFloat64((m - 1.0)/2.0))
+    t3.Mul(t2, ConstFloat64(2.0))
+    t2.Lgamma(t2)
+    t3.Lgamma(t3)
+    t2.Mul(t2, ConstFloat64(2.0))
+    t2.Sub(t2, t3)
+    t2.Mul(t2, ConstFloat64(m))
+    z.Sub(z, t2)
+  }
+  result := LkjDistribution{
+    Eta: eta.CloneScalar(),
+    n  : n,
+    z  : z,
+    t  : t1 }
+
+  return &result, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *LkjDistribution) Clone() *LkjDistribution {
+  return &LkjDistribution{
+    Eta: obj.Eta.CloneScalar(),
+    n  : obj.n,
+    z  : obj.z  .CloneScalar(),
+    t  : obj.t  .CloneScalar() }
+}
+
+func (obj *LkjDistribution) CloneMatrixPdf() MatrixPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *LkjDistribution) ScalarType() ScalarType {
+  return obj.Eta.Type()
+}
+
+func (obj *LkjDistribution) Dims() (int, int) {
+  return obj.n, obj.n
+}
+
+func (obj *LkjDistribution) LogPdf(r Scalar, x ConstMatrix) error {
+  if n1, n2 := x.Dims(); n1 != obj.n || n2 != obj.n {
+    return fmt.Errorf("input matrix has invalid dimension")
+  }
+  // check that x is a correlation matrix
+  for i := 0; i < obj.n; i++ {
+    if math.Abs(x.ConstAt(i, i).GetFloat64() - 1.0) > 1e-8 {
+      r.SetFloat64(math.Inf(-1))
+      return nil
+    }
+  }
+  xDet, err := determinant.Run(x, determinant.PositiveDefinite{true}, &obj.inSituDet)
+  if err != nil {
+    return err
+  }
+  t := obj.t
+  t.Sub(obj.Eta, ConstFloat64(1.0))
+  r.Log(xDet)
+  r.Mul(r, t)
+  r.Add(r, obj.z)
+  return nil
+}
+
+// Draw a sample using the C-vine method, i.e. partial correlations are
+// drawn from scaled Beta distributions and converted to correlations
+func (obj *LkjDistribution) Sample(x Matrix, r *rand.Rand) error {
+  n := obj.n
+  if n1, n2 := x.Dims(); n1 != n || n2 != n {
+    return fmt.Errorf("matrix has invalid dimension")
+  }
+  // partial correlations
+  p := make([][]float64, n)
+  for i := 0; i < n; i++ {
+    p[i] = make([]float64, n)
+  }
+  y := NullFloat64()
+  b := obj.Eta.GetFloat64() + float64(n - 1)/2.0
+  for i := 0; i < n; i++ {
+    x.At(i, i).SetFloat64(1.0)
+  }
+  for k := 0; k < n-1; k++ {
+    b -= 0.5
+    beta, err := scalarDistribution.NewBetaDistribution(NewFloat64(b), NewFloat64(b), false)
+    if err != nil {
+      return err
+    }
+    for i := k+1; i < n; i++ {
+      if err := beta.Sample(y, r); err != nil {
+        return err
+      }
+      p[k][i] = 2.0*y.GetFloat64() - 1.0
+      // convert partial correlation to correlation
+      c := p[k][i]
+      for l := k-1; l >= 0; l-- {
+        c = c*math.Sqrt((1.0 - p[l][i]*p[l][i])*(1.0 - p[l][k]*p[l][k])) + p[l][i]*p[l][k]
+      }
+      x.At(k, i).SetFloat64(c)
+      x.At(i, k).SetFloat64(c)
+    }
+  }
+  return nil
+}
+
+func (obj *LkjDistribution) Pdf(r Scalar, x ConstMatrix) error {
+  if err := obj.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *LkjDistribution) GetParameters() Vector {
+  p := NullDenseVector(obj.ScalarType(), 1)
+  p.At(0).Set(obj.Eta)
+  return p
+}
+
+func (obj *LkjDistribution) SetParameters(parameters Vector) error {
+  if tmp, err := NewLkjDistribution(parameters.At(0), obj.n); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *LkjDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  n, ok := config.GetNamedParameterAsInt("N"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  eta, ok := config.GetNamedParameterAsScalar("Eta", t); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if tmp, err := NewLkjDistribution(eta, n); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+func (obj *LkjDistribution) ExportConfig() ConfigDistribution {
+
+  config := struct{
+    Eta float64
+    N   int }{}
+  config.Eta = obj.Eta.GetFloat64()
+  config.N   = obj.n
+
+  return NewConfigDistribution("matrix:lkj distribution", config)
+}
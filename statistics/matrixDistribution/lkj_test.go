@@ -0,0 +1,96 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestLkjDistribution1(t *testing.T) {
+
+  x := NewDenseFloat64Matrix([]float64{
+    1.0, 0.3, 0.2,
+    0.3, 1.0, 0.1,
+    0.2, 0.1, 1.0 }, 3, 3)
+  r := NewFloat64(0.0)
+
+  dist, _ := NewLkjDistribution(NewFloat64(1.5), 3)
+  dist.LogPdf(r, x)
+
+  if math.Abs(r.GetFloat64() - -1.09542978) > 1e-6 {
+    t.Error("test failed")
+  }
+}
+
+func TestLkjDistribution2(t *testing.T) {
+  // density of 2 x 2 correlation matrices integrates to one
+  dist, _ := NewLkjDistribution(NewFloat64(2.5), 2)
+  x := NewDenseFloat64Matrix([]float64{1, 0, 0, 1}, 2, 2)
+  r := NewFloat64(0.0)
+  s := 0.0
+  n := 10000
+  for i := 0; i < n; i++ {
+    c := -1.0 + 2.0*(float64(i) + 0.5)/float64(n)
+    x.At(0, 1).SetFloat64(c)
+    x.At(1, 0).SetFloat64(c)
+    dist.Pdf(r, x)
+    s += 2.0*r.GetFloat64()/float64(n)
+  }
+  if math.Abs(s - 1.0) > 1e-6 {
+    t.Error("test failed")
+  }
+}
+
+func TestLkjDistributionSample(t *testing.T) {
+  eta := 2.0
+  dist, _ := NewLkjDistribution(NewFloat64(eta), 4)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Matrix(4, 4)
+  y := NewFloat64(0.0)
+  n := 20000
+  v := [4][4]float64{}
+  for k := 0; k < n; k++ {
+    if err := dist.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    // samples must be positive definite correlation matrices
+    if err := dist.LogPdf(y, x); err != nil || math.IsInf(y.GetFloat64(), -1) {
+      t.Fatal("test failed")
+    }
+    for i := 0; i < 4; i++ {
+      for j := 0; j < 4; j++ {
+        v[i][j] += x.At(i, j).GetFloat64()*x.At(i, j).GetFloat64()/float64(n)
+      }
+    }
+  }
+  // off-diagonal elements have variance 1/(2 eta + d - 1)
+  for i := 0; i < 4; i++ {
+    for j := 0; j < 4; j++ {
+      if i != j && math.Abs(v[i][j] - 1.0/(2.0*eta + 3.0)) > 0.01 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
@@ -0,0 +1,261 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
+import   "github.com/pbenner/autodiff/algorithm/determinant"
+import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
+
+/* -------------------------------------------------------------------------- */
+
+// Matrix normal distribution of n x p matrices with mean M, row covariance
+// U (n x n) and column covariance V (p x p), i.e. vec(X) is normal
+// distributed with covariance V (x) U
+type MatrixNormalDistribution struct {
+  M    Matrix
+  U    Matrix
+  V    Matrix
+  UInv Matrix
+  VInv Matrix
+  z    Scalar
+  // state
+  t1   Matrix
+  t2   Matrix
+  t3   Matrix
+  t    Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewMatrixNormalDistribution(m, u, v Matrix) (*MatrixNormalDistribution, error) {
+
+  t  := m.ElementType()
+  t1 := NullScalar(t)
+  t2 := NullScalar(t)
+
+  n, p := m.Dims()
+
+  if n1, n2 := u.Dims(); n1 != n || n2 != n {
+    return nil, fmt.Errorf("NewMatrixNormalDistribution(): U has invalid dimension!")
+  }
+  if p1, p2 := v.Dims(); p1 != p || p2 != p {
+    return nil, fmt.Errorf("NewMatrixNormalDistribution(): V has invalid dimension!")
+  }
+  uInv, err := matrixInverse.Run(u, matrixInverse.PositiveDefinite{true})
+  if err != nil { return nil, err }
+  vInv, err := matrixInverse.Run(v, matrixInverse.PositiveDefinite{true})
+  if err != nil { return nil, err }
+  uDet, err := determinant  .Run(u, determinant  .PositiveDefinite{true})
+  if err != nil { return nil, err }
+  vDet, err := determinant  .Run(v, determinant  .PositiveDefinite{true})
+  if err != nil { return nil, err }
+
+  // -1/2 [ n p log(2pi) + p log|U| + n log|V| ]
+  z := NewScalar(t, -float64(n*p)/2.0*math.Log(2.0*math.Pi))
+  z.Sub(z, t1.Mul(ConstFloat64(float64(p)/2.0), t2.Log(uDet)))
+  z.Sub(z, t1.Mul(ConstFloat64(float64(n)/2.0), t2.Log(vDet)))
+
+  result := MatrixNormalDistribution{
+    M   : m.CloneMatrix(),
+    U   : u.CloneMatrix(),
+    V   : v.CloneMatrix(),
+    UInv: uInv,
+    VInv: vInv,
+    z   : z,
+    t1  : NullDenseMatrix(t, n, p),
+    t2  : NullDenseMatrix(t, n, p),
+    t3  : NullDenseMatrix(t, n, p),
+    t   : NullScalar(t) }
+
+  return &result, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MatrixNormalDistribution) Clone() *MatrixNormalDistribution {
+  return &MatrixNormalDistribution{
+    M   : obj.M   .CloneMatrix(),
+    U   : obj.U   .CloneMatrix(),
+    V   : obj.V   .CloneMatrix(),
+    UInv: obj.UInv.CloneMatrix(),
+    VInv: obj.VInv.CloneMatrix(),
+    z   : obj.z   .CloneScalar(),
+    t1  : obj.t1  .CloneMatrix(),
+    t2  : obj.t2  .CloneMatrix(),
+    t3  : obj.t3  .CloneMatrix(),
+    t   : obj.t   .CloneScalar() }
+}
+
+func (obj *MatrixNormalDistribution) CloneMatrixPdf() MatrixPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MatrixNormalDistribution) ScalarType() ScalarType {
+  return obj.M.ElementType()
+}
+
+func (obj *MatrixNormalDistribution) Dims() (int, int) {
+  return obj.M.Dims()
+}
+
+func (obj *MatrixNormalDistribution) Mean() Matrix {
+  return obj.M.CloneMatrix()
+}
+
+func (obj *MatrixNormalDistribution) LogPdf(r Scalar, x ConstMatrix) error {
+  n, p := obj.Dims()
+  if n1, p1 := x.Dims(); n1 != n || p1 != p {
+    return fmt.Errorf("input matrix has invalid dimension")
+  }
+  d := obj.t1
+  a := obj.t2
+  b := obj.t3
+  t := obj.t
+  // tr[V^-1 (X-M)^T U^-1 (X-M)] = sum_ij (U^-1 D)_ij (D V^-1)_ij
+  d.MsubM(x, obj.M)
+  a.MdotM(obj.UInv, d)
+  b.MdotM(d, obj.VInv)
+  r.SetFloat64(0.0)
+  for i := 0; i < n; i++ {
+    for j := 0; j < p; j++ {
+      r.Add(r, t.Mul(a.At(i, j), b.At(i, j)))
+    }
+  }
+  r.Div(r, ConstFloat64(-2.0))
+  r.Add(r, obj.z)
+  return nil
+}
+
+// Draw a sample X = M + A Z B^T, where U = A A^T, V = B B^T and Z is a
+// matrix of independent standard normal random variables
+func (obj *MatrixNormalDistribution) Sample(x Matrix, r *rand.Rand) error {
+  n, p := obj.Dims()
+  if n1, p1 := x.Dims(); n1 != n || p1 != p {
+    return fmt.Errorf("matrix has invalid dimension")
+  }
+  A, _, err := cholesky.Run(obj.U)
+  if err != nil {
+    return err
+  }
+  B, _, err := cholesky.Run(obj.V)
+  if err != nil {
+    return err
+  }
+  z := NullDenseFloat64Matrix(n, p)
+  for i := 0; i < n; i++ {
+    for j := 0; j < p; j++ {
+      z.At(i, j).SetFloat64(r.NormFloat64())
+    }
+  }
+  w := NullDenseFloat64Matrix(n, p)
+  w.MdotM(AsDenseFloat64Matrix(A), z)
+  z.MdotM(w, AsDenseFloat64Matrix(B).T())
+  for i := 0; i < n; i++ {
+    for j := 0; j < p; j++ {
+      x.At(i, j).SetFloat64(obj.M.At(i, j).GetFloat64() + z.At(i, j).GetFloat64())
+    }
+  }
+  return nil
+}
+
+func (obj *MatrixNormalDistribution) Pdf(r Scalar, x ConstMatrix) error {
+  if err := obj.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MatrixNormalDistribution) GetParameters() Vector {
+  p := obj.M.AsVector()
+  p  = p.AppendVector(obj.U.AsVector())
+  p  = p.AppendVector(obj.V.AsVector())
+  return p
+}
+
+func (obj *MatrixNormalDistribution) SetParameters(parameters Vector) error {
+  n, p := obj.Dims()
+  m := parameters.Slice(0, n*p).AsMatrix(n, p); parameters = parameters.Slice(n*p, parameters.Dim())
+  u := parameters.Slice(0, n*n).AsMatrix(n, n); parameters = parameters.Slice(n*n, parameters.Dim())
+  v := parameters.Slice(0, p*p).AsMatrix(p, p)
+  if tmp, err := NewMatrixNormalDistribution(m, u, v); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MatrixNormalDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  n, ok := config.GetNamedParameterAsInt("N"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  p, ok := config.GetNamedParameterAsInt("P"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  m, ok := config.GetNamedParametersAsMatrix("M", t, n, p); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  u, ok := config.GetNamedParametersAsMatrix("U", t, n, n); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  v, ok := config.GetNamedParametersAsMatrix("V", t, p, p); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if tmp, err := NewMatrixNormalDistribution(m, u, v); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+func (obj *MatrixNormalDistribution) ExportConfig() ConfigDistribution {
+
+  n, p := obj.Dims()
+
+  config := struct{
+    M []float64
+    U []float64
+    V []float64
+    N   int
+    P   int }{}
+  config.M = AsDenseFloat64Vector(obj.M.AsVector())
+  config.U = AsDenseFloat64Vector(obj.U.AsVector())
+  config.V = AsDenseFloat64Vector(obj.V.AsVector())
+  config.N = n
+  config.P = p
+
+  return NewConfigDistribution("matrix:matrix normal distribution", config)
+}
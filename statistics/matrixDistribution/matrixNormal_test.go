@@ -0,0 +1,79 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestMatrixNormalDistribution1(t *testing.T) {
+
+  m := NewDenseFloat64Matrix([]float64{1,  2.0,  3.0, 4}, 2, 2)
+  u := NewDenseFloat64Matrix([]float64{2,  0.5,  0.5, 1}, 2, 2)
+  v := NewDenseFloat64Matrix([]float64{1, -0.2, -0.2, 3}, 2, 2)
+  x := NewDenseFloat64Matrix([]float64{1.5, 1, 2, 5}, 2, 2)
+  r := NewFloat64(0.0)
+
+  dist, _ := NewMatrixNormalDistribution(m, u, v)
+  dist.LogPdf(r, x)
+
+  if math.Abs(r.GetFloat64() - -6.37750900) > 1e-4 {
+    t.Error("test failed")
+  }
+}
+
+func TestMatrixNormalDistributionSample(t *testing.T) {
+
+  m := NewDenseFloat64Matrix([]float64{1,  2.0,  3.0, 4}, 2, 2)
+  u := NewDenseFloat64Matrix([]float64{2,  0.5,  0.5, 1}, 2, 2)
+  v := NewDenseFloat64Matrix([]float64{1, -0.2, -0.2, 3}, 2, 2)
+
+  dist, _ := NewMatrixNormalDistribution(m, u, v)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Matrix(2, 2)
+  n := 100000
+  // mean and covariance of X_00 and X_11, i.e. U_00 V_00, U_11 V_11 and
+  // U_01 V_01
+  m1 := [2]float64{}
+  s  := [3]float64{}
+  for k := 0; k < n; k++ {
+    if err := dist.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    d0 := x.At(0, 0).GetFloat64() - 1.0
+    d1 := x.At(1, 1).GetFloat64() - 4.0
+    m1[0] += d0/float64(n)
+    m1[1] += d1/float64(n)
+    s [0] += d0*d0/float64(n)
+    s [1] += d1*d1/float64(n)
+    s [2] += d0*d1/float64(n)
+  }
+  if math.Abs(m1[0]) > 0.02 || math.Abs(m1[1]) > 0.02 {
+    t.Error("test failed")
+  }
+  if math.Abs(s[0] - 2.0) > 0.05 || math.Abs(s[1] - 3.0) > 0.05 || math.Abs(s[2] - -0.1) > 0.05 {
+    t.Error("test failed")
+  }
+}
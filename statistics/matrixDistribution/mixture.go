@@ -20,6 +20,7 @@ package matrixDistribution
 
 import   "fmt"
 import   "bytes"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff/statistics"
 import   "github.com/pbenner/autodiff/statistics/generic"
@@ -86,6 +87,15 @@ func (obj *Mixture) LogPdf(r Scalar, x ConstMatrix) error {
   return obj.Mixture.LogPdf(r, MixtureDataRecord{obj.Edist, x})
 }
 
+func (obj *Mixture) Sample(x Matrix, r *rand.Rand) error {
+  k := obj.Mixture.SampleComponent(r)
+  if s, ok := obj.Edist[k].(MatrixSampler); !ok {
+    return fmt.Errorf("mixture component `%d' does not support sampling", k)
+  } else {
+    return s.Sample(x, r)
+  }
+}
+
 func (obj *Mixture) Likelihood(r Scalar, x ConstMatrix, states []int) error {
   return obj.Mixture.Likelihood(r, MixtureDataRecord{obj.Edist, x}, states)
 }
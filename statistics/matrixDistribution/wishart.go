@@ -0,0 +1,265 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
+import   "github.com/pbenner/autodiff/algorithm/determinant"
+import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
+
+/* -------------------------------------------------------------------------- */
+
+type WishartDistribution struct {
+  Nu   Scalar
+  S    Matrix
+  SInv Matrix
+  SDet Scalar
+  d    Scalar
+  z    Scalar
+  // state
+  t    Scalar
+  m    Matrix
+  inSituDet determinant.InSitu
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewWishartDistribution(nu Scalar, s Matrix) (*WishartDistribution, error) {
+
+  t  := nu.Type()
+  t1 := NullScalar(t)
+  t2 := NullScalar(t)
+
+  n, m := s.Dims()
+
+  if n != m {
+    return nil, fmt.Errorf("NewWishartDistribution(): S is not a square matrix!")
+  }
+  if nu.GetFloat64() <= float64(n) - 1.0 {
+    return nil, fmt.Errorf("NewWishartDistribution(): degrees of freedom must be greater than n-1")
+  }
+  sInv, err := matrixInverse.Run(s, matrixInverse.PositiveDefinite{true})
+  if err != nil {
+    return nil, err
+  }
+  sDet, err := determinant.Run(s, determinant.PositiveDefinite{true})
+  if err != nil {
+    return nil, err
+  }
+  d := NewScalar(t, float64(n))
+  // negative log partition function
+  z := NewScalar(t, 0.0)
+  z.Neg(t1.Mul(t1.Div(nu, ConstFloat64(2.0)), t2.Log(sDet)))                            // |S|^(nu/2)
+  z.Sub(z, t1.Mul(t1.Mul(nu, t1.Div(d, ConstFloat64(2.0))), t2.Log(ConstFloat64(2.0)))) // 2^(nu n/2)
+  z.Sub(z, t1.Mlgamma(t1.Div(nu, ConstFloat64(2.0)), n))                                // Gamma_n(nu/2)
+
+  result := WishartDistribution{
+    Nu  : nu,
+    S   : s,
+    SInv: sInv,
+    SDet: sDet,
+    d   : d,
+    z   : z,
+    t   : NewScalar(t, 0.0),
+    m   : NullDenseMatrix(t, n, n) }
+
+  return &result, nil
+
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *WishartDistribution) Clone() *WishartDistribution {
+  return &WishartDistribution{
+    Nu  : obj.Nu  .CloneScalar(),
+    S   : obj.S   .CloneMatrix(),
+    SInv: obj.SInv.CloneMatrix(),
+    SDet: obj.SDet.CloneScalar(),
+    d   : obj.d   .CloneScalar(),
+    z   : obj.z   .CloneScalar(),
+    t   : obj.t   .CloneScalar(),
+    m   : obj.m   .CloneMatrix() }
+}
+
+func (obj *WishartDistribution) CloneMatrixPdf() MatrixPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *WishartDistribution) ScalarType() ScalarType {
+  return obj.Nu.Type()
+}
+
+func (obj *WishartDistribution) dim() int {
+  n, _ := obj.S.Dims()
+  return n
+}
+
+func (obj *WishartDistribution) Dims() (int, int) {
+  return obj.S.Dims()
+}
+
+func (obj *WishartDistribution) Mean() Matrix {
+  n := obj.dim()
+  m := NullDenseMatrix(obj.ScalarType(), n, n)
+  return m.MmulS(obj.S, obj.Nu)
+}
+
+func (obj *WishartDistribution) Variance() Matrix {
+  n := obj.dim()
+  m := NullDenseMatrix(obj.ScalarType(), n, n)
+  t := NullScalar(obj.ScalarType())
+  // nu (S_ij^2 + S_ii S_jj)
+  for i := 0; i < n; i++ {
+    for j := 0; j < n; j++ {
+      m.At(i, j).Mul(obj.S.At(i, j), obj.S.At(i, j))
+      m.At(i, j).Add(m.At(i, j), t.Mul(obj.S.At(i, i), obj.S.At(j, j)))
+      m.At(i, j).Mul(m.At(i, j), obj.Nu)
+    }
+  }
+  return m
+}
+
+func (obj *WishartDistribution) LogPdf(r Scalar, x ConstMatrix) error {
+  xDet, err := determinant.Run(x, determinant.PositiveDefinite{true}, &obj.inSituDet)
+  if err != nil {
+    return err
+  }
+  xDet.Log(xDet)
+  t := obj.t
+  m := obj.m
+  m.MdotM(obj.SInv, x)
+  t.Mtrace(m)
+  t.Div(t, ConstFloat64(2.0))
+  // density
+  r.Sub(obj.Nu, obj.d)
+  r.Sub(r, ConstFloat64(1.0))
+  r.Div(r, ConstFloat64(2.0))
+  r.Mul(r, xDet)
+  r.Sub(r, t)
+  r.Add(r, obj.z)
+  return nil
+}
+
+// Draw a sample using the Bartlett decomposition. If S = L L^T and A is
+// lower triangular with A_ii^2 ~ ChiSquared(nu-i) and A_ij ~ N(0,1), then
+// L A A^T L^T is Wishart distributed.
+func (obj *WishartDistribution) Sample(x Matrix, r *rand.Rand) error {
+  n := obj.dim()
+  if n1, n2 := x.Dims(); n1 != n || n2 != n {
+    return fmt.Errorf("matrix has invalid dimension")
+  }
+  L, _, err := cholesky.Run(obj.S)
+  if err != nil {
+    return err
+  }
+  L  = AsDenseFloat64Matrix(L)
+  a := NullDenseFloat64Matrix(n, n)
+  c := NullFloat64()
+  for i := 0; i < n; i++ {
+    if chi2, err := scalarDistribution.NewChiSquaredDistribution(Float64Type, obj.Nu.GetFloat64() - float64(i)); err != nil {
+      return err
+    } else {
+      if err := chi2.Sample(c, r); err != nil {
+        return err
+      }
+    }
+    a.At(i, i).SetFloat64(math.Sqrt(c.GetFloat64()))
+    for j := 0; j < i; j++ {
+      a.At(i, j).SetFloat64(r.NormFloat64())
+    }
+  }
+  w := NullDenseFloat64Matrix(n, n)
+  w.MdotM(L, a)
+  x.MdotM(w, w.T())
+  return nil
+}
+
+func (obj *WishartDistribution) Pdf(r Scalar, x ConstMatrix) error {
+  if err := obj.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *WishartDistribution) GetParameters() Vector {
+  p := obj.S.AsVector()
+  p  = p.AppendScalar(obj.Nu)
+  return p
+}
+
+func (obj *WishartDistribution) SetParameters(parameters Vector) error {
+  n := obj.dim()
+  s  := parameters.Slice(0, n*n).AsMatrix(n, n)
+  nu := parameters.At(n*n)
+  if tmp, err := NewWishartDistribution(nu, s); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *WishartDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  n, ok := config.GetNamedParameterAsInt("N"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  nu, ok := config.GetNamedParameterAsScalar("Nu", t); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  sigma, ok := config.GetNamedParametersAsMatrix("Sigma", t, n, n); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if tmp, err := NewWishartDistribution(nu, sigma); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+func (obj *WishartDistribution) ExportConfig() ConfigDistribution {
+
+  n := obj.dim()
+
+  config := struct{
+    Nu      float64
+    Sigma []float64
+    N       int }{}
+  config.Nu    = obj.Nu.GetFloat64()
+  config.Sigma = AsDenseFloat64Vector(obj.S.AsVector())
+  config.N     = n
+
+  return NewConfigDistribution("matrix:wishart distribution", config)
+}
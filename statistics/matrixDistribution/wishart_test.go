@@ -0,0 +1,106 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
+
+/* -------------------------------------------------------------------------- */
+
+func TestWishartDistribution1(t *testing.T) {
+
+  x  := NewDenseFloat64Matrix([]float64{2, -0.3, -0.3, 4}, 2, 2)
+  nu := NewFloat64(3.0)
+  s  := NewDenseFloat64Matrix([]float64{1, +0.3, +0.3, 1}, 2, 2)
+  r  := NewFloat64(0.0)
+
+  wishart, _ := NewWishartDistribution(nu, s)
+  wishart.LogPdf(r, x)
+
+  if math.Abs(r.GetFloat64() - -5.78516262) > 1e-4 {
+    t.Error("test failed")
+  }
+  // if X ~ W(nu, S) then X^-1 ~ IW(nu, S^-1)
+  xInv, _ := matrixInverse.Run(x)
+  sInv, _ := matrixInverse.Run(s)
+  q  := NewFloat64(0.0)
+  iw, _ := NewInverseWishartDistribution(nu, sInv)
+  iw.LogPdf(q, xInv)
+  // Jacobian |X|^-(p+1)
+  q.Sub(q, ConstFloat64(3.0*math.Log(2.0*4.0 - 0.3*0.3)))
+
+  if math.Abs(r.GetFloat64() - q.GetFloat64()) > 1e-8 {
+    t.Error("test failed")
+  }
+}
+
+func TestWishartDistribution2(t *testing.T) {
+  // derivative with respect to nu
+  x  := NewDenseFloat64Matrix([]float64{2, -0.3, -0.3, 4}, 2, 2)
+  s  := NewDenseReal64Matrix ([]float64{1, +0.3, +0.3, 1}, 2, 2)
+  nu := NewReal64(3.0)
+  nu.SetVariable(0, 1, 1)
+  r  := NewReal64(0.0)
+
+  wishart, _ := NewWishartDistribution(nu, s)
+  wishart.LogPdf(r, x)
+
+  f := func(nu float64) float64 {
+    w, _ := NewWishartDistribution(NewFloat64(nu), AsDenseFloat64Matrix(s))
+    y := NewFloat64(0.0)
+    w.LogPdf(y, x)
+    return y.GetFloat64()
+  }
+  h := 1e-6
+  if math.Abs(r.GetDerivative(0) - (f(3.0+h) - f(3.0-h))/(2.0*h)) > 1e-6 {
+    t.Error("test failed")
+  }
+}
+
+func TestWishartDistributionSample(t *testing.T) {
+  nu := NewFloat64(5.0)
+  s  := NewDenseFloat64Matrix([]float64{1, +0.3, +0.3, 1}, 2, 2)
+
+  wishart, _ := NewWishartDistribution(nu, s)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Matrix(2, 2)
+  m := NullDenseFloat64Matrix(2, 2)
+  n := 100000
+  for k := 0; k < n; k++ {
+    if err := wishart.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    m.MaddM(m, x)
+  }
+  m.MdivS(m, ConstFloat64(float64(n)))
+  // mean: nu S
+  for i := 0; i < 2; i++ {
+    for j := 0; j < 2; j++ {
+      if math.Abs(m.At(i, j).GetFloat64() - 5.0*s.At(i, j).GetFloat64()) > 0.05 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
@@ -0,0 +1,42 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+type StdEstimator struct {
+  x []ConstMatrix
+  n   int
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *StdEstimator) GetData() ([]ConstMatrix, int) {
+  return obj.x, obj.n
+}
+
+func (obj *StdEstimator) SetData(x []ConstMatrix, n int) error {
+  obj.x = x
+  obj.n = n
+  return nil
+}
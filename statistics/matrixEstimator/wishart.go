@@ -0,0 +1,200 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/matrixDistribution"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// Maximum likelihood estimator of the scale matrix S of a Wishart
+// distribution with fixed degrees of freedom nu, i.e. S = mean(X)/nu. The
+// degrees of freedom have no closed-form estimate.
+type WishartEstimator struct {
+  *matrixDistribution.WishartDistribution
+  StdEstimator
+  // parameters
+  n int
+  // state
+  sum_g     []float64
+  sum_x   [][]float64
+  gamma_max float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewWishartEstimator(nu float64, s []float64) (*WishartEstimator, error) {
+  n := int(math.Sqrt(float64(len(s))))
+  if n*n != len(s) {
+    return nil, fmt.Errorf("s has invalid dimension")
+  }
+  if dist, err := matrixDistribution.NewWishartDistribution(NewFloat64(nu), NewDenseFloat64Matrix(s, n, n)); err != nil {
+    return nil, err
+  } else {
+    r := WishartEstimator{}
+    r.WishartDistribution = dist
+    r.n                   = n
+    return &r, nil
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *WishartEstimator) Clone() *WishartEstimator {
+  r := WishartEstimator{}
+  r.WishartDistribution = obj.WishartDistribution.Clone()
+  r.n = obj.n
+  r.x = obj.x
+  return &r
+}
+
+func (obj *WishartEstimator) CloneMatrixEstimator() MatrixEstimator {
+  return obj.Clone()
+}
+
+func (obj *WishartEstimator) CloneMatrixBatchEstimator() MatrixBatchEstimator {
+  return obj.Clone()
+}
+
+/* batch estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *WishartEstimator) Initialize(p ThreadPool) error {
+  obj.sum_g = make(  []float64, p.NumberOfThreads())
+  obj.sum_x = make([][]float64, p.NumberOfThreads())
+  for i := 0; i < p.NumberOfThreads(); i++ {
+    obj.sum_x[i] = make([]float64, obj.n*obj.n)
+  }
+  obj.gamma_max = 0.0
+  return nil
+}
+
+func (obj *WishartEstimator) NewObservation(x ConstMatrix, gamma ConstScalar, p ThreadPool) error {
+  if n1, n2 := x.Dims(); n1 != obj.n || n2 != obj.n {
+    return fmt.Errorf("x has invalid dimension (expected dimension `%dx%d' but data has dimension `%dx%d')", obj.n, obj.n, n1, n2)
+  }
+  id := p.GetThreadId()
+  g  := 1.0
+  if gamma != nil {
+    g = math.Exp(gamma.GetFloat64() - obj.gamma_max)
+  }
+  obj.sum_g[id] += g
+  for i := 0; i < obj.n; i++ {
+    for j := 0; j < obj.n; j++ {
+      obj.sum_x[id][i*obj.n+j] += g*x.ConstAt(i, j).GetFloat64()
+    }
+  }
+  return nil
+}
+
+/* estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *WishartEstimator) updateEstimate() error {
+  sum_g := obj.sum_g[0]
+  sum_x := obj.sum_x[0]
+  for k := 1; k < len(obj.sum_x); k++ {
+    sum_g += obj.sum_g[k]
+    for i := range sum_x {
+      sum_x[i] += obj.sum_x[k][i]
+    }
+  }
+  obj.sum_g = nil
+  obj.sum_x = nil
+  if sum_g == 0.0 {
+    return fmt.Errorf("cannot estimate wishart distribution without observations")
+  }
+  nu := obj.Nu.GetFloat64()
+  s  := NullDenseFloat64Matrix(obj.n, obj.n)
+  for i := 0; i < obj.n; i++ {
+    for j := 0; j < obj.n; j++ {
+      s.At(i, j).SetFloat64(sum_x[i*obj.n+j]/sum_g/nu)
+    }
+  }
+  if t, err := matrixDistribution.NewWishartDistribution(obj.Nu, AsDenseMatrix(obj.ScalarType(), s)); err != nil {
+    return err
+  } else {
+    *obj.WishartDistribution = *t
+  }
+  return nil
+}
+
+func (obj *WishartEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  g := p.NewJobGroup()
+  x := obj.x
+
+  // initialize estimator
+  obj.Initialize(p)
+
+  // rescale gamma
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma != nil {
+    obj.gamma_max = math.Inf(-1)
+    for i := 0; i < gamma.Dim(); i++ {
+      if g := gamma.ConstAt(i).GetFloat64(); obj.gamma_max < g {
+        obj.gamma_max = g
+      }
+    }
+  }
+  // compute sufficient statistics
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma == nil {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], nil, p)
+    }); err != nil {
+      return err
+    }
+  } else {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], gamma.ConstAt(i), p)
+    }); err != nil {
+      return err
+    }
+  }
+  if err := p.Wait(g); err != nil {
+    return err
+  }
+  // update estimate
+  if err := obj.updateEstimate(); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (obj *WishartEstimator) EstimateOnData(x []ConstMatrix, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, len(x)); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *WishartEstimator) GetEstimate() (MatrixPdf, error) {
+  if obj.sum_x != nil {
+    if err := obj.updateEstimate(); err != nil {
+      return nil, err
+    }
+  }
+  return obj.WishartDistribution, nil
+}
@@ -0,0 +1,63 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package matrixEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import   "github.com/pbenner/autodiff/statistics/matrixDistribution"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestWishart1(t *testing.T) {
+
+  s := NewDenseFloat64Matrix([]float64{1, 0.3, 0.3, 2}, 2, 2)
+
+  d, _ := matrixDistribution.NewWishartDistribution(NewFloat64(5.0), s)
+  g    := rand.New(rand.NewSource(1))
+  x    := make([]ConstMatrix, 10000)
+  for i := 0; i < len(x); i++ {
+    y := NullDenseFloat64Matrix(2, 2)
+    d.Sample(y, g)
+    x[i] = y
+  }
+
+  if estimator, err := NewWishartEstimator(5.0, []float64{1, 0, 0, 1}); err != nil {
+    t.Error(err)
+  } else {
+    if err := estimator.EstimateOnData(x, nil, ThreadPool{}); err != nil {
+      t.Fatal(err)
+    }
+    r, _ := estimator.GetEstimate()
+    e    := r.(*matrixDistribution.WishartDistribution).S
+
+    for i := 0; i < 2; i++ {
+      for j := 0; j < 2; j++ {
+        if math.Abs(e.At(i, j).GetFloat64() - s.At(i, j).GetFloat64()) > 0.05 {
+          t.Error("test failed")
+        }
+      }
+    }
+  }
+}
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -151,6 +152,18 @@ func (dist *BetaDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *BetaDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // theta = g1/(g1 + g2) with g1 ~ Gamma(alpha), g2 ~ Gamma(beta)
+  g1 := randGamma(dist.Alpha.GetFloat64(), r)
+  g2 := randGamma(dist.Beta .GetFloat64(), r)
+  if dist.LogScale {
+    x.SetFloat64(math.Log(g1) - math.Log(g1 + g2))
+  } else {
+    x.SetFloat64(g1/(g1 + g2))
+  }
+  return nil
+}
+
 func (dist *BetaDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -159,6 +172,54 @@ func (dist *BetaDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *BetaDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  if err := dist.Cdf(r, x); err != nil {
+    return err
+  }
+  r.Log(r)
+  return nil
+}
+
+func (dist *BetaDistribution) Cdf(r Scalar, x ConstScalar) error {
+  t := NullScalar(r.Type())
+  if dist.LogScale {
+    t.Exp(x)
+  } else {
+    t.Set(x)
+  }
+  setBetaI(r, dist.Alpha, dist.Beta, t)
+  return nil
+}
+
+func (dist *BetaDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  t := NullScalar(r.Type())
+  if dist.LogScale {
+    t.Exp(x)
+  } else {
+    t.Set(x)
+  }
+  // 1 - I_x(alpha, beta) = I_{1-x}(beta, alpha)
+  t.Sub(ConstFloat64(1.0), t)
+  setBetaI(r, dist.Beta, dist.Alpha, t)
+  r.Log(r)
+  return nil
+}
+
+func (dist *BetaDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  a := dist.Alpha.GetFloat64()
+  b := dist.Beta .GetFloat64()
+  q := invertCdf(func(x float64) float64 { return betaI(a, b, x) }, p.GetFloat64(), a/(a+b), 0.0, 1.0)
+  f := math.Exp((a - 1.0)*math.Log(q) + (b - 1.0)*math.Log1p(-q) - logBeta(a, b))
+  if dist.LogScale {
+    return setQuantile(r, p, math.Log(q), f*q, dist.Cdf)
+  } else {
+    return setQuantile(r, p, q, f, dist.Cdf)
+  }
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *BetaDistribution) GetParameters() Vector {
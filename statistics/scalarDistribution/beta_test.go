@@ -20,6 +20,7 @@ package scalarDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -85,3 +86,28 @@ func TestBeta4(t *testing.T) {
     t.Error("test failed")
   }
 }
+
+func TestBetaSample(t *testing.T) {
+  // test both scales
+  for _, logScale := range []bool{false, true} {
+    d, _ := NewBetaDistribution(NewFloat64(2.0), NewFloat64(5.0), logScale)
+
+    r := rand.New(rand.NewSource(1))
+    x := NullFloat64()
+    n := 100000
+    m := 0.0
+    for i := 0; i < n; i++ {
+      if err := d.Sample(x, r); err != nil {
+        t.Fatal(err)
+      }
+      if logScale {
+        m += math.Exp(x.GetFloat64())/float64(n)
+      } else {
+        m += x.GetFloat64()/float64(n)
+      }
+    }
+    if math.Abs(m - 2.0/7.0) > 0.005 {
+      t.Error("test failed")
+    }
+  }
+}
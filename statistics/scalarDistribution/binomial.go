@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -134,6 +135,19 @@ func (dist *BinomialDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *BinomialDistribution) Sample(x Scalar, r *rand.Rand) error {
+  n := int(dist.n.GetFloat64())
+  p := math.Exp(dist.Theta.GetFloat64())
+  k := 0
+  for i := 0; i < n; i++ {
+    if r.Float64() < p {
+      k++
+    }
+  }
+  x.SetFloat64(float64(k))
+  return nil
+}
+
 func (dist *BinomialDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -142,6 +156,69 @@ func (dist *BinomialDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+// Set r to the logarithm of sum_{k=k1}^{k2} P(X = k)
+func (dist *BinomialDistribution) logSum(r Scalar, k1, k2 int) error {
+  t1 := NullScalar(r.Type())
+  t2 := NullScalar(r.Type())
+  r.SetFloat64(math.Inf(-1))
+  for k := k1; k <= k2; k++ {
+    if err := dist.LogPdf(t1, ConstFloat64(float64(k))); err != nil {
+      return err
+    }
+    r.LogAdd(r, t1, t2)
+  }
+  return nil
+}
+
+func (dist *BinomialDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  n := dist.GetN()
+  k := math.Floor(x.GetFloat64())
+  if k >= float64(n) {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  return dist.logSum(r, 0, int(k))
+}
+
+func (dist *BinomialDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *BinomialDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  n := dist.GetN()
+  k := math.Floor(x.GetFloat64())
+  if k < 0.0 {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  if k >= float64(n) {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  return dist.logSum(r, int(k)+1, n)
+}
+
+func (dist *BinomialDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  n := float64(dist.GetN())
+  theta := math.Exp(dist.Theta.GetFloat64())
+  // F(k) = I_{1-theta}(n-k, k+1)
+  cdf := func(k float64) float64 {
+    if k >= n {
+      return 1.0
+    }
+    return betaI(n-k, k+1.0, 1.0-theta)
+  }
+  r.SetFloat64(invertDiscreteCdf(cdf, p.GetFloat64(), 0.0, n))
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *BinomialDistribution) GetParameters() Vector {
@@ -19,6 +19,8 @@ package scalarDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
+import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -76,6 +78,23 @@ func (dist *CategoricalDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *CategoricalDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // Theta stores log probabilities, which are not necessarily normalized
+  z := 0.0
+  for i := 0; i < dist.Theta.Dim(); i++ {
+    z += math.Exp(dist.Theta.At(i).GetFloat64())
+  }
+  u := z*r.Float64()
+  for i := 0; i < dist.Theta.Dim(); i++ {
+    if u -= math.Exp(dist.Theta.At(i).GetFloat64()); u < 0.0 {
+      x.SetFloat64(float64(i))
+      return nil
+    }
+  }
+  x.SetFloat64(float64(dist.Theta.Dim()-1))
+  return nil
+}
+
 func (dist *CategoricalDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -85,8 +104,10 @@ func (dist *CategoricalDistribution) Pdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *CategoricalDistribution) LogCdf(r Scalar, x ConstScalar) error {
-  r.Reset()
-  for i := 0; i <= int(x.GetFloat64()); i++ {
+  n := dist.Theta.Dim()
+  k := int(math.Min(math.Floor(x.GetFloat64()), float64(n-1)))
+  r.SetFloat64(math.Inf(-1))
+  for i := 0; i <= k; i++ {
     r.LogAdd(r, dist.Theta.At(i), dist.t)
   }
   return nil
@@ -100,6 +121,37 @@ func (dist *CategoricalDistribution) Cdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *CategoricalDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  n := dist.Theta.Dim()
+  k := int(math.Max(math.Floor(x.GetFloat64()), -1.0))
+  r.SetFloat64(math.Inf(-1))
+  for i := k+1; i < n; i++ {
+    r.LogAdd(r, dist.Theta.At(i), dist.t)
+  }
+  return nil
+}
+
+func (dist *CategoricalDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  // Theta stores log probabilities, which are not necessarily normalized
+  n := dist.Theta.Dim()
+  z := 0.0
+  for i := 0; i < n; i++ {
+    z += math.Exp(dist.Theta.At(i).GetFloat64())
+  }
+  u := z*p.GetFloat64()
+  for i := 0; i < n; i++ {
+    if u -= math.Exp(dist.Theta.At(i).GetFloat64()); u <= 0.0 {
+      r.SetFloat64(float64(i))
+      return nil
+    }
+  }
+  r.SetFloat64(float64(n-1))
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *CategoricalDistribution) GetParameters() Vector {
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -77,6 +78,11 @@ func (obj *CauchyDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (obj *CauchyDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(obj.Mu.GetFloat64() + obj.Sigma.GetFloat64()*math.Tan(math.Pi*(randUniformOpen(r) - 0.5)))
+  return nil
+}
+
 func (obj *CauchyDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := obj.LogPdf(r, x); err != nil {
     return err
@@ -85,6 +91,58 @@ func (obj *CauchyDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+// Set r to log F(s (x-mu)/sigma), where F is the standard Cauchy cdf and s
+// is either 1 or -1
+func (obj *CauchyDistribution) logF(r Scalar, x ConstScalar, s float64) error {
+  sigma := obj.Sigma.GetFloat64()
+  z     := (x.GetFloat64() - obj.Mu.GetFloat64())/sigma
+  // F(z) = 1/2 + atan(z)/pi = atan2(1, -z)/pi without cancellation for
+  // large negative z
+  f     := math.Atan2(1.0, -s*z)/math.Pi
+  // h = d/dz log F(s z)
+  h     := s/(math.Pi*(1.0 + z*z)*f)
+  setFirstOrder(r, math.Log(f), []float64{h/sigma, -h/sigma, -h*z/sigma}, x, obj.Mu, obj.Sigma)
+  return nil
+}
+
+func (obj *CauchyDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  return obj.logF(r, x, 1.0)
+}
+
+func (obj *CauchyDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *CauchyDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  return obj.logF(r, x, -1.0)
+}
+
+func (obj *CauchyDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  switch p.GetFloat64() {
+  case 0.0:
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  case 1.0:
+    r.SetFloat64(math.Inf( 1))
+    return nil
+  }
+  // q = mu + sigma tan(pi (p - 1/2))
+  t := NullScalar(r.Type())
+  t.Sub(p, ConstFloat64(0.5))
+  t.Mul(t, ConstFloat64(math.Pi))
+  t.Tan(t)
+  r.Mul(obj.Sigma, t)
+  r.Add(r, obj.Mu)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (obj *CauchyDistribution) GetParameters() Vector {
@@ -0,0 +1,371 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/special"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* first order expansions
+ * -------------------------------------------------------------------------- */
+
+// Add c (a - a0) to r, where a0 is the current value of a. The value of r
+// does not change, but derivatives with respect to a are propagated to r.
+// This is used for quantities that cannot be computed with scalar
+// operations.
+func addDerivative(r Scalar, c float64, a ConstScalar) {
+  if c == 0.0 || math.IsInf(c, 0) || math.IsNaN(c) {
+    return
+  }
+  t := NullScalar(r.Type())
+  t.Mul(a, ConstFloat64(c))
+  t.Sub(t, ConstFloat64(t.GetFloat64()))
+  r.Add(r, t)
+}
+
+// Set r to v with first derivatives dv[i] with respect to a[i]
+func setFirstOrder(r Scalar, v float64, dv []float64, a ...ConstScalar) {
+  // r may be one of the arguments, so accumulate derivatives in a
+  // temporary variable first
+  t := NullScalar(r.Type())
+  for i := 0; i < len(a); i++ {
+    addDerivative(t, dv[i], a[i])
+  }
+  r.SetFloat64(v)
+  r.Add(r, t)
+}
+
+// Set r to log(1 - exp(a)) for a <= 0
+func setLog1mExp(r Scalar, a ConstScalar) {
+  if v := a.GetFloat64(); v > -math.Ln2 {
+    // avoid cancellation close to zero
+    setFirstOrder(r, math.Log(-math.Expm1(v)), []float64{-1.0/math.Expm1(-v)}, a)
+  } else {
+    r.Exp(a)
+    r.Neg(r)
+    r.Log1p(r)
+  }
+}
+
+/* incomplete gamma and beta functions
+ * -------------------------------------------------------------------------- */
+
+// Central finite difference of f at x
+func finiteDifference(f func(float64) float64, x float64) float64 {
+  h := 1e-6*math.Max(1.0, math.Abs(x))
+  // stay within the domain of shape parameters
+  if x > 0.0 && h >= x {
+    h = x/2.0
+  }
+  return (f(x+h) - f(x-h))/(2.0*h)
+}
+
+// Set r to the regularized lower incomplete gamma function P(a, y). The
+// derivative with respect to the shape parameter a is computed by finite
+// differences.
+func setGammaP(r Scalar, a, y ConstScalar) {
+  a0 := a.GetFloat64()
+  y0 := y.GetFloat64()
+  if y0 <= 0.0 {
+    r.SetFloat64(0.0)
+    return
+  }
+  da := finiteDifference(func(a float64) float64 { return special.GammaP(a, y0) }, a0)
+  t  := NullScalar(r.Type())
+  addDerivative(t, da, a)
+  r.GammaP(a0, y)
+  r.Add(r, t)
+}
+
+// Set r to log Q(a, y) = log(1 - P(a, y))
+func setLogGammaQ(r Scalar, a, y ConstScalar) {
+  a0 := a.GetFloat64()
+  y0 := y.GetFloat64()
+  if y0 <= 0.0 {
+    r.SetFloat64(0.0)
+    return
+  }
+  if special.GammaP(a0, y0) < 0.5 {
+    setGammaP(r, a, y)
+    r.Neg(r)
+    r.Log1p(r)
+  } else {
+    // compute Q directly to retain precision in the upper tail
+    q  := special.GammaQ(a0, y0)
+    dy := special.GammaPfirstDerivative(a0, y0)
+    da := finiteDifference(func(a float64) float64 { return special.GammaP(a, y0) }, a0)
+    setFirstOrder(r, math.Log(q), []float64{-dy/q, -da/q}, y, a)
+  }
+}
+
+// Continued fraction for the incomplete beta function (modified Lentz's
+// method)
+func betaContinuedFraction(a, b, x float64) float64 {
+  const tiny = 1e-300
+  c := 1.0
+  d := 1.0 - (a + b)*x/(a + 1.0)
+  if math.Abs(d) < tiny {
+    d = tiny
+  }
+  d = 1.0/d
+  h := d
+  for m := 1; m <= 10000; m++ {
+    k  := float64(m)
+    // even step
+    aa := k*(b - k)*x/((a + 2.0*k - 1.0)*(a + 2.0*k))
+    d   = 1.0 + aa*d
+    c   = 1.0 + aa/c
+    if math.Abs(d) < tiny {
+      d = tiny
+    }
+    if math.Abs(c) < tiny {
+      c = tiny
+    }
+    d  = 1.0/d
+    h *= d*c
+    // odd step
+    aa  = -(a + k)*(a + b + k)*x/((a + 2.0*k)*(a + 2.0*k + 1.0))
+    d   = 1.0 + aa*d
+    c   = 1.0 + aa/c
+    if math.Abs(d) < tiny {
+      d = tiny
+    }
+    if math.Abs(c) < tiny {
+      c = tiny
+    }
+    d  = 1.0/d
+    h *= d*c
+    if math.Abs(d*c - 1.0) < 1e-16 {
+      break
+    }
+  }
+  return h
+}
+
+// Logarithm of the beta function B(a, b)
+func logBeta(a, b float64) float64 {
+  la, _ := math.Lgamma(a)
+  lb, _ := math.Lgamma(b)
+  lc, _ := math.Lgamma(a + b)
+  return la + lb - lc
+}
+
+// Regularized incomplete beta function I_x(a, b)
+func betaI(a, b, x float64) float64 {
+  if x <= 0.0 {
+    return 0.0
+  }
+  if x >= 1.0 {
+    return 1.0
+  }
+  z := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - logBeta(a, b))
+  // the continued fraction converges rapidly for x < (a+1)/(a+b+2)
+  if x < (a + 1.0)/(a + b + 2.0) {
+    return z*betaContinuedFraction(a, b, x)/a
+  } else {
+    return 1.0 - z*betaContinuedFraction(b, a, 1.0-x)/b
+  }
+}
+
+// Set r to I_x(a, b), derivatives with respect to a and b are computed by
+// finite differences
+func setBetaI(r Scalar, a, b, x ConstScalar) {
+  a0 := a.GetFloat64()
+  b0 := b.GetFloat64()
+  x0 := x.GetFloat64()
+  if x0 <= 0.0 {
+    r.SetFloat64(0.0)
+    return
+  }
+  if x0 >= 1.0 {
+    r.SetFloat64(1.0)
+    return
+  }
+  dx := math.Exp((a0 - 1.0)*math.Log(x0) + (b0 - 1.0)*math.Log1p(-x0) - logBeta(a0, b0))
+  da := finiteDifference(func(a float64) float64 { return betaI(a, b0, x0) }, a0)
+  db := finiteDifference(func(b float64) float64 { return betaI(a0, b, x0) }, b0)
+  setFirstOrder(r, betaI(a0, b0, x0), []float64{dx, da, db}, x, a, b)
+}
+
+/* quantile functions
+ * -------------------------------------------------------------------------- */
+
+func checkProbability(p ConstScalar) error {
+  if v := p.GetFloat64(); v < 0.0 || v > 1.0 || math.IsNaN(v) {
+    return fmt.Errorf("invalid probability `%v'", v)
+  }
+  return nil
+}
+
+// Invert a continuous cdf numerically by bisection, lower and upper are the
+// bounds of the support and x0 is a point within the support
+func invertCdf(cdf func(float64) float64, p, x0, lower, upper float64) float64 {
+  if p <= 0.0 {
+    return lower
+  }
+  if p >= 1.0 {
+    return upper
+  }
+  // find bracketing interval
+  a := lower
+  b := upper
+  if math.IsInf(a, -1) {
+    s := math.Max(1.0, math.Abs(x0))
+    for a = x0 - s; cdf(a) > p; a -= s {
+      s *= 2.0
+    }
+  }
+  if math.IsInf(b, 1) {
+    s := math.Max(1.0, math.Abs(x0))
+    for b = x0 + s; cdf(b) < p; b += s {
+      s *= 2.0
+    }
+  }
+  for i := 0; i < 1100; i++ {
+    c := a + (b - a)/2.0
+    if c <= a || c >= b {
+      break
+    }
+    if cdf(c) < p {
+      a = c
+    } else {
+      b = c
+    }
+  }
+  return a + (b - a)/2.0
+}
+
+// Smallest integer k within [lower, upper] such that cdf(k) >= p
+func invertDiscreteCdf(cdf func(float64) float64, p, lower, upper float64) float64 {
+  if p <= 0.0 {
+    return lower
+  }
+  if p >= 1.0 {
+    return upper
+  }
+  // exponential search for an upper bound
+  a := lower
+  b := lower
+  for s := 1.0; b < upper && cdf(b) < p; s *= 2.0 {
+    a = b + 1.0
+    b = math.Min(b + s, upper)
+  }
+  if math.IsInf(b, 1) {
+    return b
+  }
+  // binary search
+  for a < b {
+    c := math.Floor(a + (b - a)/2.0)
+    if cdf(c) < p {
+      a = c + 1.0
+    } else {
+      b = c
+    }
+  }
+  return a
+}
+
+// Set r to the quantile q of a continuous distribution with density f at q.
+// Derivatives are obtained by implicit differentiation of F(q) = p, i.e.
+// dq = (dp - dF)/f(q), where dF is the derivative of the cdf with respect
+// to the parameters.
+func setQuantile(r Scalar, p ConstScalar, q, f float64, cdf func(Scalar, ConstScalar) error) error {
+  if f <= 0.0 || math.IsInf(f, 0) || math.IsNaN(f) || math.IsInf(q, 0) {
+    r.SetFloat64(q)
+    return nil
+  }
+  t := NullScalar(r.Type())
+  if err := cdf(t, ConstFloat64(q)); err != nil {
+    return err
+  }
+  t.Sub(p, t)
+  t.Div(t, ConstFloat64(f))
+  t.Sub(t, ConstFloat64(t.GetFloat64()))
+  r.SetFloat64(q)
+  r.Add(r, t)
+  return nil
+}
+
+/* interval probabilities
+ * -------------------------------------------------------------------------- */
+
+// Set r to log P(a < X <= b). If a lies in the upper tail, the
+// probability is computed from the survival function to avoid
+// cancellation.
+func logProbability(r Scalar, f ScalarCdf, a, b float64) error {
+  switch {
+  case math.IsInf(a, -1) && math.IsInf(b, 1):
+    r.SetFloat64(0.0)
+    return nil
+  case math.IsInf(a, -1):
+    return f.LogCdf(r, ConstFloat64(b))
+  case math.IsInf(b, 1):
+    return f.LogSurvival(r, ConstFloat64(a))
+  }
+  t1 := NullScalar(r.Type())
+  t2 := NullScalar(r.Type())
+  if err := f.LogCdf(t1, ConstFloat64(a)); err != nil {
+    return err
+  }
+  if t1.GetFloat64() > -math.Ln2 {
+    // log(S(a) - S(b))
+    if err := f.LogSurvival(t1, ConstFloat64(a)); err != nil {
+      return err
+    }
+    if err := f.LogSurvival(t2, ConstFloat64(b)); err != nil {
+      return err
+    }
+  } else {
+    // log(F(b) - F(a))
+    if err := f.LogCdf(t2, ConstFloat64(b)); err != nil {
+      return err
+    }
+    t1, t2 = t2, t1
+  }
+  if math.IsInf(t2.GetFloat64(), -1) {
+    r.Set(t1)
+    return nil
+  }
+  // log(exp(t1) - exp(t2)) = t1 + log(1 - exp(t2 - t1))
+  t2.Sub(t2, t1)
+  setLog1mExp(t2, t2)
+  r.Add(t1, t2)
+  return nil
+}
+
+// Infinite interval bounds cannot be stored in json files and are
+// replaced by the largest finite float64 values
+func exportBound(v float64) float64 {
+  switch {
+  case math.IsInf(v,  1): return  math.MaxFloat64
+  case math.IsInf(v, -1): return -math.MaxFloat64
+  }
+  return v
+}
+
+func importBound(v float64) float64 {
+  switch {
+  case v >=  math.MaxFloat64: return math.Inf( 1)
+  case v <= -math.MaxFloat64: return math.Inf(-1)
+  }
+  return v
+}
@@ -0,0 +1,269 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+type cdfTestCase struct {
+  name     string
+  discrete bool
+  // construct distribution from parameters
+  new      func(p []Scalar) (ScalarPdf, error)
+  params   []float64
+  // points at which the cdf is evaluated
+  x        []float64
+}
+
+func cdfTestCases() []cdfTestCase {
+  normal := func(p []Scalar) (ScalarPdf, error) { return NewNormalDistribution(p[0], p[1]) }
+  gamma  := func(p []Scalar) (ScalarPdf, error) { return NewGammaDistribution (p[0], p[1]) }
+  return []cdfTestCase{
+    {"beta", false, func(p []Scalar) (ScalarPdf, error) { return NewBetaDistribution(p[0], p[1], false) },
+      []float64{2.0, 3.0}, []float64{0.1, 0.4, 0.9}},
+    {"beta (log scale)", false, func(p []Scalar) (ScalarPdf, error) { return NewBetaDistribution(p[0], p[1], true) },
+      []float64{2.0, 3.0}, []float64{-2.0, -1.0, -0.1}},
+    {"binomial", true, func(p []Scalar) (ScalarPdf, error) { return NewBinomialDistribution(p[0], 10) },
+      []float64{0.3}, []float64{0.0, 3.0, 7.0}},
+    {"categorical", true, func(p []Scalar) (ScalarPdf, error) { return NewCategoricalDistribution(AsDenseVector(p[0].Type(), NewDenseFloat64Vector([]float64{0.2, 0.5, 0.3}))) },
+      []float64{}, []float64{0.0, 1.0}},
+    {"cauchy", false, func(p []Scalar) (ScalarPdf, error) { return NewCauchyDistribution(p[0], p[1]) },
+      []float64{1.0, 2.0}, []float64{-100.0, 0.5, 30.0}},
+    {"chi-squared", false, func(p []Scalar) (ScalarPdf, error) { return NewChiSquaredDistribution(p[0].Type(), 3.0) },
+      []float64{}, []float64{0.5, 3.0, 20.0}},
+    {"delta", true, func(p []Scalar) (ScalarPdf, error) { return NewDeltaDistribution(p[0]) },
+      []float64{2.0}, []float64{1.0, 3.0}},
+    {"exponential", false, func(p []Scalar) (ScalarPdf, error) { return NewExponentialDistribution(p[0]) },
+      []float64{1.5}, []float64{1e-10, 0.5, 20.0}},
+    {"gamma", false, gamma,
+      []float64{2.5, 1.5}, []float64{0.2, 1.5, 10.0}},
+    {"generalized gamma", false, func(p []Scalar) (ScalarPdf, error) { return NewGeneralizedGammaDistribution(p[0], p[1], p[2]) },
+      []float64{2.0, 3.0, 1.5}, []float64{0.5, 2.0, 6.0}},
+    {"geometric", true, func(p []Scalar) (ScalarPdf, error) { return NewGeometricDistribution(p[0]) },
+      []float64{0.3}, []float64{0.0, 2.0, 10.0}},
+    {"gev", false, func(p []Scalar) (ScalarPdf, error) { return NewGevDistribution(p[0], p[1], p[2]) },
+      []float64{1.0, 2.0, 0.2}, []float64{-1.0, 1.0, 10.0}},
+    {"gev (xi < 0)", false, func(p []Scalar) (ScalarPdf, error) { return NewGevDistribution(p[0], p[1], p[2]) },
+      []float64{1.0, 2.0, -0.3}, []float64{-1.0, 1.0, 5.0}},
+    {"gpareto", false, func(p []Scalar) (ScalarPdf, error) { return NewGParetoDistribution(p[0], p[1], p[2]) },
+      []float64{1.0, 2.0, 0.2}, []float64{1.5, 3.0, 20.0}},
+    {"laplace", false, func(p []Scalar) (ScalarPdf, error) { return NewLaplaceDistribution(p[0], p[1]) },
+      []float64{1.0, 2.0}, []float64{-3.0, 1.5, 40.0}},
+    {"mixture", false, func(p []Scalar) (ScalarPdf, error) {
+      n1, _ := NewNormalDistribution(p[0], p[1])
+      n2, _ := NewNormalDistribution(p[2], p[3])
+      return NewMixture(AsDenseVector(p[0].Type(), NewDenseFloat64Vector([]float64{0.3, 0.7})), []ScalarPdf{n1, n2}) },
+      []float64{-1.0, 1.0, 2.0, 0.5}, []float64{-2.0, 0.5, 2.5}},
+    {"negative binomial", true, func(p []Scalar) (ScalarPdf, error) { return NewNegativeBinomialDistribution(p[0], p[1]) },
+      []float64{3.5, 0.4}, []float64{0.0, 2.0, 8.0}},
+    {"normal", false, normal,
+      []float64{1.0, 2.0}, []float64{-50.0, 0.5, 3.0, 60.0}},
+    {"pareto", false, func(p []Scalar) (ScalarPdf, error) { return NewParetoDistribution(p[0], p[1]) },
+      []float64{1.0, 2.5}, []float64{1.5, 3.0, 100.0}},
+    {"log transform", false, func(p []Scalar) (ScalarPdf, error) {
+      d, _ := NewGammaDistribution(p[0], p[1])
+      return NewPdfLogTransform(d, 1.0) },
+      []float64{2.5, 1.5}, []float64{0.5, 3.0, 10.0}},
+    {"translation", false, func(p []Scalar) (ScalarPdf, error) {
+      d, _ := NewNormalDistribution(p[0], p[1])
+      return NewPdfTranslation(d, 2.0) },
+      []float64{1.0, 2.0}, []float64{-2.0, 0.5}},
+    {"poisson", true, func(p []Scalar) (ScalarPdf, error) { return NewPoissonDistribution(p[0]) },
+      []float64{3.5}, []float64{0.0, 3.0, 12.0}},
+    {"zero inflated poisson", true, func(p []Scalar) (ScalarPdf, error) { return NewZeroInflatedPoissonDistribution(p[0], p[1]) },
+      []float64{0.3, 2.0}, []float64{0.0, 2.0, 9.0}},
+    {"hurdle negative binomial", true, func(p []Scalar) (ScalarPdf, error) { return NewHurdleNegativeBinomialDistribution(p[0], p[1], p[2]) },
+      []float64{0.2, 3.0, 0.4}, []float64{0.0, 2.0, 9.0}},
+    {"power law", false, func(p []Scalar) (ScalarPdf, error) { return NewPowerLawDistribution(p[0], p[1]) },
+      []float64{2.5, 1.0}, []float64{1.5, 3.0, 100.0}},
+  }
+}
+
+func newCdfTestCase(c cdfTestCase, t ScalarType) (ScalarPdf, error) {
+  p := make([]Scalar, len(c.params))
+  for i := range p {
+    p[i] = NewScalar(t, c.params[i])
+    if t == Real64Type {
+      p[i].(MagicScalar).SetVariable(i, len(p), 1)
+    }
+  }
+  // some distributions have no differentiable parameters
+  if len(p) == 0 {
+    p = append(p, NewScalar(t, 0.0))
+  }
+  return c.new(p)
+}
+
+/* -------------------------------------------------------------------------- */
+
+func TestCdf1(test *testing.T) {
+  // cdf and survival function must sum to one, quantiles must invert the
+  // cdf
+  for _, c := range cdfTestCases() {
+    d, err := newCdfTestCase(c, Float64Type)
+    if err != nil {
+      test.Fatal(err)
+    }
+    f := d.(ScalarCdf)
+    q := d.(ScalarQuantile)
+    r1 := NewFloat64(0.0)
+    r2 := NewFloat64(0.0)
+    for _, x := range c.x {
+      if err := f.LogCdf(r1, ConstFloat64(x)); err != nil {
+        test.Fatal(err)
+      }
+      if err := f.LogSurvival(r2, ConstFloat64(x)); err != nil {
+        test.Fatal(err)
+      }
+      if v := math.Exp(r1.GetFloat64()) + math.Exp(r2.GetFloat64()); math.Abs(v - 1.0) > 1e-10 {
+        test.Errorf("test failed for `%s' at x = %v: F(x) + S(x) = %v", c.name, x, v)
+      }
+    }
+    for _, p := range []float64{0.01, 0.3, 0.5, 0.9, 0.999} {
+      if err := q.Quantile(r1, ConstFloat64(p)); err != nil {
+        test.Fatal(err)
+      }
+      if err := f.Cdf(r2, r1); err != nil {
+        test.Fatal(err)
+      }
+      if c.discrete {
+        if r2.GetFloat64() < p {
+          test.Errorf("test failed for `%s' at p = %v", c.name, p)
+        }
+        if err := f.Cdf(r2, ConstFloat64(r1.GetFloat64()-1.0)); err != nil {
+          test.Fatal(err)
+        }
+        if r2.GetFloat64() >= p && r1.GetFloat64() > 0.0 {
+          test.Errorf("test failed for `%s' at p = %v", c.name, p)
+        }
+      } else {
+        if math.Abs(r2.GetFloat64() - p) > 1e-8 {
+          test.Errorf("test failed for `%s' at p = %v: F(Q(p)) = %v", c.name, p, r2.GetFloat64())
+        }
+      }
+    }
+  }
+}
+
+func TestCdf2(test *testing.T) {
+  // compare derivatives with finite differences
+  const h = 1e-6
+  eval := func(c cdfTestCase, t ScalarType, method string, x float64, params []float64) (Scalar, error) {
+    c.params = params
+    d, err := newCdfTestCase(c, t)
+    if err != nil {
+      return nil, err
+    }
+    r := NewScalar(t, 0.0)
+    switch method {
+    case "LogCdf":
+      err = d.(ScalarCdf).LogCdf(r, ConstFloat64(x))
+    case "LogSurvival":
+      err = d.(ScalarCdf).LogSurvival(r, ConstFloat64(x))
+    case "Quantile":
+      err = d.(ScalarQuantile).Quantile(r, ConstFloat64(x))
+    }
+    return r, err
+  }
+  for _, c := range cdfTestCases() {
+    methods := []string{"LogCdf", "LogSurvival"}
+    if !c.discrete {
+      methods = append(methods, "Quantile")
+    }
+    for _, method := range methods {
+      x := c.x
+      if method == "Quantile" {
+        x = []float64{0.05, 0.5, 0.95}
+      }
+      for _, xi := range x {
+        r, err := eval(c, Real64Type, method, xi, c.params)
+        if err != nil {
+          test.Fatal(err)
+        }
+        for i := range c.params {
+          p1 := append([]float64{}, c.params...)
+          p2 := append([]float64{}, c.params...)
+          p1[i] -= h
+          p2[i] += h
+          r1, err1 := eval(c, Float64Type, method, xi, p1)
+          r2, err2 := eval(c, Float64Type, method, xi, p2)
+          if err1 != nil || err2 != nil {
+            test.Fatal(fmt.Errorf("evaluation failed"))
+          }
+          d1 := (r2.GetFloat64() - r1.GetFloat64())/(2.0*h)
+          d2 := r.GetDerivative(i)
+          if math.Abs(d1 - d2) > 1e-4*math.Max(1.0, math.Abs(d1)) {
+            test.Errorf("test failed for `%s' (%s at %v): derivative %d is %v, expected %v", c.name, method, xi, i, d2, d1)
+          }
+        }
+      }
+    }
+  }
+}
+
+func TestCdf3(test *testing.T) {
+  // compare with values computed in R
+  r := NewFloat64(0.0)
+  check := func(name string, f func(Scalar) error, v float64) {
+    if err := f(r); err != nil {
+      test.Error(err)
+    } else if math.Abs(r.GetFloat64() - v) > 1e-6 {
+      test.Errorf("test failed for `%s': got %v, expected %v", name, r.GetFloat64(), v)
+    }
+  }
+  {
+    d, _ := NewChiSquaredDistribution(Float64Type, 1.0)
+    // qchisq(0.95, 1)
+    check("chi-squared", func(r Scalar) error { return d.Quantile(r, ConstFloat64(0.95)) }, 3.841458820694124)
+  }
+  {
+    d, _ := NewBetaDistribution(NewFloat64(2.0), NewFloat64(3.0), false)
+    // pbeta(0.4, 2, 3)
+    check("beta", func(r Scalar) error { return d.Cdf(r, ConstFloat64(0.4)) }, 0.5248)
+  }
+  {
+    d, _ := NewPoissonDistribution(NewFloat64(3.0))
+    // ppois(2, 3)
+    check("poisson", func(r Scalar) error { return d.Cdf(r, ConstFloat64(2.0)) }, 0.42319008112684353)
+  }
+  {
+    d, _ := NewNegativeBinomialDistribution(NewFloat64(3.0), NewFloat64(0.4))
+    // pnbinom(4, 3, 0.6, lower.tail=FALSE)
+    check("negative binomial", func(r Scalar) error {
+      if err := d.LogSurvival(r, ConstFloat64(4.0)); err != nil {
+        return err
+      }
+      r.Exp(r)
+      return nil
+    }, 0.096256)
+  }
+  {
+    d, _ := NewNormalDistribution(NewFloat64(0.0), NewFloat64(1.0))
+    // pnorm(-40, log.p=TRUE)
+    check("normal", func(r Scalar) error { return d.LogCdf(r, ConstFloat64(-40.0)) }, -804.6084420137538)
+    // qnorm(0.975)
+    check("normal", func(r Scalar) error { return d.Quantile(r, ConstFloat64(0.975)) }, 1.959963984540054)
+  }
+}
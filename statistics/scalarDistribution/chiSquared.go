@@ -19,9 +19,12 @@ package scalarDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
+import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/special"
 
 /* -------------------------------------------------------------------------- */
 
@@ -77,6 +80,11 @@ func (dist *ChiSquaredDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *ChiSquaredDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(2.0*randGamma(dist.L.GetFloat64(), r))
+  return nil
+}
+
 func (dist *ChiSquaredDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -94,11 +102,32 @@ func (dist *ChiSquaredDistribution) LogCdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *ChiSquaredDistribution) Cdf(r Scalar, x ConstScalar) error {
-  r.Div(x, dist.C)
-  r.GammaP(dist.L.GetFloat64(), r)
+  t := NullScalar(r.Type())
+  t.Div(x, dist.C)
+  setGammaP(r, dist.L, t)
+  return nil
+}
+
+func (dist *ChiSquaredDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  t := NullScalar(r.Type())
+  t.Div(x, dist.C)
+  setLogGammaQ(r, dist.L, t)
   return nil
 }
 
+func (dist *ChiSquaredDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  l := dist.L.GetFloat64()
+  c := dist.C.GetFloat64()
+  y := invertCdf(func(y float64) float64 { return special.GammaP(l, y) }, p.GetFloat64(), l, 0.0, math.Inf(1))
+  // density at q = c y
+  lg, _ := math.Lgamma(l)
+  f     := math.Exp((l - 1.0)*math.Log(y) - y - lg)/c
+  return setQuantile(r, p, c*y, f, dist.Cdf)
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *ChiSquaredDistribution) GetParameters() Vector {
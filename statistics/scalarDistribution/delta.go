@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -61,6 +62,11 @@ func (dist *DeltaDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *DeltaDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(dist.X.GetFloat64())
+  return nil
+}
+
 func (dist *DeltaDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -69,6 +75,40 @@ func (dist *DeltaDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *DeltaDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() >= dist.X.GetFloat64() {
+    r.SetFloat64(0.0)
+  } else {
+    r.SetFloat64(math.Inf(-1))
+  }
+  return nil
+}
+
+func (dist *DeltaDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *DeltaDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() >= dist.X.GetFloat64() {
+    r.SetFloat64(math.Inf(-1))
+  } else {
+    r.SetFloat64(0.0)
+  }
+  return nil
+}
+
+func (dist *DeltaDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  r.Set(dist.X)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *DeltaDistribution) GetParameters() Vector {
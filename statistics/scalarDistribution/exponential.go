@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -81,6 +82,11 @@ func (dist *ExponentialDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *ExponentialDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(r.ExpFloat64()/dist.Lambda.GetFloat64())
+  return nil
+}
+
 func (dist *ExponentialDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -97,9 +103,7 @@ func (dist *ExponentialDistribution) LogCdf(r Scalar, x ConstScalar) error {
 
   r.Mul(dist.Lambda, x)
   r.Neg(r)
-  r.Exp(r)
-  r.Neg(r)
-  r.Log1p(r)
+  setLog1mExp(r, r)
 
   return nil
 }
@@ -112,6 +116,28 @@ func (dist *ExponentialDistribution) Cdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *ExponentialDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() < 0 {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  r.Mul(dist.Lambda, x)
+  r.Neg(r)
+  return nil
+}
+
+func (dist *ExponentialDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  // q = -log(1-p)/lambda
+  r.Neg(p)
+  r.Log1p(r)
+  r.Div(r, dist.Lambda)
+  r.Neg(r)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist ExponentialDistribution) GetParameters() Vector {
@@ -20,9 +20,11 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/special"
 
 /* -------------------------------------------------------------------------- */
 
@@ -91,6 +93,11 @@ func (dist *GammaDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *GammaDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(randGamma(dist.Alpha.GetFloat64(), r)/dist.Beta.GetFloat64())
+  return nil
+}
+
 func (dist *GammaDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -108,11 +115,32 @@ func (dist *GammaDistribution) LogCdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *GammaDistribution) Cdf(r Scalar, x ConstScalar) error {
-  r.Mul(x, dist.Beta)
-  r.GammaP(dist.Alpha.GetFloat64(), r)
+  t := NullScalar(r.Type())
+  t.Mul(x, dist.Beta)
+  setGammaP(r, dist.Alpha, t)
+  return nil
+}
+
+func (dist *GammaDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  t := NullScalar(r.Type())
+  t.Mul(x, dist.Beta)
+  setLogGammaQ(r, dist.Alpha, t)
   return nil
 }
 
+func (dist *GammaDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  alpha := dist.Alpha.GetFloat64()
+  beta  := dist.Beta .GetFloat64()
+  y     := invertCdf(func(y float64) float64 { return special.GammaP(alpha, y) }, p.GetFloat64(), alpha, 0.0, math.Inf(1))
+  // density at q = y/beta
+  lg, _ := math.Lgamma(alpha)
+  f     := beta*math.Exp((alpha - 1.0)*math.Log(y) - y - lg)
+  return setQuantile(r, p, y/beta, f, dist.Cdf)
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *GammaDistribution) GetParameters() Vector {
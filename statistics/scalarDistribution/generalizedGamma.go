@@ -20,9 +20,11 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/special"
 
 /* -------------------------------------------------------------------------- */
 
@@ -90,6 +92,14 @@ func (dist *GeneralizedGammaDistribution) LogPdf(r Scalar, x ConstScalar) error
   return nil
 }
 
+func (dist *GeneralizedGammaDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // x = a g^(1/p) with g ~ Gamma(d/p)
+  p := dist.P.GetFloat64()
+  g := randGamma(dist.D.GetFloat64()/p, r)
+  x.SetFloat64(dist.A.GetFloat64()*math.Pow(g, 1.0/p))
+  return nil
+}
+
 func (dist *GeneralizedGammaDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -98,6 +108,54 @@ func (dist *GeneralizedGammaDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+// The cdf is given by P(d/p, (x/a)^p), where P denotes the regularized
+// lower incomplete gamma function
+func (dist *GeneralizedGammaDistribution) gammaArgs(r Scalar, x ConstScalar) (Scalar, Scalar) {
+  s := NullScalar(r.Type())
+  s.Div(dist.D, dist.P)
+  y := NullScalar(r.Type())
+  if x.GetFloat64() > 0.0 {
+    y.Div(x, dist.A)
+    y.Pow(y, dist.P)
+  }
+  return s, y
+}
+
+func (dist *GeneralizedGammaDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  if err := dist.Cdf(r, x); err != nil {
+    return err
+  }
+  r.Log(r)
+  return nil
+}
+
+func (dist *GeneralizedGammaDistribution) Cdf(r Scalar, x ConstScalar) error {
+  s, y := dist.gammaArgs(r, x)
+  setGammaP(r, s, y)
+  return nil
+}
+
+func (dist *GeneralizedGammaDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  s, y := dist.gammaArgs(r, x)
+  setLogGammaQ(r, s, y)
+  return nil
+}
+
+func (dist *GeneralizedGammaDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  a     := dist.A.GetFloat64()
+  d     := dist.D.GetFloat64()
+  c     := dist.P.GetFloat64()
+  s     := d/c
+  y     := invertCdf(func(y float64) float64 { return special.GammaP(s, y) }, p.GetFloat64(), s, 0.0, math.Inf(1))
+  q     := a*math.Pow(y, 1.0/c)
+  lg, _ := math.Lgamma(s)
+  f     := math.Exp(math.Log(c) - d*math.Log(a) - lg + (d - 1.0)*math.Log(q) - y)
+  return setQuantile(r, p, q, f, dist.Cdf)
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *GeneralizedGammaDistribution) GetParameters() Vector {
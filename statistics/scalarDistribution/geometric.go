@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -85,6 +86,15 @@ func (dist *GeometricDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *GeometricDistribution) Sample(x Scalar, r *rand.Rand) error {
+  if dist.p.GetFloat64() == 1.0 {
+    x.SetFloat64(0.0)
+  } else {
+    x.SetFloat64(math.Floor(math.Log(randUniformOpen(r))/dist.p2.GetFloat64()))
+  }
+  return nil
+}
+
 func (dist *GeometricDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -93,6 +103,48 @@ func (dist *GeometricDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *GeometricDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  k := math.Floor(x.GetFloat64())
+  if k < 0.0 {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  // F(k) = 1 - (1-p)^(k+1)
+  r.Mul(ConstFloat64(k+1.0), dist.p2)
+  setLog1mExp(r, r)
+  return nil
+}
+
+func (dist *GeometricDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *GeometricDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  k := math.Floor(x.GetFloat64())
+  if k < 0.0 {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  r.Mul(ConstFloat64(k+1.0), dist.p2)
+  return nil
+}
+
+func (dist *GeometricDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  p2  := dist.p2.GetFloat64()
+  cdf := func(k float64) float64 {
+    return -math.Expm1((k + 1.0)*p2)
+  }
+  r.SetFloat64(invertDiscreteCdf(cdf, p.GetFloat64(), 0.0, math.Inf(1)))
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist GeometricDistribution) GetParameters() Vector {
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -119,6 +120,20 @@ func (dist *GevDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *GevDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // inverse cdf method
+  mu    := dist.Mu   .GetFloat64()
+  sigma := dist.Sigma.GetFloat64()
+  xi    := dist.Xi   .GetFloat64()
+  y     := -math.Log(randUniformOpen(r))
+  if xi == 0.0 {
+    x.SetFloat64(mu - sigma*math.Log(y))
+  } else {
+    x.SetFloat64(mu + sigma*(math.Pow(y, -xi) - 1.0)/xi)
+  }
+  return nil
+}
+
 func (dist *GevDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -129,7 +144,12 @@ func (dist *GevDistribution) Pdf(r Scalar, x ConstScalar) error {
 
 func (dist *GevDistribution) LogCdf(r Scalar, x ConstScalar) error {
   if dist.Xi.GetFloat64()*(x.GetFloat64() - dist.Mu.GetFloat64())/dist.Sigma.GetFloat64() <= -1 {
-    r.SetFloat64(math.Inf(-1))
+    if dist.Xi.GetFloat64() < 0.0 {
+      // x is above the upper end point of the support
+      r.SetFloat64(0.0)
+    } else {
+      r.SetFloat64(math.Inf(-1))
+    }
     return nil
   }
   r.Set(x)
@@ -158,6 +178,40 @@ func (dist *GevDistribution) Cdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *GevDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  setLog1mExp(r, r)
+  return nil
+}
+
+func (dist *GevDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  // t = -log(p)
+  t := NullScalar(r.Type())
+  t.Log(p)
+  t.Neg(t)
+  if dist.Xi.GetFloat64() == 0.0 {
+    // t = -log(-log(p))
+    t.Log(t)
+    t.Neg(t)
+  } else {
+    // t = ((-log(p))^(-xi) - 1)/xi
+    t.Log(t)
+    t.Mul(t, dist.Xi)
+    t.Neg(t)
+    t.Exp(t)
+    t.Sub(t, ConstFloat64(1.0))
+    t.Div(t, dist.Xi)
+  }
+  r.Mul(t, dist.Sigma)
+  r.Add(r, dist.Mu)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *GevDistribution) GetParameters() Vector {
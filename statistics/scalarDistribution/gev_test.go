@@ -20,6 +20,7 @@ package scalarDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -94,3 +95,22 @@ func TestGevDistribution3(t *testing.T) {
     t.Error("Gev LogPdf failed!")
   }
 }
+
+func TestGevSample(t *testing.T) {
+  d, _ := NewGevDistribution(NewFloat64(1.0), NewFloat64(2.0), NewFloat64(0.2))
+
+  r := rand.New(rand.NewSource(1))
+  x := NullFloat64()
+  n := 100000
+  m := 0.0
+  for i := 0; i < n; i++ {
+    if err := d.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    m += x.GetFloat64()/float64(n)
+  }
+  // mean: mu + sigma (Gamma(1-xi) - 1)/xi
+  if math.Abs(m - (1.0 + 2.0*(math.Gamma(0.8) - 1.0)/0.2)) > 0.05 {
+    t.Error("test failed")
+  }
+}
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -99,7 +100,7 @@ func (dist *GParetoDistribution) LogPdf(r Scalar, x ConstScalar) error {
     }
   }
 
-  r.Sub(r, dist.Mu)
+  r.Sub(x, dist.Mu)
   r.Div(r, dist.Sigma)
 
   if dist.Xi.GetFloat64() == 0.0 {
@@ -108,12 +109,26 @@ func (dist *GParetoDistribution) LogPdf(r Scalar, x ConstScalar) error {
     r.Mul(r, dist.Xi)
     r.Log1p(r)
     r.Mul(r, dist.cx2) // cx2 = -1/xi - 1
-    r.Sub(r, dist.cs)  // cs  = log sigma
   }
+  r.Sub(r, dist.cs)    // cs  = log sigma
 
   return nil
 }
 
+func (dist *GParetoDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // inverse cdf method
+  mu    := dist.Mu   .GetFloat64()
+  sigma := dist.Sigma.GetFloat64()
+  xi    := dist.Xi   .GetFloat64()
+  u     := randUniformOpen(r)
+  if xi == 0.0 {
+    x.SetFloat64(mu - sigma*math.Log(u))
+  } else {
+    x.SetFloat64(mu + sigma*(math.Pow(u, -xi) - 1.0)/xi)
+  }
+  return nil
+}
+
 func (dist *GParetoDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -123,41 +138,63 @@ func (dist *GParetoDistribution) Pdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *GParetoDistribution) LogCdf(r Scalar, x ConstScalar) error {
-  if dist.Xi.GetFloat64() >= 0 {
-    // xi >= 0
-    if x.GetFloat64() < dist.Mu.GetFloat64() {
-      r.SetFloat64(math.Inf(-1))
-      return nil
-    }
-  } else {
-    // xi < 0
-    if x.GetFloat64() < dist.Mu.GetFloat64() || x.GetFloat64() > dist.Mu.GetFloat64() - dist.Sigma.GetFloat64()/dist.Xi.GetFloat64() {
-      r.SetFloat64(math.Inf(-1))
-      return nil
-    }
+  if err := dist.LogSurvival(r, x); err != nil {
+    return err
   }
-  r.Sub(r, dist.Mu)
+  setLog1mExp(r, r)
+  return nil
+}
+
+func (dist *GParetoDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *GParetoDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() < dist.Mu.GetFloat64() {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  if dist.Xi.GetFloat64() < 0 && x.GetFloat64() > dist.Mu.GetFloat64() - dist.Sigma.GetFloat64()/dist.Xi.GetFloat64() {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  r.Sub(x, dist.Mu)
   r.Div(r, dist.Sigma)
 
   if dist.Xi.GetFloat64() == 0.0 {
     r.Neg(r)
-    r.Exp(r)
   } else {
     r.Mul(r, dist.Xi)
-    r.Add(r, ConstFloat64(1.0))
-    r.Pow(r, dist.cx1)
+    r.Log1p(r)
+    r.Mul(r, dist.cx1) // cx1 = -1/xi
   }
-  r.Neg(r)
-  r.Log1p(r)
-
   return nil
 }
 
-func (dist *GParetoDistribution) Cdf(r Scalar, x ConstScalar) error {
-  if err := dist.LogCdf(r, x); err != nil {
+func (dist *GParetoDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
     return err
   }
-  r.Exp(r)
+  // t = log(1-p)
+  t := NullScalar(r.Type())
+  t.Neg(p)
+  t.Log1p(t)
+  if dist.Xi.GetFloat64() == 0.0 {
+    t.Neg(t)
+  } else {
+    // t = ((1-p)^(-xi) - 1)/xi
+    t.Mul(t, dist.Xi)
+    t.Neg(t)
+    t.Exp(t)
+    t.Sub(t, ConstFloat64(1.0))
+    t.Div(t, dist.Xi)
+  }
+  r.Mul(t, dist.Sigma)
+  r.Add(r, dist.Mu)
   return nil
 }
 
@@ -34,6 +34,7 @@ func init() {
   ScalarPdfRegistry["scalar:gamma distribution"]              = new(GammaDistribution)
   ScalarPdfRegistry["scalar:generalized gamma distribution"]  = new(GeneralizedGammaDistribution)
   ScalarPdfRegistry["scalar:geometric distribution"]          = new(GeometricDistribution)
+  ScalarPdfRegistry["scalar:hurdle distribution"]             = new(HurdleDistribution)
   ScalarPdfRegistry["scalar:gev distribution"]                = new(GevDistribution)
   ScalarPdfRegistry["scalar:mixture distribution"]            = new(Mixture)
   ScalarPdfRegistry["scalar:laplace distribution"]            = new(LaplaceDistribution)
@@ -45,4 +46,7 @@ func init() {
   ScalarPdfRegistry["scalar:power law distribution"]          = new(PowerLawDistribution)
   ScalarPdfRegistry["scalar:pdf log transform"]               = new(PdfLogTransform)
   ScalarPdfRegistry["scalar:pdf translation"]                 = new(PdfTranslation)
+  ScalarPdfRegistry["scalar:pdf truncation"]                  = new(PdfTruncation)
+  ScalarPdfRegistry["scalar:pdf censoring"]                   = new(PdfCensoring)
+  ScalarPdfRegistry["scalar:zero inflated distribution"]      = new(ZeroInflatedDistribution)
 }
@@ -19,6 +19,8 @@ package scalarDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
+import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -68,14 +70,23 @@ func (dist *LaplaceDistribution) ScalarType() ScalarType {
 
 func (dist *LaplaceDistribution) LogPdf(r Scalar, x ConstScalar) error {
 
+  // t = log(2 sigma)
+  t := NullScalar(r.Type())
+  t.Mul(dist.Sigma, dist.c2)
+  t.Log(t)
+
   r.Sub(x, dist.Mu)
   r.Abs(r)
   r.Div(r, dist.Sigma)
   r.Neg(r)
-  r.Exp(r)
-  r.Div(r, dist.Sigma)
-  r.Div(r, dist.c2)
+  r.Sub(r, t)
+
+  return nil
+}
 
+func (dist *LaplaceDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // difference of two exponential random variables
+  x.SetFloat64(dist.Mu.GetFloat64() + dist.Sigma.GetFloat64()*(r.ExpFloat64() - r.ExpFloat64()))
   return nil
 }
 
@@ -87,23 +98,29 @@ func (dist *LaplaceDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
-func (dist *LaplaceDistribution) LogCdf(r Scalar, x Vector) error {
-
-  r.Sub(x.At(0), dist.Mu)
-  r.Abs(r)
+// Set r to log F(s (x-mu)/sigma), where F is the standard Laplace cdf and
+// s is either 1 or -1
+func (dist *LaplaceDistribution) logF(r Scalar, x ConstScalar, s float64) error {
+  r.Sub(x, dist.Mu)
   r.Div(r, dist.Sigma)
-  r.Neg(r)
-  r.Exp(r)
-  r.Div(r, dist.c2)
-
-  if x.At(0).Greater(dist.Mu) {
+  r.Mul(r, ConstFloat64(s))
+  if r.GetFloat64() < 0.0 {
+    // log F(z) = z - log 2
+    r.Sub(r, ConstFloat64(math.Ln2))
+  } else {
+    // log F(z) = log(1 - exp(-z)/2)
     r.Neg(r)
-    r.Add(r, dist.c1)
+    r.Sub(r, ConstFloat64(math.Ln2))
+    setLog1mExp(r, r)
   }
   return nil
 }
 
-func (dist *LaplaceDistribution) Cdf(r Scalar, x Vector) error {
+func (dist *LaplaceDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  return dist.logF(r, x, 1.0)
+}
+
+func (dist *LaplaceDistribution) Cdf(r Scalar, x ConstScalar) error {
   if err := dist.LogCdf(r, x); err != nil {
     return err
   }
@@ -111,6 +128,31 @@ func (dist *LaplaceDistribution) Cdf(r Scalar, x Vector) error {
   return nil
 }
 
+func (dist *LaplaceDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  return dist.logF(r, x, -1.0)
+}
+
+func (dist *LaplaceDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  t := NullScalar(r.Type())
+  if p.GetFloat64() < 0.5 {
+    // q = mu + sigma log(2p)
+    t.Mul(p, dist.c2)
+    t.Log(t)
+  } else {
+    // q = mu - sigma log(2(1-p))
+    t.Sub(dist.c1, p)
+    t.Mul(t, dist.c2)
+    t.Log(t)
+    t.Neg(t)
+  }
+  r.Mul(t, dist.Sigma)
+  r.Add(r, dist.Mu)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *LaplaceDistribution) GetParameters() Vector {
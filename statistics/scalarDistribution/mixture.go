@@ -19,7 +19,9 @@ package scalarDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
+import   "math"
 import   "bytes"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff/statistics"
 import   "github.com/pbenner/autodiff/statistics/generic"
@@ -76,6 +78,96 @@ func (obj *Mixture) LogPdf(r Scalar, x ConstScalar) error {
   return obj.Mixture.LogPdf(r, MixtureDataRecord{obj.Edist, x})
 }
 
+func (obj *Mixture) Sample(x Scalar, r *rand.Rand) error {
+  k := obj.Mixture.SampleComponent(r)
+  if s, ok := obj.Edist[k].(ScalarSampler); !ok {
+    return fmt.Errorf("mixture component `%d' does not support sampling", k)
+  } else {
+    return s.Sample(x, r)
+  }
+}
+
+// Set r to log sum_j w_j exp(f_j(x)), where f_j is either the log cdf or
+// the log survival function of the jth component
+func (obj *Mixture) logSum(r Scalar, x ConstScalar, survival bool) error {
+  t1 := NullScalar(r.Type())
+  t2 := NullScalar(r.Type())
+  s  := NullScalar(r.Type())
+  s.SetFloat64(math.Inf(-1))
+  for j := 0; j < obj.NComponents(); j++ {
+    edist, ok := obj.Edist[j].(ScalarCdf)
+    if !ok {
+      return fmt.Errorf("mixture component `%d' does not provide a cdf", j)
+    }
+    if survival {
+      if err := edist.LogSurvival(t1, x); err != nil {
+        return err
+      }
+    } else {
+      if err := edist.LogCdf(t1, x); err != nil {
+        return err
+      }
+    }
+    t1.Add(t1, obj.LogWeights.At(j))
+    s .LogAdd(s, t1, t2)
+  }
+  r.Set(s)
+  return nil
+}
+
+func (obj *Mixture) LogCdf(r Scalar, x ConstScalar) error {
+  return obj.logSum(r, x, false)
+}
+
+func (obj *Mixture) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *Mixture) LogSurvival(r Scalar, x ConstScalar) error {
+  return obj.logSum(r, x, true)
+}
+
+func (obj *Mixture) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  // the quantile of the mixture lies between the smallest and largest
+  // quantile of its components
+  a := math.Inf( 1)
+  b := math.Inf(-1)
+  t := NewFloat64(0.0)
+  for j := 0; j < obj.NComponents(); j++ {
+    edist, ok := obj.Edist[j].(ScalarQuantile)
+    if !ok {
+      return fmt.Errorf("mixture component `%d' does not provide a quantile function", j)
+    }
+    if err := edist.Quantile(t, ConstFloat64(p.GetFloat64())); err != nil {
+      return err
+    }
+    a = math.Min(a, t.GetFloat64())
+    b = math.Max(b, t.GetFloat64())
+  }
+  var err error
+  cdf := func(x float64) float64 {
+    if e := obj.Cdf(t, ConstFloat64(x)); e != nil {
+      err = e
+    }
+    return t.GetFloat64()
+  }
+  q := invertCdf(cdf, p.GetFloat64(), a, a, b)
+  if err != nil {
+    return err
+  }
+  if err := obj.LogPdf(t, ConstFloat64(q)); err != nil {
+    return err
+  }
+  return setQuantile(r, p, q, math.Exp(t.GetFloat64()), obj.Cdf)
+}
+
 func (obj *Mixture) Likelihood(r Scalar, x ConstScalar, states []int) error {
   return obj.Mixture.Likelihood(r, MixtureDataRecord{obj.Edist, x}, states)
 }
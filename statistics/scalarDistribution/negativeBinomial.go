@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -112,6 +113,14 @@ func (dist *NegativeBinomialDistribution) LogPdf(r Scalar, x ConstScalar) error
   return nil
 }
 
+func (dist *NegativeBinomialDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // gamma-Poisson mixture: k ~ Poisson(lambda), lambda ~ Gamma(r, p/(1-p))
+  p      := dist.P.GetFloat64()
+  lambda := randGamma(dist.R.GetFloat64(), r)*p/(1.0 - p)
+  x.SetFloat64(randPoisson(lambda, r))
+  return nil
+}
+
 func (dist *NegativeBinomialDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -120,6 +129,56 @@ func (dist *NegativeBinomialDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *NegativeBinomialDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  k  := math.Floor(x.GetFloat64())
+  t1 := NullScalar(r.Type())
+  t2 := NullScalar(r.Type())
+  s  := NullScalar(r.Type())
+  s.SetFloat64(math.Inf(-1))
+  for i := 0.0; i <= k; i++ {
+    if err := dist.LogPdf(t1, ConstFloat64(i)); err != nil {
+      return err
+    }
+    s.LogAdd(s, t1, t2)
+  }
+  r.Set(s)
+  return nil
+}
+
+func (dist *NegativeBinomialDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *NegativeBinomialDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  k := math.Floor(x.GetFloat64())
+  if k < 0.0 {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  // S(k) = I_p(k+1, r)
+  setBetaI(r, ConstFloat64(k+1.0), dist.R, dist.P)
+  r.Log(r)
+  return nil
+}
+
+func (dist *NegativeBinomialDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  n := dist.R.GetFloat64()
+  q := dist.P.GetFloat64()
+  // F(k) = I_{1-p}(r, k+1)
+  cdf := func(k float64) float64 {
+    return betaI(n, k+1.0, 1.0-q)
+  }
+  r.SetFloat64(invertDiscreteCdf(cdf, p.GetFloat64(), 0.0, math.Inf(1)))
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *NegativeBinomialDistribution) GetParameters() Vector {
@@ -20,6 +20,7 @@ package scalarDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -38,3 +39,24 @@ func TestNegativeBinomial(t *testing.T) {
     t.Error("test failed")
   }
 }
+
+func TestNegativeBinomialSample(t *testing.T) {
+  d, _ := NewNegativeBinomialDistribution(NewFloat64(3), NewFloat64(0.3))
+
+  r  := rand.New(rand.NewSource(1))
+  x  := NullFloat64()
+  n  := 100000
+  m1 := 0.0
+  m2 := 0.0
+  for i := 0; i < n; i++ {
+    if err := d.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    m1 += x.GetFloat64()/float64(n)
+    m2 += x.GetFloat64()*x.GetFloat64()/float64(n)
+  }
+  // mean: r p/(1-p), variance: r p/(1-p)^2
+  if math.Abs(m1 - 3.0*0.3/0.7) > 0.02 || math.Abs(m2 - m1*m1 - 3.0*0.3/0.49) > 0.05 {
+    t.Error("test failed")
+  }
+}
@@ -20,10 +20,12 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff/statistics"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/special"
 
 /* -------------------------------------------------------------------------- */
 
@@ -87,48 +89,50 @@ func (obj *NormalDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
-func (dist *NormalDistribution) LogCdf(r Scalar, x ConstScalar) error {
-  t := dist.Sigma.CloneScalar()
-  t.Mul(t, ConstFloat64(math.Sqrt(2.0)))
-
-  r.Sub(x, dist.Mu)
-  r.Div(r, t)
-  r.Neg(r)
-  r.LogErfc(r)
-  r.Sub(r, ConstFloat64(math.Log(2.0)))
-
-  // if computation of derivatives failed, return an approximation
-  if r.GetOrder() >= 1 {
-    for i := 0; i < r.GetN(); i++ {
-      if math.IsNaN(r.GetDerivative(i)) && x.GetFloat64() < 0.0 {
-        panic("computation of derivative failed; try using MagicLogCdf()")
-      }
-    }
-  }
+func (obj *NormalDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(obj.Mu.GetFloat64() + obj.Sigma.GetFloat64()*r.NormFloat64())
   return nil
 }
 
-func (dist *NormalDistribution) MagicLogCdf(r MagicScalar, x ConstScalar) error {
+// Set r to log Phi(s (x-mu)/sigma), where Phi denotes the standard normal
+// cdf and s is either 1 or -1
+func (dist *NormalDistribution) logPhi(r Scalar, x ConstScalar, s float64) error {
   t := dist.Sigma.CloneScalar()
-  t.Mul(t, ConstFloat64(math.Sqrt(2.0)))
+  t.Mul(t, ConstFloat64(-s*math.Sqrt(2.0)))
 
   r.Sub(x, dist.Mu)
   r.Div(r, t)
-  r.Neg(r)
   r.LogErfc(r)
   r.Sub(r, ConstFloat64(math.Log(2.0)))
 
-  // if computation of derivatives failed, return an approximation
+  // derivatives of log erfc are unstable far in the tail, use the ratio of
+  // density and cdf instead
   if r.GetOrder() >= 1 {
     for i := 0; i < r.GetN(); i++ {
-      if math.IsNaN(r.GetDerivative(i)) && x.GetFloat64() < 0.0 {
-        r.SetDerivative(i, -x.GetFloat64()*x.GetDerivative(i))
+      if math.IsNaN(r.GetDerivative(i)) || math.IsInf(r.GetDerivative(i), 0) {
+        mu    := dist.Mu   .GetFloat64()
+        sigma := dist.Sigma.GetFloat64()
+        z     := (x.GetFloat64() - mu)/sigma
+        v     := special.LogErfc(-s*z/math.Sqrt2) - math.Ln2
+        // h = d/dz log Phi(s z)
+        h     := s*math.Exp(-0.5*z*z - 0.5*math.Log(2.0*math.Pi) - v)
+        setFirstOrder(r, v, []float64{h/sigma, -h/sigma, -h*z/sigma}, x, dist.Mu, dist.Sigma)
+        break
       }
     }
   }
   return nil
 }
 
+func (dist *NormalDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  return dist.logPhi(r, x, 1.0)
+}
+
+// Deprecated: LogCdf is numerically stable for all arguments
+func (dist *NormalDistribution) MagicLogCdf(r MagicScalar, x ConstScalar) error {
+  return dist.LogCdf(r, x)
+}
+
 func (dist *NormalDistribution) Cdf(r Scalar, x ConstScalar) error {
   if err := dist.LogCdf(r, x); err != nil {
     return err
@@ -137,6 +141,30 @@ func (dist *NormalDistribution) Cdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *NormalDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  return dist.logPhi(r, x, -1.0)
+}
+
+func (dist *NormalDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  z := -math.Sqrt2*math.Erfcinv(2.0*p.GetFloat64())
+  if math.IsInf(z, 0) {
+    r.SetFloat64(z)
+    return nil
+  }
+  // derivative with respect to p, i.e. sigma/phi(z)
+  dp := dist.Sigma.GetFloat64()*math.Exp(0.5*z*z + 0.5*math.Log(2.0*math.Pi))
+  t  := NullScalar(r.Type())
+  addDerivative(t, dp, p)
+  // q = mu + sigma z
+  r.Mul(dist.Sigma, ConstFloat64(z))
+  r.Add(r, dist.Mu)
+  r.Add(r, t)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (obj *NormalDistribution) GetParameters() Vector {
@@ -20,6 +20,7 @@ package scalarDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -42,3 +43,54 @@ func TestNormal1(t *testing.T) {
     t.Error("Normal LogCdf failed!")
   }
 }
+
+func TestNormal2(t *testing.T) {
+
+  // compare reverse-mode with forward-mode derivatives
+  mu1    := NewReal64(3.0)
+  sigma1 := NewReal64(math.Sqrt(2.0))
+  mu2    := NewReverseReal64(3.0)
+  sigma2 := NewReverseReal64(math.Sqrt(2.0))
+
+  Variables(1, mu1, sigma1)
+  Variables(1, mu2, sigma2)
+
+  normal1, _ := NewNormalDistribution(mu1, sigma1)
+  normal2, _ := NewNormalDistribution(mu2, sigma2)
+
+  x  := ConstFloat64(2.2)
+  y1 := NullReal64()
+  y2 := NullReverseReal64()
+
+  normal1.LogPdf(y1, x)
+  normal2.LogPdf(y2, x)
+
+  if math.Abs(y1.GetFloat64() - y2.GetFloat64()) > 1e-10 {
+    t.Error("test failed")
+  }
+  for i := 0; i < 2; i++ {
+    if math.Abs(y1.GetDerivative(i) - y2.GetDerivative(i)) > 1e-10 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestNormal3(t *testing.T) {
+  normal, _ := NewNormalDistribution(NewFloat64(3.0), NewFloat64(2.0))
+
+  r  := rand.New(rand.NewSource(1))
+  x  := NullFloat64()
+  n  := 100000
+  m1 := 0.0
+  m2 := 0.0
+  for i := 0; i < n; i++ {
+    if err := normal.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    m1 += x.GetFloat64()/float64(n)
+    m2 += x.GetFloat64()*x.GetFloat64()/float64(n)
+  }
+  if math.Abs(m1 - 3.0) > 0.05 || math.Abs(m2 - m1*m1 - 4.0) > 0.1 {
+    t.Error("test failed")
+  }
+}
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -83,7 +84,7 @@ func (dist *ParetoDistribution) ScalarType() ScalarType {
 }
 
 func (dist *ParetoDistribution) LogPdf(r Scalar, x ConstScalar) error {
-  if x.GetFloat64() < 0 {
+  if x.GetFloat64() < dist.Lambda.GetFloat64() {
     r.SetFloat64(math.Inf(-1))
     return nil
   }
@@ -96,6 +97,11 @@ func (dist *ParetoDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *ParetoDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(dist.Lambda.GetFloat64()*math.Pow(randUniformOpen(r), -1.0/dist.Kappa.GetFloat64()))
+  return nil
+}
+
 func (dist *ParetoDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -105,16 +111,14 @@ func (dist *ParetoDistribution) Pdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *ParetoDistribution) LogCdf(r Scalar, x ConstScalar) error {
-  if x.GetFloat64() < 0 {
+  if x.GetFloat64() < dist.Lambda.GetFloat64() {
     r.SetFloat64(math.Inf(-1))
     return nil
   }
-
-  r.Div(dist.Lambda, x)
-  r.Pow(r, dist.Kappa)
-  r.Neg(r)
-  r.Log1p(r)
-
+  if err := dist.LogSurvival(r, x); err != nil {
+    return err
+  }
+  setLog1mExp(r, r)
   return nil
 }
 
@@ -126,6 +130,32 @@ func (dist *ParetoDistribution) Cdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *ParetoDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() < dist.Lambda.GetFloat64() {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  // S(x) = (lambda/x)^kappa
+  r.Div(dist.Lambda, x)
+  r.Log(r)
+  r.Mul(r, dist.Kappa)
+  return nil
+}
+
+func (dist *ParetoDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  // q = lambda (1-p)^(-1/kappa)
+  r.Neg(p)
+  r.Log1p(r)
+  r.Div(r, dist.Kappa)
+  r.Neg(r)
+  r.Exp(r)
+  r.Mul(r, dist.Lambda)
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist ParetoDistribution) GetParameters() Vector {
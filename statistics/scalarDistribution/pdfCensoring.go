@@ -0,0 +1,146 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+// A possibly censored observation, which is known to lie in the closed
+// interval [Lower, Upper]. Exact observations have Lower == Upper. Left-censored
+// observations (e.g. below a detection limit L) are given by [-Inf, L],
+// right-censored observations (e.g. survival times beyond U) by [U, Inf].
+type CensoredObservation struct {
+  Lower float64
+  Upper float64
+}
+
+// Exact observation x
+func NewExactObservation(x float64) CensoredObservation {
+  return CensoredObservation{x, x}
+}
+
+func (obj CensoredObservation) IsCensored() bool {
+  return obj.Lower != obj.Upper
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Likelihood of censored observations. Exact observations contribute the
+// log density, censored observations with interval [a, b] contribute
+// log P(a <= X <= b). The censoring status is carried by each observation
+// (see CensoredObservation), the censored distribution must provide a
+// cdf. LogPdf treats all observations as exact.
+type PdfCensoring struct {
+  ScalarPdf
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewPdfCensoring(scalarPdf ScalarPdf) (*PdfCensoring, error) {
+  if _, ok := scalarPdf.(ScalarCdf); !ok {
+    return nil, fmt.Errorf("distribution does not provide a cdf")
+  }
+  r := PdfCensoring{}
+  r.ScalarPdf = scalarPdf
+  return &r, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfCensoring) Clone() *PdfCensoring {
+  r, _ := NewPdfCensoring(obj.ScalarPdf.CloneScalarPdf())
+  return r
+}
+
+func (obj *PdfCensoring) CloneScalarPdf() ScalarPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Log-likelihood of a possibly censored observation x
+func (obj *PdfCensoring) LogPdfCensored(r Scalar, x CensoredObservation) error {
+  switch {
+  case math.IsNaN(x.Lower) || math.IsNaN(x.Upper) || x.Lower > x.Upper:
+    return fmt.Errorf("invalid censoring interval [%v, %v]", x.Lower, x.Upper)
+  case x.IsCensored():
+    // P(a <= X <= b) = F(b) - F(a-), where the left limit F(a-) differs
+    // from F(a) if X has positive mass at a, e.g. for discrete distributions
+    return logProbability(r, obj.ScalarPdf.(ScalarCdf), math.Nextafter(x.Lower, math.Inf(-1)), x.Upper)
+  default:
+    return obj.ScalarPdf.LogPdf(r, ConstFloat64(x.Lower))
+  }
+}
+
+// Draw an observation and censor it if it falls into one of the given
+// intervals
+func (obj *PdfCensoring) SampleCensored(r *rand.Rand, intervals []CensoredObservation) (CensoredObservation, error) {
+  s, ok := obj.ScalarPdf.(ScalarSampler)
+  if !ok {
+    return CensoredObservation{}, fmt.Errorf("distribution does not support sampling")
+  }
+  x := NullFloat64()
+  if err := s.Sample(x, r); err != nil {
+    return CensoredObservation{}, err
+  }
+  for _, v := range intervals {
+    if v.Lower <= x.GetFloat64() && x.GetFloat64() <= v.Upper {
+      return v, nil
+    }
+  }
+  return NewExactObservation(x.GetFloat64()), nil
+}
+
+func (obj *PdfCensoring) Sample(x Scalar, r *rand.Rand) error {
+  if s, ok := obj.ScalarPdf.(ScalarSampler); !ok {
+    return fmt.Errorf("distribution does not support sampling")
+  } else {
+    return s.Sample(x, r)
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfCensoring) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  if len(config.Distributions) != 1 {
+    return fmt.Errorf("invalid config file")
+  }
+  if tmp, err := ImportScalarPdfConfig(config.Distributions[0], t); err != nil {
+    return err
+  } else {
+    if tmp, err := NewPdfCensoring(tmp); err != nil {
+      return err
+    } else {
+      *obj = *tmp
+    }
+  }
+  return nil
+}
+
+func (obj *PdfCensoring) ExportConfig() ConfigDistribution {
+
+  return NewConfigDistribution("scalar:pdf censoring", []float64{}, obj.ScalarPdf.ExportConfig())
+}
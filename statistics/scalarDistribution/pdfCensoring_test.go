@@ -0,0 +1,102 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestPdfCensoring1(t *testing.T) {
+
+  d1, _ := NewNormalDistribution(NewFloat64(0.0), NewFloat64(1.0))
+  d2, err := NewPdfCensoring(d1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  r := NewFloat64(0.0)
+
+  // uncensored observation
+  if err := d2.LogPdfCensored(r, NewExactObservation(0.0)); err != nil || math.Abs(r.GetFloat64() - -0.918939) > 1e-5 {
+    t.Error("test failed")
+  }
+  // left-censored observation
+  if err := d2.LogPdfCensored(r, CensoredObservation{math.Inf(-1), -1.0}); err != nil || math.Abs(r.GetFloat64() - -1.841022) > 1e-5 {
+    t.Error("test failed")
+  }
+  // interval-censored observation
+  if err := d2.LogPdfCensored(r, CensoredObservation{1.0, 2.0}); err != nil || math.Abs(r.GetFloat64() - math.Log(0.135905)) > 1e-5 {
+    t.Error("test failed")
+  }
+  // the same value is exact or censored depending on the observation
+  if err := d2.LogPdfCensored(r, NewExactObservation(1.5)); err != nil || math.Abs(r.GetFloat64() - -2.043939) > 1e-5 {
+    t.Error("test failed")
+  }
+  if err := d2.LogPdf(r, ConstFloat64(1.5)); err != nil || math.Abs(r.GetFloat64() - -2.043939) > 1e-5 {
+    t.Error("test failed")
+  }
+  // invalid interval
+  if err := d2.LogPdfCensored(r, CensoredObservation{2.0, 1.0}); err == nil {
+    t.Error("test failed")
+  }
+}
+
+func TestPdfCensoring2(t *testing.T) {
+
+  lambda := NewReal64(1.0)
+  lambda.SetVariable(0, 1, 1)
+
+  d1, _ := NewExponentialDistribution(lambda)
+  d2, _ := NewPdfCensoring(d1)
+  r := NewReal64(0.0)
+
+  // interval-censored observation
+  if err := d2.LogPdfCensored(r, CensoredObservation{1.0, 2.0}); err != nil || math.Abs(r.GetFloat64() - -1.458675) > 1e-5 {
+    t.Error("test failed")
+  }
+  // right-censored observation, log S(3) = -3 lambda
+  if err := d2.LogPdfCensored(r, CensoredObservation{3.0, math.Inf(1)}); err != nil || math.Abs(r.GetFloat64() - -3.0) > 1e-8 {
+    t.Error("test failed")
+  }
+  if math.Abs(r.GetDerivative(0) - -3.0) > 1e-6 {
+    t.Error("test failed")
+  }
+}
+
+func TestPdfCensoring3(t *testing.T) {
+
+  d1, _ := NewPoissonDistribution(NewFloat64(2.0))
+  d2, _ := NewPdfCensoring(d1)
+  r := NewFloat64(0.0)
+
+  // censoring intervals are closed, i.e. P(X = 0) is included
+  if err := d2.LogPdfCensored(r, CensoredObservation{0.0, 3.0}); err != nil || math.Abs(r.GetFloat64() - -0.1541733) > 1e-6 {
+    t.Error("test failed")
+  }
+  if err := d2.LogPdfCensored(r, CensoredObservation{1.0, 3.0}); err != nil || math.Abs(r.GetFloat64() - -0.3260236) > 1e-6 {
+    t.Error("test failed")
+  }
+  // right-censored observation, P(X >= 3)
+  if err := d2.LogPdfCensored(r, CensoredObservation{3.0, math.Inf(1)}); err != nil || math.Abs(r.GetFloat64() - -1.1291016) > 1e-6 {
+    t.Error("test failed")
+  }
+}
@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -72,6 +73,18 @@ func (obj *PdfLogTransform) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (obj *PdfLogTransform) Sample(x Scalar, r *rand.Rand) error {
+  if s, ok := obj.ScalarPdf.(ScalarSampler); !ok {
+    return fmt.Errorf("distribution does not support sampling")
+  } else {
+    if err := s.Sample(x, r); err != nil {
+      return err
+    }
+    x.SetFloat64(math.Exp(x.GetFloat64()) - obj.c)
+  }
+  return nil
+}
+
 func (obj *PdfLogTransform) Pdf(r Scalar, x ConstScalar) error {
   if err := obj.LogPdf(r, x); err != nil {
     return err
@@ -80,6 +93,57 @@ func (obj *PdfLogTransform) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (obj *PdfLogTransform) LogCdf(r Scalar, x ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  } else {
+    if v := x.GetFloat64() + obj.c; v <= 0.0 {
+      r.SetFloat64(math.Inf(-1))
+      return nil
+    }
+    y := obj.x
+    y.Add(x, ConstFloat64(obj.c))
+    y.Log(y)
+    return f.LogCdf(r, y)
+  }
+}
+
+func (obj *PdfLogTransform) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *PdfLogTransform) LogSurvival(r Scalar, x ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  } else {
+    if v := x.GetFloat64() + obj.c; v <= 0.0 {
+      r.SetFloat64(0.0)
+      return nil
+    }
+    y := obj.x
+    y.Add(x, ConstFloat64(obj.c))
+    y.Log(y)
+    return f.LogSurvival(r, y)
+  }
+}
+
+func (obj *PdfLogTransform) Quantile(r Scalar, p ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarQuantile); !ok {
+    return fmt.Errorf("distribution does not provide a quantile function")
+  } else {
+    if err := f.Quantile(r, p); err != nil {
+      return err
+    }
+    r.Exp(r)
+    r.Sub(r, ConstFloat64(obj.c))
+  }
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (obj *PdfLogTransform) ImportConfig(config ConfigDistribution, t ScalarType) error {
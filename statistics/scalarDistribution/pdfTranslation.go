@@ -19,6 +19,7 @@ package scalarDistribution
 /* -------------------------------------------------------------------------- */
 
 import   "fmt"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -65,6 +66,18 @@ func (obj *PdfTranslation) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (obj *PdfTranslation) Sample(x Scalar, r *rand.Rand) error {
+  if s, ok := obj.ScalarPdf.(ScalarSampler); !ok {
+    return fmt.Errorf("distribution does not support sampling")
+  } else {
+    if err := s.Sample(x, r); err != nil {
+      return err
+    }
+    x.SetFloat64(x.GetFloat64() - obj.c)
+  }
+  return nil
+}
+
 func (obj *PdfTranslation) Pdf(r Scalar, x ConstScalar) error {
   if err := obj.LogPdf(r, x); err != nil {
     return err
@@ -73,6 +86,46 @@ func (obj *PdfTranslation) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (obj *PdfTranslation) LogCdf(r Scalar, x ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  } else {
+    y := obj.x
+    y.Add(x, ConstFloat64(obj.c))
+    return f.LogCdf(r, y)
+  }
+}
+
+func (obj *PdfTranslation) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *PdfTranslation) LogSurvival(r Scalar, x ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  } else {
+    y := obj.x
+    y.Add(x, ConstFloat64(obj.c))
+    return f.LogSurvival(r, y)
+  }
+}
+
+func (obj *PdfTranslation) Quantile(r Scalar, p ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarQuantile); !ok {
+    return fmt.Errorf("distribution does not provide a quantile function")
+  } else {
+    if err := f.Quantile(r, p); err != nil {
+      return err
+    }
+    r.Sub(r, ConstFloat64(obj.c))
+  }
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (obj *PdfTranslation) ImportConfig(config ConfigDistribution, t ScalarType) error {
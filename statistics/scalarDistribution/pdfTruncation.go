@@ -0,0 +1,248 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+// Distribution truncated to the interval (lower, upper], i.e. the density
+// is renormalized by P(lower < X <= upper). The truncated distribution must
+// provide a cdf. Bounds may be infinite.
+type PdfTruncation struct {
+  ScalarPdf
+  lower float64
+  upper float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewPdfTruncation(scalarPdf ScalarPdf, lower, upper float64) (*PdfTruncation, error) {
+  if _, ok := scalarPdf.(ScalarCdf); !ok {
+    return nil, fmt.Errorf("distribution does not provide a cdf")
+  }
+  if !(lower < upper) {
+    return nil, fmt.Errorf("invalid truncation interval (%v, %v]", lower, upper)
+  }
+  r := PdfTruncation{}
+  r.ScalarPdf = scalarPdf
+  r.lower     = lower
+  r.upper     = upper
+  return &r, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfTruncation) Clone() *PdfTruncation {
+  r, _ := NewPdfTruncation(obj.ScalarPdf.CloneScalarPdf(), obj.lower, obj.upper)
+  return r
+}
+
+func (obj *PdfTruncation) CloneScalarPdf() ScalarPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfTruncation) GetBounds() (float64, float64) {
+  return obj.lower, obj.upper
+}
+
+// Set r to the logarithm of the normalization constant P(lower < X <= upper)
+func (obj *PdfTruncation) logNormalization(r Scalar) error {
+  if err := logProbability(r, obj.ScalarPdf.(ScalarCdf), obj.lower, obj.upper); err != nil {
+    return err
+  }
+  if math.IsInf(r.GetFloat64(), -1) {
+    return fmt.Errorf("truncation interval has zero probability")
+  }
+  return nil
+}
+
+func (obj *PdfTruncation) LogPdf(r Scalar, x ConstScalar) error {
+  if v := x.GetFloat64(); v <= obj.lower || v > obj.upper {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  t := NullScalar(r.Type())
+  if err := obj.logNormalization(t); err != nil {
+    return err
+  }
+  if err := obj.ScalarPdf.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Sub(r, t)
+  return nil
+}
+
+func (obj *PdfTruncation) Sample(x Scalar, r *rand.Rand) error {
+  // use the inverse cdf method if possible
+  if q, ok := obj.ScalarPdf.(ScalarQuantile); ok {
+    f  := obj.ScalarPdf.(ScalarCdf)
+    t  := NewFloat64(0.0)
+    if err := f.Cdf(t, ConstFloat64(obj.lower)); err != nil {
+      return err
+    }
+    p1 := t.GetFloat64()
+    if err := f.Cdf(t, ConstFloat64(obj.upper)); err != nil {
+      return err
+    }
+    p2 := t.GetFloat64()
+    if err := q.Quantile(x, ConstFloat64(p1 + r.Float64()*(p2 - p1))); err != nil {
+      return err
+    }
+    // correct rounding errors
+    x.SetFloat64(math.Min(math.Max(x.GetFloat64(), math.Nextafter(obj.lower, obj.upper)), obj.upper))
+    return nil
+  }
+  // rejection sampling otherwise
+  if s, ok := obj.ScalarPdf.(ScalarSampler); !ok {
+    return fmt.Errorf("distribution does not support sampling")
+  } else {
+    for i := 0; i < 1000000; i++ {
+      if err := s.Sample(x, r); err != nil {
+        return err
+      }
+      if v := x.GetFloat64(); v > obj.lower && v <= obj.upper {
+        return nil
+      }
+    }
+  }
+  return fmt.Errorf("rejection sampling failed")
+}
+
+func (obj *PdfTruncation) Pdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfTruncation) LogCdf(r Scalar, x ConstScalar) error {
+  v := x.GetFloat64()
+  if v <= obj.lower {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  if v >= obj.upper {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  t := NullScalar(r.Type())
+  if err := obj.logNormalization(t); err != nil {
+    return err
+  }
+  if err := logProbability(r, obj.ScalarPdf.(ScalarCdf), obj.lower, v); err != nil {
+    return err
+  }
+  r.Sub(r, t)
+  return nil
+}
+
+func (obj *PdfTruncation) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *PdfTruncation) LogSurvival(r Scalar, x ConstScalar) error {
+  v := x.GetFloat64()
+  if v <= obj.lower {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  if v >= obj.upper {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  t := NullScalar(r.Type())
+  if err := obj.logNormalization(t); err != nil {
+    return err
+  }
+  if err := logProbability(r, obj.ScalarPdf.(ScalarCdf), v, obj.upper); err != nil {
+    return err
+  }
+  r.Sub(r, t)
+  return nil
+}
+
+func (obj *PdfTruncation) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  if q, ok := obj.ScalarPdf.(ScalarQuantile); !ok {
+    return fmt.Errorf("distribution does not provide a quantile function")
+  } else {
+    f  := obj.ScalarPdf.(ScalarCdf)
+    t1 := NullScalar(r.Type())
+    t2 := NullScalar(r.Type())
+    if err := f.Cdf(t1, ConstFloat64(obj.lower)); err != nil {
+      return err
+    }
+    if err := f.Cdf(t2, ConstFloat64(obj.upper)); err != nil {
+      return err
+    }
+    // Q(F(lower) + p (F(upper) - F(lower)))
+    t2.Sub(t2, t1)
+    t2.Mul(t2, p)
+    t2.Add(t2, t1)
+    return q.Quantile(r, t2)
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *PdfTruncation) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  parameters, ok := config.GetParametersAsFloats(); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  if len(parameters) != 2 {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if len(config.Distributions) != 1 {
+    return fmt.Errorf("invalid config file")
+  }
+  if tmp, err := ImportScalarPdfConfig(config.Distributions[0], t); err != nil {
+    return err
+  } else {
+    if tmp, err := NewPdfTruncation(tmp, importBound(parameters[0]), importBound(parameters[1])); err != nil {
+      return err
+    } else {
+      *obj = *tmp
+    }
+  }
+  return nil
+}
+
+func (obj *PdfTruncation) ExportConfig() ConfigDistribution {
+
+  return NewConfigDistribution("scalar:pdf truncation", []float64{exportBound(obj.lower), exportBound(obj.upper)}, obj.ScalarPdf.ExportConfig())
+}
@@ -0,0 +1,108 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "os"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+func TestPdfTruncation1(t *testing.T) {
+
+  d1, _ := NewNormalDistribution(NewFloat64(0.0), NewFloat64(1.0))
+  d2, err := NewPdfTruncation(d1, -1.0, 2.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  r := NewFloat64(0.0)
+
+  if err := d2.LogPdf(r, ConstFloat64(0.5)); err != nil || math.Abs(r.GetFloat64() - -0.843772) > 1e-5 {
+    t.Error("test failed")
+  }
+  if err := d2.LogPdf(r, ConstFloat64(2.5)); err != nil || !math.IsInf(r.GetFloat64(), -1) {
+    t.Error("test failed")
+  }
+  if err := d2.Cdf(r, ConstFloat64(0.5)); err != nil || math.Abs(r.GetFloat64() - 0.650880) > 1e-5 {
+    t.Error("test failed")
+  }
+  if err := d2.Quantile(r, ConstFloat64(0.3)); err != nil || math.Abs(r.GetFloat64() - -0.242404) > 1e-5 {
+    t.Error("test failed")
+  }
+  // truncated density must integrate to one
+  s := 0.0
+  n := 10000
+  for i := 0; i < n; i++ {
+    d2.Pdf(r, ConstFloat64(-1.0 + 3.0*(float64(i)+0.5)/float64(n)))
+    s += 3.0*r.GetFloat64()/float64(n)
+  }
+  if math.Abs(s - 1.0) > 1e-6 {
+    t.Error("test failed")
+  }
+}
+
+func TestPdfTruncation2(t *testing.T) {
+  // truncation far in the upper tail
+  d1, _ := NewNormalDistribution(NewFloat64(0.0), NewFloat64(1.0))
+  d2, _ := NewPdfTruncation(d1, 8.0, 9.0)
+  r := NewFloat64(0.0)
+
+  if err := d2.LogPdf(r, ConstFloat64(8.5)); err != nil || math.Abs(r.GetFloat64() - -2.030320) > 1e-4 {
+    t.Error("test failed")
+  }
+  // samples must be within the truncation interval
+  g := rand.New(rand.NewSource(1))
+  for i := 0; i < 100; i++ {
+    if err := d2.Sample(r, g); err != nil {
+      t.Error(err); break
+    }
+    if r.GetFloat64() <= 8.0 || r.GetFloat64() > 9.0 {
+      t.Error("test failed"); break
+    }
+  }
+}
+
+func TestPdfTruncation3(t *testing.T) {
+  d1, _ := NewNormalDistribution(NewFloat64(0.0), NewFloat64(1.0))
+  d2, _ := NewPdfTruncation(d1, math.Inf(-1), 2.0)
+
+  filename := "pdfTruncation_test.json"
+
+  if err := ExportDistribution(filename, d2); err != nil {
+    t.Fatal(err)
+  }
+  defer os.Remove(filename)
+
+  if d3, err := ImportScalarPdf(filename, Float64Type); err != nil {
+    t.Error(err)
+  } else {
+    r1 := NewFloat64(0.0)
+    r2 := NewFloat64(0.0)
+    d2.LogPdf(r1, ConstFloat64(1.0))
+    d3.LogPdf(r2, ConstFloat64(1.0))
+    if math.Abs(r1.GetFloat64() - r2.GetFloat64()) > 1e-12 {
+      t.Error("test failed")
+    }
+  }
+}
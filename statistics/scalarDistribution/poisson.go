@@ -20,9 +20,11 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/special"
 
 /* -------------------------------------------------------------------------- */
 
@@ -91,6 +93,11 @@ func (dist *PoissonDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *PoissonDistribution) Sample(x Scalar, r *rand.Rand) error {
+  x.SetFloat64(randPoisson(dist.Lambda.GetFloat64(), r))
+  return nil
+}
+
 func (dist *PoissonDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -99,6 +106,55 @@ func (dist *PoissonDistribution) Pdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *PoissonDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  k  := math.Floor(x.GetFloat64())
+  t1 := NullScalar(r.Type())
+  t2 := NullScalar(r.Type())
+  s  := NullScalar(r.Type())
+  s.SetFloat64(math.Inf(-1))
+  for i := 0.0; i <= k; i++ {
+    if err := dist.LogPdf(t1, ConstFloat64(i)); err != nil {
+      return err
+    }
+    s.LogAdd(s, t1, t2)
+  }
+  r.Set(s)
+  return nil
+}
+
+func (dist *PoissonDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *PoissonDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  k := math.Floor(x.GetFloat64())
+  if k < 0.0 {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  // S(k) = P(k+1, lambda)
+  r.GammaP(k+1.0, dist.Lambda)
+  r.Log(r)
+  return nil
+}
+
+func (dist *PoissonDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  lambda := dist.Lambda.GetFloat64()
+  // F(k) = Q(k+1, lambda)
+  cdf := func(k float64) float64 {
+    return special.GammaQ(k+1.0, lambda)
+  }
+  r.SetFloat64(invertDiscreteCdf(cdf, p.GetFloat64(), 0.0, math.Inf(1)))
+  return nil
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (dist *PoissonDistribution) GetParameters() Vector {
@@ -0,0 +1,52 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestPoissonSample(t *testing.T) {
+  // test both sampling methods
+  for _, lambda := range []float64{2.5, 40.0} {
+    d, _ := NewPoissonDistribution(NewFloat64(lambda))
+
+    r  := rand.New(rand.NewSource(1))
+    x  := NullFloat64()
+    n  := 100000
+    m1 := 0.0
+    m2 := 0.0
+    for i := 0; i < n; i++ {
+      if err := d.Sample(x, r); err != nil {
+        t.Fatal(err)
+      }
+      m1 += x.GetFloat64()/float64(n)
+      m2 += x.GetFloat64()*x.GetFloat64()/float64(n)
+    }
+    if math.Abs(m1 - lambda) > 0.01*lambda || math.Abs(m2 - m1*m1 - lambda) > 0.03*lambda {
+      t.Error("test failed")
+    }
+  }
+}
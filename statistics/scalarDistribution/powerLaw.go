@@ -20,6 +20,7 @@ package scalarDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
@@ -93,6 +94,12 @@ func (dist *PowerLawDistribution) LogPdf(r Scalar, x ConstScalar) error {
   return nil
 }
 
+func (dist *PowerLawDistribution) Sample(x Scalar, r *rand.Rand) error {
+  // inverse cdf method
+  x.SetFloat64(dist.Xmin.GetFloat64()*math.Pow(randUniformOpen(r), -1.0/(dist.Alpha.GetFloat64() - 1.0)))
+  return nil
+}
+
 func (dist *PowerLawDistribution) Pdf(r Scalar, x ConstScalar) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -102,22 +109,47 @@ func (dist *PowerLawDistribution) Pdf(r Scalar, x ConstScalar) error {
 }
 
 func (dist *PowerLawDistribution) LogCdf(r Scalar, x ConstScalar) error {
-  if x.GetFloat64() <= 0 {
+  if x.GetFloat64() < dist.Xmin.GetFloat64() {
     r.SetFloat64(math.Inf(-1))
     return nil
   }
+  if err := dist.LogSurvival(r, x); err != nil {
+    return err
+  }
+  setLog1mExp(r, r)
+  return nil
+}
+
+func (dist *PowerLawDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := dist.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (dist *PowerLawDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() < dist.Xmin.GetFloat64() {
+    r.SetFloat64(0.0)
+    return nil
+  }
+  // S(x) = (x/x_min)^(1-alpha)
   r.Div(x, dist.Xmin)
   r.Log(r)
   r.Mul(r, dist.ca)
-
   return nil
 }
 
-func (dist *PowerLawDistribution) Cdf(r Scalar, x ConstScalar) error {
-  if err := dist.LogCdf(r, x); err != nil {
+func (dist *PowerLawDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
     return err
   }
+  // q = x_min (1-p)^(1/(1-alpha))
+  r.Neg(p)
+  r.Log1p(r)
+  r.Div(r, dist.ca)
   r.Exp(r)
+  r.Mul(r, dist.Xmin)
   return nil
 }
 
@@ -0,0 +1,99 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "math"
+import   "math/rand"
+
+/* random variate generation
+ * -------------------------------------------------------------------------- */
+
+// Draw a sample from a gamma distribution with shape alpha and unit scale
+// (Marsaglia and Tsang, 2000). For alpha < 1 the sample is boosted using
+// Gamma(alpha) = Gamma(alpha+1) U^(1/alpha).
+func randGamma(alpha float64, r *rand.Rand) float64 {
+  if alpha < 1.0 {
+    u := r.Float64()
+    return randGamma(alpha+1.0, r)*math.Pow(u, 1.0/alpha)
+  }
+  d := alpha - 1.0/3.0
+  c := 1.0/math.Sqrt(9.0*d)
+  for {
+    x := r.NormFloat64()
+    v := 1.0 + c*x
+    if v <= 0.0 {
+      continue
+    }
+    v = v*v*v
+    u := r.Float64()
+    if u < 1.0 - 0.0331*x*x*x*x {
+      return d*v
+    }
+    if math.Log(u) < 0.5*x*x + d*(1.0 - v + math.Log(v)) {
+      return d*v
+    }
+  }
+}
+
+// Draw a sample from a Poisson distribution. For small lambda the
+// multiplication method is used, otherwise the transformed rejection
+// method with squeeze (PTRS, Hoermann 1993).
+func randPoisson(lambda float64, r *rand.Rand) float64 {
+  if lambda < 10.0 {
+    l := math.Exp(-lambda)
+    p := r.Float64()
+    k := 0.0
+    for p > l {
+      p *= r.Float64()
+      k += 1.0
+    }
+    return k
+  }
+  slam     := math.Sqrt(lambda)
+  loglam   := math.Log(lambda)
+  b        := 0.931 + 2.53*slam
+  a        := -0.059 + 0.02483*b
+  invalpha := 1.1239 + 1.1328/(b - 3.4)
+  vr       := 0.9277 - 3.6224/(b - 2.0)
+  for {
+    u  := r.Float64() - 0.5
+    v  := r.Float64()
+    us := 0.5 - math.Abs(u)
+    k  := math.Floor((2.0*a/us + b)*u + lambda + 0.43)
+    if us >= 0.07 && v <= vr {
+      return k
+    }
+    if k < 0.0 || (us < 0.013 && v > us) {
+      continue
+    }
+    lg, _ := math.Lgamma(k+1.0)
+    if math.Log(v) + math.Log(invalpha) - math.Log(a/(us*us) + b) <= -lambda + k*loglam - lg {
+      return k
+    }
+  }
+}
+
+// Draw a uniform sample from the open interval (0,1).
+func randUniformOpen(r *rand.Rand) float64 {
+  for {
+    if u := r.Float64(); u > 0.0 {
+      return u
+    }
+  }
+}
@@ -0,0 +1,237 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+// Zero-inflated count distribution, i.e. a mixture of a point mass at zero
+// with weight Pi and a count distribution f with weight 1-Pi:
+//   P(X = 0) = Pi + (1-Pi) f(0)
+//   P(X = k) = (1-Pi) f(k), k > 0
+type ZeroInflatedDistribution struct {
+  ScalarPdf
+  Pi Scalar
+  t1 Scalar
+  t2 Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewZeroInflatedDistribution(pi Scalar, scalarPdf ScalarPdf) (*ZeroInflatedDistribution, error) {
+  if pi.GetFloat64() < 0.0 || pi.GetFloat64() > 1.0 {
+    return nil, fmt.Errorf("invalid parameters")
+  }
+  t := scalarPdf.ScalarType()
+  r := ZeroInflatedDistribution{}
+  r.ScalarPdf = scalarPdf
+  r.Pi        = NewScalar(t, 0.0)
+  r.Pi.Set(pi)
+  r.t1        = NewScalar(t, 0.0)
+  r.t2        = NewScalar(t, 0.0)
+  return &r, nil
+}
+
+func NewZeroInflatedPoissonDistribution(pi, lambda Scalar) (*ZeroInflatedDistribution, error) {
+  if f, err := NewPoissonDistribution(lambda); err != nil {
+    return nil, err
+  } else {
+    return NewZeroInflatedDistribution(pi, f)
+  }
+}
+
+func NewZeroInflatedNegativeBinomialDistribution(pi, r, p Scalar) (*ZeroInflatedDistribution, error) {
+  if f, err := NewNegativeBinomialDistribution(r, p); err != nil {
+    return nil, err
+  } else {
+    return NewZeroInflatedDistribution(pi, f)
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedDistribution) Clone() *ZeroInflatedDistribution {
+  r, _ := NewZeroInflatedDistribution(obj.Pi, obj.ScalarPdf.CloneScalarPdf())
+  return r
+}
+
+func (obj *ZeroInflatedDistribution) CloneScalarPdf() ScalarPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedDistribution) LogPdf(r Scalar, x ConstScalar) error {
+  t1 := obj.t1
+  t2 := obj.t2
+  if err := obj.ScalarPdf.LogPdf(t1, x); err != nil {
+    return err
+  }
+  t2.Sub(ConstFloat64(1.0), obj.Pi)
+  if x.GetFloat64() == 0.0 {
+    // log(Pi + (1-Pi) f(0))
+    t1.Exp(t1)
+    t1.Mul(t1, t2)
+    t1.Add(t1, obj.Pi)
+    r.Log(t1)
+  } else {
+    // log(1-Pi) + log f(x)
+    t2.Log(t2)
+    r.Add(t1, t2)
+  }
+  return nil
+}
+
+func (obj *ZeroInflatedDistribution) Sample(x Scalar, r *rand.Rand) error {
+  if s, ok := obj.ScalarPdf.(ScalarSampler); !ok {
+    return fmt.Errorf("distribution does not support sampling")
+  } else {
+    if r.Float64() < obj.Pi.GetFloat64() {
+      x.SetFloat64(0.0)
+      return nil
+    }
+    return s.Sample(x, r)
+  }
+}
+
+func (obj *ZeroInflatedDistribution) Pdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedDistribution) LogCdf(r Scalar, x ConstScalar) error {
+  if x.GetFloat64() < 0.0 {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  // F(x) >= Pi, hence there is no cancellation
+  if err := obj.LogSurvival(r, x); err != nil {
+    return err
+  }
+  setLog1mExp(r, r)
+  return nil
+}
+
+func (obj *ZeroInflatedDistribution) Cdf(r Scalar, x ConstScalar) error {
+  if err := obj.LogCdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+func (obj *ZeroInflatedDistribution) LogSurvival(r Scalar, x ConstScalar) error {
+  if f, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  } else {
+    if x.GetFloat64() < 0.0 {
+      r.SetFloat64(0.0)
+      return nil
+    }
+    // log(1-Pi) + log S(x)
+    t := NullScalar(r.Type())
+    t.Sub(ConstFloat64(1.0), obj.Pi)
+    t.Log(t)
+    if err := f.LogSurvival(r, x); err != nil {
+      return err
+    }
+    r.Add(r, t)
+  }
+  return nil
+}
+
+func (obj *ZeroInflatedDistribution) Quantile(r Scalar, p ConstScalar) error {
+  if err := checkProbability(p); err != nil {
+    return err
+  }
+  if _, ok := obj.ScalarPdf.(ScalarCdf); !ok {
+    return fmt.Errorf("distribution does not provide a cdf")
+  }
+  t := NewFloat64(0.0)
+  cdf := func(k float64) float64 {
+    obj.Cdf(t, ConstFloat64(k))
+    return t.GetFloat64()
+  }
+  r.SetFloat64(invertDiscreteCdf(cdf, p.GetFloat64(), 0.0, math.Inf(1)))
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedDistribution) GetParameters() Vector {
+  p := NullDenseVector(obj.ScalarType(), 1)
+  p.At(0).Set(obj.Pi)
+  return p.AppendVector(obj.ScalarPdf.GetParameters())
+}
+
+func (obj *ZeroInflatedDistribution) SetParameters(parameters Vector) error {
+  if parameters.Dim() < 1 {
+    return fmt.Errorf("invalid set of parameters")
+  }
+  if pi := parameters.At(0).GetFloat64(); pi < 0.0 || pi > 1.0 {
+    return fmt.Errorf("invalid parameters")
+  }
+  if err := obj.ScalarPdf.SetParameters(parameters.Slice(1, parameters.Dim())); err != nil {
+    return err
+  }
+  obj.Pi.Set(parameters.At(0))
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  parameters, ok := config.GetParametersAsFloats(); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  if len(parameters) != 1 {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if len(config.Distributions) != 1 {
+    return fmt.Errorf("invalid config file")
+  }
+  if tmp, err := ImportScalarPdfConfig(config.Distributions[0], t); err != nil {
+    return err
+  } else {
+    if tmp, err := NewZeroInflatedDistribution(NewScalar(t, parameters[0]), tmp); err != nil {
+      return err
+    } else {
+      *obj = *tmp
+    }
+  }
+  return nil
+}
+
+func (obj *ZeroInflatedDistribution) ExportConfig() ConfigDistribution {
+
+  return NewConfigDistribution("scalar:zero inflated distribution", []float64{obj.Pi.GetFloat64()}, obj.ScalarPdf.ExportConfig())
+}
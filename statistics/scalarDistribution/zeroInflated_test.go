@@ -0,0 +1,113 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "os"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+func TestZeroInflated1(t *testing.T) {
+
+  d, err := NewZeroInflatedPoissonDistribution(NewFloat64(0.3), NewFloat64(2.0))
+  if err != nil {
+    t.Fatal(err)
+  }
+  r := NewFloat64(0.0)
+
+  if err := d.LogPdf(r, ConstFloat64(0.0)); err != nil || math.Abs(r.GetFloat64() - -0.9295413897) > 1e-8 {
+    t.Error("test failed")
+  }
+  if err := d.LogPdf(r, ConstFloat64(3.0)); err != nil || math.Abs(r.GetFloat64() - -2.0689928715) > 1e-8 {
+    t.Error("test failed")
+  }
+  if err := d.Cdf(r, ConstFloat64(2.0)); err != nil || math.Abs(r.GetFloat64() - 0.7736734913) > 1e-8 {
+    t.Error("test failed")
+  }
+  if err := d.Quantile(r, ConstFloat64(0.7)); err != nil || r.GetFloat64() != 2.0 {
+    t.Error("test failed")
+  }
+}
+
+func TestZeroInflated2(t *testing.T) {
+
+  d, _ := NewZeroInflatedNegativeBinomialDistribution(NewFloat64(0.2), NewFloat64(3.0), NewFloat64(0.4))
+  r := NewFloat64(0.0)
+
+  if err := d.LogPdf(r, ConstFloat64(0.0)); err != nil || math.Abs(r.GetFloat64() - -0.9867131962) > 1e-8 {
+    t.Error("test failed")
+  }
+  // probabilities must sum to one
+  s := 0.0
+  for k := 0; k < 200; k++ {
+    d.Pdf(r, ConstFloat64(float64(k)))
+    s += r.GetFloat64()
+  }
+  if math.Abs(s - 1.0) > 1e-10 {
+    t.Error("test failed")
+  }
+  // fraction of zeros in a sample
+  d.Pdf(r, ConstFloat64(0.0))
+  p0 := r.GetFloat64()
+  g  := rand.New(rand.NewSource(1))
+  n  := 100000
+  m  := 0
+  for i := 0; i < n; i++ {
+    if err := d.Sample(r, g); err != nil {
+      t.Fatal(err)
+    }
+    if r.GetFloat64() == 0.0 {
+      m++
+    }
+  }
+  if math.Abs(float64(m)/float64(n) - p0) > 0.01 {
+    t.Error("test failed")
+  }
+}
+
+func TestZeroInflated3(t *testing.T) {
+  d1, _ := NewZeroInflatedNegativeBinomialDistribution(NewFloat64(0.2), NewFloat64(3.0), NewFloat64(0.4))
+
+  filename := "zeroInflated_test.json"
+
+  if err := ExportDistribution(filename, d1); err != nil {
+    t.Fatal(err)
+  }
+  defer os.Remove(filename)
+
+  if d2, err := ImportScalarPdf(filename, Float64Type); err != nil {
+    t.Error(err)
+  } else {
+    r1 := NewFloat64(0.0)
+    r2 := NewFloat64(0.0)
+    for _, x := range []float64{0.0, 4.0} {
+      d1.LogPdf(r1, ConstFloat64(x))
+      d2.LogPdf(r2, ConstFloat64(x))
+      if math.Abs(r1.GetFloat64() - r2.GetFloat64()) > 1e-12 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
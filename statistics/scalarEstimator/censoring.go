@@ -0,0 +1,119 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// Estimate the parameters of a distribution from censored observations
+// by numerically maximizing the likelihood. Each observation carries its
+// own censoring interval (see scalarDistribution.CensoredObservation).
+// Data set with SetData are treated as exact observations. The estimate is
+// a censored distribution.
+type CensoringEstimator struct {
+  *NumericEstimator
+  observations []scalarDistribution.CensoredObservation
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewCensoringEstimator(f ScalarPdf) (*CensoringEstimator, error) {
+  if g, err := scalarDistribution.NewPdfCensoring(f); err != nil {
+    return nil, err
+  } else {
+    if estimator, err := NewNumericEstimator(g); err != nil {
+      return nil, err
+    } else {
+      return &CensoringEstimator{NumericEstimator: estimator}, nil
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *CensoringEstimator) Clone() *CensoringEstimator {
+  return &CensoringEstimator{obj.NumericEstimator.Clone(), obj.observations}
+}
+
+func (obj *CensoringEstimator) CloneScalarEstimator() ScalarEstimator {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *CensoringEstimator) SetData(x ConstVector, n int) error {
+  if err := obj.NumericEstimator.SetData(x, n); err != nil {
+    return err
+  }
+  obj.observations = make([]scalarDistribution.CensoredObservation, x.Dim())
+  for i := range obj.observations {
+    obj.observations[i] = scalarDistribution.NewExactObservation(x.Float64At(i))
+  }
+  return nil
+}
+
+// Set possibly censored observations, where n is the total number of
+// observations used for normalization.
+func (obj *CensoringEstimator) SetCensoredData(x []scalarDistribution.CensoredObservation, n int) error {
+  for _, v := range x {
+    if !(v.Lower <= v.Upper) {
+      return fmt.Errorf("invalid censoring interval [%v, %v]", v.Lower, v.Upper)
+    }
+  }
+  // report lower bounds as data
+  t := NullDenseFloat64Vector(len(x))
+  for i, v := range x {
+    t[i] = v.Lower
+  }
+  if err := obj.NumericEstimator.SetData(t, n); err != nil {
+    return err
+  }
+  obj.observations = x
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *CensoringEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  x := obj.observations
+  logPdf := func(f ScalarPdf, r Scalar, k int) error {
+    return f.(*scalarDistribution.PdfCensoring).LogPdfCensored(r, x[k])
+  }
+  return obj.estimate(logPdf, len(x), obj.n, gamma, p)
+}
+
+func (obj *CensoringEstimator) EstimateOnData(x, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, x.Dim()); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *CensoringEstimator) EstimateOnCensoredData(x []scalarDistribution.CensoredObservation, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetCensoredData(x, len(x)); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
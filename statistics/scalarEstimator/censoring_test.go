@@ -0,0 +1,107 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestCensoringEstimator1(test *testing.T) {
+  // draw samples from a normal distribution with detection limit 0 and
+  // right-censoring at 3
+  intervals := []scalarDistribution.CensoredObservation{{math.Inf(-1), 0.0}, {3.0, math.Inf(1)}}
+
+  d1, _ := scalarDistribution.NewNormalDistribution(NewFloat64(1.0), NewFloat64(2.0))
+  d2, _ := scalarDistribution.NewPdfCensoring(d1)
+
+  g := rand.New(rand.NewSource(1))
+  x := make([]scalarDistribution.CensoredObservation, 2000)
+  for i := range x {
+    x[i], _ = d2.SampleCensored(g, intervals)
+  }
+  // estimate parameters starting from a different distribution
+  d3, _ := scalarDistribution.NewNormalDistribution(NewFloat64(2.0), NewFloat64(1.0))
+
+  estimator, err := NewCensoringEstimator(d3)
+  if err != nil {
+    test.Fatal(err)
+  }
+  estimator.MaxIterations = 100
+  if err := estimator.EstimateOnCensoredData(x, nil, threadpool.New(2, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  p := r.GetParameters()
+
+  if math.Abs(p.At(0).GetFloat64() - 1.0) > 0.1 || math.Abs(p.At(1).GetFloat64() - 2.0) > 0.1 {
+    test.Error("test failed")
+  }
+}
+
+func TestCensoringEstimator2(test *testing.T) {
+  // survival times with individual follow-up times, observations beyond
+  // the follow-up time of a subject are right-censored
+  d1, _ := scalarDistribution.NewExponentialDistribution(NewFloat64(0.5))
+
+  g := rand.New(rand.NewSource(1))
+  t := NewFloat64(0.0)
+  x := make([]scalarDistribution.CensoredObservation, 2000)
+  for i := range x {
+    d1.Sample(t, g)
+    // follow-up time
+    if c := 4.0*g.Float64(); t.GetFloat64() > c {
+      x[i] = scalarDistribution.CensoredObservation{c, math.Inf(1)}
+    } else {
+      x[i] = scalarDistribution.NewExactObservation(t.GetFloat64())
+    }
+  }
+  d2, _ := scalarDistribution.NewExponentialDistribution(NewFloat64(1.0))
+
+  estimator, err := NewCensoringEstimator(d2)
+  if err != nil {
+    test.Fatal(err)
+  }
+  estimator.MaxIterations = 100
+  if err := estimator.EstimateOnCensoredData(x, nil, threadpool.New(2, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+
+  // maximum likelihood estimate: number of events divided by the total
+  // time at risk
+  k := 0.0
+  s := 0.0
+  for _, v := range x {
+    if !v.IsCensored() {
+      k += 1.0
+    }
+    s += v.Lower
+  }
+  if math.Abs(r.GetParameters().At(0).GetFloat64() - k/s) > 1e-4 {
+    test.Error("test failed")
+  }
+}
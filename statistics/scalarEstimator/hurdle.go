@@ -0,0 +1,155 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// Estimator of hurdle count distributions. Pi is given by the weighted
+// fraction of zeros. The zero-truncated count distribution is estimated
+// from all positive observations with EM, where the missing zeros of the
+// untruncated distribution are passed to the batch estimator with their
+// expected weight.
+type HurdleEstimator struct {
+  *scalarDistribution.HurdleDistribution
+  StdEstimator
+  estimator     ScalarBatchEstimator
+  Epsilon       float64
+  MaxIterations int
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewHurdleEstimator(pi float64, estimator ScalarBatchEstimator) (*HurdleEstimator, error) {
+  f, err := estimator.GetEstimate()
+  if err != nil {
+    return nil, err
+  }
+  if dist, err := scalarDistribution.NewHurdleDistribution(NewScalar(f.ScalarType(), pi), f.CloneScalarPdf()); err != nil {
+    return nil, err
+  } else {
+    r := HurdleEstimator{}
+    r.HurdleDistribution = dist
+    r.estimator          = estimator
+    r.Epsilon            = 1e-8
+    r.MaxIterations      = 1000
+    return &r, nil
+  }
+}
+
+func NewHurdlePoissonEstimator(pi, lambda float64) (*HurdleEstimator, error) {
+  if estimator, err := NewPoissonEstimator(lambda); err != nil {
+    return nil, err
+  } else {
+    return NewHurdleEstimator(pi, estimator)
+  }
+}
+
+func NewHurdleNegativeBinomialEstimator(pi, r, p float64) (*HurdleEstimator, error) {
+  if estimator, err := NewNegativeBinomialEstimator(r, p); err != nil {
+    return nil, err
+  } else {
+    return NewHurdleEstimator(pi, estimator)
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *HurdleEstimator) Clone() *HurdleEstimator {
+  r := HurdleEstimator{}
+  r.HurdleDistribution = obj.HurdleDistribution.Clone()
+  r.estimator          = obj.estimator.CloneScalarBatchEstimator()
+  r.Epsilon            = obj.Epsilon
+  r.MaxIterations      = obj.MaxIterations
+  r.x                  = obj.x
+  r.n                  = obj.n
+  return &r
+}
+
+func (obj *HurdleEstimator) CloneScalarEstimator() ScalarEstimator {
+  return obj.Clone()
+}
+
+/* estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *HurdleEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  // total weight and weight of zeros
+  w, w0 := sumCountWeights(obj.x, gamma)
+  if math.IsInf(w, -1) {
+    return fmt.Errorf("hurdle parameter estimation failed: no observations")
+  }
+  obj.Pi.SetFloat64(math.Exp(w0 - w))
+  // log weight of positive observations
+  w1 := w + math.Log1p(-math.Exp(w0 - w))
+  if math.IsInf(w1, -1) {
+    // the count distribution is not identifiable
+    return nil
+  }
+  // start at the current parameters
+  if err := obj.estimator.SetParameters(obj.ScalarPdf.GetParameters()); err != nil {
+    return err
+  }
+  t := NewFloat64(0.0)
+  for k := 0; k < obj.MaxIterations; k++ {
+    // E-step: expected weight of zeros removed by the truncation
+    if err := obj.ScalarPdf.LogPdf(t, ConstFloat64(0.0)); err != nil {
+      return err
+    }
+    z := w1 + t.GetFloat64() - math.Log1p(-math.Exp(t.GetFloat64()))
+    // M-step
+    f, err := estimateCounts(obj.estimator, obj.x, gamma, z, p)
+    if err != nil {
+      return err
+    }
+    q1 := obj.ScalarPdf.GetParameters()
+    q2 := f.GetParameters()
+    if err := obj.ScalarPdf.SetParameters(q2); err != nil {
+      return err
+    }
+    // check convergence
+    delta := 0.0
+    for i := 0; i < q1.Dim(); i++ {
+      delta = math.Max(delta, math.Abs(q1.ConstAt(i).GetFloat64() - q2.ConstAt(i).GetFloat64()))
+    }
+    if delta < obj.Epsilon {
+      break
+    }
+  }
+  return nil
+}
+
+func (obj *HurdleEstimator) EstimateOnData(x, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, x.Dim()); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *HurdleEstimator) GetEstimate() (ScalarPdf, error) {
+  return obj.HurdleDistribution, nil
+}
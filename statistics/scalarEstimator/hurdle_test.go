@@ -0,0 +1,55 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestHurdleEstimator1(test *testing.T) {
+  // draw samples from a hurdle Poisson distribution
+  d, _ := scalarDistribution.NewHurdlePoissonDistribution(NewFloat64(0.4), NewFloat64(2.0))
+
+  g := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(10000)
+  for i := 0; i < x.Dim(); i++ {
+    d.Sample(x.At(i), g)
+  }
+  estimator, err := NewHurdlePoissonEstimator(0.5, 5.0)
+  if err != nil {
+    test.Fatal(err)
+  }
+  if err := estimator.EstimateOnData(x, nil, threadpool.New(2, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  p := r.GetParameters()
+
+  if math.Abs(p.At(0).GetFloat64() - 0.4) > 0.02 || math.Abs(p.At(1).GetFloat64() - 2.0) > 0.1 {
+    test.Error("test failed")
+  }
+}
@@ -86,10 +86,17 @@ func (obj *NumericEstimator) SetParameters(parameters Vector) error {
 /* -------------------------------------------------------------------------- */
 
 func (obj *NumericEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  x := obj.x
+  logPdf := func(f ScalarPdf, r Scalar, k int) error {
+    return f.LogPdf(r, x.ConstAt(k))
+  }
+  return obj.estimate(logPdf, x.Dim(), obj.n, gamma, p)
+}
+
+// Maximize the likelihood of m observations, where logPdf evaluates the
+// log-likelihood of observation k. The likelihood is normalized by n.
+func (obj *NumericEstimator) estimate(logPdf func(f ScalarPdf, r Scalar, k int) error, m, n int, gamma ConstVector, p ThreadPool) error {
   nt := p.NumberOfThreads()
-  x  := obj.x
-  n  := obj.n
-  m  := obj.x.Dim()
   // create a copy of the density function
   f := make([]ScalarPdf, nt)
   for i := 0; i < len(f); i++ {
@@ -125,7 +132,7 @@ func (obj *NumericEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
       }
       if gamma != nil {
         if !math.IsInf(gamma.ConstAt(k).GetFloat64(), -1) {
-          if err := f.LogPdf(t, x.ConstAt(k)); err != nil {
+          if err := logPdf(f, t, k); err != nil {
             return err
           }
           s.Exp(gamma.ConstAt(k))
@@ -133,7 +140,7 @@ func (obj *NumericEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
           r.Add(r, t)
         }
       } else {
-        if err := f.LogPdf(t, x.ConstAt(k)); err != nil {
+        if err := logPdf(f, t, k); err != nil {
           return err
         }
         r.Add(r, t)
@@ -0,0 +1,57 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+/* -------------------------------------------------------------------------- */
+
+// Estimate the parameters of a distribution truncated to the interval
+// (lower, upper] by numerically maximizing the likelihood. The estimate
+// is a truncated distribution.
+type TruncationEstimator struct {
+  *NumericEstimator
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewTruncationEstimator(f ScalarPdf, lower, upper float64) (*TruncationEstimator, error) {
+  if g, err := scalarDistribution.NewPdfTruncation(f, lower, upper); err != nil {
+    return nil, err
+  } else {
+    if estimator, err := NewNumericEstimator(g); err != nil {
+      return nil, err
+    } else {
+      return &TruncationEstimator{estimator}, nil
+    }
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *TruncationEstimator) Clone() *TruncationEstimator {
+  return &TruncationEstimator{obj.NumericEstimator.Clone()}
+}
+
+func (obj *TruncationEstimator) CloneScalarEstimator() ScalarEstimator {
+  return obj.Clone()
+}
@@ -0,0 +1,204 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+import . "github.com/pbenner/autodiff/logarithmetic"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// EM estimator of zero-inflated count distributions. Each zero is either a
+// structural zero or a sample from the count distribution, which is
+// estimated with the given batch estimator. The E-step computes the
+// posterior probability that zeros are structural, the M-step updates Pi
+// and passes all observations with their expected weights to the batch
+// estimator.
+type ZeroInflatedEstimator struct {
+  *scalarDistribution.ZeroInflatedDistribution
+  StdEstimator
+  estimator     ScalarBatchEstimator
+  Epsilon       float64
+  MaxIterations int
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewZeroInflatedEstimator(pi float64, estimator ScalarBatchEstimator) (*ZeroInflatedEstimator, error) {
+  f, err := estimator.GetEstimate()
+  if err != nil {
+    return nil, err
+  }
+  if dist, err := scalarDistribution.NewZeroInflatedDistribution(NewScalar(f.ScalarType(), pi), f.CloneScalarPdf()); err != nil {
+    return nil, err
+  } else {
+    r := ZeroInflatedEstimator{}
+    r.ZeroInflatedDistribution = dist
+    r.estimator                = estimator
+    r.Epsilon                  = 1e-8
+    r.MaxIterations            = 1000
+    return &r, nil
+  }
+}
+
+func NewZeroInflatedPoissonEstimator(pi, lambda float64) (*ZeroInflatedEstimator, error) {
+  if estimator, err := NewPoissonEstimator(lambda); err != nil {
+    return nil, err
+  } else {
+    return NewZeroInflatedEstimator(pi, estimator)
+  }
+}
+
+func NewZeroInflatedNegativeBinomialEstimator(pi, r, p float64) (*ZeroInflatedEstimator, error) {
+  if estimator, err := NewNegativeBinomialEstimator(r, p); err != nil {
+    return nil, err
+  } else {
+    return NewZeroInflatedEstimator(pi, estimator)
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedEstimator) Clone() *ZeroInflatedEstimator {
+  r := ZeroInflatedEstimator{}
+  r.ZeroInflatedDistribution = obj.ZeroInflatedDistribution.Clone()
+  r.estimator                = obj.estimator.CloneScalarBatchEstimator()
+  r.Epsilon                  = obj.Epsilon
+  r.MaxIterations            = obj.MaxIterations
+  r.x                        = obj.x
+  r.n                        = obj.n
+  return &r
+}
+
+func (obj *ZeroInflatedEstimator) CloneScalarEstimator() ScalarEstimator {
+  return obj.Clone()
+}
+
+/* estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *ZeroInflatedEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  // total weight and weight of zeros
+  w, w0 := sumCountWeights(obj.x, gamma)
+  if math.IsInf(w, -1) {
+    return fmt.Errorf("zero-inflated parameter estimation failed: no observations")
+  }
+  // start at the current parameters
+  if err := obj.estimator.SetParameters(obj.ScalarPdf.GetParameters()); err != nil {
+    return err
+  }
+  pi := obj.Pi.GetFloat64()
+  t  := NewFloat64(0.0)
+  for k := 0; k < obj.MaxIterations; k++ {
+    // E-step: log posterior probability that a zero is structural
+    if err := obj.ScalarPdf.LogPdf(t, ConstFloat64(0.0)); err != nil {
+      return err
+    }
+    z := math.Log(pi) - LogAdd(math.Log(pi), math.Log1p(-pi) + t.GetFloat64())
+    // M-step
+    pi_new := math.Exp(w0 + z - w)
+    f, err := estimateCounts(obj.estimator, obj.x, gamma, w0 + math.Log1p(-math.Exp(z)), p)
+    if err != nil {
+      return err
+    }
+    q1 := obj.ScalarPdf.GetParameters()
+    q2 := f.GetParameters()
+    if err := obj.ScalarPdf.SetParameters(q2); err != nil {
+      return err
+    }
+    // check convergence
+    delta := math.Abs(pi_new - pi)
+    for i := 0; i < q1.Dim(); i++ {
+      delta = math.Max(delta, math.Abs(q1.ConstAt(i).GetFloat64() - q2.ConstAt(i).GetFloat64()))
+    }
+    pi = pi_new
+    if delta < obj.Epsilon {
+      break
+    }
+  }
+  obj.Pi.SetFloat64(pi)
+  return nil
+}
+
+func (obj *ZeroInflatedEstimator) EstimateOnData(x, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, x.Dim()); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *ZeroInflatedEstimator) GetEstimate() (ScalarPdf, error) {
+  return obj.ZeroInflatedDistribution, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+// Compute the log of the total weight and the log weight of all zeros
+func sumCountWeights(x, gamma ConstVector) (float64, float64) {
+  w  := math.Inf(-1)
+  w0 := math.Inf(-1)
+  for i := 0; i < x.Dim(); i++ {
+    g := 0.0
+    if gamma != nil {
+      g = gamma.ConstAt(i).GetFloat64()
+    }
+    w = LogAdd(w, g)
+    if x.ConstAt(i).GetFloat64() == 0.0 {
+      w0 = LogAdd(w0, g)
+    }
+  }
+  return w, w0
+}
+
+// Estimate the count distribution from all positive observations and a
+// single zero with log weight w0
+func estimateCounts(estimator ScalarBatchEstimator, x, gamma ConstVector, w0 float64, p ThreadPool) (ScalarPdf, error) {
+  g := p.NewJobGroup()
+
+  if err := estimator.Initialize(p); err != nil {
+    return nil, err
+  }
+  if err := p.AddRangeJob(0, x.Dim(), g, func(i int, p ThreadPool, erf func() error) error {
+    if x.ConstAt(i).GetFloat64() == 0.0 {
+      return nil
+    }
+    if gamma == nil {
+      return estimator.NewObservation(x.ConstAt(i), ConstFloat64(0.0), p)
+    } else {
+      return estimator.NewObservation(x.ConstAt(i), gamma.ConstAt(i), p)
+    }
+  }); err != nil {
+    return nil, err
+  }
+  if err := p.Wait(g); err != nil {
+    return nil, err
+  }
+  if !math.IsInf(w0, -1) {
+    if err := estimator.NewObservation(ConstFloat64(0.0), ConstFloat64(w0), p); err != nil {
+      return nil, err
+    }
+  }
+  return estimator.GetEstimate()
+}
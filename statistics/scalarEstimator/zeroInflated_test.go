@@ -0,0 +1,118 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scalarEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestZeroInflatedEstimator1(test *testing.T) {
+  // draw samples from a zero-inflated Poisson distribution
+  d, _ := scalarDistribution.NewZeroInflatedPoissonDistribution(NewFloat64(0.3), NewFloat64(3.0))
+
+  g := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(10000)
+  for i := 0; i < x.Dim(); i++ {
+    d.Sample(x.At(i), g)
+  }
+  estimator, err := NewZeroInflatedPoissonEstimator(0.5, 1.0)
+  if err != nil {
+    test.Fatal(err)
+  }
+  if err := estimator.EstimateOnData(x, nil, threadpool.New(2, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  p := r.GetParameters()
+
+  if math.Abs(p.At(0).GetFloat64() - 0.3) > 0.02 || math.Abs(p.At(1).GetFloat64() - 3.0) > 0.1 {
+    test.Error("test failed")
+  }
+}
+
+func TestZeroInflatedEstimator2(test *testing.T) {
+  // draw samples from a zero-inflated negative binomial distribution
+  d, _ := scalarDistribution.NewZeroInflatedNegativeBinomialDistribution(NewFloat64(0.2), NewFloat64(3.0), NewFloat64(0.4))
+
+  g := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(10000)
+  for i := 0; i < x.Dim(); i++ {
+    d.Sample(x.At(i), g)
+  }
+  // r is kept fixed by the negative binomial estimator
+  estimator, err := NewZeroInflatedNegativeBinomialEstimator(0.5, 3.0, 0.6)
+  if err != nil {
+    test.Fatal(err)
+  }
+  if err := estimator.EstimateOnData(x, nil, threadpool.New(1, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  p := r.GetParameters()
+
+  if math.Abs(p.At(0).GetFloat64() - 0.2) > 0.03 || math.Abs(p.At(2).GetFloat64() - 0.4) > 0.02 {
+    test.Error("test failed")
+  }
+}
+
+func TestZeroInflatedEstimator3(test *testing.T) {
+  // zero-inflated Poisson as emission distribution of a mixture
+  d1, _ := scalarDistribution.NewZeroInflatedPoissonDistribution(NewFloat64(0.4), NewFloat64(2.0))
+  d2, _ := scalarDistribution.NewPoissonDistribution(NewFloat64(12.0))
+
+  g := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(10000)
+  for i := 0; i < x.Dim(); i++ {
+    if i < x.Dim()/2 {
+      d1.Sample(x.At(i), g)
+    } else {
+      d2.Sample(x.At(i), g)
+    }
+  }
+  e1, _ := NewZeroInflatedPoissonEstimator(0.5, 1.0)
+  e2, _ := NewPoissonEstimator(8.0)
+
+  estimator, err := NewMixtureEstimator(nil, []ScalarEstimator{e1, e2}, 1e-8, -1)
+  if err != nil {
+    test.Fatal(err)
+  }
+  if err := estimator.EstimateOnData(x, nil, threadpool.New(2, 100)); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  m := r.(*scalarDistribution.Mixture)
+  p := m.Edist[0].GetParameters()
+  q := m.Edist[1].GetParameters()
+
+  if math.Abs(p.At(0).GetFloat64() - 0.4) > 0.03 || math.Abs(p.At(1).GetFloat64() - 2.0) > 0.1 {
+    test.Error("test failed")
+  }
+  if math.Abs(q.At(0).GetFloat64() - 12.0) > 0.2 {
+    test.Error("test failed")
+  }
+}
@@ -0,0 +1,203 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
+
+/* -------------------------------------------------------------------------- */
+
+type DirichletDistribution struct {
+  Alpha Vector
+  // log Gamma(sum alpha) - sum log Gamma(alpha)
+  z     Scalar
+  t1    Scalar
+  t2    Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewDirichletDistribution(alpha Vector) (*DirichletDistribution, error) {
+  if alpha.Dim() < 2 {
+    return nil, fmt.Errorf("alpha has invalid dimension")
+  }
+  t  := alpha.ElementType()
+  t1 := NewScalar(t, 0.0)
+  a0 := NewScalar(t, 0.0)
+  z  := NewScalar(t, 0.0)
+  for i := 0; i < alpha.Dim(); i++ {
+    if alpha.At(i).GetFloat64() <= 0.0 {
+      return nil, fmt.Errorf("invalid parameters")
+    }
+    a0.Add(a0, alpha.At(i))
+    z .Sub(z, t1.Lgamma(alpha.At(i)))
+  }
+  z.Add(z, t1.Lgamma(a0))
+
+  result := DirichletDistribution{
+    Alpha: alpha.CloneVector(),
+    z    : z,
+    t1   : t1,
+    t2   : NewScalar(t, 0.0) }
+
+  return &result, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletDistribution) Clone() *DirichletDistribution {
+  return &DirichletDistribution{
+    Alpha: dist.Alpha.CloneVector(),
+    z    : dist.z    .CloneScalar(),
+    t1   : dist.t1   .CloneScalar(),
+    t2   : dist.t2   .CloneScalar() }
+}
+
+func (obj *DirichletDistribution) CloneVectorPdf() VectorPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletDistribution) Dim() int {
+  return dist.Alpha.Dim()
+}
+
+func (dist *DirichletDistribution) ScalarType() ScalarType {
+  return dist.Alpha.ElementType()
+}
+
+func (dist *DirichletDistribution) Mean() Vector {
+  a0 := NullScalar(dist.ScalarType())
+  for i := 0; i < dist.Dim(); i++ {
+    a0.Add(a0, dist.Alpha.At(i))
+  }
+  r := dist.Alpha.CloneVector()
+  r.VdivS(r, a0)
+  return r
+}
+
+func (dist *DirichletDistribution) LogPdf(r Scalar, x ConstVector) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("input vector has invalid dimension")
+  }
+  // check that x is on the simplex
+  s := 0.0
+  for i := 0; i < x.Dim(); i++ {
+    if v := x.ConstAt(i).GetFloat64(); v <= 0.0 {
+      r.SetFloat64(math.Inf(-1))
+      return nil
+    } else {
+      s += v
+    }
+  }
+  if math.Abs(s - 1.0) > 1e-8 {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  t1 := dist.t1
+  t2 := dist.t2
+  r.Set(dist.z)
+  // sum (alpha - 1) log x
+  for i := 0; i < x.Dim(); i++ {
+    t1.Log(x.ConstAt(i))
+    t2.Sub(dist.Alpha.At(i), ConstFloat64(1.0))
+    t1.Mul(t1, t2)
+    r.Add(r, t1)
+  }
+  return nil
+}
+
+func (dist *DirichletDistribution) Sample(x Vector, r *rand.Rand) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("vector has invalid dimension")
+  }
+  return sampleDirichlet(x, dist.Alpha, r)
+}
+
+// Draw a sample from a Dirichlet distribution by normalizing independent
+// Gamma(alpha_i, 1) random variables.
+func sampleDirichlet(x Vector, alpha ConstVector, r *rand.Rand) error {
+  s := 0.0
+  for i := 0; i < alpha.Dim(); i++ {
+    if g, err := scalarDistribution.NewGammaDistribution(NewFloat64(alpha.ConstAt(i).GetFloat64()), NewFloat64(1.0)); err != nil {
+      return err
+    } else {
+      if err := g.Sample(x.At(i), r); err != nil {
+        return err
+      }
+    }
+    s += x.At(i).GetFloat64()
+  }
+  for i := 0; i < alpha.Dim(); i++ {
+    x.At(i).SetFloat64(x.At(i).GetFloat64()/s)
+  }
+  return nil
+}
+
+func (dist *DirichletDistribution) Pdf(r Scalar, x ConstVector) error {
+  if err := dist.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletDistribution) GetParameters() Vector {
+  return dist.Alpha
+}
+
+func (dist *DirichletDistribution) SetParameters(parameters Vector) error {
+  if tmp, err := NewDirichletDistribution(parameters); err != nil {
+    return err
+  } else {
+    *dist = *tmp
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *DirichletDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  if parameters, ok := config.GetParametersAsFloats(); !ok {
+    return fmt.Errorf("invalid config file")
+  } else {
+    alpha := AsDenseVector(t, NewDenseFloat64Vector(parameters))
+
+    if tmp, err := NewDirichletDistribution(alpha); err != nil {
+      return err
+    } else {
+      *obj = *tmp
+    }
+    return nil
+  }
+}
+
+func (obj *DirichletDistribution) ExportConfig() ConfigDistribution {
+
+  return NewConfigDistribution("vector:dirichlet distribution", AsDenseFloat64Vector(obj.Alpha))
+}
@@ -0,0 +1,207 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+// Dirichlet-Multinomial (multivariate Polya) distribution, i.e. a
+// multinomial distribution with probabilities drawn from a Dirichlet
+// distribution. As for the multinomial distribution, the number of trials
+// n is only used for sampling.
+type DirichletMultinomialDistribution struct {
+  Alpha Vector
+  n     int
+  a0    Scalar
+  // log Gamma(sum alpha) - sum log Gamma(alpha)
+  z     Scalar
+  t1    Scalar
+  t2    Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewDirichletMultinomialDistribution(alpha Vector, n int) (*DirichletMultinomialDistribution, error) {
+  if alpha.Dim() < 2 {
+    return nil, fmt.Errorf("alpha has invalid dimension")
+  }
+  if n < 0 {
+    return nil, fmt.Errorf("invalid number of trials")
+  }
+  t  := alpha.ElementType()
+  t1 := NewScalar(t, 0.0)
+  a0 := NewScalar(t, 0.0)
+  z  := NewScalar(t, 0.0)
+  for i := 0; i < alpha.Dim(); i++ {
+    if alpha.At(i).GetFloat64() <= 0.0 {
+      return nil, fmt.Errorf("invalid parameters")
+    }
+    a0.Add(a0, alpha.At(i))
+    z .Sub(z, t1.Lgamma(alpha.At(i)))
+  }
+  z.Add(z, t1.Lgamma(a0))
+
+  result := DirichletMultinomialDistribution{
+    Alpha: alpha.CloneVector(),
+    n    : n,
+    a0   : a0,
+    z    : z,
+    t1   : t1,
+    t2   : NewScalar(t, 0.0) }
+
+  return &result, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletMultinomialDistribution) Clone() *DirichletMultinomialDistribution {
+  return &DirichletMultinomialDistribution{
+    Alpha: dist.Alpha.CloneVector(),
+    n    : dist.n,
+    a0   : dist.a0   .CloneScalar(),
+    z    : dist.z    .CloneScalar(),
+    t1   : dist.t1   .CloneScalar(),
+    t2   : dist.t2   .CloneScalar() }
+}
+
+func (obj *DirichletMultinomialDistribution) CloneVectorPdf() VectorPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletMultinomialDistribution) Dim() int {
+  return dist.Alpha.Dim()
+}
+
+func (dist *DirichletMultinomialDistribution) ScalarType() ScalarType {
+  return dist.Alpha.ElementType()
+}
+
+func (dist *DirichletMultinomialDistribution) GetN() int {
+  return dist.n
+}
+
+func (dist *DirichletMultinomialDistribution) SetN(n int) error {
+  if n < 0 {
+    return fmt.Errorf("invalid number of trials")
+  }
+  dist.n = n
+  return nil
+}
+
+func (dist *DirichletMultinomialDistribution) LogPdf(r Scalar, x ConstVector) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("input vector has invalid dimension")
+  }
+  k, ok := countVector(x)
+  if !ok {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  t1 := dist.t1
+  t2 := dist.t2
+  // log Gamma(k+1) + log Gamma(a0) - log Gamma(k+a0) - sum log Gamma(alpha_i)
+  t1.Add(dist.a0, ConstFloat64(k))
+  t1.Lgamma(t1)
+  r.Lgamma(ConstFloat64(k+1.0))
+  r.Add(r, dist.z)
+  r.Sub(r, t1)
+  for i := 0; i < x.Dim(); i++ {
+    // log Gamma(x_i+alpha_i) - log Gamma(x_i+1)
+    t1.Add(x.ConstAt(i), dist.Alpha.At(i))
+    t1.Lgamma(t1)
+    t2.Add(x.ConstAt(i), ConstFloat64(1.0))
+    t2.Lgamma(t2)
+    r.Add(r, t1)
+    r.Sub(r, t2)
+  }
+  return nil
+}
+
+func (dist *DirichletMultinomialDistribution) Sample(x Vector, r *rand.Rand) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("vector has invalid dimension")
+  }
+  p := NullDenseFloat64Vector(dist.Dim())
+  if err := sampleDirichlet(p, dist.Alpha, r); err != nil {
+    return err
+  }
+  sampleMultinomial(x, p, dist.n, r)
+  return nil
+}
+
+func (dist *DirichletMultinomialDistribution) Pdf(r Scalar, x ConstVector) error {
+  if err := dist.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *DirichletMultinomialDistribution) GetParameters() Vector {
+  return dist.Alpha
+}
+
+func (dist *DirichletMultinomialDistribution) SetParameters(parameters Vector) error {
+  if tmp, err := NewDirichletMultinomialDistribution(parameters, dist.n); err != nil {
+    return err
+  } else {
+    *dist = *tmp
+  }
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *DirichletMultinomialDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  n, ok := config.GetNamedParameterAsInt("N"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  alpha, ok := config.GetNamedParametersAsVector("Alpha", t); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if tmp, err := NewDirichletMultinomialDistribution(alpha, n); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+func (obj *DirichletMultinomialDistribution) ExportConfig() ConfigDistribution {
+
+  config := struct{
+    Alpha []float64
+    N       int }{}
+  config.Alpha = AsDenseFloat64Vector(obj.Alpha)
+  config.N     = obj.n
+
+  return NewConfigDistribution("vector:dirichlet multinomial distribution", config)
+}
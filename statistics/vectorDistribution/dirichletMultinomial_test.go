@@ -0,0 +1,54 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestDirichletMultinomialDistribution1(t *testing.T) {
+
+  alpha := NewDenseFloat64Vector([]float64{1,2,3})
+
+  dist, _ := NewDirichletMultinomialDistribution(alpha, 6)
+
+  x := NewDenseFloat64Vector([]float64{1,2,3})
+  y := NewFloat64(0.0)
+
+  dist.LogPdf(y, x)
+
+  if math.Abs(y.GetFloat64() - -2.734368) > 1e-4 {
+    t.Error("test failed")
+  }
+  // probabilities of all count vectors with total 4 sum to one
+  s := 0.0
+  for i := 0; i <= 4; i++ {
+    for j := 0; j <= 4-i; j++ {
+      dist.Pdf(y, NewDenseFloat64Vector([]float64{float64(i), float64(j), float64(4-i-j)}))
+      s += y.GetFloat64()
+    }
+  }
+  if math.Abs(s - 1.0) > 1e-8 {
+    t.Error("test failed")
+  }
+}
@@ -0,0 +1,75 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+
+/* -------------------------------------------------------------------------- */
+
+func TestDirichletDistribution1(t *testing.T) {
+
+  alpha := NewDenseFloat64Vector([]float64{2,3,4})
+
+  dist, _ := NewDirichletDistribution(alpha)
+
+  x := NewDenseFloat64Vector([]float64{0.2,0.3,0.5})
+  y := NewFloat64(0.0)
+
+  dist.LogPdf(y, x)
+
+  if math.Abs(y.GetFloat64() - 2.022871) > 1e-4 {
+    t.Error("test failed")
+  }
+  // outside of the simplex
+  dist.LogPdf(y, NewDenseFloat64Vector([]float64{0.2,0.3,0.6}))
+
+  if !math.IsInf(y.GetFloat64(), -1) {
+    t.Error("test failed")
+  }
+}
+
+func TestDirichletDistributionSample(t *testing.T) {
+
+  alpha := NewDenseFloat64Vector([]float64{2,3,4})
+
+  dist, _ := NewDirichletDistribution(alpha)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(3)
+  n := 10000
+  m := [3]float64{}
+  for k := 0; k < n; k++ {
+    if err := dist.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    for i := 0; i < 3; i++ {
+      m[i] += x[i]/float64(n)
+    }
+  }
+  for i := 0; i < 3; i++ {
+    if math.Abs(m[i] - alpha[i]/9.0) > 0.01 {
+      t.Error("test failed")
+    }
+  }
+}
@@ -20,6 +20,7 @@ package vectorDistribution
 
 import   "fmt"
 import   "bytes"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff/statistics"
 import   "github.com/pbenner/autodiff/statistics/generic"
@@ -85,6 +86,15 @@ func (obj *Mixture) LogPdf(r Scalar, x ConstVector) error {
   return obj.Mixture.LogPdf(r, MixtureDataRecord{obj.Edist, x})
 }
 
+func (obj *Mixture) Sample(x Vector, r *rand.Rand) error {
+  k := obj.Mixture.SampleComponent(r)
+  if s, ok := obj.Edist[k].(VectorSampler); !ok {
+    return fmt.Errorf("mixture component `%d' does not support sampling", k)
+  } else {
+    return s.Sample(x, r)
+  }
+}
+
 func (obj *Mixture) Likelihood(r Scalar, x ConstVector, states []int) error {
   return obj.Mixture.Likelihood(r, MixtureDataRecord{obj.Edist, x}, states)
 }
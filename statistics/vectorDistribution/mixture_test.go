@@ -19,6 +19,8 @@ package vectorDistribution
 /* -------------------------------------------------------------------------- */
 
 //import   "fmt"
+import   "math"
+import   "math/rand"
 import   "testing"
 import   "os"
 
@@ -56,3 +58,27 @@ func Test1(test *testing.T) {
   }
   os.Remove("mixture_test.json")
 }
+
+func TestMixtureSample(test *testing.T) {
+  sigma   := NewDenseFloat64Matrix([]float64{1,0,0,1}, 2, 2)
+  normal1, _ := NewNormalDistribution(NewDenseFloat64Vector([]float64{-2,0}), sigma)
+  normal2, _ := NewNormalDistribution(NewDenseFloat64Vector([]float64{ 2,4}), sigma)
+
+  mixture, err := NewMixture(NewDenseFloat64Vector([]float64{1.0, 3.0}), []VectorPdf{normal1, normal2}); if err != nil {
+    test.Error(err); return
+  }
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(2)
+  n := 100000
+  m := [2]float64{}
+  for k := 0; k < n; k++ {
+    if err := mixture.Sample(x, r); err != nil {
+      test.Fatal(err)
+    }
+    m[0] += x[0]/float64(n)
+    m[1] += x[1]/float64(n)
+  }
+  if math.Abs(m[0] - 1.0) > 0.03 || math.Abs(m[1] - 3.0) > 0.03 {
+    test.Error("test failed")
+  }
+}
@@ -0,0 +1,229 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+import   "math/rand"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+// Multinomial distribution over count vectors. The number of trials n is
+// only used for sampling, LogPdf evaluates the probability of x given its
+// total count sum_i x_i, so that observations may have different numbers
+// of trials. Theta stores log probabilities.
+type MultinomialDistribution struct {
+  Theta Vector
+  n     int
+  t1    Scalar
+  t2    Scalar
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewMultinomialDistribution(theta_ Vector, n int) (*MultinomialDistribution, error) {
+  if theta_.Dim() < 2 {
+    return nil, fmt.Errorf("theta has invalid dimension")
+  }
+  if n < 0 {
+    return nil, fmt.Errorf("invalid number of trials")
+  }
+  t     := theta_.ElementType()
+  theta := NullDenseVector(t, theta_.Dim())
+
+  for i := 0; i < theta.Dim(); i++ {
+    if theta_.At(i).GetFloat64() < 0 {
+      return nil, fmt.Errorf("invalid negative probability")
+    }
+    theta.At(i).Log(theta_.At(i))
+  }
+  result := MultinomialDistribution{
+    Theta: theta,
+    n    : n,
+    t1   : NewScalar(t, 0.0),
+    t2   : NewScalar(t, 0.0) }
+
+  return &result, nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *MultinomialDistribution) Clone() *MultinomialDistribution {
+  return &MultinomialDistribution{
+    Theta: dist.Theta.CloneVector(),
+    n    : dist.n,
+    t1   : dist.t1   .CloneScalar(),
+    t2   : dist.t2   .CloneScalar() }
+}
+
+func (obj *MultinomialDistribution) CloneVectorPdf() VectorPdf {
+  return obj.Clone()
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *MultinomialDistribution) Dim() int {
+  return dist.Theta.Dim()
+}
+
+func (dist *MultinomialDistribution) ScalarType() ScalarType {
+  return dist.Theta.ElementType()
+}
+
+func (dist *MultinomialDistribution) GetN() int {
+  return dist.n
+}
+
+func (dist *MultinomialDistribution) SetN(n int) error {
+  if n < 0 {
+    return fmt.Errorf("invalid number of trials")
+  }
+  dist.n = n
+  return nil
+}
+
+// Check that x is a vector of non-negative integers and return the total
+// count
+func countVector(x ConstVector) (float64, bool) {
+  k := 0.0
+  for i := 0; i < x.Dim(); i++ {
+    if v := x.ConstAt(i).GetFloat64(); v < 0.0 || math.Floor(v) != v {
+      return 0.0, false
+    } else {
+      k += v
+    }
+  }
+  return k, true
+}
+
+func (dist *MultinomialDistribution) LogPdf(r Scalar, x ConstVector) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("input vector has invalid dimension")
+  }
+  k, ok := countVector(x)
+  if !ok {
+    r.SetFloat64(math.Inf(-1))
+    return nil
+  }
+  t1 := dist.t1
+  t2 := dist.t2
+  // log Gamma(k+1)
+  r.Lgamma(ConstFloat64(k+1.0))
+  for i := 0; i < x.Dim(); i++ {
+    if x.ConstAt(i).GetFloat64() == 0.0 {
+      continue
+    }
+    // -log Gamma(x_i+1)
+    t1.Add(x.ConstAt(i), ConstFloat64(1.0))
+    t1.Lgamma(t1)
+    r.Sub(r, t1)
+    // x_i log theta_i
+    t2.Mul(x.ConstAt(i), dist.Theta.At(i))
+    r.Add(r, t2)
+  }
+  return nil
+}
+
+func (dist *MultinomialDistribution) Sample(x Vector, r *rand.Rand) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("vector has invalid dimension")
+  }
+  p := make([]float64, dist.Dim())
+  for i := 0; i < dist.Dim(); i++ {
+    p[i] = math.Exp(dist.Theta.At(i).GetFloat64())
+  }
+  sampleMultinomial(x, p, dist.n, r)
+  return nil
+}
+
+// Draw n categories with probabilities p and store the counts in x
+func sampleMultinomial(x Vector, p []float64, n int, r *rand.Rand) {
+  c := make([]int, len(p))
+  s := 0.0
+  for i := 0; i < len(p); i++ {
+    s += p[i]
+  }
+  for k := 0; k < n; k++ {
+    u := s*r.Float64()
+    j := 0
+    for ; j < len(p)-1; j++ {
+      if u -= p[j]; u < 0.0 {
+        break
+      }
+    }
+    c[j]++
+  }
+  for i := 0; i < len(p); i++ {
+    x.At(i).SetFloat64(float64(c[i]))
+  }
+}
+
+func (dist *MultinomialDistribution) Pdf(r Scalar, x ConstVector) error {
+  if err := dist.LogPdf(r, x); err != nil {
+    return err
+  }
+  r.Exp(r)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (dist *MultinomialDistribution) GetParameters() Vector {
+  return dist.Theta
+}
+
+func (dist *MultinomialDistribution) SetParameters(parameters Vector) error {
+  dist.Theta.Set(parameters)
+  return nil
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MultinomialDistribution) ImportConfig(config ConfigDistribution, t ScalarType) error {
+
+  n, ok := config.GetNamedParameterAsInt("N"); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+  theta, ok := config.GetNamedParametersAsVector("Theta", t); if !ok {
+    return fmt.Errorf("invalid config file")
+  }
+
+  if tmp, err := NewMultinomialDistribution(theta, n); err != nil {
+    return err
+  } else {
+    *obj = *tmp
+  }
+  return nil
+}
+
+func (obj *MultinomialDistribution) ExportConfig() ConfigDistribution {
+
+  config := struct{
+    Theta []float64
+    N       int }{}
+  config.Theta = make([]float64, obj.Dim())
+  config.N     = obj.n
+  for i := 0; i < obj.Dim(); i++ {
+    config.Theta[i] = math.Exp(obj.Theta.At(i).GetFloat64())
+  }
+  return NewConfigDistribution("vector:multinomial distribution", config)
+}
@@ -0,0 +1,101 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorDistribution
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "os"
+import   "testing"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/autodiff/statistics"
+
+/* -------------------------------------------------------------------------- */
+
+func TestMultinomialDistribution1(t *testing.T) {
+
+  theta := NewDenseFloat64Vector([]float64{0.2,0.3,0.5})
+
+  dist, _ := NewMultinomialDistribution(theta, 6)
+
+  x := NewDenseFloat64Vector([]float64{1,2,3})
+  y := NewFloat64(0.0)
+
+  dist.LogPdf(y, x)
+
+  if math.Abs(y.GetFloat64() - -2.002481) > 1e-4 {
+    t.Error("test failed")
+  }
+}
+
+func TestMultinomialDistributionSample(t *testing.T) {
+
+  theta := NewDenseFloat64Vector([]float64{0.2,0.3,0.5})
+
+  dist, _ := NewMultinomialDistribution(theta, 10)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(3)
+  n := 10000
+  m := [3]float64{}
+  for k := 0; k < n; k++ {
+    if err := dist.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    if x[0] + x[1] + x[2] != 10 {
+      t.Fatal("test failed")
+    }
+    for i := 0; i < 3; i++ {
+      m[i] += x[i]/float64(n)
+    }
+  }
+  for i := 0; i < 3; i++ {
+    if math.Abs(m[i] - 10*theta[i]) > 0.05 {
+      t.Error("test failed")
+    }
+  }
+}
+
+func TestMultinomialDistributionConfig(t *testing.T) {
+
+  theta := NewDenseFloat64Vector([]float64{0.2,0.3,0.5})
+
+  d1, _ := NewMultinomialDistribution(theta, 6)
+
+  filename := "multinomial_test.json"
+
+  if err := ExportDistribution(filename, d1); err != nil {
+    t.Fatal(err)
+  }
+  defer os.Remove(filename)
+
+  if d2, err := ImportVectorPdf(filename, Float64Type); err != nil {
+    t.Error(err)
+  } else {
+    x  := NewDenseFloat64Vector([]float64{1,2,3})
+    y1 := NewFloat64(0.0)
+    y2 := NewFloat64(0.0)
+    d1.LogPdf(y1, x)
+    d2.LogPdf(y2, x)
+    if math.Abs(y1.GetFloat64() - y2.GetFloat64()) > 1e-12 {
+      t.Error("test failed")
+    }
+  }
+}
@@ -20,11 +20,13 @@ package vectorDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
 import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
 
+import   "github.com/pbenner/autodiff/algorithm/cholesky"
 import   "github.com/pbenner/autodiff/algorithm/determinant"
 import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
 
@@ -144,6 +146,35 @@ func (dist *NormalDistribution) LogPdf(r Scalar, x ConstVector) error {
   return nil
 }
 
+func (dist *NormalDistribution) Sample(x Vector, r *rand.Rand) error {
+  if x.Dim() != dist.Dim() {
+    return fmt.Errorf("vector has invalid dimension")
+  }
+  return sampleNormal(x, dist.Mu, dist.Sigma, 1.0, r)
+}
+
+// Draw a sample x = mu + s L z, where Sigma = L L^T and z is a vector of
+// independent standard normal random variables.
+func sampleNormal(x Vector, mu ConstVector, sigma ConstMatrix, s float64, r *rand.Rand) error {
+  L, _, err := cholesky.Run(sigma)
+  if err != nil {
+    return err
+  }
+  n := mu.Dim()
+  z := make([]float64, n)
+  for i := 0; i < n; i++ {
+    z[i] = r.NormFloat64()
+  }
+  for i := 0; i < n; i++ {
+    v := 0.0
+    for j := 0; j <= i; j++ {
+      v += L.ConstAt(i, j).GetFloat64()*z[j]
+    }
+    x.At(i).SetFloat64(mu.ConstAt(i).GetFloat64() + s*v)
+  }
+  return nil
+}
+
 func (dist *NormalDistribution) Pdf(r Scalar, x ConstVector) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
@@ -20,6 +20,7 @@ package vectorDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -321,3 +322,36 @@ func TestNormalFit2(t *testing.T) {
     t.Error("TestNormalFit2 failed!")
   }
 }
+
+func TestNormalDistributionSample(t *testing.T) {
+  mu     := NewDenseFloat64Vector([]float64{2,3})
+  sigma  := NewDenseFloat64Matrix([]float64{2,1,1,2}, 2, 2)
+  normal, _ := NewNormalDistribution(mu, sigma)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(2)
+  n := 100000
+  m := [2]float64{}
+  s := [2][2]float64{}
+  for k := 0; k < n; k++ {
+    if err := normal.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    for i := 0; i < 2; i++ {
+      m[i] += x[i]/float64(n)
+      for j := 0; j < 2; j++ {
+        s[i][j] += (x[i] - mu[i])*(x[j] - mu[j])/float64(n)
+      }
+    }
+  }
+  for i := 0; i < 2; i++ {
+    if math.Abs(m[i] - mu[i]) > 0.02 {
+      t.Error("test failed")
+    }
+    for j := 0; j < 2; j++ {
+      if math.Abs(s[i][j] - sigma.At(i, j).GetFloat64()) > 0.05 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
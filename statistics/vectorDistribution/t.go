@@ -20,9 +20,11 @@ package vectorDistribution
 
 import   "fmt"
 import   "math"
+import   "math/rand"
 
 import . "github.com/pbenner/autodiff"
 import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/scalarDistribution"
 import   "github.com/pbenner/autodiff/algorithm/determinant"
 import   "github.com/pbenner/autodiff/algorithm/matrixInverse"
 
@@ -156,6 +158,23 @@ func (dist *TDistribution) LogPdf(r Scalar, x ConstVector) error {
   return nil
 }
 
+func (dist *TDistribution) Sample(x Vector, r *rand.Rand) error {
+  if x.Dim() != dist.Mu.Dim() {
+    return fmt.Errorf("vector has invalid dimension")
+  }
+  // x = mu + L z / sqrt(w/nu), where w ~ ChiSquared(nu)
+  nu := dist.Nu.GetFloat64()
+  w  := NullFloat64()
+  if chi2, err := scalarDistribution.NewChiSquaredDistribution(Float64Type, nu); err != nil {
+    return err
+  } else {
+    if err := chi2.Sample(w, r); err != nil {
+      return err
+    }
+  }
+  return sampleNormal(x, dist.Mu, dist.Sigma, 1.0/math.Sqrt(w.GetFloat64()/nu), r)
+}
+
 func (dist *TDistribution) Pdf(r Scalar, x ConstVector) error {
   if err := dist.LogPdf(r, x); err != nil {
     return err
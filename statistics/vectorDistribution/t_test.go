@@ -20,6 +20,7 @@ package vectorDistribution
 
 //import   "fmt"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
@@ -43,3 +44,38 @@ func TestTDistribution1(t *testing.T) {
     t.Error("T LogPdf failed!")
   }
 }
+
+func TestTDistributionSample(t *testing.T) {
+  nu     := NewFloat64(5.0)
+  mu     := NewDenseFloat64Vector([]float64{2,3})
+  sigma  := NewDenseFloat64Matrix([]float64{2,1,1,2}, 2, 2)
+  dist, _ := NewTDistribution(nu, mu, sigma)
+
+  r := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(2)
+  n := 100000
+  m := [2]float64{}
+  s := [2][2]float64{}
+  for k := 0; k < n; k++ {
+    if err := dist.Sample(x, r); err != nil {
+      t.Fatal(err)
+    }
+    for i := 0; i < 2; i++ {
+      m[i] += x[i]/float64(n)
+      for j := 0; j < 2; j++ {
+        s[i][j] += (x[i] - mu[i])*(x[j] - mu[j])/float64(n)
+      }
+    }
+  }
+  // covariance: nu/(nu-2) Sigma
+  for i := 0; i < 2; i++ {
+    if math.Abs(m[i] - mu[i]) > 0.03 {
+      t.Error("test failed")
+    }
+    for j := 0; j < 2; j++ {
+      if math.Abs(s[i][j] - 5.0/3.0*sigma.At(i, j).GetFloat64()) > 0.15 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
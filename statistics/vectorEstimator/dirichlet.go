@@ -0,0 +1,237 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Reference:
+// Minka, Thomas. "Estimating a Dirichlet distribution." (2000)
+
+/* -------------------------------------------------------------------------- */
+
+package vectorEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
+import   "github.com/pbenner/autodiff/special"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// Maximum likelihood estimator of the Dirichlet distribution, which uses
+// Minka's fixed-point iteration
+//   alpha_i <- digamma^-1(digamma(sum_j alpha_j) + mean(log x_i))
+// starting at the current parameters
+type DirichletEstimator struct {
+  *vectorDistribution.DirichletDistribution
+  StdEstimator
+  // parameters
+  n             int
+  Epsilon       float64
+  MaxIterations int
+  // state
+  sum_g     []float64
+  sum_l   [][]float64
+  gamma_max float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewDirichletEstimator(alpha []float64) (*DirichletEstimator, error) {
+  if dist, err := vectorDistribution.NewDirichletDistribution(NewDenseFloat64Vector(alpha)); err != nil {
+    return nil, err
+  } else {
+    r := DirichletEstimator{}
+    r.DirichletDistribution = dist
+    r.n                     = len(alpha)
+    r.Epsilon               = 1e-8
+    r.MaxIterations         = 1000
+    return &r, nil
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *DirichletEstimator) Clone() *DirichletEstimator {
+  r := DirichletEstimator{}
+  r.DirichletDistribution = obj.DirichletDistribution.Clone()
+  r.n             = obj.n
+  r.Epsilon       = obj.Epsilon
+  r.MaxIterations = obj.MaxIterations
+  r.x             = obj.x
+  return &r
+}
+
+func (obj *DirichletEstimator) CloneVectorEstimator() VectorEstimator {
+  return obj.Clone()
+}
+
+func (obj *DirichletEstimator) CloneVectorBatchEstimator() VectorBatchEstimator {
+  return obj.Clone()
+}
+
+/* batch estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *DirichletEstimator) Initialize(p ThreadPool) error {
+  obj.sum_g = make(  []float64, p.NumberOfThreads())
+  obj.sum_l = make([][]float64, p.NumberOfThreads())
+  for i := 0; i < p.NumberOfThreads(); i++ {
+    obj.sum_l[i] = make([]float64, obj.n)
+  }
+  obj.gamma_max = 0.0
+  return nil
+}
+
+func (obj *DirichletEstimator) NewObservation(x ConstVector, gamma ConstScalar, p ThreadPool) error {
+  if x.Dim() != obj.n {
+    return fmt.Errorf("x has invalid dimension (expected dimension `%d' but data has dimension `%d')", obj.n, x.Dim())
+  }
+  id := p.GetThreadId()
+  g  := 1.0
+  if gamma != nil {
+    g = math.Exp(gamma.GetFloat64() - obj.gamma_max)
+  }
+  obj.sum_g[id] += g
+  for i := 0; i < obj.n; i++ {
+    obj.sum_l[id][i] += g*math.Log(x.ConstAt(i).GetFloat64())
+  }
+  return nil
+}
+
+/* estimator interface
+ * -------------------------------------------------------------------------- */
+
+// Inverse of the digamma function computed with Newton's method
+func inverseDigamma(y float64) float64 {
+  var x float64
+  // initial value
+  if y >= -2.22 {
+    x = math.Exp(y) + 0.5
+  } else {
+    x = -1.0/(y - special.Digamma(1.0))
+  }
+  for i := 0; i < 5; i++ {
+    x -= (special.Digamma(x) - y)/special.Trigamma(x)
+  }
+  return x
+}
+
+func (obj *DirichletEstimator) updateEstimate() error {
+  sum_g := obj.sum_g[0]
+  sum_l := obj.sum_l[0]
+  for k := 1; k < len(obj.sum_l); k++ {
+    sum_g += obj.sum_g[k]
+    for i := 0; i < obj.n; i++ {
+      sum_l[i] += obj.sum_l[k][i]
+    }
+  }
+  obj.sum_g = nil
+  obj.sum_l = nil
+  if sum_g == 0.0 {
+    return fmt.Errorf("cannot estimate dirichlet distribution without observations")
+  }
+  // mean of log x
+  for i := 0; i < obj.n; i++ {
+    if sum_l[i] /= sum_g; math.IsInf(sum_l[i], -1) || math.IsNaN(sum_l[i]) {
+      return fmt.Errorf("data contains values outside the simplex")
+    }
+  }
+  alpha := AsDenseFloat64Vector(obj.Alpha)
+  for k := 0; k < obj.MaxIterations; k++ {
+    a0 := 0.0
+    for i := 0; i < obj.n; i++ {
+      a0 += alpha[i]
+    }
+    delta := 0.0
+    for i := 0; i < obj.n; i++ {
+      a := inverseDigamma(special.Digamma(a0) + sum_l[i])
+      delta    = math.Max(delta, math.Abs(a - alpha[i])/alpha[i])
+      alpha[i] = a
+    }
+    if delta < obj.Epsilon {
+      break
+    }
+  }
+  if t, err := vectorDistribution.NewDirichletDistribution(AsDenseVector(obj.ScalarType(), alpha)); err != nil {
+    return err
+  } else {
+    *obj.DirichletDistribution = *t
+  }
+  return nil
+}
+
+func (obj *DirichletEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  g := p.NewJobGroup()
+  x := obj.x
+
+  // initialize estimator
+  obj.Initialize(p)
+
+  // rescale gamma
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma != nil {
+    obj.gamma_max = math.Inf(-1)
+    for i := 0; i < gamma.Dim(); i++ {
+      if g := gamma.ConstAt(i).GetFloat64(); obj.gamma_max < g {
+        obj.gamma_max = g
+      }
+    }
+  }
+  // compute sufficient statistics
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma == nil {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], nil, p)
+    }); err != nil {
+      return err
+    }
+  } else {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], gamma.ConstAt(i), p)
+    }); err != nil {
+      return err
+    }
+  }
+  if err := p.Wait(g); err != nil {
+    return err
+  }
+  // update estimate
+  if err := obj.updateEstimate(); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (obj *DirichletEstimator) EstimateOnData(x []ConstVector, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, len(x)); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *DirichletEstimator) GetEstimate() (VectorPdf, error) {
+  if obj.sum_l != nil {
+    if err := obj.updateEstimate(); err != nil {
+      return nil, err
+    }
+  }
+  return obj.DirichletDistribution, nil
+}
@@ -0,0 +1,62 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "math/rand"
+import   "testing"
+
+import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
+
+import . "github.com/pbenner/autodiff"
+
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestDirichlet1(t *testing.T) {
+
+  alpha := []float64{2, 3, 4}
+
+  d, _ := vectorDistribution.NewDirichletDistribution(NewDenseFloat64Vector(alpha))
+  g    := rand.New(rand.NewSource(1))
+  x    := make([]ConstVector, 10000)
+  for i := 0; i < len(x); i++ {
+    y := NullDenseFloat64Vector(3)
+    d.Sample(y, g)
+    x[i] = y
+  }
+
+  if estimator, err := NewDirichletEstimator([]float64{1,1,1}); err != nil {
+    t.Error(err)
+  } else {
+    if err := estimator.EstimateOnData(x, nil, ThreadPool{}); err != nil {
+      t.Fatal(err)
+    }
+    r, _ := estimator.GetEstimate()
+    p    := r.GetParameters()
+
+    for i := 0; i < 3; i++ {
+      if math.Abs(p.At(i).GetFloat64() - alpha[i]) > 0.1*alpha[i] {
+        t.Error("test failed")
+      }
+    }
+  }
+}
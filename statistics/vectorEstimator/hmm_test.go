@@ -21,6 +21,7 @@ package vectorEstimator
 //import   "fmt"
 //import   "os"
 import   "math"
+import   "math/rand"
 import   "testing"
 
 import . "github.com/pbenner/autodiff/statistics"
@@ -511,7 +512,7 @@ func TestHmm6(test *testing.T) {
 
   r := NewDenseFloat64Vector([]float64{
     -9.628283e+04,  0.000000e+00, 0.000000e+00, -2.811200e+03, -5.298317e+00, -5.012542e-03,   // Hmm
-    -2.851585e+02,  0.000000e+00, 4.262817e+01,  4.718743e+01,  2.513352e+01,  5.887617e-01,   // Mixture component 1
+    -2.936870e+02,  0.000000e+00, 4.660748e+01,  5.097524e+01,  2.513352e+01,  5.887617e-01,   // Mixture component 1
     -6.486053e-01, -7.397659e-01, 9.793777e-01,  1.813242e+00,  1.139991e+01,  1.144401e+01 }) // Mixture component 2
   r.Slice( 0, 6).Map(func(x Scalar) { x.Exp(x) })
   r.Slice(12,14).Map(func(x Scalar) { x.Exp(x) })
@@ -533,3 +534,48 @@ func TestHmm6(test *testing.T) {
     test.Error("test failed")
   }
 }
+
+func TestHmm7(test *testing.T) {
+  // zero-inflated and hurdle emissions
+  pi := NewDenseFloat64Vector([]float64{0.5, 0.5})
+  tr := NewDenseFloat64Matrix([]float64{0.9, 0.1, 0.2, 0.8}, 2, 2)
+
+  d1, _ := scalarDistribution.NewZeroInflatedPoissonDistribution(NewFloat64(0.5), NewFloat64(1.5))
+  d2, _ := scalarDistribution.NewHurdlePoissonDistribution(NewFloat64(0.1), NewFloat64(10.0))
+
+  // simulate state sequence and observations
+  g := rand.New(rand.NewSource(1))
+  x := NullDenseFloat64Vector(10000)
+  s := 0
+  for i := 0; i < x.Dim(); i++ {
+    if s == 0 {
+      d1.Sample(x.At(i), g)
+    } else {
+      d2.Sample(x.At(i), g)
+    }
+    if g.Float64() > tr.At(s, s).GetFloat64() {
+      s = 1 - s
+    }
+  }
+  e1, _ := scalarEstimator.NewZeroInflatedPoissonEstimator(0.3, 1.0)
+  e2, _ := scalarEstimator.NewHurdlePoissonEstimator(0.3, 5.0)
+
+  estimator, err := NewHmmEstimator(pi, tr, nil, nil, nil, []ScalarEstimator{e1, e2}, 1e-8, -1)
+  if err != nil {
+    test.Fatal(err)
+  }
+  if err := estimator.EstimateOnData([]ConstVector{x}, nil, ThreadPool{}); err != nil {
+    test.Fatal(err)
+  }
+  r, _ := estimator.GetEstimate()
+  hmm := r.(*vectorDistribution.Hmm)
+  p1  := hmm.Edist[0].GetParameters()
+  p2  := hmm.Edist[1].GetParameters()
+
+  if math.Abs(p1.At(0).GetFloat64() - 0.5) > 0.05 || math.Abs(p1.At(1).GetFloat64() -  1.5) > 0.1 {
+    test.Error("test failed")
+  }
+  if math.Abs(p2.At(0).GetFloat64() - 0.1) > 0.02 || math.Abs(p2.At(1).GetFloat64() - 10.0) > 0.3 {
+    test.Error("test failed")
+  }
+}
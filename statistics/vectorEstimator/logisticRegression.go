@@ -24,6 +24,8 @@ import   "math/rand"
 import   "sort"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/coordinateDescent"
+import   "github.com/pbenner/autodiff/algorithm/minibatch"
 import   "github.com/pbenner/autodiff/algorithm/saga"
 import . "github.com/pbenner/autodiff/statistics"
 import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
@@ -93,6 +95,27 @@ type LogisticRegression struct {
   ClassWeights  [2]float64
   Seed             int64
   Hook             func(x ConstVector, step, lambda ConstScalar, i int) bool
+  // use coordinate descent instead of SAGA, which allows to combine L1Reg
+  // and TiReg (elastic-net)
+  CoordinateDescent bool
+  // if larger than one, compute a regularization path of this length
+  // with coordinate descent that ends at the requested regularization
+  // strength
+  PathLength        int
+  pathLambda      []float64
+  pathTheta       []Vector
+  // if positive, use mini-batch stochastic gradient methods with batches
+  // of this size instead of SAGA. The update rule is either "SGD"
+  // (default), "AdaGrad", "RMSProp", "Adam" or "AdamW", and the step size
+  // is multiplied by the given schedule (which may be nil). If BatchStepSize
+  // is zero, the step size of SAGA is used. Unless MaxIterations is set,
+  // the number of epochs is limited by the default of the minibatch
+  // package. The hook receives the average loss of the last epoch instead
+  // of the change of the parameters.
+  BatchSize         int
+  BatchMethod       string
+  BatchStepSize     float64
+  BatchSchedule     minibatch.ScheduleType
   sagaLogisticRegressionL1
 }
 
@@ -285,6 +308,12 @@ func (obj *LogisticRegression) Estimate(gamma ConstVector, p ThreadPool) error {
   if gamma != nil {
     panic("internal error")
   }
+  if obj.CoordinateDescent || obj.PathLength > 1 {
+    return obj.estimateCoordinateDescent()
+  }
+  if obj.BatchSize > 0 {
+    return obj.estimateMinibatch()
+  }
   { m := 0
     if obj.L1Reg != 0.0 { m++ }
     if obj.L2Reg != 0.0 { m++ }
@@ -350,6 +379,159 @@ func (obj *LogisticRegression) Estimate(gamma ConstVector, p ThreadPool) error {
   return nil
 }
 
+// Estimate parameters with cyclic coordinate descent along a path of
+// regularization strengths (glmnet). The penalty is given by
+//   L1Reg ||theta||_1 + TiReg/2 ||theta||_2^2
+// where the intercept is not regularized.
+func (obj *LogisticRegression) estimateCoordinateDescent() error {
+  if obj.L2Reg != 0.0 {
+    return fmt.Errorf("l2-regularization is not supported by coordinate descent")
+  }
+  if obj.L1Reg < 0.0 {
+    return fmt.Errorf("invalid l1-regularization constant")
+  }
+  if obj.TiReg < 0.0 {
+    return fmt.Errorf("invalid ti-regularization constant")
+  }
+  lambda := obj.L1Reg + obj.TiReg
+  alpha  := 1.0
+  if lambda != 0.0 {
+    alpha = obj.L1Reg/lambda
+  }
+  var x []ConstVector
+  if obj.sparse {
+    x = make([]ConstVector, len(obj.x_sparse))
+    for i := range obj.x_sparse {
+      x[i] = obj.x_sparse[i]
+    }
+  } else {
+    x = make([]ConstVector, len(obj.x_dense))
+    for i := range obj.x_dense {
+      x[i] = obj.x_dense[i]
+    }
+  }
+  y := make([]float64, len(x))
+  w := make([]float64, len(x))
+  for i := range x {
+    if obj.c[i] {
+      y[i] = 1.0
+      w[i] = obj.ClassWeights[1]
+    } else {
+      w[i] = obj.ClassWeights[0]
+    }
+  }
+  // do not regularize intercept
+  pf := make([]float64, obj.Theta.Dim())
+  for j := 1; j < len(pf); j++ {
+    pf[j] = 1.0
+  }
+  args := []interface{}{
+    coordinateDescent.Alpha         {alpha},
+    coordinateDescent.Weights       {w},
+    coordinateDescent.PenaltyFactors{pf},
+    coordinateDescent.Epsilon       {obj.Epsilon},
+    coordinateDescent.MaxIterations {obj.MaxIterations},
+    coordinateDescent.Hook          {obj.Hook} }
+  if obj.PathLength > 1 {
+    if lambda == 0.0 {
+      return fmt.Errorf("regularization path requires a positive regularization strength")
+    }
+    args = append(args, coordinateDescent.PathLength{obj.PathLength}, coordinateDescent.LambdaMin{lambda})
+  } else {
+    args = append(args, coordinateDescent.Lambda{[]float64{lambda}})
+  }
+  if l, r, err := coordinateDescent.Run(coordinateDescent.Binomial{}, x, y, args...); err != nil {
+    return err
+  } else {
+    obj.pathLambda = l
+    obj.pathTheta  = r
+    obj.SetParameters(r[len(r)-1].CloneVector())
+  }
+  return nil
+}
+
+// Estimate parameters with mini-batch stochastic gradient methods, where
+// the loss is the weighted negative log-likelihood of each example.
+func (obj *LogisticRegression) estimateMinibatch() error {
+  if obj.L1Reg != 0.0 || obj.L2Reg != 0.0 || obj.TiReg != 0.0 {
+    return fmt.Errorf("regularization is not supported by mini-batch training")
+  }
+  method := obj.BatchMethod
+  if method == "" {
+    method = "SGD"
+  }
+  stepSize := obj.BatchStepSize
+  if stepSize == 0.0 {
+    stepSize = obj.stepSize
+  }
+  // loss of example i given the log-probability y of the first class
+  loss := func(y float64, i int) float64 {
+    if obj.c[i] {
+      return -obj.ClassWeights[1]*y
+    } else {
+      return -obj.ClassWeights[0]*math.Log1p(-math.Exp(y))
+    }
+  }
+  var f minibatch.DenseGradientF
+  var n int
+  if obj.sparse {
+    n = len(obj.x_sparse)
+    f = func(i int, theta, gradient DenseFloat64Vector) (float64, error) {
+      y, w, x, err := obj.f_sparse(i, theta)
+      if err != nil {
+        return 0.0, err
+      }
+      for k := range gradient {
+        gradient[k] = 0.0
+      }
+      values := x.GetSparseValues()
+      for j, k := range x.GetSparseIndices() {
+        gradient[k] = w*values[j]
+      }
+      return loss(y, i), nil
+    }
+  } else {
+    n = len(obj.x_dense)
+    f = func(i int, theta, gradient DenseFloat64Vector) (float64, error) {
+      y, w, x, err := obj.f_dense(i, theta)
+      if err != nil {
+        return 0.0, err
+      }
+      for k := range gradient {
+        gradient[k] = w*x[k]
+      }
+      return loss(y, i), nil
+    }
+  }
+  var hook minibatch.Hook
+  if obj.Hook != nil {
+    hook.Value = func(x ConstVector, loss ConstScalar, epoch int) bool {
+      return obj.Hook(x, loss, ConstFloat64(0.0), epoch)
+    }
+  }
+  args := []interface{}{
+    minibatch.Method   {method},
+    minibatch.StepSize {stepSize},
+    minibatch.BatchSize{obj.BatchSize},
+    minibatch.Schedule {obj.BatchSchedule},
+    minibatch.Epsilon  {obj.Epsilon},
+    minibatch.Seed     {obj.Seed},
+    hook }
+  // the default of MaxIterations is unbounded, which does not terminate
+  // if the step size does not decay
+  if obj.MaxIterations != int(^uint(0) >> 1) {
+    args = append(args, minibatch.MaxIterations{obj.MaxIterations})
+  }
+  // f_dense and f_sparse overwrite the parameters
+  theta := obj.Theta.Clone()
+  if r, err := minibatch.Run(f, n, theta, args...); err != nil {
+    return err
+  } else {
+    obj.SetParameters(r)
+  }
+  return nil
+}
+
 func (obj *LogisticRegression) EstimateOnData(x []ConstVector, gamma ConstVector, p ThreadPool) error {
   if err := obj.SetData(x, len(x)); err != nil {
     return err
@@ -361,6 +543,12 @@ func (obj *LogisticRegression) GetEstimate() (VectorPdf, error) {
   return vectorDistribution.NewLogisticRegression(obj.Theta)
 }
 
+// Returns the regularization strengths and parameters of the last
+// regularization path computed with coordinate descent
+func (obj *LogisticRegression) GetRegularizationPath() ([]float64, []Vector) {
+  return obj.pathLambda, obj.pathTheta
+}
+
 /* -------------------------------------------------------------------------- */
 
 func (obj *LogisticRegression) estimateStepSize() {
@@ -22,6 +22,7 @@ import   "fmt"
 import   "testing"
 
 import . "github.com/pbenner/autodiff"
+import   "github.com/pbenner/autodiff/algorithm/minibatch"
 import   "github.com/pbenner/autodiff/algorithm/rprop"
 import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
 import . "github.com/pbenner/threadpool"
@@ -629,3 +630,144 @@ func TestLogistic9(test *testing.T) {
     }
   }
 }
+
+func TestLogistic10(test *testing.T) {
+
+  C := 1.0
+
+  // data
+  cellSize  := []float64{
+    1, 4, 1, 8, 1, 10, 1, 1, 1, 2, 1, 1, 3, 1, 7, 4, 1, 1, 7, 1}
+  cellShape := []float64{
+    1, 4, 1, 8, 1, 10, 1, 2, 1, 1, 1, 1, 3, 1, 5, 6, 1, 1, 7, 1}
+  class := []float64{
+    0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0, 1, 0}
+  // result from sklearn
+  r_sklearn := DenseFloat64Vector([]float64{-2.63837871, 0.16460826, 0.44788412})
+
+  for _, sparse := range []bool{false, true} {
+    // x
+    x := make([]ConstVector, len(cellSize))
+    for i := 0; i < len(cellSize); i++ {
+      if sparse {
+        x[i] = NewSparseFloat64Vector([]int{0, 1, 2, 3}, []float64{1.0, cellSize[i]-1.0, cellShape[i]-1.0, class[i]}, 4)
+      } else {
+        x[i] = NewDenseFloat64Vector([]float64{1.0, cellSize[i]-1.0, cellShape[i]-1.0, class[i]})
+      }
+    }
+    estimator, err := NewLogisticRegression(3, sparse)
+    if err != nil {
+      test.Error(err); return
+    }
+    estimator.CoordinateDescent = true
+    estimator.Epsilon           = 1e-10
+    estimator.L1Reg             = 1.0/C
+
+    err = estimator.EstimateOnData(x, nil, ThreadPool{})
+    if err != nil {
+      test.Error(err); return
+    }
+    r := estimator.GetParameters()
+    t := NullFloat64()
+    s := NullDenseFloat64Vector(r.Dim())
+    if t.Vnorm(s.VsubV(r, r_sklearn)); t.GetFloat64() > 1e-4 {
+      test.Error("test failed")
+    }
+  }
+}
+
+func TestLogistic11(test *testing.T) {
+
+  C := 1.0
+
+  // data
+  cellSize  := []float64{
+    1, 4, 1, 8, 1, 10, 1, 1, 1, 2, 1, 1, 3, 1, 7, 4, 1, 1, 7, 1}
+  cellShape := []float64{
+    1, 4, 1, 8, 1, 10, 1, 2, 1, 1, 1, 1, 3, 1, 5, 6, 1, 1, 7, 1}
+  class := []float64{
+    0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0, 1, 0}
+  // x
+  x := make([]ConstVector, len(cellSize))
+  for i := 0; i < len(cellSize); i++ {
+    x[i] = NewSparseFloat64Vector([]int{0, 1, 2, 3}, []float64{1.0, cellSize[i]-1.0, cellShape[i]-1.0, class[i]}, 4)
+  }
+  // result from sklearn
+  r_sklearn := DenseFloat64Vector([]float64{-2.63837871, 0.16460826, 0.44788412})
+
+  estimator, err := NewLogisticRegression(3, true)
+  if err != nil {
+    test.Error(err); return
+  }
+  estimator.PathLength = 20
+  estimator.Epsilon    = 1e-10
+  estimator.L1Reg      = 1.0/C
+
+  err = estimator.EstimateOnData(x, nil, ThreadPool{})
+  if err != nil {
+    test.Error(err); return
+  }
+  lambda, theta := estimator.GetRegularizationPath()
+  if len(lambda) != 20 || len(theta) != 20 {
+    test.Error("test failed"); return
+  }
+  if lambda[19] != 1.0/C {
+    test.Error("test failed")
+  }
+  // all coefficients except the intercept are zero at the beginning of
+  // the path
+  if theta[0].Float64At(1) != 0.0 || theta[0].Float64At(2) != 0.0 {
+    test.Error("test failed")
+  }
+  r := estimator.GetParameters()
+  t := NullFloat64()
+  s := NullDenseFloat64Vector(r.Dim())
+  if t.Vnorm(s.VsubV(r, r_sklearn)); t.GetFloat64() > 1e-4 {
+    test.Error("test failed")
+  }
+}
+
+func TestLogistic12(test *testing.T) {
+
+  // data
+  cellSize  := []float64{
+    1, 4, 1, 8, 1, 10, 1, 1, 1, 2, 1, 1, 3, 1, 7, 4, 1, 1, 7, 1}
+  cellShape := []float64{
+    1, 4, 1, 8, 1, 10, 1, 2, 1, 1, 1, 1, 3, 1, 5, 6, 1, 1, 7, 1}
+  class := []float64{
+    0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0, 1, 0}
+  // result of TestLogistic1
+  z := DenseFloat64Vector([]float64{-2.858321e+00, 1.840900e-01, 5.067086e-01})
+
+  for _, sparse := range []bool{false, true} {
+    // x
+    x := make([]ConstVector, len(cellSize))
+    for i := 0; i < len(cellSize); i++ {
+      if sparse {
+        x[i] = NewSparseFloat64Vector([]int{0, 1, 2, 3}, []float64{1.0, cellSize[i]-1.0, cellShape[i]-1.0, class[i]}, 4)
+      } else {
+        x[i] = NewDenseFloat64Vector([]float64{1.0, cellSize[i]-1.0, cellShape[i]-1.0, class[i]})
+      }
+    }
+    estimator, err := NewLogisticRegression(3, sparse)
+    if err != nil {
+      test.Error(err); return
+    }
+    estimator.BatchSize     = 5
+    estimator.BatchMethod   = "Adam"
+    estimator.BatchStepSize = 0.05
+    estimator.BatchSchedule = minibatch.CosineSchedule{4*2000, 0.0}
+    estimator.MaxIterations = 2000
+
+    err = estimator.EstimateOnData(x, nil, ThreadPool{})
+    if err != nil {
+      test.Error(err); return
+    }
+    r := estimator.GetParameters()
+    t := NullFloat64()
+    s := NullDenseFloat64Vector(r.Dim())
+    if t.Vnorm(s.VsubV(r, z)); t.GetFloat64() > 1e-2 {
+      test.Error("test failed")
+    }
+  }
+}
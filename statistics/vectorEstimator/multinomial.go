@@ -0,0 +1,196 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorEstimator
+
+/* -------------------------------------------------------------------------- */
+
+import   "fmt"
+import   "math"
+
+import . "github.com/pbenner/autodiff/statistics"
+import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
+
+import . "github.com/pbenner/autodiff"
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+// Maximum likelihood estimator of the multinomial distribution, i.e. the
+// probabilities are given by the relative frequencies of all categories.
+// The pseudocount is added to the counts of each category.
+type MultinomialEstimator struct {
+  *vectorDistribution.MultinomialDistribution
+  StdEstimator
+  // parameters
+  n           int
+  Pseudocount float64
+  // state
+  sum_x [][]float64
+  gamma_max float64
+}
+
+/* -------------------------------------------------------------------------- */
+
+func NewMultinomialEstimator(theta []float64, n int, pseudocount float64) (*MultinomialEstimator, error) {
+  if dist, err := vectorDistribution.NewMultinomialDistribution(NewDenseFloat64Vector(theta), n); err != nil {
+    return nil, err
+  } else {
+    r := MultinomialEstimator{}
+    r.MultinomialDistribution = dist
+    r.n                       = len(theta)
+    r.Pseudocount             = pseudocount
+    return &r, nil
+  }
+}
+
+/* -------------------------------------------------------------------------- */
+
+func (obj *MultinomialEstimator) Clone() *MultinomialEstimator {
+  r := MultinomialEstimator{}
+  r.MultinomialDistribution = obj.MultinomialDistribution.Clone()
+  r.n           = obj.n
+  r.Pseudocount = obj.Pseudocount
+  r.x           = obj.x
+  return &r
+}
+
+func (obj *MultinomialEstimator) CloneVectorEstimator() VectorEstimator {
+  return obj.Clone()
+}
+
+func (obj *MultinomialEstimator) CloneVectorBatchEstimator() VectorBatchEstimator {
+  return obj.Clone()
+}
+
+/* batch estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *MultinomialEstimator) Initialize(p ThreadPool) error {
+  obj.sum_x = make([][]float64, p.NumberOfThreads())
+  for i := 0; i < p.NumberOfThreads(); i++ {
+    obj.sum_x[i] = make([]float64, obj.n)
+  }
+  obj.gamma_max = 0.0
+  return nil
+}
+
+func (obj *MultinomialEstimator) NewObservation(x ConstVector, gamma ConstScalar, p ThreadPool) error {
+  if x.Dim() != obj.n {
+    return fmt.Errorf("x has invalid dimension (expected dimension `%d' but data has dimension `%d')", obj.n, x.Dim())
+  }
+  id := p.GetThreadId()
+  if gamma == nil {
+    for i := 0; i < obj.n; i++ {
+      obj.sum_x[id][i] += x.ConstAt(i).GetFloat64()
+    }
+  } else {
+    g := math.Exp(gamma.GetFloat64() - obj.gamma_max)
+    for i := 0; i < obj.n; i++ {
+      obj.sum_x[id][i] += g*x.ConstAt(i).GetFloat64()
+    }
+  }
+  return nil
+}
+
+/* estimator interface
+ * -------------------------------------------------------------------------- */
+
+func (obj *MultinomialEstimator) updateEstimate() error {
+  sum_x := obj.sum_x[0]
+  for k := 1; k < len(obj.sum_x); k++ {
+    for i := 0; i < obj.n; i++ {
+      sum_x[i] += obj.sum_x[k][i]
+    }
+  }
+  sum := 0.0
+  for i := 0; i < obj.n; i++ {
+    sum_x[i] += obj.Pseudocount
+    sum      += sum_x[i]
+  }
+  if sum == 0.0 {
+    return fmt.Errorf("cannot estimate multinomial distribution without observations")
+  }
+  theta := NullDenseFloat64Vector(obj.n)
+  for i := 0; i < obj.n; i++ {
+    theta[i] = sum_x[i]/sum
+  }
+  obj.sum_x = nil
+  if t, err := vectorDistribution.NewMultinomialDistribution(theta, obj.GetN()); err != nil {
+    return err
+  } else {
+    *obj.MultinomialDistribution = *t
+  }
+  return nil
+}
+
+func (obj *MultinomialEstimator) Estimate(gamma ConstVector, p ThreadPool) error {
+  g := p.NewJobGroup()
+  x := obj.x
+
+  // initialize estimator
+  obj.Initialize(p)
+
+  // rescale gamma
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma != nil {
+    obj.gamma_max = math.Inf(-1)
+    for i := 0; i < gamma.Dim(); i++ {
+      if g := gamma.ConstAt(i).GetFloat64(); obj.gamma_max < g {
+        obj.gamma_max = g
+      }
+    }
+  }
+  // compute counts
+  //////////////////////////////////////////////////////////////////////////////
+  if gamma == nil {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], nil, p)
+    }); err != nil {
+      return err
+    }
+  } else {
+    if err := p.AddRangeJob(0, len(x), g, func(i int, p ThreadPool, erf func() error) error {
+      return obj.NewObservation(x[i], gamma.ConstAt(i), p)
+    }); err != nil {
+      return err
+    }
+  }
+  if err := p.Wait(g); err != nil {
+    return err
+  }
+  // update estimate
+  if err := obj.updateEstimate(); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (obj *MultinomialEstimator) EstimateOnData(x []ConstVector, gamma ConstVector, p ThreadPool) error {
+  if err := obj.SetData(x, len(x)); err != nil {
+    return err
+  }
+  return obj.Estimate(gamma, p)
+}
+
+func (obj *MultinomialEstimator) GetEstimate() (VectorPdf, error) {
+  if obj.sum_x != nil {
+    if err := obj.updateEstimate(); err != nil {
+      return nil, err
+    }
+  }
+  return obj.MultinomialDistribution, nil
+}
@@ -0,0 +1,56 @@
+/* Copyright (C) 2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vectorEstimator
+
+/* -------------------------------------------------------------------------- */
+
+//import   "fmt"
+import   "math"
+import   "testing"
+
+import   "github.com/pbenner/autodiff/statistics/vectorDistribution"
+
+import . "github.com/pbenner/autodiff"
+
+import . "github.com/pbenner/threadpool"
+
+/* -------------------------------------------------------------------------- */
+
+func TestMultinomial1(t *testing.T) {
+
+  x := []ConstVector{
+    NewDenseFloat64Vector([]float64{1, 3, 2}),
+    NewDenseFloat64Vector([]float64{2, 4, 0}),
+    NewDenseFloat64Vector([]float64{5, 1, 2}) }
+  r := []float64{8.0/20.0, 8.0/20.0, 4.0/20.0}
+
+  if estimator, err := NewMultinomialEstimator([]float64{1,1,1}, 6, 0.0); err != nil {
+    t.Error(err)
+  } else {
+    if err := estimator.EstimateOnData(x, nil, ThreadPool{}); err != nil {
+      t.Fatal(err)
+    }
+    d, _ := estimator.GetEstimate()
+    theta := d.(*vectorDistribution.MultinomialDistribution).Theta
+
+    for i := 0; i < 3; i++ {
+      if math.Abs(math.Exp(theta.At(i).GetFloat64()) - r[i]) > 1e-8 {
+        t.Error("test failed")
+      }
+    }
+  }
+}
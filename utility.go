@@ -71,6 +71,46 @@ func isGzip(filename string) (bool, error) {
 
 /* -------------------------------------------------------------------------- */
 
+type sortIntConstComplex128 struct {
+  a []int
+  b []complex128
+}
+
+func (obj sortIntConstComplex128) Len() int {
+  return len(obj.a)
+}
+
+func (obj sortIntConstComplex128) Swap(i, j int) {
+  obj.a[i], obj.a[j] = obj.a[j], obj.a[i]
+  obj.b[i], obj.b[j] = obj.b[j], obj.b[i]
+}
+
+func (obj sortIntConstComplex128) Less(i, j int) bool {
+  return obj.a[i] < obj.a[j]
+}
+
+/* -------------------------------------------------------------------------- */
+
+type sortIntConstComplex64 struct {
+  a []int
+  b []complex64
+}
+
+func (obj sortIntConstComplex64) Len() int {
+  return len(obj.a)
+}
+
+func (obj sortIntConstComplex64) Swap(i, j int) {
+  obj.a[i], obj.a[j] = obj.a[j], obj.a[i]
+  obj.b[i], obj.b[j] = obj.b[j], obj.b[i]
+}
+
+func (obj sortIntConstComplex64) Less(i, j int) bool {
+  return obj.a[i] < obj.a[j]
+}
+
+/* -------------------------------------------------------------------------- */
+
 type sortIntConstFloat64 struct {
   a []int
   b []float64
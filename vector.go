@@ -172,10 +172,22 @@ func NullDenseVector(t ScalarType, length int) Vector {
     return NullDenseFloat32Vector(length)
   case Float64Type:
     return NullDenseFloat64Vector(length)
+  case Complex64Type:
+    return NullDenseComplex64Vector(length)
+  case Complex128Type:
+    return NullDenseComplex128Vector(length)
   case Real32Type:
     return NullDenseReal32Vector(length)
   case Real64Type:
     return NullDenseReal64Vector(length)
+  case ReverseReal32Type:
+    return NullDenseReverseReal32Vector(length)
+  case ReverseReal64Type:
+    return NullDenseReverseReal64Vector(length)
+  case TaylorReal32Type:
+    return NullDenseTaylorReal32Vector(length)
+  case TaylorReal64Type:
+    return NullDenseTaylorReal64Vector(length)
   default:
     panic("unknown type")
   }
@@ -197,10 +209,22 @@ func AsDenseVector(t ScalarType, v ConstVector) Vector {
     return AsDenseFloat32Vector(v)
   case Float64Type:
     return AsDenseFloat64Vector(v)
+  case Complex64Type:
+    return AsDenseComplex64Vector(v)
+  case Complex128Type:
+    return AsDenseComplex128Vector(v)
   case Real32Type:
     return AsDenseReal32Vector(v)
   case Real64Type:
     return AsDenseReal64Vector(v)
+  case ReverseReal32Type:
+    return AsDenseReverseReal32Vector(v)
+  case ReverseReal64Type:
+    return AsDenseReverseReal64Vector(v)
+  case TaylorReal32Type:
+    return AsDenseTaylorReal32Vector(v)
+  case TaylorReal64Type:
+    return AsDenseTaylorReal64Vector(v)
   default:
     panic("unknown type")
   }
@@ -212,6 +236,14 @@ func NullDenseMagicVector(t ScalarType, length int) MagicVector {
     return NullDenseReal32Vector(length)
   case Real64Type:
     return NullDenseReal64Vector(length)
+  case ReverseReal32Type:
+    return NullDenseReverseReal32Vector(length)
+  case ReverseReal64Type:
+    return NullDenseReverseReal64Vector(length)
+  case TaylorReal32Type:
+    return NullDenseTaylorReal32Vector(length)
+  case TaylorReal64Type:
+    return NullDenseTaylorReal64Vector(length)
   default:
     panic("unknown type")
   }
@@ -223,6 +255,14 @@ func AsDenseMagicVector(t ScalarType, v ConstVector) MagicVector {
     return AsDenseReal32Vector(v)
   case Real64Type:
     return AsDenseReal64Vector(v)
+  case ReverseReal32Type:
+    return AsDenseReverseReal32Vector(v)
+  case ReverseReal64Type:
+    return AsDenseReverseReal64Vector(v)
+  case TaylorReal32Type:
+    return AsDenseTaylorReal32Vector(v)
+  case TaylorReal64Type:
+    return AsDenseTaylorReal64Vector(v)
   default:
     panic("unknown type")
   }
@@ -244,6 +284,10 @@ func NullSparseVector(t ScalarType, length int) Vector {
     return NullSparseFloat32Vector(length)
   case Float64Type:
     return NullSparseFloat64Vector(length)
+  case Complex64Type:
+    return NullSparseComplex64Vector(length)
+  case Complex128Type:
+    return NullSparseComplex128Vector(length)
   case Real32Type:
     return NullSparseReal32Vector(length)
   case Real64Type:
@@ -269,6 +313,10 @@ func AsSparseVector(t ScalarType, v ConstVector) Vector {
     return AsSparseFloat32Vector(v)
   case Float64Type:
     return AsSparseFloat64Vector(v)
+  case Complex64Type:
+    return AsSparseComplex64Vector(v)
+  case Complex128Type:
+    return AsSparseComplex128Vector(v)
   case Real32Type:
     return AsSparseReal32Vector(v)
   case Real64Type:
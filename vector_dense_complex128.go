@@ -0,0 +1,474 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bufio"
+import "bytes"
+import "compress/gzip"
+import "encoding/json"
+import "io"
+import "os"
+import "sort"
+import "strconv"
+import "strings"
+/* -------------------------------------------------------------------------- */
+type DenseComplex128Vector []complex128
+/* constructors
+ * -------------------------------------------------------------------------- */
+func NewDenseComplex128Vector(values []complex128) DenseComplex128Vector {
+  return DenseComplex128Vector(values)
+}
+func NullDenseComplex128Vector(n int) DenseComplex128Vector {
+  return DenseComplex128Vector(make([]complex128, n))
+}
+// Convert vector type.
+func AsDenseComplex128Vector(v ConstVector) DenseComplex128Vector {
+  switch v_ := v.(type) {
+  case DenseComplex128Vector:
+    return v_.Clone()
+  }
+  values := make([]complex128, v.Dim())
+  for it := v.ConstIterator(); it.Ok(); it.Next() {
+    values[it.Index()] = it.GetConst().GetComplex128()
+  }
+  return NewDenseComplex128Vector(values)
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) Clone() DenseComplex128Vector {
+  r := make([]complex128, v.Dim())
+  copy(r, v)
+  return r
+}
+/* native vector methods
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) AT(i int) Complex128 {
+  return Complex128{&v[i]}
+}
+func (v DenseComplex128Vector) APPEND(w DenseComplex128Vector) DenseComplex128Vector {
+  return append(v, w...)
+}
+func (v DenseComplex128Vector) ToDenseComplex128Matrix(n, m int) *DenseComplex128Matrix {
+  if n*m != len(v) {
+    panic("Matrix dimension does not fit input vector!")
+  }
+  matrix := DenseComplex128Matrix{}
+  matrix.values = v
+  matrix.rows = n
+  matrix.cols = m
+  matrix.rowOffset = 0
+  matrix.rowMax = n
+  matrix.colOffset = 0
+  matrix.colMax = m
+  return &matrix
+}
+/* vector interface
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) CloneVector() Vector {
+  return v.Clone()
+}
+func (v DenseComplex128Vector) At(i int) Scalar {
+  return v.AT(i)
+}
+func (v DenseComplex128Vector) Set(w ConstVector) {
+  if v.Dim() != w.Dim() {
+    panic("Set(): Vector dimensions do not match!")
+  }
+  for i := 0; i < w.Dim(); i++ {
+    v[i] = w.ConstAt(i).GetComplex128()
+  }
+}
+func (v DenseComplex128Vector) Reset() {
+  for i := 0; i < len(v); i++ {
+    v[i] = 0.0
+  }
+}
+func (v DenseComplex128Vector) ReverseOrder() {
+  n := len(v)
+  for i := 0; i < n/2; i++ {
+    v[i], v[n-1-i] = v[n-1-i], v[i]
+  }
+}
+func (v DenseComplex128Vector) Slice(i, j int) Vector {
+  return v[i:j]
+}
+func (v DenseComplex128Vector) Swap(i, j int) {
+  v[i], v[j] = v[j], v[i]
+}
+func (v DenseComplex128Vector) AppendScalar(scalars ...Scalar) Vector {
+  for _, scalar := range scalars {
+    v = append(v, scalar.GetComplex128())
+  }
+  return v
+}
+func (v DenseComplex128Vector) AppendVector(w Vector) Vector {
+  for i := 0; i < w.Dim(); i++ {
+    v = append(v, w.ConstAt(i).GetComplex128())
+  }
+  return v
+}
+func (v DenseComplex128Vector) AsMatrix(n, m int) Matrix {
+  return v.ToDenseComplex128Matrix(n, m)
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) CloneConstVector() ConstVector {
+  return v.Clone()
+}
+func (v DenseComplex128Vector) Dim() int {
+  return len(v)
+}
+func (v DenseComplex128Vector) Int8At(i int) int8 {
+  return int8(real(v[i]))
+}
+func (v DenseComplex128Vector) Int16At(i int) int16 {
+  return int16(real(v[i]))
+}
+func (v DenseComplex128Vector) Int32At(i int) int32 {
+  return int32(real(v[i]))
+}
+func (v DenseComplex128Vector) Int64At(i int) int64 {
+  return int64(real(v[i]))
+}
+func (v DenseComplex128Vector) IntAt(i int) int {
+  return int(real(v[i]))
+}
+func (v DenseComplex128Vector) Float32At(i int) float32 {
+  return float32(real(v[i]))
+}
+func (v DenseComplex128Vector) Float64At(i int) float64 {
+  return float64(real(v[i]))
+}
+func (v DenseComplex128Vector) ConstAt(i int) ConstScalar {
+  return Complex128{&v[i]}
+}
+func (v DenseComplex128Vector) ConstSlice(i, j int) ConstVector {
+  return v[i:j]
+}
+func (v DenseComplex128Vector) AsConstMatrix(n, m int) ConstMatrix {
+  return v.ToDenseComplex128Matrix(n, m)
+}
+/* imlement ScalarContainer
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) Map(f func(Scalar)) {
+  for i := 0; i < len(v); i++ {
+    f(v.At(i))
+  }
+}
+func (v DenseComplex128Vector) MapSet(f func(ConstScalar) Scalar) {
+  for i := 0; i < len(v); i++ {
+    v[i] = f(v.ConstAt(i)).GetComplex128()
+  }
+}
+func (v DenseComplex128Vector) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  for i := 0; i < len(v); i++ {
+    r = f(r, v.ConstAt(i))
+  }
+  return r
+}
+func (v DenseComplex128Vector) ElementType() ScalarType {
+  return Complex128Type
+}
+/* permutations
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) Permute(pi []int) error {
+  if len(pi) != len(v) {
+    return fmt.Errorf("Permute(): permutation vector has invalid length!")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, len(v))
+  for i := 0; i < len(v); i++ {
+    if pi[i] < 0 || pi[i] >= len(v) || visited[pi[i]] {
+      return fmt.Errorf("Permute(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute vector by following the cycles of pi, such that element i is
+  // replaced by element pi[i]
+  for i := 0; i < len(v); i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      v[pi[j]], v[j] = v[j], v[pi[j]]
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+/* sorting
+ * -------------------------------------------------------------------------- */
+type sortDenseComplex128VectorByValue DenseComplex128Vector
+func (v sortDenseComplex128VectorByValue) Len() int { return len(v) }
+func (v sortDenseComplex128VectorByValue) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v sortDenseComplex128VectorByValue) Less(i, j int) bool { return real(v[i]) < real(v[j]) }
+func (v DenseComplex128Vector) Sort(reverse bool) {
+  if reverse {
+    sort.Sort(sort.Reverse(sortDenseComplex128VectorByValue(v)))
+  } else {
+    sort.Sort(sortDenseComplex128VectorByValue(v))
+  }
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString("[")
+  for i, _ := range v {
+    if i != 0 {
+      buffer.WriteString(", ")
+    }
+    buffer.WriteString(v.ConstAt(i).String())
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (v DenseComplex128Vector) Table() string {
+  var buffer bytes.Buffer
+  for i, _ := range v {
+    buffer.WriteString(v.ConstAt(i).String())
+    buffer.WriteString("\n")
+  }
+  return buffer.String()
+}
+func (v DenseComplex128Vector) Export(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  w := bufio.NewWriter(f)
+  defer w.Flush()
+  if _, err := fmt.Fprintf(w, "%s\n", v.Table()); err != nil {
+    return err
+  }
+  return nil
+}
+func (v *DenseComplex128Vector) Import(filename string) error {
+  var reader *bufio.Reader
+  // open file
+  f, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  isgzip, err := isGzip(filename)
+  if err != nil {
+    return err
+  }
+  // check if file is gzipped
+  if isgzip {
+    g, err := gzip.NewReader(f)
+    if err != nil {
+      return err
+    }
+    defer g.Close()
+    reader = bufio.NewReader(g)
+  } else {
+    reader = bufio.NewReader(f)
+  }
+  // reset vector
+  *v = DenseComplex128Vector{}
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    for i := 0; i < len(fields); i++ {
+      value, err := strconv.ParseFloat(fields[i], 64)
+      if err != nil {
+        return fmt.Errorf("invalid table")
+      }
+      *v = append(*v, complex128(complex(value, 0)))
+    }
+  }
+  return nil
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) MarshalJSON() ([]byte, error) {
+  r := complex128JsonSlice{}
+  r = complex128JsonSlice(v)
+  return json.MarshalIndent(r, "", "  ")
+}
+func (v *DenseComplex128Vector) UnmarshalJSON(data []byte) error {
+  r := complex128JsonSlice{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  *v = DenseComplex128Vector(r)
+  return nil
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (v DenseComplex128Vector) ConstIterator() VectorConstIterator {
+  return v.ITERATOR()
+}
+func (v DenseComplex128Vector) ConstIteratorFrom(i int) VectorConstIterator {
+  return v.ITERATOR_FROM(i)
+}
+func (v DenseComplex128Vector) Iterator() VectorIterator {
+  return v.ITERATOR()
+}
+func (v DenseComplex128Vector) IteratorFrom(i int) VectorIterator {
+  return v.ITERATOR_FROM(i)
+}
+func (v DenseComplex128Vector) JointIterator(b ConstVector) VectorJointIterator {
+  return v.JOINT_ITERATOR(b)
+}
+func (v DenseComplex128Vector) ConstJointIterator(b ConstVector) VectorConstJointIterator {
+  return v.JOINT_ITERATOR(b)
+}
+func (v DenseComplex128Vector) ITERATOR() *DenseComplex128VectorIterator {
+  r := DenseComplex128VectorIterator{v, -1}
+  r.Next()
+  return &r
+}
+func (v DenseComplex128Vector) ITERATOR_FROM(i int) *DenseComplex128VectorIterator {
+  r := DenseComplex128VectorIterator{v, i-1}
+  r.Next()
+  return &r
+}
+func (v DenseComplex128Vector) JOINT_ITERATOR(b ConstVector) *DenseComplex128VectorJointIterator {
+  r := DenseComplex128VectorJointIterator{}
+  r.it1 = v.ITERATOR()
+  r.it2 = b.ConstIterator()
+  r.idx = -1
+  r.Next()
+  return &r
+}
+/* const iterator
+ * -------------------------------------------------------------------------- */
+type DenseComplex128VectorIterator struct {
+  v DenseComplex128Vector
+  i int
+}
+func (obj *DenseComplex128VectorIterator) GetConst() ConstScalar {
+  return obj.GET()
+}
+func (obj *DenseComplex128VectorIterator) Get() Scalar {
+  return obj.GET()
+}
+func (obj *DenseComplex128VectorIterator) GET() Complex128 {
+  return Complex128{&obj.v[obj.i]}
+}
+func (obj *DenseComplex128VectorIterator) Ok() bool {
+  return obj.i < len(obj.v)
+}
+func (obj *DenseComplex128VectorIterator) Next() {
+  obj.i++
+}
+func (obj *DenseComplex128VectorIterator) Index() int {
+  return obj.i
+}
+func (obj *DenseComplex128VectorIterator) Clone() *DenseComplex128VectorIterator {
+  return &DenseComplex128VectorIterator{obj.v, obj.i}
+}
+func (obj *DenseComplex128VectorIterator) CloneIterator() VectorIterator {
+  return &DenseComplex128VectorIterator{obj.v, obj.i}
+}
+func (obj *DenseComplex128VectorIterator) CloneConstIterator() VectorConstIterator {
+  return &DenseComplex128VectorIterator{obj.v, obj.i}
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type DenseComplex128VectorJointIterator struct {
+  it1 *DenseComplex128VectorIterator
+  it2 VectorConstIterator
+  idx int
+  s1 Complex128
+  s2 ConstScalar
+}
+func (obj *DenseComplex128VectorJointIterator) Index() int {
+  return obj.idx
+}
+func (obj *DenseComplex128VectorJointIterator) Ok() bool {
+  return !(obj.s1.ptr == nil || obj.s1.GetComplex128() == 0.0) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == 0.0)
+}
+func (obj *DenseComplex128VectorJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1.ptr = nil
+  obj.s2 = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+}
+func (obj *DenseComplex128VectorJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *DenseComplex128VectorJointIterator) Get() (Scalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *DenseComplex128VectorJointIterator) GET() (Complex128, ConstScalar) {
+  return obj.s1, obj.s2
+}
+func (obj *DenseComplex128VectorJointIterator) Clone() *DenseComplex128VectorJointIterator {
+  r := DenseComplex128VectorJointIterator{}
+  r.it1 = obj.it1.Clone()
+  r.it2 = obj.it2.CloneConstIterator()
+  r.idx = obj.idx
+  r.s1 = obj.s1
+  r.s2 = obj.s2
+  return &r
+}
+func (obj *DenseComplex128VectorJointIterator) CloneJointIterator() VectorJointIterator {
+  return obj.Clone()
+}
+func (obj *DenseComplex128VectorJointIterator) CloneConstJointIterator() VectorConstJointIterator {
+  return obj.Clone()
+}
@@ -193,14 +193,24 @@ func (v DenseIntVector) Permute(pi []int) error {
   if len(pi) != len(v) {
     return fmt.Errorf("Permute(): permutation vector has invalid length!")
   }
-  // permute vector
+  // check that pi is a permutation
+  visited := make([]bool, len(v))
   for i := 0; i < len(v); i++ {
-    if pi[i] < 0 || pi[i] >= len(v) {
+    if pi[i] < 0 || pi[i] >= len(v) || visited[pi[i]] {
       return fmt.Errorf("Permute(): invalid permutation")
     }
-    if i != pi[i] && pi[i] > i {
-      // permute elements
-      v[pi[i]], v[i] = v[i], v[pi[i]]
+    visited[pi[i]] = true
+  }
+  // permute vector by following the cycles of pi, such that element i is
+  // replaced by element pi[i]
+  for i := 0; i < len(v); i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      v[pi[j]], v[j] = v[j], v[pi[j]]
+      visited[pi[j]] = false
     }
   }
   return nil
@@ -304,7 +314,7 @@ func (v *DenseIntVector) Import(filename string) error {
  * -------------------------------------------------------------------------- */
 func (v DenseIntVector) MarshalJSON() ([]byte, error) {
   r := []int{}
-  r = v
+  r = []int(v)
   return json.MarshalIndent(r, "", "  ")
 }
 func (v *DenseIntVector) UnmarshalJSON(data []byte) error {
@@ -312,7 +322,7 @@ func (v *DenseIntVector) UnmarshalJSON(data []byte) error {
   if err := json.Unmarshal(data, &r); err != nil {
     return err
   }
-  *v = r
+  *v = DenseIntVector(r)
   return nil
 }
 /* iterator methods
@@ -399,8 +409,8 @@ func (obj *DenseIntVectorJointIterator) Index() int {
   return obj.idx
 }
 func (obj *DenseIntVectorJointIterator) Ok() bool {
-  return !(obj.s1.ptr == nil || obj.s1.GetFloat64() == 0.0) ||
-         !(obj.s2 == nil || obj.s2.GetFloat64() == 0.0)
+  return !(obj.s1.ptr == nil || obj.s1.GetInt() == 0.0) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == 0.0)
 }
 func (obj *DenseIntVectorJointIterator) Next() {
   ok1 := obj.it1.Ok()
@@ -231,6 +231,10 @@ func (r DenseInt16Vector) MdotV(a ConstMatrix, b ConstVector) Vector {
   if r.AT(0) == b.ConstAt(0) {
     panic("result and argument must be different vectors")
   }
+  if a, ok := a.(compressedConstMatrix); ok {
+    a.mdotv(r, b)
+    return r
+  }
   t := 0.0
   for i := 0; i < n; i++ {
     r.AT(i).Reset()
@@ -275,6 +279,10 @@ func (r DenseInt16Vector) VdotM(a ConstVector, b ConstMatrix) Vector {
   if r.AT(0) == a.ConstAt(0) {
     panic("result and argument must be different vectors")
   }
+  if b, ok := b.(compressedConstMatrix); ok {
+    b.vdotm(r, a)
+    return r
+  }
   t := 0.0
   for i := 0; i < m; i++ {
     r.AT(i).Reset()
@@ -0,0 +1,628 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bufio"
+import "bytes"
+import "compress/gzip"
+import "encoding/json"
+import "io"
+import "os"
+import "sort"
+import "strconv"
+import "strings"
+/* vector type declaration
+ * -------------------------------------------------------------------------- */
+type DenseReverseReal64Vector []*ReverseReal64
+/* constructors
+ * -------------------------------------------------------------------------- */
+// Allocate a new vector. Scalars are set to the given values.
+func NewDenseReverseReal64Vector(values []float64) DenseReverseReal64Vector {
+  v := nilDenseReverseReal64Vector(len(values))
+  for i, _ := range values {
+    v[i] = NewReverseReal64(values[i])
+  }
+  return v
+}
+// Allocate a new vector. All scalars are set to zero.
+func NullDenseReverseReal64Vector(length int) DenseReverseReal64Vector {
+  v := nilDenseReverseReal64Vector(length)
+  if length > 0 {
+    for i := 0; i < length; i++ {
+      v[i] = NewReverseReal64(0.0)
+    }
+  }
+  return v
+}
+// Create a empty vector without allocating memory for the scalar variables.
+func nilDenseReverseReal64Vector(length int) DenseReverseReal64Vector {
+  return make(DenseReverseReal64Vector, length)
+}
+// Convert vector type.
+func AsDenseReverseReal64Vector(v ConstVector) DenseReverseReal64Vector {
+  switch v_ := v.(type) {
+  case DenseReverseReal64Vector:
+    return v_.Clone()
+  }
+  r := NullDenseReverseReal64Vector(v.Dim())
+  for i := 0; i < v.Dim(); i++ {
+    r.AT(i).Set(v.ConstAt(i))
+  }
+  return r
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+// Create a deep copy of the vector.
+func (v DenseReverseReal64Vector) Clone() DenseReverseReal64Vector {
+  result := make(DenseReverseReal64Vector, len(v))
+  for i, _ := range v {
+    result[i] = v[i].Clone()
+  }
+  return result
+}
+/* native vector methods
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) AT(i int) *ReverseReal64 {
+  return v[i]
+}
+func (v DenseReverseReal64Vector) SET(w DenseReverseReal64Vector) {
+  if v.Dim() != w.Dim() {
+    panic("Set(): Vector dimensions do not match!")
+  }
+  for i := 0; i < w.Dim(); i++ {
+    v[i].SET(w[i])
+  }
+}
+func (v DenseReverseReal64Vector) SLICE(i, j int) DenseReverseReal64Vector {
+  return v[i:j]
+}
+func (v DenseReverseReal64Vector) APPEND(w DenseReverseReal64Vector) DenseReverseReal64Vector {
+  return append(v, w...)
+}
+func (v DenseReverseReal64Vector) ToDenseReverseReal64Matrix(n, m int) *DenseReverseReal64Matrix {
+  if n*m != len(v) {
+    panic("Matrix dimension does not fit input vector!")
+  }
+  matrix := DenseReverseReal64Matrix{}
+  matrix.values = v
+  matrix.rows = n
+  matrix.cols = m
+  matrix.rowOffset = 0
+  matrix.rowMax = n
+  matrix.colOffset = 0
+  matrix.colMax = m
+  matrix.initTmp()
+  return &matrix
+}
+/* vector interface
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) CloneVector() Vector {
+  return v.Clone()
+}
+func (v DenseReverseReal64Vector) At(i int) Scalar {
+  return v.AT(i)
+}
+// Copy scalars from w into this vector. The lengths of both vectors must
+// match.
+func (v DenseReverseReal64Vector) Set(w ConstVector) {
+  if v.Dim() != w.Dim() {
+    panic("Set(): Vector dimensions do not match!")
+  }
+  for i := 0; i < w.Dim(); i++ {
+    v[i].Set(w.ConstAt(i))
+  }
+}
+func (v DenseReverseReal64Vector) Reset() {
+  for i := 0; i < len(v); i++ {
+    v[i].Reset()
+  }
+}
+func (v DenseReverseReal64Vector) ReverseOrder() {
+  n := len(v)
+  for i := 0; i < n/2; i++ {
+    v[i], v[n-1-i] = v[n-1-i], v[i]
+  }
+}
+func (v DenseReverseReal64Vector) Slice(i, j int) Vector {
+  return v[i:j]
+}
+func (v DenseReverseReal64Vector) Swap(i, j int) {
+  v[i], v[j] = v[j], v[i]
+}
+func (v DenseReverseReal64Vector) AppendScalar(scalars ...Scalar) Vector {
+  for _, scalar := range scalars {
+    switch s := scalar.(type) {
+    case *ReverseReal64:
+      v = append(v, s)
+    default:
+      v = append(v, s.ConvertScalar(ReverseReal64Type).(*ReverseReal64))
+    }
+  }
+  return v
+}
+func (v DenseReverseReal64Vector) AppendVector(w_ Vector) Vector {
+  switch w := w_.(type) {
+  case DenseReverseReal64Vector:
+    return append(v, w...)
+  default:
+    for i := 0; i < w.Dim(); i++ {
+      v = append(v, w.At(i).ConvertScalar(ReverseReal64Type).(*ReverseReal64))
+    }
+    return v
+  }
+}
+func (v DenseReverseReal64Vector) AsMatrix(n, m int) Matrix {
+  return v.ToDenseReverseReal64Matrix(n, m)
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) CloneConstVector() ConstVector {
+  return v.Clone()
+}
+func (v DenseReverseReal64Vector) Dim() int {
+  return len(v)
+}
+func (v DenseReverseReal64Vector) Int8At(i int) int8 {
+  return v[i].GetInt8()
+}
+func (v DenseReverseReal64Vector) Int16At(i int) int16 {
+  return v[i].GetInt16()
+}
+func (v DenseReverseReal64Vector) Int32At(i int) int32 {
+  return v[i].GetInt32()
+}
+func (v DenseReverseReal64Vector) Int64At(i int) int64 {
+  return v[i].GetInt64()
+}
+func (v DenseReverseReal64Vector) IntAt(i int) int {
+  return v[i].GetInt()
+}
+func (v DenseReverseReal64Vector) Float32At(i int) float32 {
+  return v[i].GetFloat32()
+}
+func (v DenseReverseReal64Vector) Float64At(i int) float64 {
+  return v[i].GetFloat64()
+}
+func (v DenseReverseReal64Vector) ConstAt(i int) ConstScalar {
+  return v[i]
+}
+func (v DenseReverseReal64Vector) ConstSlice(i, j int) ConstVector {
+  return v[i:j]
+}
+func (v DenseReverseReal64Vector) AsConstMatrix(n, m int) ConstMatrix {
+  return v.ToDenseReverseReal64Matrix(n, m)
+}
+/* magic interface
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) CloneMagicVector() MagicVector {
+  return v.Clone()
+}
+func (v DenseReverseReal64Vector) MagicAt(i int) MagicScalar {
+  return v.AT(i)
+}
+func (v DenseReverseReal64Vector) MagicSlice(i, j int) MagicVector {
+  return v[i:j]
+}
+func (v DenseReverseReal64Vector) ResetDerivatives() {
+  for i := 0; i < len(v); i++ {
+    v[i].ResetDerivatives()
+  }
+}
+func (v DenseReverseReal64Vector) AppendMagicScalar(scalars ...MagicScalar) MagicVector {
+  for _, scalar := range scalars {
+    switch s := scalar.(type) {
+    case *ReverseReal64:
+      v = append(v, s)
+    default:
+      v = append(v, s.ConvertMagicScalar(ReverseReal64Type).(*ReverseReal64))
+    }
+  }
+  return v
+}
+func (v DenseReverseReal64Vector) AppendMagicVector(w_ MagicVector) MagicVector {
+  switch w := w_.(type) {
+  case DenseReverseReal64Vector:
+    return append(v, w...)
+  default:
+    for i := 0; i < w.Dim(); i++ {
+      v = append(v, w.MagicAt(i).ConvertMagicScalar(ReverseReal64Type).(*ReverseReal64))
+    }
+    return v
+  }
+}
+func (v DenseReverseReal64Vector) AsMagicMatrix(n, m int) MagicMatrix {
+  return v.ToDenseReverseReal64Matrix(n, m)
+}
+/* imlement MagicScalarContainer
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) Map(f func(Scalar)) {
+  for i := 0; i < len(v); i++ {
+    f( v[i])
+  }
+}
+func (v DenseReverseReal64Vector) MapSet(f func(ConstScalar) Scalar) {
+  for i := 0; i < len(v); i++ {
+    v[i].Set(f(v.ConstAt(i)))
+  }
+}
+func (v DenseReverseReal64Vector) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  for i := 0; i < len(v); i++ {
+    r = f(r, v.ConstAt(i))
+  }
+  return r
+}
+func (v DenseReverseReal64Vector) ElementType() ScalarType {
+  return ReverseReal64Type
+}
+// Treat all elements as variables for automatic differentiation. This method should only be called on a single vector or matrix. If multiple vectors should be treated as variables, then a single vector must be allocated first and sliced after calling this method.
+func (v DenseReverseReal64Vector) Variables(order int) error {
+  for i, _ := range v {
+    if err := v[i].SetVariable(i, len(v), order); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+/* permutations
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) Permute(pi []int) error {
+  if len(pi) != len(v) {
+    return fmt.Errorf("Permute(): permutation vector has invalid length!")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, len(v))
+  for i := 0; i < len(v); i++ {
+    if pi[i] < 0 || pi[i] >= len(v) || visited[pi[i]] {
+      return fmt.Errorf("Permute(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute vector by following the cycles of pi, such that element i is
+  // replaced by element pi[i]
+  for i := 0; i < len(v); i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      v[pi[j]], v[j] = v[j], v[pi[j]]
+      visited[pi[j]] = false
+    }
+  }
+  return nil
+}
+/* sorting
+ * -------------------------------------------------------------------------- */
+type sortDenseReverseReal64VectorByValue DenseReverseReal64Vector
+func (v sortDenseReverseReal64VectorByValue) Len() int { return len(v) }
+func (v sortDenseReverseReal64VectorByValue) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v sortDenseReverseReal64VectorByValue) Less(i, j int) bool { return v[i].GetFloat64() < v[j].GetFloat64() }
+func (v DenseReverseReal64Vector) Sort(reverse bool) {
+  if reverse {
+    sort.Sort(sort.Reverse(sortDenseReverseReal64VectorByValue(v)))
+  } else {
+    sort.Sort(sortDenseReverseReal64VectorByValue(v))
+  }
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (v DenseReverseReal64Vector) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString("[")
+  for i, _ := range v {
+    if i != 0 {
+      buffer.WriteString(", ")
+    }
+    buffer.WriteString(v[i].String())
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (v DenseReverseReal64Vector) Table() string {
+  var buffer bytes.Buffer
+  for i, _ := range v {
+    buffer.WriteString(v[i].String())
+    buffer.WriteString("\n")
+  }
+  return buffer.String()
+}
+func (v DenseReverseReal64Vector) Export(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  w := bufio.NewWriter(f)
+  defer w.Flush()
+  if _, err := fmt.Fprintf(w, "%s\n", v.Table()); err != nil {
+    return err
+  }
+  return nil
+}
+func (v *DenseReverseReal64Vector) Import(filename string) error {
+  var reader *bufio.Reader
+  // open file
+  f, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  isgzip, err := isGzip(filename)
+  if err != nil {
+    return err
+  }
+  // check if file is gzipped
+  if isgzip {
+    g, err := gzip.NewReader(f)
+    if err != nil {
+      return err
+    }
+    defer g.Close()
+    reader = bufio.NewReader(g)
+  } else {
+    reader = bufio.NewReader(f)
+  }
+  // reset vector
+  *v = DenseReverseReal64Vector{}
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    for i := 0; i < len(fields); i++ {
+      value, err := strconv.ParseFloat(fields[i], 64)
+      if err != nil {
+        return fmt.Errorf("invalid table")
+      }
+      *v = append(*v, NewReverseReal64(float64(value)))
+    }
+  }
+  return nil
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj DenseReverseReal64Vector) MarshalJSON() ([]byte, error) {
+  r := []*ReverseReal64{}
+  r = obj
+  return json.MarshalIndent(r, "", "  ")
+}
+func (obj *DenseReverseReal64Vector) UnmarshalJSON(data []byte) error {
+  r := []*ReverseReal64{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  *obj = nilDenseReverseReal64Vector(len(r))
+  for i := 0; i < len(r); i++ {
+    (*obj)[i] = r[i]
+  }
+  return nil
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj DenseReverseReal64Vector) ConstIterator() VectorConstIterator {
+  return obj.ITERATOR()
+}
+func (obj DenseReverseReal64Vector) ConstIteratorFrom(i int) VectorConstIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj DenseReverseReal64Vector) MagicIterator() VectorMagicIterator {
+  return obj.ITERATOR()
+}
+func (obj DenseReverseReal64Vector) MagicIteratorFrom(i int) VectorMagicIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj DenseReverseReal64Vector) Iterator() VectorIterator {
+  return obj.ITERATOR()
+}
+func (obj DenseReverseReal64Vector) IteratorFrom(i int) VectorIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj DenseReverseReal64Vector) JointIterator(b ConstVector) VectorJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj DenseReverseReal64Vector) ConstJointIterator(b ConstVector) VectorConstJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj DenseReverseReal64Vector) ITERATOR() *DenseReverseReal64VectorIterator {
+  r := DenseReverseReal64VectorIterator{obj, -1}
+  r.Next()
+  return &r
+}
+func (obj DenseReverseReal64Vector) ITERATOR_FROM(i int) *DenseReverseReal64VectorIterator {
+  r := DenseReverseReal64VectorIterator{obj, i-1}
+  r.Next()
+  return &r
+}
+func (obj DenseReverseReal64Vector) JOINT_ITERATOR(b ConstVector) *DenseReverseReal64VectorJointIterator {
+  r := DenseReverseReal64VectorJointIterator{obj.ITERATOR(), b.ConstIterator(), -1, nil, nil}
+  r.Next()
+  return &r
+}
+func (obj DenseReverseReal64Vector) JOINT_ITERATOR_(b DenseReverseReal64Vector) *DenseReverseReal64VectorJointIterator_ {
+  r := DenseReverseReal64VectorJointIterator_{obj.ITERATOR(), b.ITERATOR(), -1, nil, nil}
+  r.Next()
+  return &r
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+type DenseReverseReal64VectorIterator struct {
+  v DenseReverseReal64Vector
+  i int
+}
+func (obj *DenseReverseReal64VectorIterator) GetConst() ConstScalar {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorIterator) GetMagic() MagicScalar {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorIterator) Get() Scalar {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorIterator) GET() *ReverseReal64 {
+  return obj.v[obj.i]
+}
+func (obj *DenseReverseReal64VectorIterator) Ok() bool {
+  return obj.i < len(obj.v)
+}
+func (obj *DenseReverseReal64VectorIterator) Next() {
+  obj.i++
+}
+func (obj *DenseReverseReal64VectorIterator) Index() int {
+  return obj.i
+}
+func (obj *DenseReverseReal64VectorIterator) Clone() *DenseReverseReal64VectorIterator {
+  return &DenseReverseReal64VectorIterator{obj.v, obj.i}
+}
+func (obj *DenseReverseReal64VectorIterator) CloneConstIterator() VectorConstIterator {
+  return &DenseReverseReal64VectorIterator{obj.v, obj.i}
+}
+func (obj *DenseReverseReal64VectorIterator) CloneMagicIterator() VectorMagicIterator {
+  return &DenseReverseReal64VectorIterator{obj.v, obj.i}
+}
+func (obj *DenseReverseReal64VectorIterator) CloneIterator() VectorIterator {
+  return &DenseReverseReal64VectorIterator{obj.v, obj.i}
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type DenseReverseReal64VectorJointIterator struct {
+  it1 *DenseReverseReal64VectorIterator
+  it2 VectorConstIterator
+  idx int
+  s1 *ReverseReal64
+  s2 ConstScalar
+}
+func (obj *DenseReverseReal64VectorJointIterator) Index() int {
+  return obj.idx
+}
+func (obj *DenseReverseReal64VectorJointIterator) Ok() bool {
+  return !(obj.s1 == nil || obj.s1.GetFloat64() == 0.0) ||
+         !(obj.s2 == nil || obj.s2.GetFloat64() == 0.0)
+}
+func (obj *DenseReverseReal64VectorJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1 = nil
+  obj.s2 = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1 = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if obj.s1 != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstFloat64(0.0)
+  }
+}
+func (obj *DenseReverseReal64VectorJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorJointIterator) GetMagic() (MagicScalar, ConstScalar) {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorJointIterator) Get() (Scalar, ConstScalar) {
+  return obj.GET()
+}
+func (obj *DenseReverseReal64VectorJointIterator) GET() (*ReverseReal64, ConstScalar) {
+  if obj.s1 == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *DenseReverseReal64VectorJointIterator) Clone() *DenseReverseReal64VectorJointIterator {
+  r := DenseReverseReal64VectorJointIterator{}
+  r.it1 = obj.it1.Clone()
+  r.it2 = obj.it2.CloneConstIterator()
+  r.idx = obj.idx
+  r.s1 = obj.s1
+  r.s2 = obj.s2
+  return &r
+}
+func (obj *DenseReverseReal64VectorJointIterator) CloneConstJointIterator() VectorConstJointIterator {
+  return obj.Clone()
+}
+func (obj *DenseReverseReal64VectorJointIterator) CloneJointIterator() VectorJointIterator {
+  return obj.Clone()
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type DenseReverseReal64VectorJointIterator_ struct {
+  it1 *DenseReverseReal64VectorIterator
+  it2 *DenseReverseReal64VectorIterator
+  idx int
+  s1 *ReverseReal64
+  s2 *ReverseReal64
+}
+func (obj *DenseReverseReal64VectorJointIterator_) Index() int {
+  return obj.idx
+}
+func (obj *DenseReverseReal64VectorJointIterator_) Ok() bool {
+  return obj.s1 != nil || obj.s2 != nil
+}
+func (obj *DenseReverseReal64VectorJointIterator_) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1 = nil
+  obj.s2 = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1 = nil
+      obj.s2 = obj.it2.GET()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GET()
+    }
+  }
+  if obj.s1 != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  }
+}
+func (obj *DenseReverseReal64VectorJointIterator_) GET() (*ReverseReal64, *ReverseReal64) {
+  return obj.s1, obj.s2
+}
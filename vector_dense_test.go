@@ -52,6 +52,30 @@ func TestVectorSort(t *testing.T) {
   }
 }
 
+func TestVectorPermute(t *testing.T) {
+
+  v1 := NewDenseFloat64Vector([]float64{1,2,3,4})
+  v2 := NewSparseFloat64Vector([]int{0,2}, []float64{1,3}, 4)
+  // pi is a cycle and not a product of disjoint transpositions
+  pi := []int{3,0,1,2}
+
+  if err := v1.Permute(pi); err != nil {
+    t.Error(err)
+  }
+  if !v1.Equals(NewDenseFloat64Vector([]float64{4,1,2,3}), 1e-12) {
+    t.Error("test failed")
+  }
+  if err := v2.Permute(pi); err != nil {
+    t.Error(err)
+  }
+  if !v2.Equals(NewDenseFloat64Vector([]float64{0,1,0,3}), 1e-12) {
+    t.Error("test failed")
+  }
+  if err := v1.Permute([]int{0,0,1,2}); err == nil {
+    t.Error("test failed")
+  }
+}
+
 func TestVectorAsMatrix(t *testing.T) {
 
   v := NewDenseFloat64Vector([]float64{1,2,3,4,5,6})
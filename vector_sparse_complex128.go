@@ -0,0 +1,978 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bytes"
+import "bufio"
+import "compress/gzip"
+import "encoding/json"
+import "errors"
+import "io"
+import "os"
+import "sort"
+import "strconv"
+import "strings"
+/* vector type declaration
+ * -------------------------------------------------------------------------- */
+type SparseComplex128Vector struct {
+  vectorSparseIndex
+  values map[int]Complex128
+  n int
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+// Allocate a new vector. Scalars are set to the given values.
+func NewSparseComplex128Vector(indices []int, values []complex128, n int) *SparseComplex128Vector {
+  if len(indices) != len(values) {
+    panic("number of indices does not match number of values")
+  }
+  r := nilSparseComplex128Vector(n)
+  for i, k := range indices {
+    if k >= n {
+      panic("index larger than vector dimension")
+    }
+    if _, ok := r.values[k]; ok {
+      panic("index appeared multiple times")
+    } else {
+      if values[i] != 0.0 {
+        r.values[k] = NewComplex128(values[i])
+        r.indexInsert(k)
+      }
+    }
+  }
+  return r
+}
+// Allocate a new vector. All scalars are set to zero.
+func NullSparseComplex128Vector(length int) *SparseComplex128Vector {
+  v := nilSparseComplex128Vector(length)
+  return v
+}
+// Create a empty vector without allocating memory for the scalar variables.
+func nilSparseComplex128Vector(length int) *SparseComplex128Vector {
+  return &SparseComplex128Vector{values: make(map[int]Complex128), n: length}
+}
+// Convert vector type.
+func AsSparseComplex128Vector(v ConstVector) *SparseComplex128Vector {
+  switch v_ := v.(type) {
+  case *SparseComplex128Vector:
+    return v_.Clone()
+  }
+  r := NullSparseComplex128Vector(v.Dim())
+  for it := v.ConstIterator(); it.Ok(); it.Next() {
+    r.AT(it.Index()).Set(it.GetConst())
+  }
+  return r
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+// Create a deep copy of the vector.
+func (obj *SparseComplex128Vector) Clone() *SparseComplex128Vector {
+  r := nilSparseComplex128Vector(obj.n)
+  for i, v := range obj.values {
+    r.values[i] = v.Clone()
+  }
+  r.vectorSparseIndex = obj.indexClone()
+  return r
+}
+/* native vector methods
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) AT(i int) Complex128 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v
+  } else {
+    v = NullComplex128()
+    obj.values[i] = v
+    obj.indexInsert(i)
+    return v
+  }
+}
+func (obj *SparseComplex128Vector) AT_(i int) Complex128 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v
+  } else {
+    return Complex128{}
+  }
+}
+func (obj *SparseComplex128Vector) SET(x *SparseComplex128Vector) {
+  if obj == x {
+    return
+  }
+  if obj.Dim() != x.Dim() {
+    panic("Set(): Vector dimensions do not match!")
+  }
+  for it := obj.JOINT_ITERATOR_(x); it.Ok(); it.Next() {
+    s1, s2 := it.GET()
+    switch {
+    case s1.ptr != nil && s2.ptr != nil: s1.SET(s2)
+    case s1.ptr != nil : s1.SetComplex128(0)
+    default : obj.AT(it.Index()).SET(s2)
+    }
+  }
+}
+func (obj *SparseComplex128Vector) SLICE(i, j int) *SparseComplex128Vector {
+  r := nilSparseComplex128Vector(j-i)
+  for it := obj.indexIteratorFrom(i); it.Ok(); it.Next() {
+    if it.Get() >= j {
+      break
+    }
+    k := it.Get()
+    r.values[k-i] = obj.values[k]
+    r.indexInsert(k-i)
+  }
+  return r
+}
+func (obj *SparseComplex128Vector) APPEND(w *SparseComplex128Vector) *SparseComplex128Vector {
+  r := obj.Clone()
+  r.n = obj.n + w.Dim()
+  for it := w.ITERATOR(); it.Ok(); it.Next() {
+    i := obj.n+it.Index()
+    r.values[i] = it.GET()
+    r.indexInsert(i)
+  }
+  return r
+}
+func (obj *SparseComplex128Vector) ToSparseComplex128Matrix(n, m int) *SparseComplex128Matrix {
+  if n*m != obj.n {
+    panic("Matrix dimension does not fit input vector!")
+  }
+  v := NullSparseComplex128Vector(obj.n)
+  for it := obj.ITERATOR(); it.Ok(); it.Next() {
+    v.At(it.Index()).Set(it.GET())
+  }
+  matrix := SparseComplex128Matrix{}
+  matrix.values = v
+  matrix.rows = n
+  matrix.cols = m
+  matrix.rowOffset = 0
+  matrix.rowMax = n
+  matrix.colOffset = 0
+  matrix.colMax = m
+  matrix.initTmp()
+  return &matrix
+}
+/* vector interface
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) CloneVector() Vector {
+  return obj.Clone()
+}
+func (obj *SparseComplex128Vector) At(i int) Scalar {
+  return obj.AT(i)
+}
+// Copy scalars from w into this vector. The lengths of both vectors must
+// match.
+func (obj *SparseComplex128Vector) Set(x ConstVector) {
+  if obj == x {
+    return
+  }
+  if obj.Dim() != x.Dim() {
+    panic("Set(): Vector dimensions do not match!")
+  }
+  for it := obj.JOINT_ITERATOR(x); it.Ok(); it.Next() {
+    s1, s2 := it.GET()
+    switch {
+    case s1.ptr != nil && s2 != nil: s1.Set(s2)
+    case s1.ptr != nil : s1.SetComplex128(0)
+    default : obj.AT(it.Index()).Set(s2)
+    }
+  }
+}
+func (obj *SparseComplex128Vector) Reset() {
+  for _, v := range obj.values {
+    v.Reset()
+  }
+}
+func (obj *SparseComplex128Vector) ReverseOrder() {
+  n := obj.Dim()
+  values := make(map[int]Complex128)
+  index := vectorSparseIndex{}
+  for i, s := range obj.values {
+    j := n-i-1
+    values[j] = s
+    index.indexInsert(j)
+  }
+  obj.values = values
+  obj.vectorSparseIndex = index
+}
+func (obj *SparseComplex128Vector) Slice(i, j int) Vector {
+  return obj.SLICE(i, j)
+}
+func (obj *SparseComplex128Vector) Swap(i, j int) {
+  obj.values[i], obj.values[j] = obj.values[j], obj.values[i]
+}
+func (obj *SparseComplex128Vector) AppendScalar(scalars ...Scalar) Vector {
+  r := obj.Clone()
+  r.n = obj.n + len(scalars)
+  for i, scalar := range scalars {
+    switch s := scalar.(type) {
+    case Complex128:
+      r.values[obj.n+i] = s
+    default:
+      r.values[obj.n+i] = s.ConvertScalar(Complex128Type).(Complex128)
+    }
+    r.indexInsert(obj.n+i)
+  }
+  return r
+}
+func (obj *SparseComplex128Vector) AppendVector(w_ Vector) Vector {
+  switch w := w_.(type) {
+  case *SparseComplex128Vector:
+    return obj.APPEND(w)
+  default:
+    r := obj.Clone()
+    r.n = obj.n + w.Dim()
+    for it := w.Iterator(); it.Ok(); it.Next() {
+      r.values[obj.n+it.Index()] = it.Get().ConvertScalar(Complex128Type).(Complex128)
+      r.indexInsert(obj.n+it.Index())
+    }
+    return r
+  }
+}
+func (v *SparseComplex128Vector) AsMatrix(n, m int) Matrix {
+  return v.ToSparseComplex128Matrix(n, m)
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) CloneConstVector() ConstVector {
+  return obj.Clone()
+}
+func (obj *SparseComplex128Vector) Dim() int {
+  return obj.n
+}
+func (obj *SparseComplex128Vector) Int8At(i int) int8 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetInt8()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) Int16At(i int) int16 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetInt16()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) Int32At(i int) int32 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetInt32()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) Int64At(i int) int64 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetInt64()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) IntAt(i int) int {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetInt()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) Float32At(i int) float32 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetFloat32()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) Float64At(i int) float64 {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v.GetFloat64()
+  } else {
+    return 0
+  }
+}
+func (obj *SparseComplex128Vector) ConstAt(i int) ConstScalar {
+  if i < 0 || i >= obj.Dim() {
+    panic("index out of bounds")
+  }
+  if v, ok := obj.values[i]; ok {
+    return v
+  } else {
+    return ConstComplex128(0.0)
+  }
+}
+func (obj *SparseComplex128Vector) ConstSlice(i, j int) ConstVector {
+  return obj.SLICE(i, j)
+}
+func (v *SparseComplex128Vector) AsConstMatrix(n, m int) ConstMatrix {
+  return v.ToSparseComplex128Matrix(n, m)
+}
+/* imlement ScalarContainer
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) Map(f func(Scalar)) {
+  for _, v := range obj.values {
+    f(v)
+  }
+}
+func (obj *SparseComplex128Vector) MapSet(f func(ConstScalar) Scalar) {
+  for _, v := range obj.values {
+    v.Set(f(v))
+  }
+}
+func (obj *SparseComplex128Vector) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  for _, v := range obj.values {
+    r = f(r, v)
+  }
+  return r
+}
+func (obj *SparseComplex128Vector) ElementType() ScalarType {
+  return Complex128Type
+}
+/* permutations
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) Permute(pi []int) error {
+  if len(pi) != obj.n {
+    return errors.New("Permute(): permutation vector has invalid length!")
+  }
+  // check that pi is a permutation
+  visited := make([]bool, obj.n)
+  for i := 0; i < obj.n; i++ {
+    if pi[i] < 0 || pi[i] >= obj.n || visited[pi[i]] {
+      return errors.New("Permute(): invalid permutation")
+    }
+    visited[pi[i]] = true
+  }
+  // permute vector by following the cycles of pi, such that element i is
+  // replaced by element pi[i]
+  for i := 0; i < obj.n; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
+      // permute elements
+      _, ok1 := obj.values[j]
+      _, ok2 := obj.values[pi[j]]
+      if ok1 && ok2 {
+        obj.values[pi[j]], obj.values[j] = obj.values[j], obj.values[pi[j]]
+      } else
+      if ok1 {
+        obj.values[pi[j]] = obj.values[j]
+        delete(obj.values, j)
+      } else
+      if ok2 {
+        obj.values[j] = obj.values[pi[j]]
+        delete(obj.values, pi[j])
+      }
+      visited[pi[j]] = false
+    }
+  }
+  obj.vectorSparseIndex = vectorSparseIndex{}
+  for i := 0; i < len(pi); i++ {
+    obj.indexInsert(pi[i])
+  }
+  return nil
+}
+/* sorting
+ * -------------------------------------------------------------------------- */
+type sortSparseComplex128VectorByValue struct {
+  Value []Complex128
+}
+func (obj sortSparseComplex128VectorByValue) Len() int {
+  return len(obj.Value)
+}
+func (obj sortSparseComplex128VectorByValue) Swap(i, j int) {
+  obj.Value[i], obj.Value[j] = obj.Value[j], obj.Value[i]
+}
+func (obj sortSparseComplex128VectorByValue) Less(i, j int) bool {
+  return obj.Value[i].Smaller(obj.Value[j])
+}
+func (obj *SparseComplex128Vector) Sort(reverse bool) {
+  r := sortSparseComplex128VectorByValue{}
+  for it := obj.ITERATOR(); it.Ok(); it.Next() {
+    r.Value = append(r.Value, it.GET())
+  }
+  ip := 0
+  in := 0
+  if reverse {
+    in = obj.n - len(obj.values)
+  } else {
+    ip = obj.n - len(obj.values)
+  }
+  obj.values = make(map[int]Complex128)
+  obj.vectorSparseIndex = vectorSparseIndex{}
+  if reverse {
+    sort.Sort(sort.Reverse(r))
+  } else {
+    sort.Sort(sortSparseComplex128VectorByValue(r))
+  }
+  for i := 0; i < len(r.Value); i++ {
+    if real(r.Value[i].GetComplex128()) > 0.0 {
+      // copy negative values
+      obj.values[i+ip] = r.Value[i]
+      obj.indexInsert(i+ip)
+    } else {
+      // copy negative values
+      obj.values[i+in] = r.Value[i]
+      obj.indexInsert(i+in)
+    }
+  }
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString(fmt.Sprintf("%d:[", obj.n))
+  first := true
+  for it := obj.ConstIterator(); it.Ok(); it.Next() {
+    if !first {
+      buffer.WriteString(", ")
+    } else {
+      first = false
+    }
+    buffer.WriteString(fmt.Sprintf("%d:%s", it.Index(), it.GetConst()))
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (obj *SparseComplex128Vector) Table() string {
+  var buffer bytes.Buffer
+  n := obj.Dim()
+  for i := 0; i < n; i++ {
+    buffer.WriteString(obj.ConstAt(i).String())
+    buffer.WriteString("\n")
+  }
+  return buffer.String()
+}
+func (obj *SparseComplex128Vector) Export(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  w := bufio.NewWriter(f)
+  defer w.Flush()
+  if _, err := fmt.Fprintf(w, "%d\n", obj.Dim()); err != nil {
+    return err
+  }
+  for it := obj.ITERATOR(); it.Ok(); it.Next() {
+    i := it.Index()
+    if _, err := fmt.Fprintf(w, "%d %v\n", i, it.GET()); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+func (obj *SparseComplex128Vector) Import(filename string) error {
+  values := []complex128{}
+  indices := []int{}
+  n := 0
+  var reader *bufio.Reader
+  // open file
+  f, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer f.Close()
+  isgzip, err := isGzip(filename)
+  if err != nil {
+    return err
+  }
+  // check if file is gzipped
+  if isgzip {
+    g, err := gzip.NewReader(f)
+    if err != nil {
+      return err
+    }
+    defer g.Close()
+    reader = bufio.NewReader(g)
+  } else {
+    reader = bufio.NewReader(f)
+  }
+  // scan header
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 1 {
+      return fmt.Errorf("invalid sparse vector format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      n = int(v)
+    }
+    break
+  }
+  for i_ := 1;; i_++ {
+    l, err := bufioReadLine(reader)
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      return err
+    }
+    if len(l) == 0 {
+      continue
+    }
+    fields := strings.Fields(l)
+    if len(fields) != 2 {
+      return fmt.Errorf("invalid sparse vector format")
+    }
+    if v, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+      return err
+    } else {
+      indices = append(indices, int(v))
+    }
+    if v, err := strconv.ParseFloat(fields[1], 64); err != nil {
+      return err
+    } else {
+      values = append(values, complex128(complex(v, 0)))
+    }
+  }
+  *obj = *NewSparseComplex128Vector(indices, values, n)
+  return nil
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) MarshalJSON() ([]byte, error) {
+  k := []int{}
+  v := []complex128{}
+  r := struct{
+    Index []int
+    Value complex128JsonSlice
+    Length int}{}
+  for it := obj.ConstIterator(); it.Ok(); it.Next() {
+    k = append(k, it.Index())
+    v = append(v, it.GetConst().GetComplex128())
+  }
+  r.Index = k
+  r.Value = v
+  r.Length = obj.n
+  return json.MarshalIndent(r, "", "  ")
+}
+func (obj *SparseComplex128Vector) UnmarshalJSON(data []byte) error {
+  r := struct{
+    Index []int
+    Value complex128JsonSlice
+    Length int}{}
+  if err := json.Unmarshal(data, &r); err != nil {
+    return err
+  }
+  if len(r.Index) != len(r.Value) {
+    return fmt.Errorf("invalid sparse vector")
+  }
+  *obj = *NewSparseComplex128Vector(r.Index, r.Value, r.Length)
+  return nil
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj *SparseComplex128Vector) ConstIterator() VectorConstIterator {
+  return obj.ITERATOR()
+}
+func (obj *SparseComplex128Vector) ConstIteratorFrom(i int) VectorConstIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj *SparseComplex128Vector) Iterator() VectorIterator {
+  return obj.ITERATOR()
+}
+func (obj *SparseComplex128Vector) IteratorFrom(i int) VectorIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj *SparseComplex128Vector) JointIterator(b ConstVector) VectorJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj *SparseComplex128Vector) ConstJointIterator(b ConstVector) VectorConstJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj *SparseComplex128Vector) ITERATOR() *SparseComplex128VectorIterator {
+  r := SparseComplex128VectorIterator{obj.indexIterator(), obj}
+  r.skip()
+  return &r
+}
+func (obj *SparseComplex128Vector) ITERATOR_FROM(i int) *SparseComplex128VectorIterator {
+  r := SparseComplex128VectorIterator{obj.indexIteratorFrom(i), obj}
+  r.skip()
+  return &r
+}
+func (obj *SparseComplex128Vector) JOINT_ITERATOR(b ConstVector) *SparseComplex128VectorJointIterator {
+  r := SparseComplex128VectorJointIterator{obj.ITERATOR(), b.ConstIterator(), -1, Complex128{}, nil}
+  r.Next()
+  return &r
+}
+func (obj *SparseComplex128Vector) JOINT3_ITERATOR(b, c ConstVector) *SparseComplex128VectorJoint3Iterator {
+  r := SparseComplex128VectorJoint3Iterator{obj.ITERATOR(), b.ConstIterator(), c.ConstIterator(), -1, Complex128{}, nil, nil}
+  r.Next()
+  return &r
+}
+func (obj *SparseComplex128Vector) JOINT_ITERATOR_(b *SparseComplex128Vector) *SparseComplex128VectorJointIterator_ {
+  r := SparseComplex128VectorJointIterator_{obj.ITERATOR(), b.ITERATOR(), -1, Complex128{}, Complex128{}}
+  r.Next()
+  return &r
+}
+func (obj *SparseComplex128Vector) JOINT3_ITERATOR_(b, c *SparseComplex128Vector) *SparseComplex128VectorJoint3Iterator_ {
+  r := SparseComplex128VectorJoint3Iterator_{obj.ITERATOR(), b.ITERATOR(), c.ITERATOR(), -1, Complex128{}, Complex128{}, Complex128{}}
+  r.Next()
+  return &r
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128VectorIterator struct {
+  vectorSparseIndexIterator
+  v *SparseComplex128Vector
+}
+func (obj *SparseComplex128VectorIterator) Get() Scalar {
+  if v := obj.GET(); v.ptr == nil {
+    return nil
+  } else {
+    return v
+  }
+}
+func (obj *SparseComplex128VectorIterator) GetConst() ConstScalar {
+  if v, ok := obj.v.values[obj.Index()]; ok {
+    return v
+  } else {
+    return nil
+  }
+}
+func (obj *SparseComplex128VectorIterator) GET() Complex128 {
+  if v, ok := obj.v.values[obj.Index()]; ok {
+    return v
+  } else {
+    return Complex128{}
+  }
+}
+func (obj *SparseComplex128VectorIterator) Next() {
+  obj.vectorSparseIndexIterator.Next()
+  obj.skip()
+}
+func (obj *SparseComplex128VectorIterator) skip() {
+  for obj.Ok() && obj.GET().nullScalar() {
+    i := obj.Index()
+    obj.vectorSparseIndexIterator.Next()
+    delete(obj.v.values, i)
+    obj.v.indexDelete(i)
+  }
+}
+func (obj *SparseComplex128VectorIterator) Index() int {
+  return obj.vectorSparseIndexIterator.Get()
+}
+func (obj *SparseComplex128VectorIterator) Clone() *SparseComplex128VectorIterator {
+  return &SparseComplex128VectorIterator{*obj.vectorSparseIndexIterator.Clone(), obj.v}
+}
+func (obj *SparseComplex128VectorIterator) CloneConstIterator() VectorConstIterator {
+  return &SparseComplex128VectorIterator{*obj.vectorSparseIndexIterator.Clone(), obj.v}
+}
+func (obj *SparseComplex128VectorIterator) CloneIterator() VectorIterator {
+  return &SparseComplex128VectorIterator{*obj.vectorSparseIndexIterator.Clone(), obj.v}
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128VectorJointIterator struct {
+  it1 *SparseComplex128VectorIterator
+  it2 VectorConstIterator
+  idx int
+  s1 Complex128
+  s2 ConstScalar
+}
+func (obj *SparseComplex128VectorJointIterator) Index() int {
+  return obj.idx
+}
+func (obj *SparseComplex128VectorJointIterator) Ok() bool {
+  return !(obj.s1.ptr == nil || obj.s1.GetComplex128() == complex128(0)) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == complex128(0))
+}
+func (obj *SparseComplex128VectorJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1.ptr = nil
+  obj.s2 = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+}
+func (obj *SparseComplex128VectorJointIterator) Get() (Scalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *SparseComplex128VectorJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2
+  } else {
+    return obj.s1, obj.s2
+  }
+}
+func (obj *SparseComplex128VectorJointIterator) GET() (Complex128, ConstScalar) {
+  return obj.s1, obj.s2
+}
+func (obj *SparseComplex128VectorJointIterator) Clone() *SparseComplex128VectorJointIterator {
+  r := SparseComplex128VectorJointIterator{}
+  r.it1 = obj.it1.Clone()
+  r.it2 = obj.it2.CloneConstIterator()
+  r.idx = obj.idx
+  r.s1 = obj.s1
+  r.s2 = obj.s2
+  return &r
+}
+func (obj *SparseComplex128VectorJointIterator) CloneConstJointIterator() VectorConstJointIterator {
+  return obj.Clone()
+}
+func (obj *SparseComplex128VectorJointIterator) CloneJointIterator() VectorJointIterator {
+  return obj.Clone()
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128VectorJoint3Iterator struct {
+  it1 *SparseComplex128VectorIterator
+  it2 VectorConstIterator
+  it3 VectorConstIterator
+  idx int
+  s1 Complex128
+  s2 ConstScalar
+  s3 ConstScalar
+}
+func (obj *SparseComplex128VectorJoint3Iterator) Index() int {
+  return obj.idx
+}
+func (obj *SparseComplex128VectorJoint3Iterator) Ok() bool {
+  return !(obj.s1.ptr == nil || obj.s1.GetComplex128() == complex128(0)) ||
+         !(obj.s2 == nil || obj.s2.GetComplex128() == complex128(0)) ||
+         !(obj.s3 == nil || obj.s3.GetComplex128() == complex128(0))
+}
+func (obj *SparseComplex128VectorJoint3Iterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  ok3 := obj.it3.Ok()
+  obj.s1.ptr = nil
+  obj.s2 = nil
+  obj.s3 = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    i := obj.it2.Index()
+    switch {
+    case obj.idx > i || !ok1:
+      obj.idx = i
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GetConst()
+    case obj.idx == i:
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if ok3 {
+    i := obj.it3.Index()
+    switch {
+    case obj.idx > i || (!ok1 && !ok2):
+      obj.idx = i
+      obj.s1.ptr = nil
+      obj.s2 = nil
+      obj.s3 = obj.it3.GetConst()
+    case obj.idx == i:
+      obj.s3 = obj.it3.GetConst()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2 != nil {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+  if obj.s3 != nil {
+    obj.it3.Next()
+  } else {
+    obj.s3 = ConstComplex128(0.0)
+  }
+}
+func (obj *SparseComplex128VectorJoint3Iterator) Get() (Scalar, ConstScalar, ConstScalar) {
+  if obj.s1.ptr == nil {
+    return nil, obj.s2, obj.s3
+  } else {
+    return obj.s1, obj.s2, obj.s3
+  }
+}
+func (obj *SparseComplex128VectorJoint3Iterator) GET() (Complex128, ConstScalar, ConstScalar) {
+  return obj.s1, obj.s2, obj.s3
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128VectorJointIterator_ struct {
+  it1 *SparseComplex128VectorIterator
+  it2 *SparseComplex128VectorIterator
+  idx int
+  s1 Complex128
+  s2 Complex128
+}
+func (obj *SparseComplex128VectorJointIterator_) Index() int {
+  return obj.idx
+}
+func (obj *SparseComplex128VectorJointIterator_) Ok() bool {
+  return obj.s1.ptr != nil || obj.s2.ptr != nil
+}
+func (obj *SparseComplex128VectorJointIterator_) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1.ptr = nil
+  obj.s2.ptr = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GET()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GET()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2.ptr != nil {
+    obj.it2.Next()
+  }
+}
+func (obj *SparseComplex128VectorJointIterator_) GET() (Complex128, Complex128) {
+  return obj.s1, obj.s2
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseComplex128VectorJoint3Iterator_ struct {
+  it1 *SparseComplex128VectorIterator
+  it2 *SparseComplex128VectorIterator
+  it3 *SparseComplex128VectorIterator
+  idx int
+  s1 Complex128
+  s2 Complex128
+  s3 Complex128
+}
+func (obj *SparseComplex128VectorJoint3Iterator_) Index() int {
+  return obj.idx
+}
+func (obj *SparseComplex128VectorJoint3Iterator_) Ok() bool {
+  return obj.s1.ptr != nil || obj.s2.ptr != nil || obj.s3.ptr != nil
+}
+func (obj *SparseComplex128VectorJoint3Iterator_) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  ok3 := obj.it3.Ok()
+  obj.s1.ptr = nil
+  obj.s2.ptr = nil
+  obj.s3.ptr = nil
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    i := obj.it2.Index()
+    switch {
+    case obj.idx > i || !ok1:
+      obj.idx = i
+      obj.s1.ptr = nil
+      obj.s2 = obj.it2.GET()
+    case obj.idx == i:
+      obj.s2 = obj.it2.GET()
+    }
+  }
+  if ok3 {
+    i := obj.it3.Index()
+    switch {
+    case obj.idx > i || (!ok1 && !ok2):
+      obj.idx = i
+      obj.s1.ptr = nil
+      obj.s2.ptr = nil
+      obj.s3 = obj.it3.GET()
+    case obj.idx == i:
+      obj.s3 = obj.it3.GET()
+    }
+  }
+  if obj.s1.ptr != nil {
+    obj.it1.Next()
+  }
+  if obj.s2.ptr != nil {
+    obj.it2.Next()
+  }
+  if obj.s3.ptr != nil {
+    obj.it3.Next()
+  }
+}
+func (obj *SparseComplex128VectorJoint3Iterator_) GET() (Complex128, Complex128, Complex128) {
+  return obj.s1, obj.s2, obj.s3
+}
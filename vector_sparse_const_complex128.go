@@ -0,0 +1,423 @@
+/* -*- mode: go; -*-
+ *
+ * Copyright (C) 2015-2020 Philipp Benner
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+/* -------------------------------------------------------------------------- */
+/* -------------------------------------------------------------------------- */
+package autodiff
+/* -------------------------------------------------------------------------- */
+import "fmt"
+import "bytes"
+import "encoding/json"
+import "sort"
+/* vector type declaration
+ * -------------------------------------------------------------------------- */
+type SparseConstComplex128Vector struct {
+  values []complex128
+  indices []int
+  idxmap map[int]int
+  n int
+}
+/* constructors
+ * -------------------------------------------------------------------------- */
+func UnsafeSparseConstComplex128Vector(indices []int, values []complex128, n int) SparseConstComplex128Vector {
+  r := nilSparseConstComplex128Vector(n)
+  r.indices = indices
+  r.values = values
+  return r
+}
+// Allocate a new vector. Scalars are set to the given values.
+func NewSparseConstComplex128Vector(indices []int, values []complex128, n int) SparseConstComplex128Vector {
+  if len(indices) != len(values) {
+    panic("invalid number of indices")
+  }
+  sort.Sort(sortIntConstComplex128{indices, values})
+  r := nilSparseConstComplex128Vector(n)
+  r.indices = indices[0:0]
+  r.values = make([]complex128, 0, len(values))
+  for i, k := range indices {
+    if k >= n {
+      panic("index larger than vector dimension")
+    }
+    if values[i] != 0.0 {
+      r.values = append(r.values, values[i])
+      r.indices = append(r.indices, k)
+    }
+  }
+  return r
+}
+func nilSparseConstComplex128Vector(n int) SparseConstComplex128Vector {
+  r := SparseConstComplex128Vector{}
+  r.n = n
+  // create map here so that no pointer receivers are needed
+  r.idxmap = make(map[int]int)
+  return r
+}
+// Convert vector type.
+func AsSparseConstComplex128Vector(v ConstVector) SparseConstComplex128Vector {
+  switch v_ := v.(type) {
+  case SparseConstComplex128Vector:
+    return v_
+  }
+  indices := []int{}
+  values := []complex128{}
+  n := v.Dim()
+  for it := v.ConstIterator(); it.Ok(); it.Next() {
+    indices = append(indices, it.Index())
+    values = append(values, it.GetConst().GetComplex128())
+  }
+  return NewSparseConstComplex128Vector(indices, values, n)
+}
+/* cloning
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) Clone() SparseConstComplex128Vector {
+  r := nilSparseConstComplex128Vector(obj.n)
+  r.indices = obj.indices
+  r.values = obj.values
+  return r
+}
+/* methods specific to this type
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) GetSparseIndices() []int {
+  return obj.indices
+}
+func (obj SparseConstComplex128Vector) GetSparseValues() []complex128 {
+  return obj.values
+}
+func (obj SparseConstComplex128Vector) First() (int, ConstComplex128) {
+  return obj.indices[0], ConstComplex128(obj.values[0])
+}
+func (obj SparseConstComplex128Vector) Last() (int, ConstComplex128) {
+  i := len(obj.indices) - 1
+  return obj.indices[i], ConstComplex128(obj.values[i])
+}
+func (obj SparseConstComplex128Vector) CreateIndex() {
+  if len(obj.idxmap) == 0 {
+    for i, k := range obj.indices {
+      obj.idxmap[k] = i
+    }
+  }
+}
+/* const interface
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) CloneConstVector() ConstVector {
+  return obj.Clone()
+}
+func (obj SparseConstComplex128Vector) Dim() int {
+  return obj.n
+}
+func (obj SparseConstComplex128Vector) Int8At(i int) int8 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return int8(real(obj.values[k]))
+  } else {
+    return int8(0)
+  }
+}
+func (obj SparseConstComplex128Vector) Int16At(i int) int16 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return int16(real(obj.values[k]))
+  } else {
+    return int16(0)
+  }
+}
+func (obj SparseConstComplex128Vector) Int32At(i int) int32 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return int32(real(obj.values[k]))
+  } else {
+    return int32(0)
+  }
+}
+func (obj SparseConstComplex128Vector) Int64At(i int) int64 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return int64(real(obj.values[k]))
+  } else {
+    return int64(0)
+  }
+}
+func (obj SparseConstComplex128Vector) IntAt(i int) int {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return int(real(obj.values[k]))
+  } else {
+    return int(0)
+  }
+}
+func (obj SparseConstComplex128Vector) Float32At(i int) float32 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return float32(real(obj.values[k]))
+  } else {
+    return float32(0)
+  }
+}
+func (obj SparseConstComplex128Vector) Float64At(i int) float64 {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return float64(real(obj.values[k]))
+  } else {
+    return float64(0)
+  }
+}
+func (obj SparseConstComplex128Vector) ConstAt(i int) ConstScalar {
+  if len(obj.idxmap) == 0 {
+    obj.CreateIndex()
+  }
+  if k, ok := obj.idxmap[i]; ok {
+    return ConstComplex128(obj.values[k])
+  } else {
+    return ConstComplex128(0.0)
+  }
+}
+func (obj SparseConstComplex128Vector) ConstSlice(i, j int) ConstVector {
+  if i == 0 {
+    k1 := 0
+    k2 := sort.SearchInts(obj.indices, j)
+    r := nilSparseConstComplex128Vector(j)
+    r.values = obj.values [k1:k2]
+    r.indices = obj.indices[k1:k2]
+    return r
+  } else {
+    k1 := sort.SearchInts(obj.indices, i)
+    k2 := sort.SearchInts(obj.indices, j)
+    r := nilSparseConstComplex128Vector(j-i)
+    r.values = obj.values[k1:k2]
+    r.indices = make([]int, k2-k1)
+    for k := k1; k < k2; k++ {
+      r.indices[k-k1] = obj.indices[k] - i
+    }
+    return r
+  }
+}
+func (obj SparseConstComplex128Vector) AsConstMatrix(n, m int) ConstMatrix {
+  panic("not implemented")
+}
+/* imlement ScalarContainer
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) Reduce(f func(Scalar, ConstScalar) Scalar, r Scalar) Scalar {
+  for _, v := range obj.values {
+    r = f(r, ConstComplex128(v))
+  }
+  return r
+}
+func (obj SparseConstComplex128Vector) ElementType() ScalarType {
+  return ConstComplex128Type
+}
+/* iterator methods
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) ConstIterator() VectorConstIterator {
+  return obj.ITERATOR()
+}
+func (obj SparseConstComplex128Vector) ConstIteratorFrom(i int) VectorConstIterator {
+  return obj.ITERATOR_FROM(i)
+}
+func (obj SparseConstComplex128Vector) ConstJointIterator(b ConstVector) VectorConstJointIterator {
+  return obj.JOINT_ITERATOR(b)
+}
+func (obj SparseConstComplex128Vector) ITERATOR() *SparseConstComplex128VectorIterator {
+  r := SparseConstComplex128VectorIterator{0, obj}
+  return &r
+}
+func (obj SparseConstComplex128Vector) ITERATOR_FROM(i int) *SparseConstComplex128VectorIterator {
+  k := 0
+  for j, idx := range obj.indices {
+    if idx >= i {
+      k = j
+      break
+    }
+  }
+  r := SparseConstComplex128VectorIterator{k, obj}
+  return &r
+}
+func (obj SparseConstComplex128Vector) JOINT_ITERATOR(b ConstVector) *SparseConstComplex128VectorJointIterator {
+  r := SparseConstComplex128VectorJointIterator{}
+  r.it1 = obj.ITERATOR()
+  r.it2 = b.ConstIterator()
+  r.idx = -1
+  r.Next()
+  return &r
+}
+/* type conversion
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) String() string {
+  var buffer bytes.Buffer
+  buffer.WriteString(fmt.Sprintf("%d:[", obj.n))
+  first := true
+  for it := obj.ConstIterator(); it.Ok(); it.Next() {
+    if !first {
+      buffer.WriteString(", ")
+    } else {
+      first = false
+    }
+    buffer.WriteString(fmt.Sprintf("%d:%v", it.Index(), it.GetConst()))
+  }
+  buffer.WriteString("]")
+  return buffer.String()
+}
+func (obj SparseConstComplex128Vector) Table() string {
+  var buffer bytes.Buffer
+  first := true
+  for it := obj.ConstIterator(); it.Ok(); it.Next() {
+    if !first {
+      buffer.WriteString(" ")
+    } else {
+      first = false
+    }
+    buffer.WriteString(fmt.Sprintf("%d:%v", it.Index(), it.GetConst()))
+  }
+  if len(obj.indices) > 0 {
+    if i := obj.indices[len(obj.indices)-1]; i != obj.n-1 {
+      buffer.WriteString(fmt.Sprintf(" %d:%v", i, complex128(0)))
+    }
+  }
+  return buffer.String()
+}
+/* json
+ * -------------------------------------------------------------------------- */
+func (obj SparseConstComplex128Vector) MarshalJSON() ([]byte, error) {
+  k := []int{}
+  v := []complex128{}
+  r := struct{
+    Index []int
+    Value complex128JsonSlice
+    Length int}{}
+  for it := obj.ConstIterator(); it.Ok(); it.Next() {
+    k = append(k, it.Index())
+    v = append(v, it.GetConst().GetComplex128())
+  }
+  r.Index = k
+  r.Value = v
+  r.Length = obj.n
+  return json.MarshalIndent(r, "", "  ")
+}
+/* iterator
+ * -------------------------------------------------------------------------- */
+type SparseConstComplex128VectorIterator struct {
+  i int
+  v SparseConstComplex128Vector
+}
+func (obj *SparseConstComplex128VectorIterator) GetConst() ConstScalar {
+  return obj.GET()
+}
+func (obj *SparseConstComplex128VectorIterator) GET() ConstComplex128 {
+  return ConstComplex128(obj.v.values[obj.i])
+}
+func (obj *SparseConstComplex128VectorIterator) Ok() bool {
+  return obj.i < len(obj.v.indices)
+}
+func (obj *SparseConstComplex128VectorIterator) Index() int {
+  return obj.v.indices[obj.i]
+}
+func (obj *SparseConstComplex128VectorIterator) Next() {
+  obj.i += 1
+}
+func (obj *SparseConstComplex128VectorIterator) Clone() *SparseConstComplex128VectorIterator {
+  return &SparseConstComplex128VectorIterator{obj.i, obj.v}
+}
+func (obj *SparseConstComplex128VectorIterator) CloneConstIterator() VectorConstIterator {
+  return &SparseConstComplex128VectorIterator{obj.i, obj.v}
+}
+/* joint iterator
+ * -------------------------------------------------------------------------- */
+type SparseConstComplex128VectorJointIterator struct {
+  it1 *SparseConstComplex128VectorIterator
+  it2 VectorConstIterator
+  idx int
+  s1 ConstComplex128
+  s2 ConstScalar
+}
+func (obj *SparseConstComplex128VectorJointIterator) Index() int {
+  return obj.idx
+}
+func (obj *SparseConstComplex128VectorJointIterator) Ok() bool {
+  return !(obj.s1.GetComplex128() == complex128(0)) ||
+         !(obj.s2.GetComplex128() == complex128(0))
+}
+func (obj *SparseConstComplex128VectorJointIterator) Next() {
+  ok1 := obj.it1.Ok()
+  ok2 := obj.it2.Ok()
+  obj.s1 = ConstComplex128(0)
+  obj.s2 = ConstComplex128(0)
+  if ok1 {
+    obj.idx = obj.it1.Index()
+    obj.s1 = obj.it1.GET()
+  }
+  if ok2 {
+    switch {
+    case obj.idx > obj.it2.Index() || !ok1:
+      obj.idx = obj.it2.Index()
+      obj.s1 = ConstComplex128(0)
+      obj.s2 = obj.it2.GetConst()
+    case obj.idx == obj.it2.Index():
+      obj.s2 = obj.it2.GetConst()
+    }
+  }
+  if obj.s1 != ConstComplex128(0) {
+    obj.it1.Next()
+  }
+  if obj.s2 != ConstComplex128(0) {
+    obj.it2.Next()
+  } else {
+    obj.s2 = ConstComplex128(0.0)
+  }
+}
+func (obj *SparseConstComplex128VectorJointIterator) GetConst() (ConstScalar, ConstScalar) {
+  return obj.s1, obj.s2
+}
+func (obj *SparseConstComplex128VectorJointIterator) GET() (ConstComplex128, ConstScalar) {
+  return obj.s1, obj.s2
+}
+func (obj *SparseConstComplex128VectorJointIterator) CloneConstJointIterator() VectorConstJointIterator {
+  r := SparseConstComplex128VectorJointIterator{}
+  r.it1 = obj.it1.Clone()
+  r.it2 = obj.it2.CloneConstIterator()
+  r.idx = obj.idx
+  r.s1 = obj.s1
+  r.s2 = obj.s2
+  return &r
+}
+/* math
+ * -------------------------------------------------------------------------- */
+// Test if elements in a equal elements in b.
+func (a SparseConstComplex128Vector) Equals(b ConstVector, epsilon float64) bool {
+  if a.Dim() != b.Dim() {
+    panic("Equals(): Vector dimensions do not match!")
+  }
+  for it := a.ConstJointIterator(b); it.Ok(); it.Next() {
+    s1, s2 := it.GetConst()
+    if !s1.Equals(s2, epsilon) {
+      return false
+    }
+  }
+  return true
+}
@@ -0,0 +1,16 @@
+
+#define CONST_SCALAR_NAME ConstComplex128
+#define       SCALAR_NAME ConstComplex128
+#define   GET_METHOD_NAME GetComplex128
+#define   SET_METHOD_NAME SetComplex128
+#define       MATRIX_NAME SparseConstComplex128Matrix
+#define       VECTOR_NAME SparseConstComplex128Vector
+
+#define       STORED_TYPE complex128
+#define CONST_SCALAR_TYPE CONST_SCALAR_NAME
+#define       SCALAR_TYPE       SCALAR_NAME
+#define       MATRIX_TYPE       MATRIX_NAME
+#define       VECTOR_TYPE       VECTOR_NAME
+
+#define COMPLEX 1
+#define STORED_JSON_SLICE complex128JsonSlice
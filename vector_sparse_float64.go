@@ -375,26 +375,37 @@ func (obj *SparseFloat64Vector) Permute(pi []int) error {
   if len(pi) != obj.n {
     return errors.New("Permute(): permutation vector has invalid length!")
   }
-  // permute vector
+  // check that pi is a permutation
+  visited := make([]bool, obj.n)
   for i := 0; i < obj.n; i++ {
-    if pi[i] < 0 || pi[i] >= obj.n {
+    if pi[i] < 0 || pi[i] >= obj.n || visited[pi[i]] {
       return errors.New("Permute(): invalid permutation")
     }
-    if i != pi[i] && pi[i] > i {
+    visited[pi[i]] = true
+  }
+  // permute vector by following the cycles of pi, such that element i is
+  // replaced by element pi[i]
+  for i := 0; i < obj.n; i++ {
+    if !visited[i] {
+      continue
+    }
+    visited[i] = false
+    for j := i; pi[j] != i; j = pi[j] {
       // permute elements
-      _, ok1 := obj.values[i]
-      _, ok2 := obj.values[pi[i]]
+      _, ok1 := obj.values[j]
+      _, ok2 := obj.values[pi[j]]
       if ok1 && ok2 {
-        obj.values[pi[i]], obj.values[i] = obj.values[i], obj.values[pi[i]]
+        obj.values[pi[j]], obj.values[j] = obj.values[j], obj.values[pi[j]]
       } else
       if ok1 {
-        obj.values[pi[i]] = obj.values[i]
-        delete(obj.values, i)
+        obj.values[pi[j]] = obj.values[j]
+        delete(obj.values, j)
       } else
       if ok2 {
-        obj.values[i] = obj.values[pi[i]]
-        delete(obj.values, pi[i])
+        obj.values[j] = obj.values[pi[j]]
+        delete(obj.values, pi[j])
       }
+      visited[pi[j]] = false
     }
   }
   obj.vectorSparseIndex = vectorSparseIndex{}
@@ -251,10 +251,10 @@ func (r *SparseInt64Vector) VdivV(a, b ConstVector) Vector {
   for i := 0; i < n; i++ {
     c1 := a.ConstAt(i)
     c2 := b.ConstAt(i)
-    if c1.GetFloat64() != 0.0 || c2.GetFloat64() == 0.0 {
+    if c1.GetComplex128() != 0.0 || c2.GetComplex128() == 0.0 {
       r.At(i).Div(c1, c2)
     } else {
-      if r.ConstAt(i).GetFloat64() != 0.0 {
+      if r.ConstAt(i).GetComplex128() != 0.0 {
         r.At(i).Reset()
       }
     }
@@ -272,7 +272,7 @@ func (r *SparseInt64Vector) VdivS(a ConstVector, b ConstScalar) Vector {
   if n != a.Dim() {
     panic("vector dimensions do not match")
   }
-  if b.GetFloat64() == 0.0 {
+  if b.GetComplex128() == 0.0 {
     for i := 0; i < n; i++ {
       r.At(i).Div(a.ConstAt(i), b)
     }